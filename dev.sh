@@ -104,20 +104,25 @@ case "${1:-run}" in
         print_success "Setup completed!"
         print_warning "Don't forget to:"
         echo "  1. Configure your database connection"
-        echo "  2. Run database setup: mysql -u root -p < sql/database/setup_database.sql"
+        echo "  2. Run database setup: ./dev.sh db-setup"
         echo "  3. Update DB_PASSWORD in your environment"
         ;;
     "db-setup")
         echo "🗄️  Setting up database..."
-        print_warning "This will create/reset the database. Continue? (y/N)"
+        print_warning "This will create the database and apply pending migrations. Continue? (y/N)"
         read -r response
         if [[ "$response" =~ ^([yY][eE][sS]|[yY])$ ]]; then
-            mysql -u root -p < sql/database/setup_database.sql
+            mysql -u root -p -e "CREATE DATABASE IF NOT EXISTS expenses_db CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci"
+            go run . migrate up
             print_success "Database setup completed!"
         else
             echo "Database setup cancelled."
         fi
         ;;
+    "migrate")
+        echo "🗄️  Running schema migrations..."
+        go run . migrate "${@:2}"
+        ;;
     "help"|"-h"|"--help")
         echo "Available commands:"
         echo "  build     - Build the application"
@@ -128,6 +133,7 @@ case "${1:-run}" in
         echo "  clean     - Clean build artifacts"
         echo "  setup     - Setup development environment"
         echo "  db-setup  - Setup database"
+        echo "  migrate   - Manage schema migrations (up, down [n], status, check)"
         echo "  help      - Show this help"
         ;;
     *)
@@ -1,25 +1,47 @@
 package dto
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 // DTOs específicos para el frontend Angular
 
 // SalaryDTO representa la configuración de salario para el frontend
 type SalaryDTO struct {
 	MonthlyAmount float64 `json:"monthly_amount" binding:"required,min=0"`
+	Version       uint    `json:"version,omitempty"` // Solo lectura; ausente si el mes hereda la configuración
 }
 
 // FixedExpenseDTO representa un gasto fijo para el frontend
 type FixedExpenseDTO struct {
-	ID          int     `json:"id"`
-	PocketName  string  `json:"pocket_name"`
-	ConceptName string  `json:"concept_name" binding:"required,min=1,max=255"`
-	Amount      float64 `json:"amount" binding:"required,min=0"`
-	PaymentDay  int     `json:"payment_day" binding:"required,min=1,max=31"`
-	Month       string  `json:"month"`
-	IsPaid      bool    `json:"is_paid"`
-	PaidDate    *string `json:"paid_date"`
-	PocketID    int     `json:"pocket_id,omitempty" binding:"omitempty,min=1"` // Solo para operaciones de escritura
+	ID          int      `json:"id"`
+	PocketName  string   `json:"pocket_name"`
+	ConceptName string   `json:"concept_name" binding:"required,min=1,max=255"`
+	Amount      float64  `json:"amount" binding:"required,min=0"`
+	PaymentDay  int      `json:"payment_day" binding:"required,min=1,max=31"`
+	Month       string   `json:"month"`
+	IsPaid      bool     `json:"is_paid"`
+	PaidDate    *string  `json:"paid_date"`
+	PocketID    int      `json:"pocket_id,omitempty" binding:"omitempty,min=1"` // Solo para operaciones de escritura
+	Tags        []string `json:"tags" binding:"omitempty,max=20,dive,max=50"`   // Nombres de etiquetas; se crean si no existen. Omitir para no modificarlas
+
+	// Solo lectura: pagos registrados y estado derivado
+	ActualAmount  *float64                 `json:"actual_amount"` // Monto real facturado, si difiere del planeado
+	PaidAmount    float64                  `json:"paid_amount"`
+	PaymentStatus string                   `json:"payment_status"` // "unpaid" | "partial" | "paid" | "overpaid"
+	Payments      []FixedExpensePaymentDTO `json:"payments,omitempty"`
+	Version       uint                     `json:"version"` // Solo lectura; enviar como If-Match al modificar
+}
+
+// FixedExpensePaymentDTO representa un pago (total o parcial) de un gasto fijo
+type FixedExpensePaymentDTO struct {
+	ID        int       `json:"id"`
+	Amount    float64   `json:"amount" binding:"required,gt=0"`
+	PaidDate  string    `json:"paid_date,omitempty"` // Opcional, por defecto la fecha actual
+	Method    string    `json:"method,omitempty" binding:"max=50"`
+	Note      string    `json:"note,omitempty" binding:"max=500"`
+	CreatedAt time.Time `json:"created_at,omitempty"`
 }
 
 // DailyExpenseDTO representa un gasto diario para el frontend
@@ -27,20 +49,47 @@ type DailyExpenseDTO struct {
 	ID          int       `json:"id"`
 	Amount      float64   `json:"amount" binding:"required,min=0"`
 	Description string    `json:"description" binding:"required,min=1,max=255"`
-	Date        string    `json:"date,omitempty"`       // Opcional, se asigna automáticamente a la fecha actual
-	CreatedAt   time.Time `json:"created_at,omitempty"` // Timestamp de creación
+	Date        string    `json:"date,omitempty"` // Opcional, se asigna automáticamente a la fecha actual
+	PocketID    int       `json:"pocket_id,omitempty" binding:"omitempty,min=1"`
+	PocketName  string    `json:"pocket_name,omitempty"`                       // Solo lectura
+	Tags        []string  `json:"tags" binding:"omitempty,max=20,dive,max=50"` // Nombres de etiquetas; se crean si no existen. Omitir para no modificarlas
+	CreatedAt   time.Time `json:"created_at,omitempty"`                        // Timestamp de creación
+
+	// Líneas de división entre bolsillos; deben sumar amount y excluyen pocket_id. Omitir para no modificarlas
+	Splits []DailyExpenseSplitDTO `json:"splits,omitempty" binding:"omitempty,max=20,dive"`
+
+	Version uint `json:"version"` // Solo lectura; enviar como If-Match al modificar o eliminar
+}
+
+// QuickEntryRequest representa un gasto diario escrito como texto libre
+// Ejemplo: "taxi 18.500 ayer @transporte #trabajo"
+type QuickEntryRequest struct {
+	Text   string `json:"text" binding:"required,max=500"`
+	Create bool   `json:"create"` // false devuelve solo la vista previa; true crea el gasto
+}
+
+// DailyExpenseSplitDTO representa la parte de un gasto diario que paga un bolsillo
+type DailyExpenseSplitDTO struct {
+	ID         int     `json:"id"`
+	PocketID   int     `json:"pocket_id" binding:"required,min=1"`
+	PocketName string  `json:"pocket_name,omitempty"` // Solo lectura
+	Amount     float64 `json:"amount" binding:"required,gt=0"`
+	Note       string  `json:"note,omitempty" binding:"max=255"`
 }
 
 // PocketDTO representa un bolsillo para el frontend
 type PocketDTO struct {
-	ID          int    `json:"id"`
-	Name        string `json:"name" binding:"required,min=1,max=255"`
-	Description string `json:"description" binding:"required,min=1"`
+	ID             int    `json:"id"`
+	Name           string `json:"name" binding:"required,min=1,max=255"`
+	Description    string `json:"description" binding:"required,min=1"`
+	RolloverPolicy string `json:"rollover_policy,omitempty" binding:"omitempty,oneof=rollover sweep"` // Por defecto "rollover"
+	Version        uint   `json:"version"`                                                            // Solo lectura; enviar como If-Match al modificar o eliminar
 }
 
 // DailyExpensesConfigDTO representa la configuración de gastos diarios
 type DailyExpensesConfigDTO struct {
 	MonthlyBudget float64 `json:"monthly_budget" binding:"required,min=0"`
+	Version       uint    `json:"version,omitempty"` // Solo lectura; ausente si el mes hereda la configuración
 }
 
 // MonthlySummaryDTO representa el resumen mensual para el dashboard
@@ -54,6 +103,398 @@ type MonthlySummaryDTO struct {
 	FixedExpensesTotal int     `json:"fixed_expenses_total"`
 	DailyBudgetUsed    float64 `json:"daily_budget_used"`
 	DailyBudgetTotal   float64 `json:"daily_budget_total"`
+	TotalTransfers     float64 `json:"total_transfers"` // Informativo, no se cuenta como gasto
+
+	// Gastos fijos planeados (total_fixed_expenses) vs. realmente pagados
+	TotalFixedActual      float64                   `json:"total_fixed_actual"`      // Suma de lo pagado en gastos fijos
+	FixedVariance         float64                   `json:"fixed_variance"`          // Real - planeado de los conceptos ya pagados
+	FixedExpenseVariances []FixedExpenseVarianceDTO `json:"fixed_expense_variances"` // Detalle por concepto
+
+	// Reembolsos de gastos pagados por cuenta de terceros, ya descontados de remaining_budget
+	TotalReimbursed       float64 `json:"total_reimbursed"`       // Reembolsado de los gastos del mes
+	PendingReimbursements float64 `json:"pending_reimbursements"` // Aún por cobrar de los gastos del mes
+	NetExpenses           float64 `json:"net_expenses"`           // Gastos fijos + diarios - reembolsado
+}
+
+// FixedExpenseVarianceDTO representa la diferencia entre lo planeado y lo pagado en un gasto fijo
+type FixedExpenseVarianceDTO struct {
+	FixedExpenseID int     `json:"fixed_expense_id"`
+	ConceptName    string  `json:"concept_name"`
+	PocketName     string  `json:"pocket_name"`
+	Planned        float64 `json:"planned"`
+	Actual         float64 `json:"actual"`
+	Variance       float64 `json:"variance"` // Solo para conceptos pagados; 0 mientras esté pendiente o parcial
+	PaymentStatus  string  `json:"payment_status"`
+}
+
+// TransferDTO representa una transferencia entre cuentas y bolsillos
+type TransferDTO struct {
+	ID                  int       `json:"id"`
+	SourceType          string    `json:"source_type" binding:"required,oneof=account pocket"`
+	SourcePocketID      int       `json:"source_pocket_id,omitempty" binding:"omitempty,min=1"`
+	SourceAccount       string    `json:"source_account,omitempty" binding:"max=255"`
+	SourceName          string    `json:"source_name"` // Solo lectura: nombre del bolsillo o cuenta
+	DestinationType     string    `json:"destination_type" binding:"required,oneof=account pocket"`
+	DestinationPocketID int       `json:"destination_pocket_id,omitempty" binding:"omitempty,min=1"`
+	DestinationAccount  string    `json:"destination_account,omitempty" binding:"max=255"`
+	DestinationName     string    `json:"destination_name"` // Solo lectura: nombre del bolsillo o cuenta
+	Amount              float64   `json:"amount" binding:"required,gt=0"`
+	Date                string    `json:"date,omitempty"` // Opcional, por defecto la fecha actual
+	Description         string    `json:"description" binding:"max=500"`
+	CreatedAt           time.Time `json:"created_at,omitempty"`
+	Version             uint      `json:"version"` // Solo lectura; enviar como If-Match al modificar o eliminar
+}
+
+// TransferBalanceDTO representa el saldo neto movido por transferencias hacia una cuenta o bolsillo
+type TransferBalanceDTO struct {
+	Type     string  `json:"type"` // "account" o "pocket"
+	PocketID int     `json:"pocket_id,omitempty"`
+	Name     string  `json:"name"`
+	Balance  float64 `json:"balance"`
+}
+
+// PocketAllocationDTO representa la asignación mensual de un bolsillo (sobre)
+type PocketAllocationDTO struct {
+	Amount float64 `json:"amount" binding:"min=0"`
+}
+
+// EnvelopeDTO representa el saldo de un bolsillo tipo sobre en un mes
+type EnvelopeDTO struct {
+	PocketID       int     `json:"pocket_id"`
+	PocketName     string  `json:"pocket_name"`
+	Month          string  `json:"month"`
+	RolloverPolicy string  `json:"rollover_policy"`
+	OpeningBalance float64 `json:"opening_balance"`  // Saldo arrastrado del mes anterior
+	Allocation     float64 `json:"allocation"`       // Asignación del mes
+	TransfersIn    float64 `json:"transfers_in"`     // Transferencias recibidas
+	TransfersOut   float64 `json:"transfers_out"`    // Transferencias enviadas
+	Available      float64 `json:"available"`        // Saldo inicial + asignación + transferencias netas
+	SpentDaily     float64 `json:"spent_daily"`      // Gastos diarios del bolsillo
+	SpentFixed     float64 `json:"spent_fixed"`      // Pagado en gastos fijos del bolsillo, como total_fixed_actual
+	ClosingBalance float64 `json:"closing_balance"`  // Saldo al cierre del mes
+	SweptToSavings float64 `json:"swept_to_savings"` // Sobrante enviado a ahorros (política "sweep")
+}
+
+// WebhookSubscriptionDTO representa una suscripción a eventos del libro de gastos
+type WebhookSubscriptionDTO struct {
+	ID          int       `json:"id"`
+	URL         string    `json:"url" binding:"required,url,max=2048"`
+	Events      []string  `json:"events" binding:"required,min=1"` // Nombres de eventos o "*" para todos
+	Active      *bool     `json:"active,omitempty"`                // Por defecto true
+	Description string    `json:"description" binding:"max=500"`
+	Secret      string    `json:"secret,omitempty" binding:"max=255"` // Solo se devuelve al crear; se genera si se omite
+	CreatedAt   time.Time `json:"created_at,omitempty"`
+}
+
+// WebhookDeliveryDTO representa un intento de entrega de un webhook
+type WebhookDeliveryDTO struct {
+	ID         int       `json:"id"`
+	EventID    string    `json:"event_id"`
+	Event      string    `json:"event"`
+	Attempt    int       `json:"attempt"`
+	StatusCode int       `json:"status_code"`
+	Success    bool      `json:"success"`
+	Error      string    `json:"error,omitempty"`
+	DurationMs int64     `json:"duration_ms"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// AlertRuleDTO representa una regla de alerta de presupuesto
+type AlertRuleDTO struct {
+	ID               int     `json:"id"`
+	Kind             string  `json:"kind" binding:"required,oneof=daily_budget pocket"`
+	PocketID         int     `json:"pocket_id,omitempty" binding:"omitempty,min=1"` // Requerido para reglas "pocket"
+	PocketName       string  `json:"pocket_name,omitempty"`                         // Solo lectura
+	ThresholdPercent float64 `json:"threshold_percent" binding:"required,gt=0,max=1000"`
+	Active           *bool   `json:"active,omitempty"` // Por defecto true
+}
+
+// AlertDTO representa una alerta de presupuesto disparada
+type AlertDTO struct {
+	ID               int        `json:"id"`
+	RuleID           int        `json:"rule_id"`
+	Month            string     `json:"month"`
+	Kind             string     `json:"kind"`
+	PocketID         int        `json:"pocket_id,omitempty"`
+	ThresholdPercent float64    `json:"threshold_percent"`
+	Budget           float64    `json:"budget"`       // Presupuesto diario del mes o asignación del bolsillo
+	Spent            float64    `json:"spent"`        // Gasto al momento de disparar la alerta
+	PercentUsed      float64    `json:"percent_used"` // Porcentaje del presupuesto usado al disparar
+	Message          string     `json:"message"`
+	Acknowledged     bool       `json:"acknowledged"`
+	AcknowledgedAt   *time.Time `json:"acknowledged_at"`
+	CreatedAt        time.Time  `json:"created_at"`
+}
+
+// AttachmentDTO representa un comprobante (imagen o PDF) adjunto a un gasto
+type AttachmentDTO struct {
+	ID           int       `json:"id"`
+	ExpenseType  string    `json:"expense_type"` // "daily" | "fixed"
+	ExpenseID    int       `json:"expense_id"`
+	FileName     string    `json:"file_name"`
+	ContentType  string    `json:"content_type"`
+	Size         int64     `json:"size"`                    // Bytes
+	SHA256       string    `json:"sha256"`                  // Hash del contenido (deduplicación)
+	URL          string    `json:"url"`                     // Descarga del archivo original
+	ThumbnailURL string    `json:"thumbnail_url,omitempty"` // Solo imágenes
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// TagDTO representa una etiqueta transversal de gastos (ej. "vacaciones-2026")
+type TagDTO struct {
+	ID        int       `json:"id"`
+	Name      string    `json:"name" binding:"required,min=1,max=50"`
+	Color     string    `json:"color,omitempty" binding:"omitempty,len=7"` // Ej. "#1e88e5"
+	CreatedAt time.Time `json:"created_at,omitempty"`
+}
+
+// TagTotalDTO representa el gasto de una etiqueta en un rango de fechas
+type TagTotalDTO struct {
+	TagID       int     `json:"tag_id"`
+	Name        string  `json:"name"`
+	DailyAmount float64 `json:"daily_amount"`
+	DailyCount  int     `json:"daily_count"`
+	FixedAmount float64 `json:"fixed_amount"`
+	FixedCount  int     `json:"fixed_count"`
+	TotalAmount float64 `json:"total_amount"`
+	TotalCount  int     `json:"total_count"`
+}
+
+// TagTotalsDTO representa los totales por etiqueta de un rango de fechas
+type TagTotalsDTO struct {
+	StartDate string        `json:"start_date"`
+	EndDate   string        `json:"end_date"`
+	Tags      []TagTotalDTO `json:"tags"`
+}
+
+// ReceivableDTO representa un gasto pagado por cuenta de un tercero que será reembolsado
+type ReceivableDTO struct {
+	ID           int      `json:"id"`
+	ExpenseType  string   `json:"expense_type" binding:"required,oneof=daily fixed"`
+	ExpenseID    int      `json:"expense_id" binding:"required,min=1"`
+	Counterparty string   `json:"counterparty" binding:"required,min=1,max=255"` // Amigo, empleador, etc.
+	Amount       *float64 `json:"amount" binding:"omitempty,gt=0"`               // Opcional, por defecto el monto del gasto
+	Note         string   `json:"note,omitempty" binding:"max=500"`
+
+	// Solo lectura: datos del gasto, reembolsos y estado derivado
+	ExpenseDescription string             `json:"expense_description"`
+	ExpenseAmount      float64            `json:"expense_amount"`
+	ExpenseMonth       string             `json:"expense_month"`
+	ReimbursedAmount   float64            `json:"reimbursed_amount"`
+	OutstandingAmount  float64            `json:"outstanding_amount"`
+	Status             string             `json:"status"` // "outstanding" | "partial" | "settled"
+	Reimbursements     []ReimbursementDTO `json:"reimbursements,omitempty"`
+	CreatedAt          time.Time          `json:"created_at"`
+}
+
+// UpdateReceivableRequest representa los cambios de un gasto reembolsable
+type UpdateReceivableRequest struct {
+	Counterparty string   `json:"counterparty" binding:"required,min=1,max=255"`
+	Amount       *float64 `json:"amount" binding:"omitempty,gt=0"` // Omitir para conservar el monto actual
+	Note         string   `json:"note,omitempty" binding:"max=500"`
+}
+
+// ReimbursementDTO representa un reembolso recibido (total o parcial)
+type ReimbursementDTO struct {
+	ID           int       `json:"id"`
+	Amount       float64   `json:"amount" binding:"required,gt=0"`
+	ReceivedDate string    `json:"received_date,omitempty"` // Opcional, por defecto la fecha actual
+	Note         string    `json:"note,omitempty" binding:"max=500"`
+	CreatedAt    time.Time `json:"created_at,omitempty"`
+}
+
+// CounterpartyBalanceDTO representa lo que aún debe un tercero
+type CounterpartyBalanceDTO struct {
+	Counterparty string  `json:"counterparty"`
+	Amount       float64 `json:"amount"`
+	Reimbursed   float64 `json:"reimbursed"`
+	Outstanding  float64 `json:"outstanding"`
+	Count        int     `json:"count"` // Gastos pendientes de reembolso
+}
+
+// OutstandingReceivablesDTO representa el reporte de cuentas por cobrar pendientes
+type OutstandingReceivablesDTO struct {
+	TotalOutstanding float64                  `json:"total_outstanding"`
+	Counterparties   []CounterpartyBalanceDTO `json:"counterparties"`
+	Receivables      []ReceivableDTO          `json:"receivables"`
+}
+
+// HouseholdMemberDTO representa una persona del hogar que comparte gastos (ej. un compañero de apartamento)
+type HouseholdMemberDTO struct {
+	ID        int       `json:"id"`
+	Name      string    `json:"name" binding:"required,min=1,max=100"`
+	Email     string    `json:"email,omitempty" binding:"omitempty,email,max=255"`
+	IsActive  *bool     `json:"is_active"` // Omitir para conservar el estado (al crear: activo)
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ExpenseShareDTO representa quién pagó un gasto y cómo se reparte entre los miembros
+type ExpenseShareDTO struct {
+	ID          int                   `json:"id"`
+	ExpenseType string                `json:"expense_type" binding:"required,oneof=daily fixed"`
+	ExpenseID   int                   `json:"expense_id" binding:"required,min=1"`
+	PayerID     int                   `json:"payer_id" binding:"required,min=1"`
+	Method      string                `json:"method" binding:"required,oneof=equal percentage exact"`
+	Parts       []ExpenseSharePartDTO `json:"parts" binding:"omitempty,dive"` // En "equal" puede omitirse: se reparte entre todos los miembros activos
+
+	// Solo lectura
+	PayerName          string    `json:"payer_name"`
+	ExpenseDescription string    `json:"expense_description"`
+	ExpenseAmount      float64   `json:"expense_amount"`
+	ExpenseMonth       string    `json:"expense_month"`
+	CreatedAt          time.Time `json:"created_at"`
+}
+
+// ExpenseSharePartDTO representa la parte de un miembro en un gasto compartido
+type ExpenseSharePartDTO struct {
+	MemberID   int      `json:"member_id" binding:"required,min=1"`
+	Percentage *float64 `json:"percentage,omitempty" binding:"omitempty,gt=0,lte=100"` // Solo método "percentage"
+	Amount     *float64 `json:"amount,omitempty" binding:"omitempty,gt=0"`             // Solo método "exact"
+
+	// Solo lectura
+	MemberName string  `json:"member_name"`
+	OwedAmount float64 `json:"owed_amount"` // Lo que le corresponde pagar
+}
+
+// MemberBalanceDTO representa lo que un miembro pagó frente a lo que le correspondía
+type MemberBalanceDTO struct {
+	MemberID   int     `json:"member_id"`
+	MemberName string  `json:"member_name"`
+	Paid       float64 `json:"paid"`
+	Owed       float64 `json:"owed"`
+	Net        float64 `json:"net"` // Positivo: le deben; negativo: debe
+}
+
+// SettlementDTO representa una transferencia para saldar cuentas entre miembros
+type SettlementDTO struct {
+	FromMemberID int     `json:"from_member_id"`
+	FromName     string  `json:"from_name"`
+	ToMemberID   int     `json:"to_member_id"`
+	ToName       string  `json:"to_name"`
+	Amount       float64 `json:"amount"`
+}
+
+// SettleUpDTO representa el cierre de cuentas del hogar para un mes
+type SettleUpDTO struct {
+	Month       string             `json:"month"`
+	Balances    []MemberBalanceDTO `json:"balances"`
+	Settlements []SettlementDTO    `json:"settlements"`
+}
+
+// CategorizationRuleDTO representa una regla que asigna bolsillo y etiquetas a los gastos diarios
+type CategorizationRuleDTO struct {
+	ID         int      `json:"id"`
+	Name       string   `json:"name" binding:"required,min=1,max=100"`
+	MatchType  string   `json:"match_type" binding:"required,oneof=contains regex"`
+	Pattern    string   `json:"pattern" binding:"required,min=1,max=255"` // "contains" ignora mayúsculas y tildes
+	MinAmount  *float64 `json:"min_amount,omitempty" binding:"omitempty,min=0"`
+	MaxAmount  *float64 `json:"max_amount,omitempty" binding:"omitempty,min=0"`
+	PocketID   int      `json:"pocket_id,omitempty" binding:"omitempty,min=1"`
+	PocketName string   `json:"pocket_name,omitempty"`                       // Solo lectura
+	Tags       []string `json:"tags" binding:"omitempty,max=20,dive,max=50"` // Omitir para no modificarlas
+	Priority   int      `json:"priority" binding:"min=0,max=10000"`          // Menor se evalúa primero
+	Active     *bool    `json:"active,omitempty"`                            // Por defecto true
+}
+
+// CategorizationChangeDTO representa el cambio que una regla hace (o haría) sobre un gasto diario
+type CategorizationChangeDTO struct {
+	ExpenseID      int      `json:"expense_id"`
+	Description    string   `json:"description"`
+	Amount         float64  `json:"amount"`
+	Date           string   `json:"date"`
+	RuleID         int      `json:"rule_id"`
+	RuleName       string   `json:"rule_name"`
+	FromPocketID   *int     `json:"from_pocket_id"`
+	FromPocketName string   `json:"from_pocket_name,omitempty"`
+	ToPocketID     *int     `json:"to_pocket_id"` // null si el bolsillo no cambia
+	ToPocketName   string   `json:"to_pocket_name,omitempty"`
+	AddedTags      []string `json:"added_tags"`
+}
+
+// ApplyRulesResultDTO representa el resultado (o la vista previa) de aplicar las reglas a un mes
+type ApplyRulesResultDTO struct {
+	Month     string                    `json:"month"`
+	DryRun    bool                      `json:"dry_run"`
+	Overwrite bool                      `json:"overwrite"`
+	Changed   int                       `json:"changed"`
+	Changes   []CategorizationChangeDTO `json:"changes"`
+}
+
+// ErrorResponseDTO es el sobre JSON común de todas las respuestas de error
+type ErrorResponseDTO struct {
+	Error   string          `json:"error"`             // Resumen de la operación que falló
+	Code    string          `json:"code"`              // Código estable: "not_found" | "validation_error" | "conflict" | "forbidden" | "internal_error"
+	Details string          `json:"details,omitempty"` // Mensaje del error
+	Fields  []FieldErrorDTO `json:"fields,omitempty"`  // Campos inválidos, solo en errores de validación
+	Current interface{}     `json:"current,omitempty"` // Estado actual del recurso, solo en conflictos de versión
+}
+
+// FieldErrorDTO describe por qué un campo del request es inválido
+type FieldErrorDTO struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// PocketSuggestionDTO representa un bolsillo sugerido para un gasto diario
+type PocketSuggestionDTO struct {
+	PocketID   int     `json:"pocket_id"`
+	PocketName string  `json:"pocket_name"`
+	Confidence float64 `json:"confidence"` // Probabilidad estimada entre 0 y 1
+}
+
+// PocketSuggestionsDTO representa las sugerencias de bolsillo para una descripción
+type PocketSuggestionsDTO struct {
+	Description string                `json:"description"`
+	Suggestions []PocketSuggestionDTO `json:"suggestions"` // Vacío si ninguna palabra es conocida
+	Model       SuggestionModelDTO    `json:"model"`
+}
+
+// SuggestionModelDTO describe el modelo local entrenado con el historial de gastos diarios
+type SuggestionModelDTO struct {
+	TrainedAt time.Time `json:"trained_at"`
+	Examples  int       `json:"examples"` // Gastos con bolsillo usados para entrenar
+	Pockets   int       `json:"pockets"`  // Bolsillos que el modelo puede sugerir
+}
+
+// AuditEntryDTO representa un cambio registrado en el historial de auditoría
+type AuditEntryDTO struct {
+	ID        int             `json:"id"`
+	Entity    string          `json:"entity"` // Tabla modificada, ej: "daily_expenses"
+	EntityID  int             `json:"entity_id"`
+	Action    string          `json:"action"` // create | update | delete
+	Before    json.RawMessage `json:"before"` // Fila antes del cambio; null al crear
+	After     json.RawMessage `json:"after"`  // Fila después del cambio; null al eliminar
+	Actor     string          `json:"actor"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// LedgerEventDTO representa un evento del registro de eventos del libro
+type LedgerEventDTO struct {
+	ID         int             `json:"id"`     // Orden en que se reproducen los eventos
+	Name       string          `json:"name"`   // ej: "daily_expense.created"
+	Entity     string          `json:"entity"` // Tabla de la entidad, ej: "daily_expenses"
+	EntityID   int             `json:"entity_id"`
+	Data       json.RawMessage `json:"data"` // Entidad después del cambio; null al eliminar
+	OccurredAt time.Time       `json:"occurred_at"`
+}
+
+// ConsistencyReportDTO representa la comparación del libro reconstruido con las tablas actuales
+type ConsistencyReportDTO struct {
+	CheckedAt       time.Time        `json:"checked_at"`
+	Consistent      bool             `json:"consistent"`
+	Events          int              `json:"events"`           // Eventos reproducidos
+	LastEventID     int              `json:"last_event_id"`    // Último evento reproducido
+	EntitiesChecked int              `json:"entities_checked"` // Entidades comparadas
+	Discrepancies   []DiscrepancyDTO `json:"discrepancies"`
+}
+
+// DiscrepancyDTO representa una entidad cuyo estado actual no coincide con sus eventos
+type DiscrepancyDTO struct {
+	Entity    string          `json:"entity"`
+	EntityID  int             `json:"entity_id"`
+	Kind      string          `json:"kind"`      // mismatch | missing_row | untracked
+	Projected json.RawMessage `json:"projected"` // Estado según los eventos; null si ningún evento la registró
+	Current   json.RawMessage `json:"current"`   // Fila actual; null si la tabla no la tiene
 }
 
 // ExpenseStatus representa los posibles estados de un gasto fijo
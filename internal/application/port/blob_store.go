@@ -0,0 +1,18 @@
+package port
+
+import (
+	"errors"
+	"io"
+)
+
+// ErrBlobNotFound is returned when a blob does not exist in the store
+var ErrBlobNotFound = errors.New("blob not found")
+
+// BlobStore stores binary content (receipts, thumbnails) by key
+// Implementations may be backed by the local filesystem or an object storage service
+type BlobStore interface {
+	Put(key string, content io.Reader) error
+	Get(key string) (io.ReadCloser, error)
+	Delete(key string) error
+	Exists(key string) (bool, error)
+}
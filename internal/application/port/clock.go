@@ -0,0 +1,9 @@
+package port
+
+import "time"
+
+// Clock tells the current time in the business timezone
+// Dates and months ("today", "current month", overdue checks) are derived from it
+type Clock interface {
+	Now() time.Time
+}
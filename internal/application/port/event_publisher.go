@@ -0,0 +1,7 @@
+package port
+
+// EventPublisher publishes ledger events to interested subscribers
+// Publishing must not block the caller nor fail the operation that triggered it
+type EventPublisher interface {
+	Publish(name string, data interface{})
+}
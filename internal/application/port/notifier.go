@@ -0,0 +1,9 @@
+package port
+
+import "expenses-api/internal/domain/reminder"
+
+// Notifier delivers reminder notifications through a channel such as email or webhooks
+type Notifier interface {
+	Name() string
+	Notify(notification reminder.Notification) error
+}
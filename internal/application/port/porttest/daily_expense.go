@@ -0,0 +1,225 @@
+package porttest
+
+import (
+	"expenses-api/internal/domain/civil"
+	"expenses-api/internal/domain/daily_expense"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+// RunDailyExpenseRepository runs the DailyExpenseRepository contract
+func RunDailyExpenseRepository(t *testing.T, newRepos Factory) {
+	t.Run("Create and GetByID resolve the pocket", func(t *testing.T) {
+		repos := newRepos(t)
+		p := createPocket(t, repos, "Comida")
+
+		expense := &daily_expense.DailyExpense{Description: " Almuerzo ", Amount: 25000, Date: civil.MustParseDate("2026-03-10"), PocketID: &p.ID}
+		requireNoError(t, repos.DailyExpenses.Create(ctx, expense))
+		if expense.ID == 0 || expense.CreatedAt.IsZero() {
+			t.Fatalf("expected ID and creation time to be assigned, got %+v", *expense)
+		}
+
+		got, err := repos.DailyExpenses.GetByID(expense.ID)
+		requireNoError(t, err)
+		if got.Description != "Almuerzo" || got.Amount != 25000 || got.Date != civil.MustParseDate("2026-03-10") {
+			t.Fatalf("unexpected daily expense %+v", *got)
+		}
+		if got.PocketID == nil || *got.PocketID != p.ID || got.Pocket == nil || got.Pocket.Name != "Comida" {
+			t.Fatalf("expected pocket Comida, got %+v", got.Pocket)
+		}
+
+		_, err = repos.DailyExpenses.GetByID(expense.ID + 100)
+		requireNotFound(t, err)
+	})
+
+	t.Run("Create rejects invalid expenses", func(t *testing.T) {
+		repos := newRepos(t)
+
+		requireError(t, repos.DailyExpenses.Create(ctx, &daily_expense.DailyExpense{Description: "Almuerzo", Amount: 0, Date: civil.MustParseDate("2026-03-10")}), "creating an expense without amount")
+		requireError(t, repos.DailyExpenses.Create(ctx, &daily_expense.DailyExpense{Description: "Almuerzo", Amount: 100, Date: civil.Date{}}), "creating an expense without date")
+
+		expenses, err := repos.DailyExpenses.GetByMonth(civil.MustParseMonth("2026-03"))
+		requireNoError(t, err)
+		if len(expenses) != 0 {
+			t.Fatalf("expected no expenses, got %d", len(expenses))
+		}
+	})
+
+	t.Run("GetByMonth and GetByDateRange filter and order newest first", func(t *testing.T) {
+		repos := newRepos(t)
+
+		for _, date := range []string{"2026-03-01", "2026-03-31", "2026-03-15", "2026-04-01", "2026-02-28"} {
+			requireNoError(t, repos.DailyExpenses.Create(ctx, &daily_expense.DailyExpense{Description: "Gasto " + date, Amount: 1000, Date: civil.MustParseDate(date)}))
+		}
+
+		month, err := repos.DailyExpenses.GetByMonth(civil.MustParseMonth("2026-03"))
+		requireNoError(t, err)
+		requireDates(t, month, "2026-03-31", "2026-03-15", "2026-03-01")
+
+		rangeExpenses, err := repos.DailyExpenses.GetByDateRange(civil.MustParseDate("2026-03-15"), civil.MustParseDate("2026-04-01"))
+		requireNoError(t, err)
+		requireDates(t, rangeExpenses, "2026-04-01", "2026-03-31", "2026-03-15")
+	})
+
+	t.Run("Update and Delete", func(t *testing.T) {
+		repos := newRepos(t)
+		p := createPocket(t, repos, "Comida")
+
+		expense := &daily_expense.DailyExpense{Description: "Almuerzo", Amount: 25000, Date: civil.MustParseDate("2026-03-10")}
+		requireNoError(t, repos.DailyExpenses.Create(ctx, expense))
+
+		expense.Amount = 30000
+		expense.Date = civil.MustParseDate("2026-04-02")
+		expense.PocketID = &p.ID
+		requireNoError(t, repos.DailyExpenses.Update(ctx, expense))
+
+		got, err := repos.DailyExpenses.GetByID(expense.ID)
+		requireNoError(t, err)
+		if got.Amount != 30000 || got.Date != civil.MustParseDate("2026-04-02") || got.Pocket == nil || got.Pocket.ID != p.ID {
+			t.Fatalf("update was not stored: %+v", *got)
+		}
+
+		march, err := repos.DailyExpenses.GetByMonth(civil.MustParseMonth("2026-03"))
+		requireNoError(t, err)
+		if len(march) != 0 {
+			t.Fatalf("expected the expense to move out of March, got %d expenses", len(march))
+		}
+
+		expense.Description = ""
+		requireError(t, repos.DailyExpenses.Update(ctx, expense), "updating to an empty description")
+
+		requireVersionConflict(t, repos.DailyExpenses.Delete(ctx, expense.ID, 1))
+		_, err = repos.DailyExpenses.GetByID(expense.ID)
+		requireNoError(t, err)
+
+		requireNoError(t, repos.DailyExpenses.Delete(ctx, expense.ID, expense.Version))
+		_, err = repos.DailyExpenses.GetByID(expense.ID)
+		requireNotFound(t, err)
+	})
+
+	t.Run("Create and Update store split lines", func(t *testing.T) {
+		repos := newRepos(t)
+		food := createPocket(t, repos, "Comida")
+		home := createPocket(t, repos, "Aseo")
+
+		expense := &daily_expense.DailyExpense{Description: "Mercado", Amount: 30000, Date: civil.MustParseDate("2026-03-10"), Splits: []daily_expense.Split{
+			{PocketID: food.ID, Amount: 20000},
+			{PocketID: home.ID, Amount: 10000, Note: "Detergente"},
+		}}
+		requireNoError(t, repos.DailyExpenses.Create(ctx, expense))
+
+		got, err := repos.DailyExpenses.GetByID(expense.ID)
+		requireNoError(t, err)
+		requireSplits(t, got.Splits, "Comida:20000", "Aseo:10000")
+
+		got.Description = "Mercado del mes"
+		got.Splits = nil
+		requireNoError(t, repos.DailyExpenses.Update(ctx, got))
+		got, err = repos.DailyExpenses.GetByID(expense.ID)
+		requireNoError(t, err)
+		requireSplits(t, got.Splits, "Comida:20000", "Aseo:10000")
+
+		keptID := got.Splits[0].ID
+		got.Splits = []daily_expense.Split{got.Splits[0], {PocketID: food.ID, Amount: 10000}}
+		requireNoError(t, repos.DailyExpenses.Update(ctx, got))
+		got, err = repos.DailyExpenses.GetByID(expense.ID)
+		requireNoError(t, err)
+		requireSplits(t, got.Splits, "Comida:20000", "Comida:10000")
+		if got.Splits[0].ID != keptID {
+			t.Fatalf("expected split line %d to be kept, got %d", keptID, got.Splits[0].ID)
+		}
+
+		got.Splits[0].PocketID = home.ID
+		got.Splits[0].Amount = 15000
+		got.Splits[1].Amount = 15000
+		got.Splits[1].Note = "Frutas"
+		requireNoError(t, repos.DailyExpenses.Update(ctx, got))
+		got, err = repos.DailyExpenses.GetByID(expense.ID)
+		requireNoError(t, err)
+		requireSplits(t, got.Splits, "Aseo:15000", "Comida:15000")
+		if got.Splits[0].ID != keptID || got.Splits[1].Note != "Frutas" {
+			t.Fatalf("expected the kept split lines to be updated in place, got %+v", got.Splits)
+		}
+
+		other := &daily_expense.DailyExpense{Description: "Farmacia", Amount: 5000, Date: civil.MustParseDate("2026-03-10"), Splits: []daily_expense.Split{
+			{PocketID: home.ID, Amount: 5000},
+		}}
+		requireNoError(t, repos.DailyExpenses.Create(ctx, other))
+		stolen := other.Splits[0]
+		stolen.DailyExpenseID = expense.ID
+		got.Splits = []daily_expense.Split{got.Splits[0], stolen}
+		if err := repos.DailyExpenses.Update(ctx, got); err == nil {
+			t.Fatal("expected a split line of another expense to be rejected")
+		}
+		got, err = repos.DailyExpenses.GetByID(expense.ID)
+		requireNoError(t, err)
+
+		got.Splits = []daily_expense.Split{}
+		requireNoError(t, repos.DailyExpenses.Update(ctx, got))
+		got, err = repos.DailyExpenses.GetByID(expense.ID)
+		requireNoError(t, err)
+		requireSplits(t, got.Splits)
+	})
+
+	t.Run("Create is safe for concurrent use", func(t *testing.T) {
+		repos := newRepos(t)
+
+		const count = 20
+		var wg sync.WaitGroup
+		errs := make(chan error, count)
+		for i := 0; i < count; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				errs <- repos.DailyExpenses.Create(ctx, &daily_expense.DailyExpense{
+					Description: fmt.Sprintf("Gasto %d", i),
+					Amount:      float64(1000 + i),
+					Date:        civil.MustParseDate("2026-03-10"),
+				})
+			}(i)
+		}
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			requireNoError(t, err)
+		}
+
+		expenses, err := repos.DailyExpenses.GetByMonth(civil.MustParseMonth("2026-03"))
+		requireNoError(t, err)
+		ids := make(map[uint]bool, len(expenses))
+		for _, expense := range expenses {
+			ids[expense.ID] = true
+		}
+		if len(ids) != count {
+			t.Fatalf("expected %d expenses with distinct IDs, got %d", count, len(ids))
+		}
+	})
+}
+
+// requireDates fails unless the expenses have exactly the given dates in order
+func requireDates(t *testing.T, expenses []daily_expense.DailyExpense, dates ...string) {
+	t.Helper()
+
+	got := make([]string, 0, len(expenses))
+	for _, expense := range expenses {
+		got = append(got, expense.Date.String())
+	}
+	if fmt.Sprint(got) != fmt.Sprint(dates) {
+		t.Fatalf("expected dates %v, got %v", dates, got)
+	}
+}
+
+// requireSplits fails unless the split lines have exactly the given pockets
+// and amounts, formatted as "pocket:amount", in order
+func requireSplits(t *testing.T, splits []daily_expense.Split, lines ...string) {
+	t.Helper()
+
+	got := make([]string, 0, len(splits))
+	for _, split := range splits {
+		got = append(got, fmt.Sprintf("%s:%.0f", split.GetPocketName(), split.Amount))
+	}
+	if fmt.Sprint(got) != fmt.Sprint(lines) {
+		t.Fatalf("expected split lines %v, got %v", lines, got)
+	}
+}
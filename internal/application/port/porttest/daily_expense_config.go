@@ -0,0 +1,50 @@
+package porttest
+
+import (
+	"expenses-api/internal/domain/civil"
+	"expenses-api/internal/domain/daily_expense_config"
+	"testing"
+)
+
+// RunDailyExpenseConfigRepository runs the DailyExpenseConfigRepository contract
+func RunDailyExpenseConfigRepository(t *testing.T, newRepos Factory) {
+	t.Run("GetByMonth of an unknown month is not found", func(t *testing.T) {
+		repo := newRepos(t).DailyExpenseConfigs
+
+		_, err := repo.GetByMonth(civil.MustParseMonth("2026-03"))
+		requireNotFound(t, err)
+	})
+
+	t.Run("CreateOrUpdate creates then updates the month", func(t *testing.T) {
+		repo := newRepos(t).DailyExpenseConfigs
+
+		created := &daily_expense_config.DailyExpenseConfig{Month: civil.MustParseMonth("2026-03"), MonthlyBudget: 1000000}
+		requireNoError(t, repo.CreateOrUpdate(ctx, created))
+		if created.ID == 0 {
+			t.Fatal("expected an ID to be assigned on create")
+		}
+
+		requireNoError(t, repo.CreateOrUpdate(ctx, &daily_expense_config.DailyExpenseConfig{Month: civil.MustParseMonth("2026-03"), MonthlyBudget: 1200000}))
+
+		got, err := repo.GetByMonth(civil.MustParseMonth("2026-03"))
+		requireNoError(t, err)
+		if got.ID != created.ID || got.MonthlyBudget != 1200000 {
+			t.Fatalf("expected config %d with 1200000, got %d with %v", created.ID, got.ID, got.MonthlyBudget)
+		}
+	})
+
+	t.Run("CreateOrUpdate rejects invalid budgets", func(t *testing.T) {
+		repo := newRepos(t).DailyExpenseConfigs
+
+		requireError(t, repo.CreateOrUpdate(ctx, &daily_expense_config.DailyExpenseConfig{Month: civil.Month{}, MonthlyBudget: 100}), "creating a config without month")
+
+		requireNoError(t, repo.CreateOrUpdate(ctx, &daily_expense_config.DailyExpenseConfig{Month: civil.MustParseMonth("2026-03"), MonthlyBudget: 100}))
+		requireError(t, repo.CreateOrUpdate(ctx, &daily_expense_config.DailyExpenseConfig{Month: civil.MustParseMonth("2026-03"), MonthlyBudget: -1}), "updating to a negative budget")
+
+		got, err := repo.GetByMonth(civil.MustParseMonth("2026-03"))
+		requireNoError(t, err)
+		if got.MonthlyBudget != 100 {
+			t.Fatalf("expected the rejected update to keep 100, got %v", got.MonthlyBudget)
+		}
+	})
+}
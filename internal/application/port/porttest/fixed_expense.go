@@ -0,0 +1,169 @@
+package porttest
+
+import (
+	"expenses-api/internal/domain/civil"
+	"expenses-api/internal/domain/fixed_expense"
+	"fmt"
+	"testing"
+)
+
+// RunFixedExpenseRepository runs the FixedExpenseRepository contract
+func RunFixedExpenseRepository(t *testing.T, newRepos Factory) {
+	t.Run("Create and GetByID resolve the pocket", func(t *testing.T) {
+		repos := newRepos(t)
+		p := createPocket(t, repos, "Hogar")
+
+		expense := &fixed_expense.FixedExpense{PocketID: p.ID, ConceptName: " Arriendo ", Amount: 1500000, PaymentDay: 5, Month: civil.MustParseMonth("2026-03")}
+		requireNoError(t, repos.FixedExpenses.Create(ctx, expense))
+		if expense.ID == 0 {
+			t.Fatal("expected an ID to be assigned on create")
+		}
+
+		got, err := repos.FixedExpenses.GetByID(expense.ID)
+		requireNoError(t, err)
+		if got.ConceptName != "Arriendo" || got.Amount != 1500000 || got.IsPaid || got.PaidDate != nil {
+			t.Fatalf("unexpected fixed expense %+v", *got)
+		}
+		if got.Pocket == nil || got.Pocket.Name != "Hogar" {
+			t.Fatalf("expected pocket Hogar, got %+v", got.Pocket)
+		}
+
+		_, err = repos.FixedExpenses.GetByID(expense.ID + 100)
+		requireNotFound(t, err)
+	})
+
+	t.Run("Create rejects invalid expenses", func(t *testing.T) {
+		repos := newRepos(t)
+		p := createPocket(t, repos, "Hogar")
+
+		requireError(t, repos.FixedExpenses.Create(ctx, &fixed_expense.FixedExpense{PocketID: p.ID, ConceptName: "Arriendo", Amount: 100, PaymentDay: 0, Month: civil.MustParseMonth("2026-03")}), "creating an expense with payment day 0")
+		requireError(t, repos.FixedExpenses.Create(ctx, &fixed_expense.FixedExpense{PocketID: p.ID, ConceptName: "", Amount: 100, PaymentDay: 5, Month: civil.MustParseMonth("2026-03")}), "creating an expense without concept")
+
+		expenses, err := repos.FixedExpenses.GetByMonth(civil.MustParseMonth("2026-03"))
+		requireNoError(t, err)
+		if len(expenses) != 0 {
+			t.Fatalf("expected no expenses, got %d", len(expenses))
+		}
+	})
+
+	t.Run("GetByMonth filters the month and orders by payment day and concept", func(t *testing.T) {
+		repos := newRepos(t)
+		p := createPocket(t, repos, "Hogar")
+
+		for _, expense := range []fixed_expense.FixedExpense{
+			{ConceptName: "Luz", PaymentDay: 15, Month: civil.MustParseMonth("2026-03")},
+			{ConceptName: "Internet", PaymentDay: 5, Month: civil.MustParseMonth("2026-03")},
+			{ConceptName: "Arriendo", PaymentDay: 5, Month: civil.MustParseMonth("2026-03")},
+			{ConceptName: "Arriendo", PaymentDay: 5, Month: civil.MustParseMonth("2026-04")},
+		} {
+			expense.PocketID = p.ID
+			expense.Amount = 1000
+			requireNoError(t, repos.FixedExpenses.Create(ctx, &expense))
+		}
+
+		expenses, err := repos.FixedExpenses.GetByMonth(civil.MustParseMonth("2026-03"))
+		requireNoError(t, err)
+		var concepts []string
+		for _, expense := range expenses {
+			concepts = append(concepts, expense.ConceptName)
+			if expense.Pocket == nil || expense.Pocket.ID != p.ID {
+				t.Fatalf("expected pocket %d on %s, got %+v", p.ID, expense.ConceptName, expense.Pocket)
+			}
+		}
+		if len(concepts) != 3 || concepts[0] != "Arriendo" || concepts[1] != "Internet" || concepts[2] != "Luz" {
+			t.Fatalf("expected Arriendo, Internet, Luz, got %v", concepts)
+		}
+
+		ranged, err := repos.FixedExpenses.GetByMonthRange(civil.MustParseMonth("2026-03"), civil.MustParseMonth("2026-04"))
+		requireNoError(t, err)
+		concepts = nil
+		for _, expense := range ranged {
+			concepts = append(concepts, expense.Month.String()+" "+expense.ConceptName)
+		}
+		if fmt.Sprint(concepts) != "[2026-03 Arriendo 2026-03 Internet 2026-03 Luz 2026-04 Arriendo]" {
+			t.Fatalf("expected March then April expenses, got %v", concepts)
+		}
+
+		april, err := repos.FixedExpenses.GetByMonthRange(civil.MustParseMonth("2026-04"), civil.MustParseMonth("2026-05"))
+		requireNoError(t, err)
+		if len(april) != 1 || april[0].Month != civil.MustParseMonth("2026-04") {
+			t.Fatalf("expected only the April expense, got %d expenses", len(april))
+		}
+	})
+
+	t.Run("Update stores the changes", func(t *testing.T) {
+		repos := newRepos(t)
+		p := createPocket(t, repos, "Hogar")
+		other := createPocket(t, repos, "Servicios")
+
+		expense := &fixed_expense.FixedExpense{PocketID: p.ID, ConceptName: "Luz", Amount: 90000, PaymentDay: 15, Month: civil.MustParseMonth("2026-03")}
+		requireNoError(t, repos.FixedExpenses.Create(ctx, expense))
+
+		actual := 95000.0
+		expense.PocketID = other.ID
+		expense.Amount = 100000
+		expense.ActualAmount = &actual
+		requireNoError(t, repos.FixedExpenses.Update(ctx, expense))
+
+		got, err := repos.FixedExpenses.GetByID(expense.ID)
+		requireNoError(t, err)
+		if got.Amount != 100000 || got.ActualAmount == nil || *got.ActualAmount != 95000 {
+			t.Fatalf("update was not stored: %+v", *got)
+		}
+		if got.Pocket == nil || got.Pocket.Name != "Servicios" {
+			t.Fatalf("expected pocket Servicios, got %+v", got.Pocket)
+		}
+
+		expense.PaymentDay = 40
+		requireError(t, repos.FixedExpenses.Update(ctx, expense), "updating to payment day 40")
+	})
+
+	t.Run("Paid status and the unpaid and overdue queries", func(t *testing.T) {
+		repos := newRepos(t)
+		p := createPocket(t, repos, "Hogar")
+
+		rent := &fixed_expense.FixedExpense{PocketID: p.ID, ConceptName: "Arriendo", Amount: 1500000, PaymentDay: 5, Month: civil.MustParseMonth("2026-03")}
+		power := &fixed_expense.FixedExpense{PocketID: p.ID, ConceptName: "Luz", Amount: 90000, PaymentDay: 8, Month: civil.MustParseMonth("2026-03")}
+		phone := &fixed_expense.FixedExpense{PocketID: p.ID, ConceptName: "Celular", Amount: 50000, PaymentDay: 20, Month: civil.MustParseMonth("2026-03")}
+		water := &fixed_expense.FixedExpense{PocketID: p.ID, ConceptName: "Agua", Amount: 40000, PaymentDay: 25, Month: civil.MustParseMonth("2026-02")}
+		internet := &fixed_expense.FixedExpense{PocketID: p.ID, ConceptName: "Internet", Amount: 80000, PaymentDay: 1, Month: civil.MustParseMonth("2026-04")}
+		for _, expense := range []*fixed_expense.FixedExpense{rent, power, phone, water, internet} {
+			requireNoError(t, repos.FixedExpenses.Create(ctx, expense))
+		}
+
+		paidDate := civil.MustParseDate("2026-03-04")
+		rent.IsPaid = true
+		rent.PaidDate = &paidDate
+		requireNoError(t, repos.FixedExpenses.Update(ctx, rent))
+
+		got, err := repos.FixedExpenses.GetByID(rent.ID)
+		requireNoError(t, err)
+		if !got.IsPaid || got.PaidDate == nil || *got.PaidDate != paidDate {
+			t.Fatalf("expected paid on %s, got %+v", paidDate, *got)
+		}
+
+		unpaid, err := repos.FixedExpenses.GetUnpaidByMonth(civil.MustParseMonth("2026-03"))
+		requireNoError(t, err)
+		if len(unpaid) != 2 || unpaid[0].ID != power.ID || unpaid[1].ID != phone.ID {
+			t.Fatalf("expected Luz and Celular unpaid, got %d expenses", len(unpaid))
+		}
+
+		overdue, err := repos.FixedExpenses.GetOverdueByMonth(civil.MustParseMonth("2026-03"), 10)
+		requireNoError(t, err)
+		if len(overdue) != 1 || overdue[0].ID != power.ID {
+			t.Fatalf("expected only Luz overdue, got %d expenses", len(overdue))
+		}
+
+		inRange, err := repos.FixedExpenses.GetUnpaidInMonthRange(civil.MustParseMonth("2026-02"), civil.MustParseMonth("2026-03"))
+		requireNoError(t, err)
+		if len(inRange) != 3 || inRange[0].ID != water.ID || inRange[1].ID != power.ID || inRange[2].ID != phone.ID {
+			t.Fatalf("expected Agua, Luz and Celular unpaid from 2026-02 to 2026-03, got %d expenses", len(inRange))
+		}
+
+		inRange, err = repos.FixedExpenses.GetUnpaidInMonthRange(civil.MustParseMonth("2026-03"), civil.MustParseMonth("2026-04"))
+		requireNoError(t, err)
+		if len(inRange) != 3 || inRange[0].ID != power.ID || inRange[1].ID != phone.ID || inRange[2].ID != internet.ID {
+			t.Fatalf("expected Luz, Celular and Internet unpaid from 2026-03 to 2026-04, got %d expenses", len(inRange))
+		}
+	})
+}
@@ -0,0 +1,163 @@
+package porttest
+
+import (
+	"expenses-api/internal/domain/pocket"
+	"testing"
+)
+
+// RunPocketRepository runs the PocketRepository contract
+func RunPocketRepository(t *testing.T, newRepos Factory) {
+	t.Run("Create assigns an ID and applies defaults", func(t *testing.T) {
+		repo := newRepos(t).Pockets
+
+		p := &pocket.Pocket{Name: "  Comida  ", Description: "Mercado"}
+		requireNoError(t, repo.Create(ctx, p))
+		if p.ID == 0 {
+			t.Fatal("expected an ID to be assigned on create")
+		}
+
+		got, err := repo.GetByID(p.ID)
+		requireNoError(t, err)
+		if got.Name != "Comida" || got.Description != "Mercado" || got.RolloverPolicy != pocket.RolloverPolicyRollover {
+			t.Fatalf("unexpected pocket %+v", *got)
+		}
+
+		byName, err := repo.GetByName("Comida")
+		requireNoError(t, err)
+		if byName.ID != p.ID {
+			t.Fatalf("expected pocket %d by name, got %d", p.ID, byName.ID)
+		}
+	})
+
+	t.Run("Create rejects invalid and duplicate names", func(t *testing.T) {
+		repo := newRepos(t).Pockets
+
+		requireError(t, repo.Create(ctx, &pocket.Pocket{Name: " "}), "creating a pocket without name")
+		requireError(t, repo.Create(ctx, &pocket.Pocket{Name: "Ahorro", RolloverPolicy: "keep"}), "creating a pocket with an unknown rollover policy")
+
+		requireNoError(t, repo.Create(ctx, &pocket.Pocket{Name: "Comida"}))
+		requireError(t, repo.Create(ctx, &pocket.Pocket{Name: "Comida"}), "creating a pocket with a duplicate name")
+
+		pockets, err := repo.GetAll()
+		requireNoError(t, err)
+		if len(pockets) != 1 {
+			t.Fatalf("expected 1 pocket, got %d", len(pockets))
+		}
+	})
+
+	t.Run("GetAll orders by name", func(t *testing.T) {
+		repo := newRepos(t).Pockets
+
+		for _, name := range []string{"Transporte", "Arriendo", "Comida"} {
+			requireNoError(t, repo.Create(ctx, &pocket.Pocket{Name: name}))
+		}
+
+		pockets, err := repo.GetAll()
+		requireNoError(t, err)
+		var names []string
+		for _, p := range pockets {
+			names = append(names, p.Name)
+		}
+		if len(names) != 3 || names[0] != "Arriendo" || names[1] != "Comida" || names[2] != "Transporte" {
+			t.Fatalf("expected pockets ordered by name, got %v", names)
+		}
+	})
+
+	t.Run("Update and Delete", func(t *testing.T) {
+		repo := newRepos(t).Pockets
+
+		p := &pocket.Pocket{Name: "Comida"}
+		requireNoError(t, repo.Create(ctx, p))
+		other := &pocket.Pocket{Name: "Transporte"}
+		requireNoError(t, repo.Create(ctx, other))
+
+		p.Name = "Alimentación"
+		p.RolloverPolicy = pocket.RolloverPolicySweep
+		requireNoError(t, repo.Update(ctx, p))
+
+		got, err := repo.GetByID(p.ID)
+		requireNoError(t, err)
+		if got.Name != "Alimentación" || got.RolloverPolicy != pocket.RolloverPolicySweep {
+			t.Fatalf("update was not stored: %+v", *got)
+		}
+		_, err = repo.GetByName("Comida")
+		requireNotFound(t, err)
+
+		other.Name = "Alimentación"
+		requireError(t, repo.Update(ctx, other), "renaming a pocket to a taken name")
+
+		requireNoError(t, repo.Delete(ctx, p.ID, 0))
+		_, err = repo.GetByID(p.ID)
+		requireNotFound(t, err)
+
+		requireNoError(t, repo.Delete(ctx, p.ID, 0))
+	})
+
+	t.Run("Update rejects stale versions", func(t *testing.T) {
+		repo := newRepos(t).Pockets
+
+		p := &pocket.Pocket{Name: "Comida"}
+		requireNoError(t, repo.Create(ctx, p))
+		if p.Version != 1 {
+			t.Fatalf("expected version 1 on create, got %d", p.Version)
+		}
+
+		stale := *p
+		p.Description = "Mercado"
+		requireNoError(t, repo.Update(ctx, p))
+		if p.Version != 2 {
+			t.Fatalf("expected version 2 after update, got %d", p.Version)
+		}
+
+		stale.Description = "Restaurantes"
+		requireVersionConflict(t, repo.Update(ctx, &stale))
+		if stale.Version != 1 {
+			t.Fatalf("expected the stale version to be kept, got %d", stale.Version)
+		}
+
+		got, err := repo.GetByID(p.ID)
+		requireNoError(t, err)
+		if got.Description != "Mercado" || got.Version != 2 {
+			t.Fatalf("stale update overwrote the pocket: %+v", *got)
+		}
+
+		requireVersionConflict(t, repo.Update(ctx, &pocket.Pocket{ID: 999, Name: "Nada", Version: 1}))
+	})
+
+	t.Run("Delete rejects stale versions", func(t *testing.T) {
+		repo := newRepos(t).Pockets
+
+		p := &pocket.Pocket{Name: "Comida"}
+		requireNoError(t, repo.Create(ctx, p))
+		p.Description = "Mercado"
+		requireNoError(t, repo.Update(ctx, p))
+
+		requireVersionConflict(t, repo.Delete(ctx, p.ID, 1))
+		_, err := repo.GetByID(p.ID)
+		requireNoError(t, err)
+
+		requireNoError(t, repo.Delete(ctx, p.ID, 2))
+		_, err = repo.GetByID(p.ID)
+		requireNotFound(t, err)
+
+		requireVersionConflict(t, repo.Delete(ctx, p.ID, 2))
+	})
+
+	t.Run("GetByID and GetByName of unknown pockets are not found", func(t *testing.T) {
+		repo := newRepos(t).Pockets
+
+		_, err := repo.GetByID(999)
+		requireNotFound(t, err)
+		_, err = repo.GetByName("Nada")
+		requireNotFound(t, err)
+	})
+}
+
+// createPocket creates a pocket for the expense contracts
+func createPocket(t *testing.T, repos Repositories, name string) *pocket.Pocket {
+	t.Helper()
+
+	p := &pocket.Pocket{Name: name}
+	requireNoError(t, repos.Pockets.Create(ctx, p))
+	return p
+}
@@ -0,0 +1,84 @@
+// Package porttest holds the contract every implementation of the repository
+// ports must satisfy. Both the GORM and the in-memory repositories are expected
+// to pass it; call it from a test with a factory returning fresh, empty
+// repositories that share one store:
+//
+//	func TestMemoryRepositories(t *testing.T) {
+//		porttest.RunRepositories(t, func(t *testing.T) porttest.Repositories {
+//			pockets := memory.NewPocketRepository()
+//			return porttest.Repositories{
+//				Salaries:            memory.NewSalaryRepository(),
+//				Pockets:             pockets,
+//				FixedExpenses:       memory.NewFixedExpenseRepository(pockets),
+//				DailyExpenses:       memory.NewDailyExpenseRepository(pockets),
+//				DailyExpenseConfigs: memory.NewDailyExpenseConfigRepository(),
+//			}
+//		})
+//	}
+package porttest
+
+import (
+	"context"
+	"errors"
+	"expenses-api/internal/application/port"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+// Repositories groups the port implementations under test
+// Expense repositories must resolve pockets created through Pockets
+type Repositories struct {
+	Salaries            port.SalaryRepository
+	Pockets             port.PocketRepository
+	FixedExpenses       port.FixedExpenseRepository
+	DailyExpenses       port.DailyExpenseRepository
+	DailyExpenseConfigs port.DailyExpenseConfigRepository
+}
+
+// ctx is the context of every change made by the contract
+var ctx = context.Background()
+
+// Factory returns fresh, empty repositories for one test case
+type Factory func(t *testing.T) Repositories
+
+// RunRepositories runs the contract of every repository port
+func RunRepositories(t *testing.T, newRepos Factory) {
+	t.Run("SalaryRepository", func(t *testing.T) { RunSalaryRepository(t, newRepos) })
+	t.Run("PocketRepository", func(t *testing.T) { RunPocketRepository(t, newRepos) })
+	t.Run("FixedExpenseRepository", func(t *testing.T) { RunFixedExpenseRepository(t, newRepos) })
+	t.Run("DailyExpenseRepository", func(t *testing.T) { RunDailyExpenseRepository(t, newRepos) })
+	t.Run("DailyExpenseConfigRepository", func(t *testing.T) { RunDailyExpenseConfigRepository(t, newRepos) })
+}
+
+// requireNotFound fails unless err reports a missing record the way GORM does
+func requireNotFound(t *testing.T, err error) {
+	t.Helper()
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("expected record not found, got %v", err)
+	}
+}
+
+// requireVersionConflict fails unless err reports a stale version
+func requireVersionConflict(t *testing.T, err error) {
+	t.Helper()
+	if !errors.Is(err, port.ErrVersionConflict) {
+		t.Fatalf("expected a version conflict, got %v", err)
+	}
+}
+
+// requireNoError fails on any error
+func requireNoError(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+// requireError fails unless an error was returned
+func requireError(t *testing.T, err error, action string) {
+	t.Helper()
+	if err == nil {
+		t.Fatalf("expected %s to fail", action)
+	}
+}
@@ -0,0 +1,69 @@
+package porttest
+
+import (
+	"expenses-api/internal/domain/civil"
+	"expenses-api/internal/domain/salary"
+	"testing"
+)
+
+// RunSalaryRepository runs the SalaryRepository contract
+func RunSalaryRepository(t *testing.T, newRepos Factory) {
+	t.Run("GetByMonth of an unknown month is not found", func(t *testing.T) {
+		repo := newRepos(t).Salaries
+
+		_, err := repo.GetByMonth(civil.MustParseMonth("2026-03"))
+		requireNotFound(t, err)
+	})
+
+	t.Run("CreateOrUpdate creates then updates the month", func(t *testing.T) {
+		repo := newRepos(t).Salaries
+
+		created := &salary.Salary{Month: civil.MustParseMonth("2026-03"), MonthlyAmount: 5000000}
+		requireNoError(t, repo.CreateOrUpdate(ctx, created))
+		if created.ID == 0 {
+			t.Fatal("expected an ID to be assigned on create")
+		}
+
+		requireNoError(t, repo.CreateOrUpdate(ctx, &salary.Salary{Month: civil.MustParseMonth("2026-03"), MonthlyAmount: 5200000}))
+		requireNoError(t, repo.CreateOrUpdate(ctx, &salary.Salary{Month: civil.MustParseMonth("2026-04"), MonthlyAmount: 4000000}))
+
+		got, err := repo.GetByMonth(civil.MustParseMonth("2026-03"))
+		requireNoError(t, err)
+		if got.ID != created.ID || got.MonthlyAmount != 5200000 {
+			t.Fatalf("expected salary %d with 5200000, got %d with %v", created.ID, got.ID, got.MonthlyAmount)
+		}
+	})
+
+	t.Run("CreateOrUpdate rejects stale versions", func(t *testing.T) {
+		repo := newRepos(t).Salaries
+
+		requireVersionConflict(t, repo.CreateOrUpdate(ctx, &salary.Salary{Month: civil.MustParseMonth("2026-03"), MonthlyAmount: 100, Version: 1}))
+
+		created := &salary.Salary{Month: civil.MustParseMonth("2026-03"), MonthlyAmount: 5000000}
+		requireNoError(t, repo.CreateOrUpdate(ctx, created))
+
+		updated := &salary.Salary{Month: created.Month, MonthlyAmount: 5200000, Version: created.Version}
+		requireNoError(t, repo.CreateOrUpdate(ctx, updated))
+		if updated.Version != created.Version+1 {
+			t.Fatalf("expected version %d after update, got %d", created.Version+1, updated.Version)
+		}
+
+		requireVersionConflict(t, repo.CreateOrUpdate(ctx, &salary.Salary{Month: created.Month, MonthlyAmount: 1, Version: created.Version}))
+
+		got, err := repo.GetByMonth(created.Month)
+		requireNoError(t, err)
+		if got.MonthlyAmount != 5200000 {
+			t.Fatalf("stale update overwrote the salary: %+v", *got)
+		}
+	})
+
+	t.Run("CreateOrUpdate rejects invalid salaries", func(t *testing.T) {
+		repo := newRepos(t).Salaries
+
+		requireError(t, repo.CreateOrUpdate(ctx, &salary.Salary{Month: civil.Month{}, MonthlyAmount: 100}), "creating a salary without month")
+		requireError(t, repo.CreateOrUpdate(ctx, &salary.Salary{Month: civil.MustParseMonth("2026-03"), MonthlyAmount: -1}), "creating a negative salary")
+
+		_, err := repo.GetByMonth(civil.MustParseMonth("2026-03"))
+		requireNotFound(t, err)
+	})
+}
@@ -1,18 +1,42 @@
 package port
 
 import (
+	"context"
+	"errors"
+	"expenses-api/internal/domain/alert"
+	"expenses-api/internal/domain/attachment"
+	"expenses-api/internal/domain/audit"
+	"expenses-api/internal/domain/categorization"
+	"expenses-api/internal/domain/civil"
 	"expenses-api/internal/domain/daily_expense"
 	"expenses-api/internal/domain/daily_expense_config"
 	"expenses-api/internal/domain/fixed_expense"
+	"expenses-api/internal/domain/household"
+	"expenses-api/internal/domain/idempotency"
+	"expenses-api/internal/domain/ledger"
 	"expenses-api/internal/domain/pocket"
+	"expenses-api/internal/domain/pocket_allocation"
+	"expenses-api/internal/domain/receivable"
+	"expenses-api/internal/domain/reminder"
 	"expenses-api/internal/domain/salary"
+	"expenses-api/internal/domain/tag"
+	"expenses-api/internal/domain/transfer"
+	"expenses-api/internal/domain/webhook"
+	"time"
 )
 
+// Methods that change data take the context of the request; it carries the
+// actor recorded in the audit log (see audit.WithActor)
+
+// ErrVersionConflict is returned when saving an entity whose stored version no
+// longer matches the version it was read with, because someone else changed it
+var ErrVersionConflict = errors.New("version conflict")
+
 // SalaryRepository defines the interface for salary data operations
 // Frontend endpoints: GET/PUT /api/config/income
 type SalaryRepository interface {
-	GetByMonth(month string) (*salary.Salary, error)
-	CreateOrUpdate(s *salary.Salary) error
+	GetByMonth(month civil.Month) (*salary.Salary, error)
+	CreateOrUpdate(ctx context.Context, s *salary.Salary) error // ErrVersionConflict when a non-zero s.Version is stale
 }
 
 // PocketRepository defines the interface for pocket data operations
@@ -21,34 +45,222 @@ type PocketRepository interface {
 	GetAll() ([]pocket.Pocket, error)
 	GetByID(id uint) (*pocket.Pocket, error)
 	GetByName(name string) (*pocket.Pocket, error)
-	Create(p *pocket.Pocket) error
-	Update(p *pocket.Pocket) error
-	Delete(id uint) error
+	Create(ctx context.Context, p *pocket.Pocket) error
+	Update(ctx context.Context, p *pocket.Pocket) error      // ErrVersionConflict when p.Version is stale; increments it on success
+	Delete(ctx context.Context, id uint, version uint) error // ErrVersionConflict when a non-zero version is stale
 }
 
 // FixedExpenseRepository defines the interface for fixed expense data operations
 // Frontend endpoints: GET /api/fixed-expenses/{month}, POST/PUT /api/fixed-expenses, PUT /api/fixed-expenses/{id}/status
 type FixedExpenseRepository interface {
-	GetByMonth(month string) ([]fixed_expense.FixedExpense, error)
+	GetByMonth(month civil.Month) ([]fixed_expense.FixedExpense, error)
+	GetByMonthRange(start, end civil.Month) ([]fixed_expense.FixedExpense, error) // Both ends included; ordered by month, payment day and concept
 	GetByID(id uint) (*fixed_expense.FixedExpense, error)
-	Create(expense *fixed_expense.FixedExpense) error
-	Update(expense *fixed_expense.FixedExpense) error
-	UpdatePaymentStatus(id uint, isPaid bool, paidDate *string) error
+	Create(ctx context.Context, expense *fixed_expense.FixedExpense) error
+	Update(ctx context.Context, expense *fixed_expense.FixedExpense) error // ErrVersionConflict when expense.Version is stale; increments it on success
+	GetUnpaidByMonth(month civil.Month) ([]fixed_expense.FixedExpense, error)
+	GetOverdueByMonth(month civil.Month, currentDay int) ([]fixed_expense.FixedExpense, error)
+	GetUnpaidInMonthRange(start, end civil.Month) ([]fixed_expense.FixedExpense, error) // Inclusive; ordered by month, payment day and concept
+}
+
+// FixedExpensePaymentRepository defines the interface for fixed expense payment data operations
+// Frontend endpoints: POST /api/fixed-expenses/{id}/payments, DELETE /api/fixed-expenses/{id}/payments/{payment_id}
+type FixedExpensePaymentRepository interface {
+	GetByFixedExpense(fixedExpenseID uint) ([]fixed_expense.Payment, error)
+	GetByID(id uint) (*fixed_expense.Payment, error)
+	Create(ctx context.Context, payment *fixed_expense.Payment) error
+	Delete(ctx context.Context, id uint) error
+	DeleteByFixedExpense(ctx context.Context, fixedExpenseID uint) error
+	Save(ctx context.Context, expense *fixed_expense.FixedExpense) error // Stores expense.Payments with the expense in one transaction; ErrVersionConflict when expense.Version is stale; increments it on success
 }
 
 // DailyExpenseRepository defines the interface for daily expense data operations
 // Frontend endpoints: GET/POST/PUT/DELETE /api/daily-expenses
 type DailyExpenseRepository interface {
-	GetByMonth(month string) ([]daily_expense.DailyExpense, error)
+	GetByMonth(month civil.Month) ([]daily_expense.DailyExpense, error)
+	GetByDateRange(startDate, endDate civil.Date) ([]daily_expense.DailyExpense, error)
 	GetByID(id uint) (*daily_expense.DailyExpense, error)
-	Create(expense *daily_expense.DailyExpense) error
-	Update(expense *daily_expense.DailyExpense) error
-	Delete(id uint) error
+	Create(ctx context.Context, expense *daily_expense.DailyExpense) error // Also stores expense.Splits and links expense.Tags, in one transaction
+	Update(ctx context.Context, expense *daily_expense.DailyExpense) error // ErrVersionConflict when expense.Version is stale; increments it on success; non-nil Splits and Tags replace the stored ones
+	Delete(ctx context.Context, id uint, version uint) error               // ErrVersionConflict when a non-zero version is stale
 }
 
 // DailyExpenseConfigRepository defines the interface for daily expense config data operations
 // Frontend endpoints: GET/PUT /api/config/daily-budget/{month}
 type DailyExpenseConfigRepository interface {
-	GetByMonth(month string) (*daily_expense_config.DailyExpenseConfig, error)
-	CreateOrUpdate(config *daily_expense_config.DailyExpenseConfig) error
+	GetByMonth(month civil.Month) (*daily_expense_config.DailyExpenseConfig, error)
+	CreateOrUpdate(ctx context.Context, config *daily_expense_config.DailyExpenseConfig) error // ErrVersionConflict when a non-zero config.Version is stale
+}
+
+// TransferRepository defines the interface for transfer data operations
+// Frontend endpoints: GET /api/transfers/{month}, POST/PUT/DELETE /api/transfers, GET /api/transfers/balances
+type TransferRepository interface {
+	GetAll() ([]transfer.Transfer, error)
+	GetByMonth(month civil.Month) ([]transfer.Transfer, error)
+	GetByDateRange(startDate, endDate civil.Date) ([]transfer.Transfer, error)
+	GetByID(id uint) (*transfer.Transfer, error)
+	Create(ctx context.Context, t *transfer.Transfer) error
+	Update(ctx context.Context, t *transfer.Transfer) error  // ErrVersionConflict when t.Version is stale; increments it on success
+	Delete(ctx context.Context, id uint, version uint) error // ErrVersionConflict when a non-zero version is stale
+}
+
+// PocketAllocationRepository defines the interface for pocket envelope allocation data operations
+// Frontend endpoints: GET /api/envelopes/{month}, PUT /api/envelopes/{month}/{pocket_id}
+type PocketAllocationRepository interface {
+	GetByMonth(month civil.Month) ([]pocket_allocation.PocketAllocation, error)
+	GetByMonthRange(start, end civil.Month) ([]pocket_allocation.PocketAllocation, error)
+	GetEarliestMonth() (civil.Month, error)
+	CreateOrUpdate(ctx context.Context, allocation *pocket_allocation.PocketAllocation) error
+}
+
+// ReminderLogRepository defines the interface for sent reminder bookkeeping
+// Used by the background reminder scheduler, no frontend endpoints
+type ReminderLogRepository interface {
+	Exists(fixedExpenseID uint, kind, channel string) (bool, error)
+	Create(log *reminder.ReminderLog) error
+}
+
+// WebhookSubscriptionRepository defines the interface for webhook subscription data operations
+// Frontend endpoints: GET/POST/PUT/DELETE /api/webhooks
+type WebhookSubscriptionRepository interface {
+	GetAll() ([]webhook.Subscription, error)
+	GetActive() ([]webhook.Subscription, error)
+	GetByID(id uint) (*webhook.Subscription, error)
+	Create(ctx context.Context, s *webhook.Subscription) error
+	Update(ctx context.Context, s *webhook.Subscription) error
+	Delete(ctx context.Context, id uint) error
+}
+
+// WebhookDeliveryRepository defines the interface for the webhook delivery log
+// Frontend endpoints: GET /api/webhooks/{id}/deliveries
+type WebhookDeliveryRepository interface {
+	GetBySubscription(subscriptionID uint, limit int) ([]webhook.Delivery, error)
+	Create(d *webhook.Delivery) error
+}
+
+// AlertRuleRepository defines the interface for budget alert rule data operations
+// Frontend endpoints: GET/POST/PUT/DELETE /api/alert-rules
+type AlertRuleRepository interface {
+	GetAll() ([]alert.Rule, error)
+	GetActive() ([]alert.Rule, error)
+	GetByID(id uint) (*alert.Rule, error)
+	Create(ctx context.Context, rule *alert.Rule) error
+	Update(ctx context.Context, rule *alert.Rule) error
+	Delete(ctx context.Context, id uint) error
+}
+
+// AlertRepository defines the interface for fired alert data operations
+// Frontend endpoints: GET /api/alerts, PUT /api/alerts/{id}/acknowledge
+type AlertRepository interface {
+	GetByMonth(month civil.Month) ([]alert.Alert, error)
+	GetPending() ([]alert.Alert, error)
+	GetByID(id uint) (*alert.Alert, error)
+	Exists(ruleID uint, month civil.Month) (bool, error)
+	Create(ctx context.Context, a *alert.Alert) error
+	Update(ctx context.Context, a *alert.Alert) error
+}
+
+// AttachmentRepository defines the interface for expense attachment data operations
+// Frontend endpoints: POST /api/daily-expenses/{id}/attachments, POST /api/fixed-expenses/{id}/attachments, GET/DELETE /api/attachments
+type AttachmentRepository interface {
+	GetByExpense(expenseType string, expenseID uint) ([]attachment.Attachment, error)
+	GetByID(id uint) (*attachment.Attachment, error)
+	GetByExpenseAndHash(expenseType string, expenseID uint, sha256 string) (*attachment.Attachment, error)
+	CountBySHA256(sha256 string) (int64, error)
+	Create(ctx context.Context, a *attachment.Attachment) error
+	Delete(ctx context.Context, id uint) error
+}
+
+// TagRepository defines the interface for expense tag data operations
+// Frontend endpoints: GET/POST/PUT/DELETE /api/tags, GET /api/tags/totals
+type TagRepository interface {
+	GetAll() ([]tag.Tag, error)
+	GetByID(id uint) (*tag.Tag, error)
+	GetByNames(names []string) ([]tag.Tag, error)
+	Create(ctx context.Context, t *tag.Tag) error
+	Update(ctx context.Context, t *tag.Tag) error
+	Delete(ctx context.Context, id uint) error
+	SetFixedExpenseTags(ctx context.Context, fixedExpenseID uint, tagIDs []uint) error
+	SetCategorizationRuleTags(ctx context.Context, ruleID uint, tagIDs []uint) error
+	GetTotals(startDate, endDate civil.Date) ([]tag.Total, error)
+}
+
+// ReceivableRepository defines the interface for reimbursable expense data operations
+// Frontend endpoints: GET/POST/PUT/DELETE /api/receivables, GET /api/receivables/outstanding
+type ReceivableRepository interface {
+	GetAll() ([]receivable.Receivable, error)
+	GetByID(id uint) (*receivable.Receivable, error)
+	GetByExpense(expenseType string, expenseID uint) (*receivable.Receivable, error)
+	GetByExpenses(expenseType string, expenseIDs []uint) ([]receivable.Receivable, error)
+	Create(ctx context.Context, r *receivable.Receivable) error
+	Update(ctx context.Context, r *receivable.Receivable) error
+	Delete(ctx context.Context, id uint) error
+}
+
+// ReimbursementRepository defines the interface for reimbursements received against receivables
+// Frontend endpoints: POST /api/receivables/{id}/reimbursements, DELETE /api/receivables/{id}/reimbursements/{reimbursement_id}
+type ReimbursementRepository interface {
+	GetByID(id uint) (*receivable.Reimbursement, error)
+	Create(ctx context.Context, r *receivable.Reimbursement) error
+	Delete(ctx context.Context, id uint) error
+}
+
+// HouseholdMemberRepository defines the interface for household member data operations
+// Frontend endpoints: GET/POST/PUT/DELETE /api/household/members
+type HouseholdMemberRepository interface {
+	GetAll() ([]household.Member, error)
+	GetByID(id uint) (*household.Member, error)
+	GetByName(name string) (*household.Member, error)
+	Create(ctx context.Context, m *household.Member) error
+	Update(ctx context.Context, m *household.Member) error
+	Delete(ctx context.Context, id uint) error
+	HasShares(id uint) (bool, error)
+}
+
+// ExpenseShareRepository defines the interface for shared expense data operations
+// Frontend endpoints: GET/POST/PUT/DELETE /api/expense-shares, GET /api/household/settle-up/{month}
+type ExpenseShareRepository interface {
+	GetByID(id uint) (*household.Share, error)
+	GetByExpense(expenseType string, expenseID uint) (*household.Share, error)
+	GetByExpenses(expenseType string, expenseIDs []uint) ([]household.Share, error)
+	Create(ctx context.Context, s *household.Share) error
+	Update(ctx context.Context, s *household.Share) error
+	Delete(ctx context.Context, id uint) error
+}
+
+// CategorizationRuleRepository defines the interface for daily expense categorization rule data operations
+// Frontend endpoints: GET/POST/PUT/DELETE /api/categorization-rules, POST /api/categorization-rules/apply/{month}
+type CategorizationRuleRepository interface {
+	GetAll() ([]categorization.Rule, error)
+	GetActive() ([]categorization.Rule, error)
+	GetByID(id uint) (*categorization.Rule, error)
+	Create(ctx context.Context, rule *categorization.Rule) error
+	Update(ctx context.Context, rule *categorization.Rule) error
+	Delete(ctx context.Context, id uint) error
+}
+
+// IdempotencyRepository defines the interface for stored idempotency keys
+// Used by the idempotency middleware on every POST sent with an Idempotency-Key header
+type IdempotencyRepository interface {
+	GetByKey(key string) (*idempotency.Record, error)
+	Reserve(record *idempotency.Record) (bool, error) // false when the key is already stored
+	Complete(key string, statusCode int, contentType, body string) error
+	Delete(key string) error
+	DeleteExpired(now time.Time) (int64, error)
+}
+
+// AuditRepository defines the interface for reading the audit log
+// Entries are appended by the database layer on every change; the log is never modified
+// Frontend endpoints: GET /api/audit
+type AuditRepository interface {
+	Find(filter audit.Filter) ([]audit.Entry, error)
+}
+
+// LedgerEventRepository defines the interface for reading the ledger event log
+// Events are appended by the database layer with every change of a ledger entity
+// Frontend endpoints: GET /api/history/events, GET /api/history/summary/{month}, GET /api/history/consistency
+type LedgerEventRepository interface {
+	Find(filter ledger.Filter) ([]ledger.Event, error) // In replay order
+	// CurrentStates returns the current rows of a ledger entity's table as JSON by ID
+	CurrentStates(entity string) (map[uint]string, error)
 }
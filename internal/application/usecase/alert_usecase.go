@@ -0,0 +1,303 @@
+package usecase
+
+import (
+	"context"
+	"expenses-api/internal/application/port"
+	"expenses-api/internal/domain/alert"
+	"expenses-api/internal/domain/apperror"
+	"expenses-api/internal/domain/civil"
+	"expenses-api/internal/domain/event"
+	"log"
+)
+
+// AlertUseCase evaluates budget threshold rules and manages fired alerts
+type AlertUseCase struct {
+	ruleRepo               port.AlertRuleRepository
+	alertRepo              port.AlertRepository
+	pocketRepo             port.PocketRepository
+	dailyExpenseRepo       port.DailyExpenseRepository
+	fixedExpenseRepo       port.FixedExpenseRepository
+	dailyExpenseConfigRepo port.DailyExpenseConfigRepository
+	allocationRepo         port.PocketAllocationRepository
+	publisher              port.EventPublisher
+	clock                  port.Clock
+}
+
+// NewAlertUseCase creates a new alert use case instance
+// The publisher is optional; when nil fired alerts are only stored
+func NewAlertUseCase(
+	ruleRepo port.AlertRuleRepository,
+	alertRepo port.AlertRepository,
+	pocketRepo port.PocketRepository,
+	dailyExpenseRepo port.DailyExpenseRepository,
+	fixedExpenseRepo port.FixedExpenseRepository,
+	dailyExpenseConfigRepo port.DailyExpenseConfigRepository,
+	allocationRepo port.PocketAllocationRepository,
+	publisher port.EventPublisher,
+	clock port.Clock,
+) *AlertUseCase {
+	return &AlertUseCase{
+		ruleRepo:               ruleRepo,
+		alertRepo:              alertRepo,
+		pocketRepo:             pocketRepo,
+		dailyExpenseRepo:       dailyExpenseRepo,
+		fixedExpenseRepo:       fixedExpenseRepo,
+		dailyExpenseConfigRepo: dailyExpenseConfigRepo,
+		allocationRepo:         allocationRepo,
+		publisher:              publisher,
+		clock:                  clock,
+	}
+}
+
+// Evaluate checks every active rule against the month's spending and stores
+// an alert for each rule that reached its threshold. A rule fires at most once
+// per month. Returns the newly fired alerts.
+//
+// Daily budget rules compare the month's daily spending with DailyBudgetTotal.
+// Pocket rules compare the pocket's daily and fixed spending with its monthly allocation.
+func (uc *AlertUseCase) Evaluate(ctx context.Context, month civil.Month) ([]alert.Alert, error) {
+	rules, err := uc.ruleRepo.GetActive()
+	if err != nil {
+		return nil, err
+	}
+	if len(rules) == 0 {
+		return nil, nil
+	}
+
+	dailyBudget, dailySpent, err := uc.getDailyBudgetUsage(month)
+	if err != nil {
+		return nil, err
+	}
+
+	pocketBudgets, pocketSpent, err := uc.getPocketUsage(month)
+	if err != nil {
+		return nil, err
+	}
+
+	var fired []alert.Alert
+	for i := range rules {
+		rule := &rules[i]
+
+		budget, spent := dailyBudget, dailySpent
+		if rule.Kind == alert.KindPocket {
+			if rule.PocketID == nil {
+				continue
+			}
+			budget, spent = pocketBudgets[*rule.PocketID], pocketSpent[*rule.PocketID]
+		}
+
+		if !rule.IsReached(spent, budget) {
+			continue
+		}
+
+		exists, err := uc.alertRepo.Exists(rule.ID, month)
+		if err != nil {
+			return fired, err
+		}
+		if exists {
+			continue
+		}
+
+		a := alert.NewAlert(rule, month, budget, spent)
+		if err := uc.alertRepo.Create(ctx, a); err != nil {
+			return fired, err
+		}
+
+		log.Printf("Alert fired for rule %d (%s %.0f%%) in %s", rule.ID, rule.Kind, rule.ThresholdPercent, month)
+		if uc.publisher != nil {
+			uc.publisher.Publish(event.AlertTriggered, a)
+		}
+		fired = append(fired, *a)
+	}
+
+	return fired, nil
+}
+
+// GetAlerts retrieves the alerts of a month, or every pending alert when month is empty
+func (uc *AlertUseCase) GetAlerts(monthParam string, pendingOnly bool) ([]alert.Alert, error) {
+	if monthParam == "" {
+		return uc.alertRepo.GetPending()
+	}
+
+	month, err := civil.ParseMonth(monthParam)
+	if err != nil {
+		return nil, apperror.Invalid("month", "invalid month format, must be YYYY-MM")
+	}
+
+	alerts, err := uc.alertRepo.GetByMonth(month)
+	if err != nil || !pendingOnly {
+		return alerts, err
+	}
+
+	pending := make([]alert.Alert, 0, len(alerts))
+	for _, a := range alerts {
+		if !a.Acknowledged {
+			pending = append(pending, a)
+		}
+	}
+	return pending, nil
+}
+
+// Acknowledge marks an alert as seen
+func (uc *AlertUseCase) Acknowledge(ctx context.Context, id uint) (*alert.Alert, error) {
+	if id == 0 {
+		return nil, apperror.Invalid("id", "alert ID is required")
+	}
+
+	a, err := uc.alertRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if a.Acknowledged {
+		return a, nil
+	}
+
+	a.Acknowledge(uc.clock.Now())
+	if err := uc.alertRepo.Update(ctx, a); err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+// GetRules retrieves all alert rules
+func (uc *AlertUseCase) GetRules() ([]alert.Rule, error) {
+	return uc.ruleRepo.GetAll()
+}
+
+// CreateRule creates a new alert rule
+func (uc *AlertUseCase) CreateRule(ctx context.Context, kind string, pocketID *uint, thresholdPercent float64, active bool) (*alert.Rule, error) {
+	if err := uc.validatePocket(kind, pocketID); err != nil {
+		return nil, err
+	}
+
+	rule := &alert.Rule{
+		Kind:             kind,
+		PocketID:         pocketID,
+		ThresholdPercent: thresholdPercent,
+		Active:           active,
+	}
+
+	if err := uc.ruleRepo.Create(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	// Reload to include pocket information
+	return uc.ruleRepo.GetByID(rule.ID)
+}
+
+// UpdateRule updates an existing alert rule
+func (uc *AlertUseCase) UpdateRule(ctx context.Context, id uint, kind string, pocketID *uint, thresholdPercent float64, active bool) (*alert.Rule, error) {
+	if id == 0 {
+		return nil, apperror.Invalid("id", "rule ID is required")
+	}
+
+	rule, err := uc.ruleRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.validatePocket(kind, pocketID); err != nil {
+		return nil, err
+	}
+
+	rule.Kind = kind
+	rule.PocketID = pocketID
+	rule.ThresholdPercent = thresholdPercent
+	rule.Active = active
+	rule.Pocket = nil
+
+	if err := uc.ruleRepo.Update(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	// Reload to include pocket information
+	return uc.ruleRepo.GetByID(id)
+}
+
+// DeleteRule deletes an alert rule and the alerts it fired
+func (uc *AlertUseCase) DeleteRule(ctx context.Context, id uint) error {
+	if id == 0 {
+		return apperror.Invalid("id", "rule ID is required")
+	}
+
+	// Verify rule exists
+	if _, err := uc.ruleRepo.GetByID(id); err != nil {
+		return err
+	}
+
+	return uc.ruleRepo.Delete(ctx, id)
+}
+
+// validatePocket verifies the pocket of pocket rules exists
+func (uc *AlertUseCase) validatePocket(kind string, pocketID *uint) error {
+	if kind != alert.KindPocket {
+		return nil
+	}
+
+	if pocketID == nil || *pocketID == 0 {
+		return apperror.Invalid("pocket_id", "pocket ID is required for pocket rules")
+	}
+
+	if _, err := uc.pocketRepo.GetByID(*pocketID); err != nil {
+		return apperror.Invalid("pocket_id", "pocket not found")
+	}
+
+	return nil
+}
+
+// getDailyBudgetUsage returns the month's daily budget and daily spending
+func (uc *AlertUseCase) getDailyBudgetUsage(month civil.Month) (float64, float64, error) {
+	var budget float64
+	if config, err := uc.dailyExpenseConfigRepo.GetByMonth(month); err == nil && config != nil {
+		budget = config.MonthlyBudget
+	}
+
+	expenses, err := uc.dailyExpenseRepo.GetByMonth(month)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	spent := 0.0
+	for _, expense := range expenses {
+		spent += expense.Amount
+	}
+
+	return budget, spent, nil
+}
+
+// getPocketUsage returns each pocket's monthly allocation and its daily plus fixed spending
+func (uc *AlertUseCase) getPocketUsage(month civil.Month) (map[uint]float64, map[uint]float64, error) {
+	budgets := make(map[uint]float64)
+	spent := make(map[uint]float64)
+
+	allocations, err := uc.allocationRepo.GetByMonth(month)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, allocation := range allocations {
+		budgets[allocation.PocketID] = allocation.Amount
+	}
+
+	dailyExpenses, err := uc.dailyExpenseRepo.GetByMonth(month)
+	if err != nil {
+		return nil, nil, err
+	}
+	// Split expenses count each line against its own pocket
+	for _, expense := range dailyExpenses {
+		for pocketID, amount := range expense.GetPocketAmounts() {
+			spent[pocketID] += amount
+		}
+	}
+
+	fixedExpenses, err := uc.fixedExpenseRepo.GetByMonth(month)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, expense := range fixedExpenses {
+		spent[expense.PocketID] += expense.Amount
+	}
+
+	return budgets, spent, nil
+}
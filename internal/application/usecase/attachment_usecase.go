@@ -0,0 +1,266 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"expenses-api/internal/application/port"
+	"expenses-api/internal/domain/apperror"
+	"expenses-api/internal/domain/attachment"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+)
+
+// AttachmentUseCase handles receipt and document attachments of daily and fixed expenses
+// File contents are deduplicated by SHA-256 hash: identical files share a single blob
+type AttachmentUseCase struct {
+	attachmentRepo   port.AttachmentRepository
+	blobStore        port.BlobStore
+	dailyExpenseRepo port.DailyExpenseRepository
+	fixedExpenseRepo port.FixedExpenseRepository
+	maxSize          int64
+}
+
+// NewAttachmentUseCase creates a new attachment use case instance
+// maxSize is the maximum accepted file size in bytes
+func NewAttachmentUseCase(
+	attachmentRepo port.AttachmentRepository,
+	blobStore port.BlobStore,
+	dailyExpenseRepo port.DailyExpenseRepository,
+	fixedExpenseRepo port.FixedExpenseRepository,
+	maxSize int64,
+) *AttachmentUseCase {
+	return &AttachmentUseCase{
+		attachmentRepo:   attachmentRepo,
+		blobStore:        blobStore,
+		dailyExpenseRepo: dailyExpenseRepo,
+		fixedExpenseRepo: fixedExpenseRepo,
+		maxSize:          maxSize,
+	}
+}
+
+// MaxSize returns the maximum accepted file size in bytes
+func (uc *AttachmentUseCase) MaxSize() int64 {
+	return uc.maxSize
+}
+
+// Upload attaches a file to an expense
+// Uploading the same file twice to the same expense returns the existing attachment
+// and created is false
+func (uc *AttachmentUseCase) Upload(ctx context.Context, expenseType string, expenseID uint, fileName string, content io.Reader) (*attachment.Attachment, bool, error) {
+	if err := uc.ensureExpenseExists(expenseType, expenseID); err != nil {
+		return nil, false, err
+	}
+
+	// Read one byte past the limit to detect oversized files
+	data, err := io.ReadAll(io.LimitReader(content, uc.maxSize+1))
+	if err != nil {
+		return nil, false, err
+	}
+	if len(data) == 0 {
+		return nil, false, apperror.Invalid("file", "file cannot be empty")
+	}
+	if int64(len(data)) > uc.maxSize {
+		return nil, false, apperror.Invalid("file", fmt.Sprintf("file exceeds the maximum size of %d MB", uc.maxSize/(1<<20)))
+	}
+
+	// Trust the content, not the client-provided file name or header
+	contentType := http.DetectContentType(data)
+	if !attachment.IsAllowedContentType(contentType) {
+		return nil, false, apperror.Invalid("file", "only JPEG, PNG, GIF images and PDF documents are allowed")
+	}
+
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+
+	existing, err := uc.attachmentRepo.GetByExpenseAndHash(expenseType, expenseID, hash)
+	if err != nil {
+		return nil, false, err
+	}
+	if existing != nil {
+		return existing, false, nil
+	}
+
+	a := &attachment.Attachment{
+		ExpenseType: expenseType,
+		ExpenseID:   expenseID,
+		FileName:    fileName,
+		ContentType: contentType,
+		Size:        int64(len(data)),
+		SHA256:      hash,
+		StorageKey:  attachment.BlobKey(hash, contentType),
+	}
+
+	if err := uc.putIfMissing(a.StorageKey, data); err != nil {
+		return nil, false, err
+	}
+
+	if a.IsImage() {
+		a.ThumbnailKey = uc.storeThumbnail(hash, data)
+	}
+
+	if err := uc.attachmentRepo.Create(ctx, a); err != nil {
+		return nil, false, err
+	}
+
+	return a, true, nil
+}
+
+// GetByExpense retrieves all attachments of an expense
+func (uc *AttachmentUseCase) GetByExpense(expenseType string, expenseID uint) ([]attachment.Attachment, error) {
+	if !attachment.IsValidExpenseType(expenseType) {
+		return nil, apperror.Invalid("expense_type", "expense type must be daily or fixed")
+	}
+	if expenseID == 0 {
+		return nil, apperror.Invalid("id", "expense ID is required")
+	}
+
+	return uc.attachmentRepo.GetByExpense(expenseType, expenseID)
+}
+
+// GetByID retrieves an attachment by ID
+func (uc *AttachmentUseCase) GetByID(id uint) (*attachment.Attachment, error) {
+	if id == 0 {
+		return nil, apperror.Invalid("attachment_id", "attachment ID is required")
+	}
+
+	a, err := uc.attachmentRepo.GetByID(id)
+	if err != nil {
+		return nil, apperror.NotFound("attachment not found")
+	}
+	return a, nil
+}
+
+// Open returns the attachment metadata and a reader over its content
+// The caller must close the reader
+func (uc *AttachmentUseCase) Open(id uint) (*attachment.Attachment, io.ReadCloser, error) {
+	a, err := uc.GetByID(id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	reader, err := uc.blobStore.Get(a.StorageKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return a, reader, nil
+}
+
+// OpenThumbnail returns a reader over the JPEG thumbnail of an image attachment
+// The caller must close the reader
+func (uc *AttachmentUseCase) OpenThumbnail(id uint) (io.ReadCloser, error) {
+	a, err := uc.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !a.HasThumbnail() {
+		return nil, apperror.NotFound("attachment has no thumbnail")
+	}
+
+	return uc.blobStore.Get(a.ThumbnailKey)
+}
+
+// Delete removes an attachment
+// The stored file and thumbnail are removed only when no other attachment shares them
+func (uc *AttachmentUseCase) Delete(ctx context.Context, id uint) error {
+	a, err := uc.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.attachmentRepo.Delete(ctx, a.ID); err != nil {
+		return err
+	}
+
+	return uc.ReleaseFiles([]attachment.Attachment{*a})
+}
+
+// ReleaseFiles removes the stored files and thumbnails of deleted attachments
+// that no remaining attachment shares
+func (uc *AttachmentUseCase) ReleaseFiles(deleted []attachment.Attachment) error {
+	released := make(map[string]bool, len(deleted))
+	for _, a := range deleted {
+		if released[a.SHA256] {
+			continue
+		}
+
+		remaining, err := uc.attachmentRepo.CountBySHA256(a.SHA256)
+		if err != nil {
+			return err
+		}
+		if remaining > 0 {
+			continue
+		}
+
+		if err := uc.blobStore.Delete(a.StorageKey); err != nil {
+			return err
+		}
+		if a.HasThumbnail() {
+			if err := uc.blobStore.Delete(a.ThumbnailKey); err != nil {
+				return err
+			}
+		}
+		released[a.SHA256] = true
+	}
+	return nil
+}
+
+// ensureExpenseExists validates the expense type and checks that the expense exists
+func (uc *AttachmentUseCase) ensureExpenseExists(expenseType string, expenseID uint) error {
+	if expenseID == 0 {
+		return apperror.Invalid("id", "expense ID is required")
+	}
+
+	switch expenseType {
+	case attachment.ExpenseTypeDaily:
+		if _, err := uc.dailyExpenseRepo.GetByID(expenseID); err != nil {
+			return apperror.NotFound("expense not found")
+		}
+	case attachment.ExpenseTypeFixed:
+		if _, err := uc.fixedExpenseRepo.GetByID(expenseID); err != nil {
+			return apperror.NotFound("expense not found")
+		}
+	default:
+		return apperror.Invalid("expense_type", "expense type must be daily or fixed")
+	}
+
+	return nil
+}
+
+// putIfMissing stores a blob unless identical content is already stored under the key
+func (uc *AttachmentUseCase) putIfMissing(key string, data []byte) error {
+	exists, err := uc.blobStore.Exists(key)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return nil
+	}
+	return uc.blobStore.Put(key, bytes.NewReader(data))
+}
+
+// storeThumbnail generates and stores the thumbnail of an image, returning its key
+// Thumbnail failures do not fail the upload; an empty key is returned instead
+func (uc *AttachmentUseCase) storeThumbnail(hash string, data []byte) string {
+	key := attachment.ThumbnailKey(hash)
+
+	if exists, err := uc.blobStore.Exists(key); err == nil && exists {
+		return key
+	}
+
+	thumbnail, err := attachment.Thumbnail(data, attachment.ThumbnailMaxSize)
+	if err != nil {
+		log.Printf("Could not generate thumbnail for %s: %v", hash, err)
+		return ""
+	}
+
+	if err := uc.blobStore.Put(key, bytes.NewReader(thumbnail)); err != nil {
+		log.Printf("Could not store thumbnail for %s: %v", hash, err)
+		return ""
+	}
+	return key
+}
@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"expenses-api/internal/application/port"
+	"expenses-api/internal/domain/apperror"
+	"expenses-api/internal/domain/audit"
+	"expenses-api/internal/domain/civil"
+	"strings"
+)
+
+// defaultAuditLimit is the number of audit entries returned when no limit is given
+const defaultAuditLimit = 100
+
+// maxAuditLimit is the largest number of audit entries returned at once
+const maxAuditLimit = 1000
+
+// AuditUseCase handles queries over the audit log of changes
+type AuditUseCase struct {
+	auditRepo port.AuditRepository
+	clock     port.Clock
+}
+
+// NewAuditUseCase creates a new audit use case instance
+func NewAuditUseCase(auditRepo port.AuditRepository, clock port.Clock) *AuditUseCase {
+	return &AuditUseCase{
+		auditRepo: auditRepo,
+		clock:     clock,
+	}
+}
+
+// GetEntries retrieves the most recent changes, newest first
+// entity is a table name such as "daily_expenses" and entityID a row of it;
+// the dates (YYYY-MM-DD, business timezone) are inclusive. Empty or zero
+// arguments do not filter
+func (uc *AuditUseCase) GetEntries(entity string, entityID uint, startDate, endDate string, limit int) ([]audit.Entry, error) {
+	filter := audit.Filter{
+		Entity:   strings.TrimSpace(entity),
+		EntityID: entityID,
+		Limit:    limit,
+	}
+	if filter.EntityID != 0 && filter.Entity == "" {
+		return nil, apperror.Invalid("entity", "entity is required to filter by entity ID")
+	}
+
+	location := uc.clock.Now().Location()
+
+	var start, end civil.Date
+	if startDate != "" {
+		parsed, err := civil.ParseDate(startDate)
+		if err != nil {
+			return nil, apperror.Invalid("start_date", "invalid start date format, must be YYYY-MM-DD")
+		}
+		start = parsed
+		filter.From = start.In(location)
+	}
+	if endDate != "" {
+		parsed, err := civil.ParseDate(endDate)
+		if err != nil {
+			return nil, apperror.Invalid("end_date", "invalid end date format, must be YYYY-MM-DD")
+		}
+		end = parsed
+		filter.To = end.AddDays(1).In(location)
+	}
+	if !start.IsZero() && !end.IsZero() && end.Before(start) {
+		return nil, apperror.Invalid("end_date", "end date cannot be before start date")
+	}
+
+	if filter.Limit <= 0 || filter.Limit > maxAuditLimit {
+		filter.Limit = defaultAuditLimit
+	}
+
+	return uc.auditRepo.Find(filter)
+}
@@ -0,0 +1,271 @@
+package usecase
+
+import (
+	"context"
+	"expenses-api/internal/application/port"
+	"expenses-api/internal/domain/apperror"
+	"expenses-api/internal/domain/categorization"
+	"expenses-api/internal/domain/civil"
+	"expenses-api/internal/domain/daily_expense"
+)
+
+// CategorizationUseCase handles the rules that auto-assign a pocket and tags to daily expenses
+type CategorizationUseCase struct {
+	ruleRepo         port.CategorizationRuleRepository
+	pocketRepo       port.PocketRepository
+	dailyExpenseRepo port.DailyExpenseRepository
+	tagUseCase       *TagUseCase
+}
+
+// NewCategorizationUseCase creates a new categorization use case instance
+// The tag use case is optional; when nil rules only assign pockets
+func NewCategorizationUseCase(
+	ruleRepo port.CategorizationRuleRepository,
+	pocketRepo port.PocketRepository,
+	dailyExpenseRepo port.DailyExpenseRepository,
+	tagUseCase *TagUseCase,
+) *CategorizationUseCase {
+	return &CategorizationUseCase{
+		ruleRepo:         ruleRepo,
+		pocketRepo:       pocketRepo,
+		dailyExpenseRepo: dailyExpenseRepo,
+		tagUseCase:       tagUseCase,
+	}
+}
+
+// GetRules retrieves all rules in evaluation order
+func (uc *CategorizationUseCase) GetRules() ([]categorization.Rule, error) {
+	return uc.ruleRepo.GetAll()
+}
+
+// CreateRule creates a new rule
+// Tags are referenced by name and created when they do not exist yet
+func (uc *CategorizationUseCase) CreateRule(ctx context.Context, rule *categorization.Rule, tags []string) (*categorization.Rule, error) {
+	tagIDs, err := uc.prepareRule(ctx, rule, tags)
+	if err != nil {
+		return nil, err
+	}
+
+	active := rule.Active
+	if err := uc.ruleRepo.Create(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	// The column defaults to active, so inactive rules are stored with an update
+	if !active {
+		rule.Active = false
+		if err := uc.ruleRepo.Update(ctx, rule); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.setTags(ctx, rule.ID, tagIDs); err != nil {
+		return nil, err
+	}
+
+	// Reload to include pocket and tag information
+	return uc.ruleRepo.GetByID(rule.ID)
+}
+
+// UpdateRule updates an existing rule
+// A nil tags slice keeps the current tags; an empty slice removes them
+func (uc *CategorizationUseCase) UpdateRule(ctx context.Context, id uint, rule *categorization.Rule, tags []string) (*categorization.Rule, error) {
+	if id == 0 {
+		return nil, apperror.Invalid("id", "rule ID is required")
+	}
+
+	existing, err := uc.ruleRepo.GetByID(id)
+	if err != nil {
+		return nil, apperror.NotFound("rule not found")
+	}
+
+	existing.Name = rule.Name
+	existing.MatchType = rule.MatchType
+	existing.Pattern = rule.Pattern
+	existing.MinAmount = rule.MinAmount
+	existing.MaxAmount = rule.MaxAmount
+	existing.PocketID = rule.PocketID
+	existing.Priority = rule.Priority
+	existing.Active = rule.Active
+	existing.Pocket = nil
+
+	if tags == nil {
+		tags = existing.GetTagNames()
+	}
+
+	tagIDs, err := uc.prepareRule(ctx, existing, tags)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.ruleRepo.Update(ctx, existing); err != nil {
+		return nil, err
+	}
+
+	if err := uc.setTags(ctx, id, tagIDs); err != nil {
+		return nil, err
+	}
+
+	// Reload to include pocket and tag information
+	return uc.ruleRepo.GetByID(id)
+}
+
+// DeleteRule deletes a rule; expenses it already categorized keep their pocket and tags
+func (uc *CategorizationUseCase) DeleteRule(ctx context.Context, id uint) error {
+	if id == 0 {
+		return apperror.Invalid("id", "rule ID is required")
+	}
+
+	if _, err := uc.ruleRepo.GetByID(id); err != nil {
+		return apperror.NotFound("rule not found")
+	}
+
+	return uc.ruleRepo.Delete(ctx, id)
+}
+
+// Match returns the first active rule that matches a new expense, or nil
+func (uc *CategorizationUseCase) Match(description string, amount float64) (*categorization.Rule, error) {
+	rules, err := uc.ruleRepo.GetActive()
+	if err != nil {
+		return nil, err
+	}
+	return categorization.Match(rules, description, amount), nil
+}
+
+// Apply runs the rules over the daily expenses of a month
+// Expenses without a pocket get the pocket of the matching rule; with overwrite,
+// pockets already assigned are replaced too. Split expenses keep their split lines.
+// Tags of the rule are added to the expense's tags. With dryRun nothing is saved
+// and the returned changes are a preview
+func (uc *CategorizationUseCase) Apply(ctx context.Context, monthParam string, dryRun, overwrite bool) ([]categorization.Change, error) {
+	month, err := civil.ParseMonth(monthParam)
+	if err != nil {
+		return nil, apperror.Invalid("month", "invalid month format, must be YYYY-MM")
+	}
+
+	rules, err := uc.ruleRepo.GetActive()
+	if err != nil {
+		return nil, err
+	}
+
+	expenses, err := uc.dailyExpenseRepo.GetByMonth(month)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]categorization.Change, 0)
+	for i := range expenses {
+		expense := &expenses[i]
+		rule := categorization.Match(rules, expense.Description, expense.Amount)
+		if rule == nil {
+			continue
+		}
+
+		change := planChange(expense, rule, overwrite)
+		if !change.ChangesPocket() && len(change.AddedTags) == 0 {
+			continue
+		}
+
+		if !dryRun {
+			if err := uc.applyChange(ctx, expense, &change); err != nil {
+				return nil, err
+			}
+		}
+
+		changes = append(changes, change)
+	}
+
+	return changes, nil
+}
+
+// prepareRule validates the rule and its pocket and resolves its tags
+// A rule must assign a pocket, tags or both
+func (uc *CategorizationUseCase) prepareRule(ctx context.Context, rule *categorization.Rule, tags []string) ([]uint, error) {
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+
+	if rule.PocketID != nil && *rule.PocketID == 0 {
+		rule.PocketID = nil
+	}
+
+	if rule.PocketID != nil {
+		if _, err := uc.pocketRepo.GetByID(*rule.PocketID); err != nil {
+			return nil, apperror.Invalid("pocket_id", "pocket not found")
+		}
+	}
+
+	if uc.tagUseCase == nil {
+		tags = nil
+	}
+
+	if rule.PocketID == nil && len(tags) == 0 {
+		return nil, apperror.Validation("rule must assign a pocket or tags")
+	}
+
+	if tags == nil {
+		return nil, nil
+	}
+	return uc.tagUseCase.ResolveIDs(ctx, tags)
+}
+
+// setTags replaces the tags of a rule with the resolved tag IDs
+func (uc *CategorizationUseCase) setTags(ctx context.Context, ruleID uint, tagIDs []uint) error {
+	if uc.tagUseCase == nil || tagIDs == nil {
+		return nil
+	}
+	return uc.tagUseCase.SetCategorizationRuleTags(ctx, ruleID, tagIDs)
+}
+
+// applyChange saves the pocket and tags a rule assigns to an existing expense
+// in one update; split lines are left as stored
+func (uc *CategorizationUseCase) applyChange(ctx context.Context, expense *daily_expense.DailyExpense, change *categorization.Change) error {
+	if change.ChangesPocket() {
+		expense.PocketID = change.ToPocketID
+		expense.Pocket = nil
+	}
+
+	var tags []daily_expense.Tag
+	if len(change.AddedTags) > 0 && uc.tagUseCase != nil {
+		tagIDs, err := uc.tagUseCase.ResolveIDs(ctx, append(expense.GetTagNames(), change.AddedTags...))
+		if err != nil {
+			return err
+		}
+		tags = tagRefs(tagIDs)
+	}
+	if !change.ChangesPocket() && tags == nil {
+		return nil
+	}
+
+	expense.Splits = nil
+	expense.Tags = tags
+	return uc.dailyExpenseRepo.Update(ctx, expense)
+}
+
+// planChange works out what a matching rule changes on an existing expense
+func planChange(expense *daily_expense.DailyExpense, rule *categorization.Rule, overwrite bool) categorization.Change {
+	change := categorization.Change{
+		ExpenseID:      expense.ID,
+		Description:    expense.Description,
+		Amount:         expense.Amount,
+		Date:           expense.Date,
+		RuleID:         rule.ID,
+		RuleName:       rule.Name,
+		FromPocketID:   expense.PocketID,
+		FromPocketName: expense.GetPocketName(),
+	}
+
+	canAssign := rule.PocketID != nil && !expense.IsSplit()
+	if canAssign && (expense.PocketID == nil || (overwrite && *expense.PocketID != *rule.PocketID)) {
+		change.ToPocketID = rule.PocketID
+		change.ToPocketName = rule.GetPocketName()
+	}
+
+	for _, name := range rule.GetTagNames() {
+		if !expense.HasTag(name) {
+			change.AddedTags = append(change.AddedTags, name)
+		}
+	}
+
+	return change
+}
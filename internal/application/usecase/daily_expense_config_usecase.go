@@ -1,10 +1,11 @@
 package usecase
 
 import (
-	"errors"
+	"context"
 	"expenses-api/internal/application/port"
+	"expenses-api/internal/domain/apperror"
+	"expenses-api/internal/domain/civil"
 	"expenses-api/internal/domain/daily_expense_config"
-	"time"
 )
 
 // DailyExpenseConfigUseCase handles daily expense config-related business logic
@@ -20,29 +21,30 @@ func NewDailyExpenseConfigUseCase(dailyExpenseConfigRepo port.DailyExpenseConfig
 }
 
 // GetByMonth retrieves daily expense configuration for a specific month
-func (uc *DailyExpenseConfigUseCase) GetByMonth(month string) (*daily_expense_config.DailyExpenseConfig, error) {
-	if month == "" {
-		return nil, errors.New("month is required")
+func (uc *DailyExpenseConfigUseCase) GetByMonth(monthParam string) (*daily_expense_config.DailyExpenseConfig, error) {
+	if monthParam == "" {
+		return nil, apperror.Invalid("month", "month is required")
 	}
 
 	// Validate month format
-	if _, err := time.Parse("2006-01", month); err != nil {
-		return nil, errors.New("invalid month format, must be YYYY-MM")
+	month, err := civil.ParseMonth(monthParam)
+	if err != nil {
+		return nil, apperror.Invalid("month", "invalid month format, must be YYYY-MM")
 	}
 
 	return uc.dailyExpenseConfigRepo.GetByMonth(month)
 }
 
 // GetByMonthWithInheritance obtiene el presupuesto diario de un mes, heredando del anterior si no existe
-func (uc *DailyExpenseConfigUseCase) GetByMonthWithInheritance(month string) (*daily_expense_config.DailyExpenseConfig, error) {
-	if month == "" {
-		return nil, errors.New("month is required")
+func (uc *DailyExpenseConfigUseCase) GetByMonthWithInheritance(monthParam string) (*daily_expense_config.DailyExpenseConfig, error) {
+	if monthParam == "" {
+		return nil, apperror.Invalid("month", "month is required")
 	}
 
 	// Validate month format
-	date, err := time.Parse("2006-01", month)
+	month, err := civil.ParseMonth(monthParam)
 	if err != nil {
-		return nil, errors.New("invalid month format, must be YYYY-MM")
+		return nil, apperror.Invalid("month", "invalid month format, must be YYYY-MM")
 	}
 
 	// Intentar obtener configuración del mes actual
@@ -52,11 +54,11 @@ func (uc *DailyExpenseConfigUseCase) GetByMonthWithInheritance(month string) (*d
 	}
 
 	// Si no existe, buscar mes anterior
-	previousMonth := date.AddDate(0, -1, 0).Format("2006-01")
+	previousMonth := month.AddMonths(-1)
 	previousConfig, err := uc.dailyExpenseConfigRepo.GetByMonth(previousMonth)
 	if err != nil {
 		// No hay configuración anterior, retornar error para que handler use valores por defecto
-		return nil, errors.New("no configuration found")
+		return nil, apperror.NotFound("no configuration found")
 	}
 
 	// Heredar configuración adaptando el mes
@@ -69,26 +71,33 @@ func (uc *DailyExpenseConfigUseCase) GetByMonthWithInheritance(month string) (*d
 }
 
 // UpdateBudget updates or creates the daily expense budget configuration for a specific month
-func (uc *DailyExpenseConfigUseCase) UpdateBudget(monthlyBudget float64, month string) error {
-	if month == "" {
-		return errors.New("month is required")
+// A non-zero version must match the stored configuration of the month
+func (uc *DailyExpenseConfigUseCase) UpdateBudget(ctx context.Context, monthlyBudget float64, monthParam string, version uint) (*daily_expense_config.DailyExpenseConfig, error) {
+	if monthParam == "" {
+		return nil, apperror.Invalid("month", "month is required")
 	}
 
 	// Validate month format
-	if _, err := time.Parse("2006-01", month); err != nil {
-		return errors.New("invalid month format, must be YYYY-MM")
+	month, err := civil.ParseMonth(monthParam)
+	if err != nil {
+		return nil, apperror.Invalid("month", "invalid month format, must be YYYY-MM")
 	}
 
 	// Validate monthly budget
 	if monthlyBudget < 0 {
-		return errors.New("monthly budget cannot be negative")
+		return nil, apperror.Invalid("monthly_budget", "monthly budget cannot be negative")
 	}
 
 	// Create config object
 	config := &daily_expense_config.DailyExpenseConfig{
 		MonthlyBudget: monthlyBudget,
 		Month:         month,
+		Version:       version,
+	}
+
+	if err := uc.dailyExpenseConfigRepo.CreateOrUpdate(ctx, config); err != nil {
+		return nil, saveConflict("daily budget configuration", err)
 	}
 
-	return uc.dailyExpenseConfigRepo.CreateOrUpdate(config)
+	return config, nil
 }
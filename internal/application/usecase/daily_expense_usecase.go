@@ -1,107 +1,263 @@
 package usecase
 
 import (
-	"errors"
+	"context"
 	"expenses-api/internal/application/port"
+	"expenses-api/internal/domain/apperror"
+	"expenses-api/internal/domain/attachment"
+	"expenses-api/internal/domain/categorization"
+	"expenses-api/internal/domain/civil"
 	"expenses-api/internal/domain/daily_expense"
+	"expenses-api/internal/domain/event"
+	"expenses-api/internal/domain/quickentry"
+	"expenses-api/internal/domain/tag"
+	"log"
 	"strings"
 	"time"
 )
 
 // DailyExpenseUseCase handles daily expense-related business logic
 type DailyExpenseUseCase struct {
-	dailyExpenseRepo port.DailyExpenseRepository
+	dailyExpenseRepo       port.DailyExpenseRepository
+	pocketRepo             port.PocketRepository
+	dailyExpenseConfigRepo port.DailyExpenseConfigRepository
+	publisher              port.EventPublisher
+	alertUseCase           *AlertUseCase
+	tagUseCase             *TagUseCase
+	categorizationUseCase  *CategorizationUseCase
+	attachmentUseCase      *AttachmentUseCase
+	clock                  port.Clock
 }
 
 // NewDailyExpenseUseCase creates a new daily expense use case instance
-func NewDailyExpenseUseCase(dailyExpenseRepo port.DailyExpenseRepository) *DailyExpenseUseCase {
+// The publisher, alert, tag, categorization and attachment use cases are optional;
+// when nil no ledger events are emitted, no budget alerts are evaluated, tags are
+// ignored, new expenses are not categorized automatically and the files of
+// deleted expenses stay stored
+func NewDailyExpenseUseCase(
+	dailyExpenseRepo port.DailyExpenseRepository,
+	pocketRepo port.PocketRepository,
+	dailyExpenseConfigRepo port.DailyExpenseConfigRepository,
+	publisher port.EventPublisher,
+	alertUseCase *AlertUseCase,
+	tagUseCase *TagUseCase,
+	categorizationUseCase *CategorizationUseCase,
+	attachmentUseCase *AttachmentUseCase,
+	clock port.Clock,
+) *DailyExpenseUseCase {
 	return &DailyExpenseUseCase{
-		dailyExpenseRepo: dailyExpenseRepo,
+		dailyExpenseRepo:       dailyExpenseRepo,
+		pocketRepo:             pocketRepo,
+		dailyExpenseConfigRepo: dailyExpenseConfigRepo,
+		publisher:              publisher,
+		alertUseCase:           alertUseCase,
+		tagUseCase:             tagUseCase,
+		categorizationUseCase:  categorizationUseCase,
+		attachmentUseCase:      attachmentUseCase,
+		clock:                  clock,
 	}
 }
 
 // GetByMonth retrieves all daily expenses for a specific month
-func (uc *DailyExpenseUseCase) GetByMonth(month string) ([]daily_expense.DailyExpense, error) {
-	if month == "" {
-		return nil, errors.New("month is required")
+func (uc *DailyExpenseUseCase) GetByMonth(monthParam string) ([]daily_expense.DailyExpense, error) {
+	if monthParam == "" {
+		return nil, apperror.Invalid("month", "month is required")
 	}
 
 	// Validate month format
-	if _, err := time.Parse("2006-01", month); err != nil {
-		return nil, errors.New("invalid month format, must be YYYY-MM")
+	month, err := civil.ParseMonth(monthParam)
+	if err != nil {
+		return nil, apperror.Invalid("month", "invalid month format, must be YYYY-MM")
 	}
 
 	return uc.dailyExpenseRepo.GetByMonth(month)
 }
 
+// GetByMonthAndTag retrieves the daily expenses of a month carrying a tag
+// An empty tag name returns every expense of the month
+func (uc *DailyExpenseUseCase) GetByMonthAndTag(month, tagName string) ([]daily_expense.DailyExpense, error) {
+	expenses, err := uc.GetByMonth(month)
+	if err != nil || tagName == "" {
+		return expenses, err
+	}
+
+	tagName = tag.NormalizeName(tagName)
+	filtered := make([]daily_expense.DailyExpense, 0, len(expenses))
+	for _, expense := range expenses {
+		if expense.HasTag(tagName) {
+			filtered = append(filtered, expense)
+		}
+	}
+	return filtered, nil
+}
+
 // GetByID retrieves a daily expense by ID
 func (uc *DailyExpenseUseCase) GetByID(id uint) (*daily_expense.DailyExpense, error) {
 	if id == 0 {
-		return nil, errors.New("expense ID is required")
+		return nil, apperror.Invalid("id", "expense ID is required")
 	}
 
 	return uc.dailyExpenseRepo.GetByID(id)
 }
 
 // Create creates a new daily expense
+// The expense either draws from a single pocket or is split across several
+// pockets with split lines that add up to the amount.
+// Tags are referenced by name and created when they do not exist yet.
+// The first matching categorization rule fills in the pocket and adds its tags
 func (uc *DailyExpenseUseCase) Create(
+	ctx context.Context,
 	description string,
 	amount float64,
 	date string,
+	pocketID *uint,
+	tags []string,
+	splits []daily_expense.Split,
 ) (*daily_expense.DailyExpense, error) {
-	// Validate input
-	description = strings.TrimSpace(description)
-	if description == "" {
-		return nil, errors.New("description is required")
+	description, expenseDate, err := uc.validateNewExpense(description, amount, date)
+	if err != nil {
+		return nil, err
 	}
 
-	if len(description) > 500 {
-		return nil, errors.New("description cannot exceed 500 characters")
+	pocketID, tags = uc.categorize(description, amount, pocketID, tags, splits)
+
+	pocketID, err = uc.validatePocket(pocketID)
+	if err != nil {
+		return nil, err
 	}
 
-	if amount <= 0 {
-		return nil, errors.New("amount must be greater than zero")
+	if err := uc.validateSplits(amount, pocketID, splits); err != nil {
+		return nil, err
 	}
 
-	if date == "" {
-		return nil, errors.New("date is required")
+	tagIDs, err := uc.resolveTags(ctx, tags)
+	if err != nil {
+		return nil, err
 	}
 
-	// Validate date format
-	if _, err := time.Parse("2006-01-02", date); err != nil {
-		return nil, errors.New("invalid date format, must be YYYY-MM-DD")
+	// Create daily expense
+	expense := &daily_expense.DailyExpense{
+		Description: description,
+		Amount:      amount,
+		Date:        expenseDate,
+		PocketID:    pocketID,
+		Tags:        tagRefs(tagIDs),
+		Splits:      splits,
 	}
 
-	// Don't allow future dates beyond today
-	expenseDate, _ := time.Parse("2006-01-02", date)
-	today := time.Now()
-	if expenseDate.After(today) {
-		return nil, errors.New("expense date cannot be in the future")
+	spentBefore := uc.monthTotal(expense.GetMonth())
+
+	if err := uc.dailyExpenseRepo.Create(ctx, expense); err != nil {
+		return nil, err
 	}
 
-	// Create daily expense
+	// Reload to include pocket, split and tag information
+	created, err := uc.dailyExpenseRepo.GetByID(expense.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.publish(event.ExpenseCreated, created)
+	uc.checkBudgetExceeded(created.GetMonth(), spentBefore)
+	uc.evaluateAlerts(ctx, created.GetMonth())
+
+	return created, nil
+}
+
+// PreviewQuickEntry parses a quick entry text such as "almuerzo 25000 ayer #oficina"
+// and returns the daily expense it would create, categorization rules included,
+// without saving it
+func (uc *DailyExpenseUseCase) PreviewQuickEntry(text string) (*daily_expense.DailyExpense, error) {
+	entry, pocketID, err := uc.parseQuickEntry(text)
+	if err != nil {
+		return nil, err
+	}
+
+	description, date, err := uc.validateNewExpense(entry.Description, entry.Amount, entry.Date.String())
+	if err != nil {
+		return nil, err
+	}
+
+	pocketID, tags := uc.categorize(description, entry.Amount, pocketID, entry.Tags, nil)
+
 	expense := &daily_expense.DailyExpense{
 		Description: description,
-		Amount:      amount,
+		Amount:      entry.Amount,
 		Date:        date,
+		PocketID:    pocketID,
 	}
 
-	if err := uc.dailyExpenseRepo.Create(expense); err != nil {
-		return nil, err
+	if pocketID != nil {
+		p, err := uc.pocketRepo.GetByID(*pocketID)
+		if err != nil {
+			return nil, apperror.Invalid("pocket_id", "pocket not found")
+		}
+		expense.Pocket = &daily_expense.Pocket{ID: p.ID, Name: p.Name}
+	}
+
+	if uc.tagUseCase != nil {
+		for _, name := range tag.NormalizeNames(tags) {
+			expense.Tags = append(expense.Tags, daily_expense.Tag{Name: name})
+		}
 	}
 
 	return expense, nil
 }
 
+// CreateQuickEntry parses a quick entry text and creates the daily expense
+func (uc *DailyExpenseUseCase) CreateQuickEntry(ctx context.Context, text string) (*daily_expense.DailyExpense, error) {
+	entry, pocketID, err := uc.parseQuickEntry(text)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.Create(ctx, entry.Description, entry.Amount, entry.Date.String(), pocketID, entry.Tags, nil)
+}
+
+// parseQuickEntry parses a quick entry text and resolves its @pocket by name,
+// ignoring case and accents
+func (uc *DailyExpenseUseCase) parseQuickEntry(text string) (*quickentry.Entry, *uint, error) {
+	entry, err := quickentry.Parse(text, uc.clock.Now())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if entry.Pocket == "" {
+		return entry, nil, nil
+	}
+
+	pockets, err := uc.pocketRepo.GetAll()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, p := range pockets {
+		if categorization.Fold(p.Name) == categorization.Fold(entry.Pocket) {
+			pocketID := p.ID
+			return entry, &pocketID, nil
+		}
+	}
+
+	return nil, nil, apperror.Invalid("text", "pocket not found: "+entry.Pocket)
+}
+
 // Update updates an existing daily expense
+// Nil splits or tags slices keep the current ones; empty slices remove them.
+// Kept split lines must still add up to the new amount. A non-zero version
+// must match the stored one
 func (uc *DailyExpenseUseCase) Update(
+	ctx context.Context,
 	id uint,
 	description string,
 	amount float64,
 	date string,
+	pocketID *uint,
+	tags []string,
+	splits []daily_expense.Split,
+	version uint,
 ) (*daily_expense.DailyExpense, error) {
 	if id == 0 {
-		return nil, errors.New("expense ID is required")
+		return nil, apperror.Invalid("id", "expense ID is required")
 	}
 
 	// Get existing expense
@@ -109,61 +265,315 @@ func (uc *DailyExpenseUseCase) Update(
 	if err != nil {
 		return nil, err
 	}
+	if err := checkVersion("expense", version, existingExpense.Version); err != nil {
+		return nil, err
+	}
 
 	// Validate input
 	description = strings.TrimSpace(description)
 	if description == "" {
-		return nil, errors.New("description is required")
+		return nil, apperror.Invalid("description", "description is required")
 	}
 
 	if len(description) > 500 {
-		return nil, errors.New("description cannot exceed 500 characters")
+		return nil, apperror.Invalid("description", "description cannot exceed 500 characters")
 	}
 
 	if amount <= 0 {
-		return nil, errors.New("amount must be greater than zero")
+		return nil, apperror.Invalid("amount", "amount must be greater than zero")
 	}
 
 	// If date is empty, keep the original date
 	if date != "" {
 		// Validate date format
-		if _, err := time.Parse("2006-01-02", date); err != nil {
-			return nil, errors.New("invalid date format, must be YYYY-MM-DD")
+		expenseDate, err := civil.ParseDate(date)
+		if err != nil {
+			return nil, apperror.Invalid("date", "invalid date format, must be YYYY-MM-DD")
 		}
 
 		// Don't allow future dates beyond today
-		expenseDate, _ := time.Parse("2006-01-02", date)
-		today := time.Now()
-		if expenseDate.After(today) {
-			return nil, errors.New("expense date cannot be in the future")
+		if isFutureDate(expenseDate, uc.clock.Now()) {
+			return nil, apperror.Invalid("date", "expense date cannot be in the future")
 		}
 
 		// Update date only if provided
-		existingExpense.Date = date
+		existingExpense.Date = expenseDate
+	}
+
+	pocketID, err = uc.validatePocket(pocketID)
+	if err != nil {
+		return nil, err
+	}
+
+	replaceSplits := splits != nil
+	if !replaceSplits {
+		splits = existingExpense.Splits
+	}
+	if err := uc.validateSplits(amount, pocketID, splits); err != nil {
+		return nil, err
+	}
+
+	tagIDs, err := uc.resolveTags(ctx, tags)
+	if err != nil {
+		return nil, err
 	}
 
 	// Update expense
 	existingExpense.Description = description
 	existingExpense.Amount = amount
+	existingExpense.PocketID = pocketID
+
+	// Nil split lines or tags are left as stored
+	existingExpense.Splits = nil
+	if replaceSplits {
+		existingExpense.Splits = splits
+	}
+	existingExpense.Tags = tagRefs(tagIDs)
+
+	spentBefore := uc.monthTotal(existingExpense.GetMonth())
 
-	if err := uc.dailyExpenseRepo.Update(existingExpense); err != nil {
+	if err := uc.dailyExpenseRepo.Update(ctx, existingExpense); err != nil {
+		return nil, saveConflict("expense", err)
+	}
+
+	// Reload to include pocket, split and tag information
+	updated, err := uc.dailyExpenseRepo.GetByID(id)
+	if err != nil {
 		return nil, err
 	}
 
-	return existingExpense, nil
+	uc.publish(event.ExpenseUpdated, updated)
+	uc.checkBudgetExceeded(updated.GetMonth(), spentBefore)
+	uc.evaluateAlerts(ctx, updated.GetMonth())
+
+	return updated, nil
 }
 
 // Delete deletes a daily expense
-func (uc *DailyExpenseUseCase) Delete(id uint) error {
+// A non-zero version must match the stored one
+func (uc *DailyExpenseUseCase) Delete(ctx context.Context, id uint, version uint) error {
 	if id == 0 {
-		return errors.New("expense ID is required")
+		return apperror.Invalid("id", "expense ID is required")
 	}
 
 	// Verify expense exists
-	_, err := uc.dailyExpenseRepo.GetByID(id)
+	expense, err := uc.dailyExpenseRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if err := checkVersion("expense", version, expense.Version); err != nil {
+		return err
+	}
+
+	var attachments []attachment.Attachment
+	if uc.attachmentUseCase != nil {
+		attachments, err = uc.attachmentUseCase.GetByExpense(attachment.ExpenseTypeDaily, id)
+		if err != nil {
+			return err
+		}
+	}
+
+	// The repository deletes the attachments with the expense; their files go once no other attachment shares them
+	if err := uc.dailyExpenseRepo.Delete(ctx, id, version); err != nil {
+		return saveConflict("expense", err)
+	}
+	if uc.attachmentUseCase != nil {
+		if err := uc.attachmentUseCase.ReleaseFiles(attachments); err != nil {
+			log.Printf("Releasing the files of daily expense %d failed: %v", id, err)
+		}
+	}
+
+	uc.publish(event.ExpenseDeleted, expense)
+	return nil
+}
+
+// validateNewExpense checks the fields of a new daily expense and returns the
+// trimmed description and the parsed date
+func (uc *DailyExpenseUseCase) validateNewExpense(description string, amount float64, date string) (string, civil.Date, error) {
+	description = strings.TrimSpace(description)
+	if description == "" {
+		return "", civil.Date{}, apperror.Invalid("description", "description is required")
+	}
+
+	if len(description) > 500 {
+		return "", civil.Date{}, apperror.Invalid("description", "description cannot exceed 500 characters")
+	}
+
+	if amount <= 0 {
+		return "", civil.Date{}, apperror.Invalid("amount", "amount must be greater than zero")
+	}
+
+	if date == "" {
+		return "", civil.Date{}, apperror.Invalid("date", "date is required")
+	}
+
+	// Validate date format
+	expenseDate, err := civil.ParseDate(date)
 	if err != nil {
+		return "", civil.Date{}, apperror.Invalid("date", "invalid date format, must be YYYY-MM-DD")
+	}
+
+	// Don't allow future dates beyond today
+	if isFutureDate(expenseDate, uc.clock.Now()) {
+		return "", civil.Date{}, apperror.Invalid("date", "expense date cannot be in the future")
+	}
+
+	return description, expenseDate, nil
+}
+
+// isFutureDate reports whether a date is after the day of now
+// Dates are compared as calendar days, so now must be in the business timezone
+func isFutureDate(date civil.Date, now time.Time) bool {
+	return date.After(civil.DateOf(now))
+}
+
+// validatePocket verifies the optional pocket exists, treating zero as no pocket
+func (uc *DailyExpenseUseCase) validatePocket(pocketID *uint) (*uint, error) {
+	if pocketID == nil || *pocketID == 0 {
+		return nil, nil
+	}
+
+	if _, err := uc.pocketRepo.GetByID(*pocketID); err != nil {
+		return nil, apperror.Invalid("pocket_id", "pocket not found")
+	}
+
+	return pocketID, nil
+}
+
+// validateSplits checks the split lines of an expense and that their pockets exist
+// An expense cannot draw from a pocket and be split at the same time
+func (uc *DailyExpenseUseCase) validateSplits(amount float64, pocketID *uint, splits []daily_expense.Split) error {
+	if len(splits) == 0 {
+		return nil
+	}
+
+	if pocketID != nil {
+		return apperror.Invalid("splits", "use either a pocket or split lines, not both")
+	}
+
+	if err := daily_expense.ValidateSplits(amount, splits); err != nil {
 		return err
 	}
 
-	return uc.dailyExpenseRepo.Delete(id)
+	for _, split := range splits {
+		if _, err := uc.pocketRepo.GetByID(split.PocketID); err != nil {
+			return apperror.Invalid("splits", "split pocket not found")
+		}
+	}
+
+	return nil
+}
+
+// categorize applies the first matching categorization rule to a new expense
+// The rule's pocket is only used when the expense has no pocket and no split lines;
+// its tags are added to the given ones. Rule lookup failures are logged and ignored
+func (uc *DailyExpenseUseCase) categorize(
+	description string,
+	amount float64,
+	pocketID *uint,
+	tags []string,
+	splits []daily_expense.Split,
+) (*uint, []string) {
+	if uc.categorizationUseCase == nil {
+		return pocketID, tags
+	}
+
+	rule, err := uc.categorizationUseCase.Match(description, amount)
+	if err != nil {
+		log.Printf("Categorization rules could not be loaded: %v", err)
+		return pocketID, tags
+	}
+	if rule == nil {
+		return pocketID, tags
+	}
+
+	if (pocketID == nil || *pocketID == 0) && len(splits) == 0 && rule.PocketID != nil {
+		pocketID = rule.PocketID
+	}
+
+	if ruleTags := rule.GetTagNames(); len(ruleTags) > 0 {
+		tags = append(append([]string{}, tags...), ruleTags...)
+	}
+
+	return pocketID, tags
+}
+
+// resolveTags returns the IDs of the named tags, creating missing ones
+// A nil result means the tags are left untouched
+func (uc *DailyExpenseUseCase) resolveTags(ctx context.Context, tags []string) ([]uint, error) {
+	if uc.tagUseCase == nil || tags == nil {
+		return nil, nil
+	}
+	return uc.tagUseCase.ResolveIDs(ctx, tags)
+}
+
+// tagRefs returns the tags with the resolved IDs for the repository to link
+// A nil result leaves the tags of an expense untouched
+func tagRefs(tagIDs []uint) []daily_expense.Tag {
+	if tagIDs == nil {
+		return nil
+	}
+	tags := make([]daily_expense.Tag, 0, len(tagIDs))
+	for _, id := range tagIDs {
+		tags = append(tags, daily_expense.Tag{ID: id})
+	}
+	return tags
+}
+
+// monthTotal returns the daily spending of a month, or zero when it cannot be loaded
+func (uc *DailyExpenseUseCase) monthTotal(month civil.Month) float64 {
+	expenses, err := uc.dailyExpenseRepo.GetByMonth(month)
+	if err != nil {
+		return 0
+	}
+
+	total := 0.0
+	for _, expense := range expenses {
+		total += expense.Amount
+	}
+	return total
+}
+
+// checkBudgetExceeded publishes budget.exceeded when the month's spending
+// crosses the configured daily budget with the last change
+func (uc *DailyExpenseUseCase) checkBudgetExceeded(month civil.Month, spentBefore float64) {
+	if uc.publisher == nil || uc.dailyExpenseConfigRepo == nil {
+		return
+	}
+
+	config, err := uc.dailyExpenseConfigRepo.GetByMonth(month)
+	if err != nil || config == nil || config.MonthlyBudget <= 0 {
+		return
+	}
+
+	spent := uc.monthTotal(month)
+	if spentBefore > config.MonthlyBudget || spent <= config.MonthlyBudget {
+		return
+	}
+
+	uc.publish(event.BudgetExceeded, event.BudgetExceededData{
+		Month:      month.String(),
+		Budget:     config.MonthlyBudget,
+		Spent:      spent,
+		ExceededBy: spent - config.MonthlyBudget,
+	})
+}
+
+// evaluateAlerts runs the budget alert rules for the month after a committed change
+// Failures are logged and never fail the expense operation
+func (uc *DailyExpenseUseCase) evaluateAlerts(ctx context.Context, month civil.Month) {
+	if uc.alertUseCase == nil {
+		return
+	}
+
+	if _, err := uc.alertUseCase.Evaluate(ctx, month); err != nil {
+		log.Printf("Alert evaluation failed for %s: %v", month, err)
+	}
+}
+
+// publish emits a ledger event when a publisher is configured
+func (uc *DailyExpenseUseCase) publish(name string, data interface{}) {
+	if uc.publisher != nil {
+		uc.publisher.Publish(name, data)
+	}
 }
@@ -0,0 +1,243 @@
+package usecase
+
+import (
+	"context"
+	"expenses-api/internal/api/dto"
+	"expenses-api/internal/application/port"
+	"expenses-api/internal/domain/apperror"
+	"expenses-api/internal/domain/civil"
+	"expenses-api/internal/domain/pocket"
+	"expenses-api/internal/domain/pocket_allocation"
+	"expenses-api/internal/domain/transfer"
+)
+
+// EnvelopeUseCase handles envelope-style pocket balances
+// Each month a pocket receives an allocation, daily and fixed spending draws it
+// down and unspent money rolls into next month or is swept to savings
+type EnvelopeUseCase struct {
+	pocketRepo           port.PocketRepository
+	pocketAllocationRepo port.PocketAllocationRepository
+	fixedExpenseRepo     port.FixedExpenseRepository
+	dailyExpenseRepo     port.DailyExpenseRepository
+	transferRepo         port.TransferRepository
+}
+
+// NewEnvelopeUseCase creates a new envelope use case instance
+func NewEnvelopeUseCase(
+	pocketRepo port.PocketRepository,
+	pocketAllocationRepo port.PocketAllocationRepository,
+	fixedExpenseRepo port.FixedExpenseRepository,
+	dailyExpenseRepo port.DailyExpenseRepository,
+	transferRepo port.TransferRepository,
+) *EnvelopeUseCase {
+	return &EnvelopeUseCase{
+		pocketRepo:           pocketRepo,
+		pocketAllocationRepo: pocketAllocationRepo,
+		fixedExpenseRepo:     fixedExpenseRepo,
+		dailyExpenseRepo:     dailyExpenseRepo,
+		transferRepo:         transferRepo,
+	}
+}
+
+// pocketMovements holds the money that entered or left a pocket during a month
+type pocketMovements struct {
+	allocation   float64
+	transfersIn  float64
+	transfersOut float64
+	spentDaily   float64
+	spentFixed   float64
+}
+
+// SetAllocation sets the allocation of a pocket for a specific month
+func (uc *EnvelopeUseCase) SetAllocation(ctx context.Context, pocketID uint, monthParam string, amount float64) (*pocket_allocation.PocketAllocation, error) {
+	if pocketID == 0 {
+		return nil, apperror.Invalid("pocket_id", "pocket ID is required")
+	}
+
+	if monthParam == "" {
+		return nil, apperror.Invalid("month", "month is required")
+	}
+
+	// Validate month format
+	month, err := civil.ParseMonth(monthParam)
+	if err != nil {
+		return nil, apperror.Invalid("month", "invalid month format, must be YYYY-MM")
+	}
+
+	if amount < 0 {
+		return nil, apperror.Invalid("amount", "allocation amount cannot be negative")
+	}
+
+	// Verify pocket exists
+	if _, err := uc.pocketRepo.GetByID(pocketID); err != nil {
+		return nil, err
+	}
+
+	allocation := &pocket_allocation.PocketAllocation{
+		PocketID: pocketID,
+		Month:    month,
+		Amount:   amount,
+	}
+
+	if err := uc.pocketAllocationRepo.CreateOrUpdate(ctx, allocation); err != nil {
+		return nil, err
+	}
+
+	return allocation, nil
+}
+
+// GetBalances computes every envelope's running balance for a month
+// Balances are carried month by month starting at the first allocation; the
+// movements of the whole range are loaded with one query per repository
+func (uc *EnvelopeUseCase) GetBalances(monthParam string) ([]dto.EnvelopeDTO, error) {
+	if monthParam == "" {
+		return nil, apperror.Invalid("month", "month is required")
+	}
+
+	// Validate month format
+	target, err := civil.ParseMonth(monthParam)
+	if err != nil {
+		return nil, apperror.Invalid("month", "invalid month format, must be YYYY-MM")
+	}
+
+	pockets, err := uc.pocketRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	// Start carrying balances from the first month with an allocation
+	start := target
+	earliestMonth, err := uc.pocketAllocationRepo.GetEarliestMonth()
+	if err != nil {
+		return nil, err
+	}
+	if !earliestMonth.IsZero() && earliestMonth.Before(target) {
+		start = earliestMonth
+	}
+
+	movementsByMonth, err := uc.getMovements(start, target)
+	if err != nil {
+		return nil, err
+	}
+
+	carry := make(map[uint]float64)
+	var envelopes []dto.EnvelopeDTO
+
+	for current := start; !current.After(target); current = current.AddMonths(1) {
+		movements := movementsByMonth[current]
+
+		isTarget := current == target
+		if isTarget {
+			envelopes = make([]dto.EnvelopeDTO, 0, len(pockets))
+		}
+
+		for i := range pockets {
+			p := &pockets[i]
+			m := movements[p.ID]
+			if m == nil {
+				m = &pocketMovements{}
+			}
+
+			opening := carry[p.ID]
+			available := opening + m.allocation + m.transfersIn - m.transfersOut
+			closing := available - m.spentDaily - m.spentFixed
+
+			// Only a positive surplus can be swept; overspending always carries over
+			var swept float64
+			if p.SweepsToSavings() && closing > 0 {
+				swept = closing
+			}
+			carry[p.ID] = closing - swept
+
+			if isTarget {
+				envelopes = append(envelopes, dto.EnvelopeDTO{
+					PocketID:       int(p.ID),
+					PocketName:     p.Name,
+					Month:          current.String(),
+					RolloverPolicy: rolloverPolicyOrDefault(p),
+					OpeningBalance: opening,
+					Allocation:     m.allocation,
+					TransfersIn:    m.transfersIn,
+					TransfersOut:   m.transfersOut,
+					Available:      available,
+					SpentDaily:     m.spentDaily,
+					SpentFixed:     m.spentFixed,
+					ClosingBalance: closing,
+					SweptToSavings: swept,
+				})
+			}
+		}
+	}
+
+	return envelopes, nil
+}
+
+// getMovements gathers allocations, transfers and spending per month and pocket
+// for every month from start to end, both included
+func (uc *EnvelopeUseCase) getMovements(start, end civil.Month) (map[civil.Month]map[uint]*pocketMovements, error) {
+	movements := make(map[civil.Month]map[uint]*pocketMovements)
+	get := func(month civil.Month, pocketID uint) *pocketMovements {
+		byPocket, ok := movements[month]
+		if !ok {
+			byPocket = make(map[uint]*pocketMovements)
+			movements[month] = byPocket
+		}
+		m, ok := byPocket[pocketID]
+		if !ok {
+			m = &pocketMovements{}
+			byPocket[pocketID] = m
+		}
+		return m
+	}
+
+	allocations, err := uc.pocketAllocationRepo.GetByMonthRange(start, end)
+	if err != nil {
+		return nil, err
+	}
+	for _, allocation := range allocations {
+		get(allocation.Month, allocation.PocketID).allocation += allocation.Amount
+	}
+
+	transfers, err := uc.transferRepo.GetByDateRange(start.FirstDay(), end.LastDay())
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range transfers {
+		month := t.Date.Month()
+		if t.SourceType == transfer.EndpointPocket && t.SourcePocketID != nil {
+			get(month, *t.SourcePocketID).transfersOut += t.Amount
+		}
+		if t.DestinationType == transfer.EndpointPocket && t.DestinationPocketID != nil {
+			get(month, *t.DestinationPocketID).transfersIn += t.Amount
+		}
+	}
+
+	dailyExpenses, err := uc.dailyExpenseRepo.GetByDateRange(start.FirstDay(), end.LastDay())
+	if err != nil {
+		return nil, err
+	}
+	// Split expenses draw each line from its own pocket
+	for _, expense := range dailyExpenses {
+		for pocketID, amount := range expense.GetPocketAmounts() {
+			get(expense.GetMonth(), pocketID).spentDaily += amount
+		}
+	}
+
+	fixedExpenses, err := uc.fixedExpenseRepo.GetByMonthRange(start, end)
+	if err != nil {
+		return nil, err
+	}
+	for _, expense := range fixedExpenses {
+		get(expense.Month, expense.PocketID).spentFixed += expense.GetActualSpent()
+	}
+
+	return movements, nil
+}
+
+// rolloverPolicyOrDefault returns the pocket rollover policy, defaulting to rollover
+func rolloverPolicyOrDefault(p *pocket.Pocket) string {
+	if p.RolloverPolicy == "" {
+		return pocket.RolloverPolicyRollover
+	}
+	return p.RolloverPolicy
+}
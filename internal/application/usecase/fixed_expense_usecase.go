@@ -1,49 +1,68 @@
 package usecase
 
 import (
-	"errors"
+	"context"
 	"expenses-api/internal/application/port"
+	"expenses-api/internal/domain/apperror"
+	"expenses-api/internal/domain/civil"
+	"expenses-api/internal/domain/event"
 	"expenses-api/internal/domain/fixed_expense"
-	"fmt"
-	"time"
+	"expenses-api/internal/domain/tag"
 )
 
 // FixedExpenseUseCase handles fixed expense-related business logic
 type FixedExpenseUseCase struct {
 	fixedExpenseRepo port.FixedExpenseRepository
+	paymentRepo      port.FixedExpensePaymentRepository
+	publisher        port.EventPublisher
+	tagUseCase       *TagUseCase
+	clock            port.Clock
 }
 
 // NewFixedExpenseUseCase creates a new fixed expense use case instance
-func NewFixedExpenseUseCase(fixedExpenseRepo port.FixedExpenseRepository) *FixedExpenseUseCase {
+// The publisher and tag use case are optional; when nil no ledger events are
+// emitted and tags are ignored
+func NewFixedExpenseUseCase(
+	fixedExpenseRepo port.FixedExpenseRepository,
+	paymentRepo port.FixedExpensePaymentRepository,
+	publisher port.EventPublisher,
+	tagUseCase *TagUseCase,
+	clock port.Clock,
+) *FixedExpenseUseCase {
 	return &FixedExpenseUseCase{
 		fixedExpenseRepo: fixedExpenseRepo,
+		paymentRepo:      paymentRepo,
+		publisher:        publisher,
+		tagUseCase:       tagUseCase,
+		clock:            clock,
 	}
 }
 
 // GetByMonth retrieves all fixed expenses for a specific month
-func (uc *FixedExpenseUseCase) GetByMonth(month string) ([]fixed_expense.FixedExpense, error) {
-	if month == "" {
-		return nil, errors.New("month is required")
+func (uc *FixedExpenseUseCase) GetByMonth(monthParam string) ([]fixed_expense.FixedExpense, error) {
+	if monthParam == "" {
+		return nil, apperror.Invalid("month", "month is required")
 	}
 
 	// Validate month format
-	if _, err := time.Parse("2006-01", month); err != nil {
-		return nil, errors.New("invalid month format, must be YYYY-MM")
+	month, err := civil.ParseMonth(monthParam)
+	if err != nil {
+		return nil, apperror.Invalid("month", "invalid month format, must be YYYY-MM")
 	}
 
 	return uc.fixedExpenseRepo.GetByMonth(month)
 }
 
 // GetByMonthWithInheritance obtiene gastos fijos de un mes, heredando del anterior si no existen
-func (uc *FixedExpenseUseCase) GetByMonthWithInheritance(month string) ([]fixed_expense.FixedExpense, error) {
-	if month == "" {
-		return nil, errors.New("month is required")
+func (uc *FixedExpenseUseCase) GetByMonthWithInheritance(monthParam string) ([]fixed_expense.FixedExpense, error) {
+	if monthParam == "" {
+		return nil, apperror.Invalid("month", "month is required")
 	}
 
 	// Validate month format
-	date, err := time.Parse("2006-01", month)
+	month, err := civil.ParseMonth(monthParam)
 	if err != nil {
-		return nil, errors.New("invalid month format, must be YYYY-MM")
+		return nil, apperror.Invalid("month", "invalid month format, must be YYYY-MM")
 	}
 
 	// Intentar obtener gastos del mes actual
@@ -53,7 +72,7 @@ func (uc *FixedExpenseUseCase) GetByMonthWithInheritance(month string) ([]fixed_
 	}
 
 	// Si no existen, buscar mes anterior
-	previousMonth := date.AddDate(0, -1, 0).Format("2006-01")
+	previousMonth := month.AddMonths(-1)
 	previousExpenses, err := uc.fixedExpenseRepo.GetByMonth(previousMonth)
 	if err != nil || len(previousExpenses) == 0 {
 		// No hay configuración anterior, retornar array vacío
@@ -73,81 +92,110 @@ func (uc *FixedExpenseUseCase) GetByMonthWithInheritance(month string) ([]fixed_
 			Month:       month,          // Actualizar al mes solicitado
 			PaidDate:    nil,            // Limpiar fecha de pago
 			Pocket:      expense.Pocket, // Mantener relación para el frontend
+			Tags:        expense.Tags,   // Mantener etiquetas para el frontend
 		}
 	}
 
 	return inheritedExpenses, nil
 }
 
+// GetByMonthAndTag obtiene los gastos fijos de un mes (con herencia) que tienen una etiqueta
+// Una etiqueta vacía devuelve todos los gastos del mes
+func (uc *FixedExpenseUseCase) GetByMonthAndTag(month, tagName string) ([]fixed_expense.FixedExpense, error) {
+	expenses, err := uc.GetByMonthWithInheritance(month)
+	if err != nil || tagName == "" {
+		return expenses, err
+	}
+
+	tagName = tag.NormalizeName(tagName)
+	filtered := make([]fixed_expense.FixedExpense, 0, len(expenses))
+	for _, expense := range expenses {
+		if expense.HasTag(tagName) {
+			filtered = append(filtered, expense)
+		}
+	}
+	return filtered, nil
+}
+
 // Create creates a new fixed expense
-func (uc *FixedExpenseUseCase) Create(expense *fixed_expense.FixedExpense) error {
+// Tags are referenced by name and created when they do not exist yet
+func (uc *FixedExpenseUseCase) Create(ctx context.Context, expense *fixed_expense.FixedExpense, tags []string) error {
 	if expense == nil {
-		return errors.New("expense is required")
+		return apperror.Validation("expense is required")
 	}
 
 	// Validate required fields
 	if expense.ConceptName == "" {
-		return errors.New("concept name is required")
+		return apperror.Invalid("concept_name", "concept name is required")
 	}
 	if expense.Amount <= 0 {
-		return errors.New("amount must be greater than 0")
+		return apperror.Invalid("amount", "amount must be greater than 0")
 	}
 	if expense.PaymentDay < 1 || expense.PaymentDay > 31 {
-		return errors.New("payment day must be between 1 and 31")
+		return apperror.Invalid("payment_day", "payment day must be between 1 and 31")
 	}
-	if expense.Month == "" {
-		return errors.New("month is required")
+	if expense.Month.IsZero() {
+		return apperror.Invalid("month", "month is required")
 	}
 	if expense.PocketID == 0 {
-		return errors.New("pocket ID is required")
+		return apperror.Invalid("pocket_id", "pocket ID is required")
 	}
 
-	// Validate month format
-	if _, err := time.Parse("2006-01", expense.Month); err != nil {
-		return errors.New("invalid month format, must be YYYY-MM")
+	tagIDs, err := uc.resolveTags(ctx, tags)
+	if err != nil {
+		return err
 	}
 
 	// Set default values
 	expense.PaidDate = nil
 
-	return uc.fixedExpenseRepo.Create(expense)
+	if err := uc.fixedExpenseRepo.Create(ctx, expense); err != nil {
+		return err
+	}
+
+	return uc.setTags(ctx, expense.ID, tagIDs)
 }
 
 // Update updates an existing fixed expense
-func (uc *FixedExpenseUseCase) Update(id uint, updatedExpense *fixed_expense.FixedExpense) error {
+// A nil tags slice keeps the current tags; an empty one removes them
+// A non-zero updatedExpense.Version must match the stored one
+func (uc *FixedExpenseUseCase) Update(ctx context.Context, id uint, updatedExpense *fixed_expense.FixedExpense, tags []string) error {
 	if id == 0 {
-		return errors.New("expense ID is required")
+		return apperror.Invalid("id", "expense ID is required")
 	}
 	if updatedExpense == nil {
-		return errors.New("expense data is required")
+		return apperror.Validation("expense data is required")
 	}
 
 	// Get existing expense
 	existingExpense, err := uc.fixedExpenseRepo.GetByID(id)
 	if err != nil {
-		return fmt.Errorf("expense not found: %w", err)
+		return apperror.NotFound("expense not found").WithCause(err)
+	}
+	if err := checkVersion("expense", updatedExpense.Version, existingExpense.Version); err != nil {
+		return err
 	}
 
 	// Validate required fields
 	if updatedExpense.ConceptName == "" {
-		return errors.New("concept name is required")
+		return apperror.Invalid("concept_name", "concept name is required")
 	}
 	if updatedExpense.Amount <= 0 {
-		return errors.New("amount must be greater than 0")
+		return apperror.Invalid("amount", "amount must be greater than 0")
 	}
 	if updatedExpense.PaymentDay < 1 || updatedExpense.PaymentDay > 31 {
-		return errors.New("payment day must be between 1 and 31")
+		return apperror.Invalid("payment_day", "payment day must be between 1 and 31")
 	}
-	if updatedExpense.Month == "" {
-		return errors.New("month is required")
+	if updatedExpense.Month.IsZero() {
+		return apperror.Invalid("month", "month is required")
 	}
 	if updatedExpense.PocketID == 0 {
-		return errors.New("pocket ID is required")
+		return apperror.Invalid("pocket_id", "pocket ID is required")
 	}
 
-	// Validate month format
-	if _, err := time.Parse("2006-01", updatedExpense.Month); err != nil {
-		return errors.New("invalid month format, must be YYYY-MM")
+	tagIDs, err := uc.resolveTags(ctx, tags)
+	if err != nil {
+		return err
 	}
 
 	// Update fields
@@ -158,31 +206,221 @@ func (uc *FixedExpenseUseCase) Update(id uint, updatedExpense *fixed_expense.Fix
 	existingExpense.PocketID = updatedExpense.PocketID
 
 	// Don't update payment status through this method
-	// Use UpdatePaymentStatus for that
+	// Use UpdatePaymentStatus or AddPayment for that, but keep the
+	// derived status in sync when the amount changes
+	wasPaid := existingExpense.IsPaid
+	if len(existingExpense.Payments) > 0 {
+		existingExpense.SyncPaymentStatus()
+	}
+
+	if err := uc.fixedExpenseRepo.Update(ctx, existingExpense); err != nil {
+		return saveConflict("expense", err)
+	}
+
+	if err := uc.setTags(ctx, id, tagIDs); err != nil {
+		return err
+	}
+
+	if existingExpense.IsPaid && !wasPaid {
+		uc.publishPaid(existingExpense)
+	}
 
-	return uc.fixedExpenseRepo.Update(existingExpense)
+	return nil
 }
 
 // GetByID retrieves a fixed expense by ID
 func (uc *FixedExpenseUseCase) GetByID(id uint) (*fixed_expense.FixedExpense, error) {
 	if id == 0 {
-		return nil, errors.New("expense ID is required")
+		return nil, apperror.Invalid("id", "expense ID is required")
 	}
 
 	return uc.fixedExpenseRepo.GetByID(id)
 }
 
-// UpdatePaymentStatus updates the payment status of a fixed expense
-func (uc *FixedExpenseUseCase) UpdatePaymentStatus(id uint, isPaid bool) error {
+// UpdatePaymentStatus marks a fixed expense as fully paid or unpaid
+// Marking as paid optionally records the actual billed amount when it differs
+// from the planned one, and records a payment for whatever is still due on
+// paidDate (today when empty). Marking as unpaid removes every recorded
+// payment and the actual amount. A non-zero version must match the stored one.
+// Like AddPayment and DeletePayment, every change is saved in one transaction.
+func (uc *FixedExpenseUseCase) UpdatePaymentStatus(ctx context.Context, id uint, isPaid bool, actualAmount *float64, paidDate string, version uint) error {
 	if id == 0 {
-		return errors.New("expense ID is required")
+		return apperror.Invalid("id", "expense ID is required")
+	}
+
+	if !isPaid {
+		_, err := uc.changePayments(ctx, id, version, func(expense *fixed_expense.FixedExpense) error {
+			expense.Payments = nil
+			expense.ActualAmount = nil
+			expense.MarkAsUnpaid()
+			return nil
+		})
+		return err
+	}
+
+	if actualAmount != nil && *actualAmount < 0 {
+		return apperror.Invalid("actual_amount", "actual amount cannot be negative")
+	}
+
+	date, err := uc.resolvePaidDate(paidDate)
+	if err != nil {
+		return err
+	}
+
+	_, err = uc.changePayments(ctx, id, version, func(expense *fixed_expense.FixedExpense) error {
+		if actualAmount != nil {
+			expense.ActualAmount = actualAmount
+		}
+
+		if remaining := expense.GetRemainingAmount(); remaining > 0 {
+			expense.Payments = append(expense.Payments, fixed_expense.Payment{
+				FixedExpenseID: id,
+				Amount:         remaining,
+				PaidDate:       date,
+			})
+			return nil
+		}
+
+		// Nothing left to pay and no payments to derive the status from (zero amount)
+		if len(expense.Payments) == 0 && !expense.IsPaid {
+			expense.IsPaid = true
+			expense.PaidDate = &date
+		}
+		return nil
+	})
+	return err
+}
+
+// AddPayment records a (possibly partial) payment against a fixed expense
+// and returns the expense with its updated payment status
+func (uc *FixedExpenseUseCase) AddPayment(ctx context.Context, id uint, amount float64, paidDate, method, note string) (*fixed_expense.FixedExpense, error) {
+	if id == 0 {
+		return nil, apperror.Invalid("id", "expense ID is required")
+	}
+
+	if amount <= 0 {
+		return nil, apperror.Invalid("amount", "payment amount must be greater than 0")
+	}
+
+	date, err := uc.resolvePaidDate(paidDate)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.changePayments(ctx, id, 0, func(expense *fixed_expense.FixedExpense) error {
+		expense.Payments = append(expense.Payments, fixed_expense.Payment{
+			FixedExpenseID: id,
+			Amount:         amount,
+			PaidDate:       date,
+			Method:         method,
+			Note:           note,
+		})
+		return nil
+	})
+}
+
+// DeletePayment removes a payment from a fixed expense and returns the
+// expense with its updated payment status. A non-zero version must match the stored one.
+func (uc *FixedExpenseUseCase) DeletePayment(ctx context.Context, id uint, paymentID uint, version uint) (*fixed_expense.FixedExpense, error) {
+	if id == 0 {
+		return nil, apperror.Invalid("id", "expense ID is required")
+	}
+	if paymentID == 0 {
+		return nil, apperror.Invalid("payment_id", "payment ID is required")
+	}
+
+	return uc.changePayments(ctx, id, version, func(expense *fixed_expense.FixedExpense) error {
+		for i, payment := range expense.Payments {
+			if payment.ID == paymentID {
+				expense.Payments = append(expense.Payments[:i], expense.Payments[i+1:]...)
+				return nil
+			}
+		}
+		return apperror.NotFound("payment not found")
+	})
+}
+
+// changePayments applies a change to a fixed expense and its payments, then
+// recomputes IsPaid and PaidDate from the payments and saves everything in one
+// transaction. A non-zero version must match the stored one. It publishes
+// fixed_expense.paid when the expense becomes paid and returns the saved expense
+func (uc *FixedExpenseUseCase) changePayments(ctx context.Context, id uint, version uint, change func(expense *fixed_expense.FixedExpense) error) (*fixed_expense.FixedExpense, error) {
+	expense, err := uc.fixedExpenseRepo.GetByID(id)
+	if err != nil {
+		return nil, apperror.NotFound("expense not found").WithCause(err)
+	}
+	if err := checkVersion("expense", version, expense.Version); err != nil {
+		return nil, err
+	}
+
+	wasPaid := expense.IsPaid
+	hadPayments := len(expense.Payments) > 0
+	if err := change(expense); err != nil {
+		return nil, err
+	}
+
+	// Expenses marked as paid before payments were tracked keep the status the change set
+	if hadPayments || len(expense.Payments) > 0 {
+		expense.SyncPaymentStatus()
+	}
+
+	if err := uc.paymentRepo.Save(ctx, expense); err != nil {
+		return nil, saveConflict("expense", err)
+	}
+
+	saved, err := uc.fixedExpenseRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if saved.IsPaid && !wasPaid {
+		uc.publishPaid(saved)
+	}
+
+	return saved, nil
+}
+
+// resolveTags returns the IDs of the named tags, creating missing ones
+// A nil result means the tags are left untouched
+func (uc *FixedExpenseUseCase) resolveTags(ctx context.Context, tags []string) ([]uint, error) {
+	if uc.tagUseCase == nil || tags == nil {
+		return nil, nil
+	}
+	return uc.tagUseCase.ResolveIDs(ctx, tags)
+}
+
+// setTags replaces the tags of an expense with the resolved tag IDs
+func (uc *FixedExpenseUseCase) setTags(ctx context.Context, id uint, tagIDs []uint) error {
+	if uc.tagUseCase == nil || tagIDs == nil {
+		return nil
+	}
+	return uc.tagUseCase.SetFixedExpenseTags(ctx, id, tagIDs)
+}
+
+// publishPaid emits fixed_expense.paid when a publisher is configured
+func (uc *FixedExpenseUseCase) publishPaid(expense *fixed_expense.FixedExpense) {
+	if uc.publisher != nil {
+		uc.publisher.Publish(event.FixedExpensePaid, expense)
+	}
+}
+
+// resolvePaidDate defaults an empty paid date to today and rejects invalid or future dates
+func (uc *FixedExpenseUseCase) resolvePaidDate(paidDate string) (civil.Date, error) {
+	now := uc.clock.Now()
+	if paidDate == "" {
+		return civil.DateOf(now), nil
+	}
+
+	// Validate date format
+	date, err := civil.ParseDate(paidDate)
+	if err != nil {
+		return civil.Date{}, apperror.Invalid("paid_date", "invalid paid date format, must be YYYY-MM-DD")
 	}
 
-	var paidDate *string
-	if isPaid {
-		currentDate := time.Now().Format("2006-01-02")
-		paidDate = &currentDate
+	// Don't allow future dates beyond today
+	if isFutureDate(date, now) {
+		return civil.Date{}, apperror.Invalid("paid_date", "paid date cannot be in the future")
 	}
 
-	return uc.fixedExpenseRepo.UpdatePaymentStatus(id, isPaid, paidDate)
+	return date, nil
 }
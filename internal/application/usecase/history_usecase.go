@@ -0,0 +1,299 @@
+package usecase
+
+import (
+	"expenses-api/internal/api/dto"
+	"expenses-api/internal/application/port"
+	"expenses-api/internal/domain/apperror"
+	"expenses-api/internal/domain/civil"
+	"expenses-api/internal/domain/daily_expense"
+	"expenses-api/internal/domain/daily_expense_config"
+	"expenses-api/internal/domain/fixed_expense"
+	"expenses-api/internal/domain/ledger"
+	"expenses-api/internal/domain/pocket"
+	"expenses-api/internal/domain/receivable"
+	"expenses-api/internal/domain/salary"
+	"expenses-api/internal/domain/transfer"
+	"sort"
+	"strings"
+	"time"
+)
+
+// defaultLedgerEventLimit is the number of events returned when no limit is given
+const defaultLedgerEventLimit = 100
+
+// maxLedgerEventLimit is the largest number of events returned at once
+const maxLedgerEventLimit = 1000
+
+// summaryLedgerEntities are the tables whose events rebuild a monthly summary
+var summaryLedgerEntities = []string{
+	salary.Salary{}.TableName(),
+	daily_expense_config.DailyExpenseConfig{}.TableName(),
+	pocket.Pocket{}.TableName(),
+	fixed_expense.FixedExpense{}.TableName(),
+	fixed_expense.Payment{}.TableName(),
+	daily_expense.DailyExpense{}.TableName(),
+	transfer.Transfer{}.TableName(),
+	receivable.Receivable{}.TableName(),
+	receivable.Reimbursement{}.TableName(),
+}
+
+// HistoryUseCase rebuilds the ledger from its event log: past monthly
+// summaries and the consistency of the current tables with the events
+type HistoryUseCase struct {
+	eventRepo port.LedgerEventRepository
+	clock     port.Clock
+}
+
+// NewHistoryUseCase creates a new history use case instance
+func NewHistoryUseCase(eventRepo port.LedgerEventRepository, clock port.Clock) *HistoryUseCase {
+	return &HistoryUseCase{
+		eventRepo: eventRepo,
+		clock:     clock,
+	}
+}
+
+// GetEvents retrieves the events after the given one in replay order, optionally of one entity
+func (uc *HistoryUseCase) GetEvents(entity string, afterID uint, limit int) ([]ledger.Event, error) {
+	entity = strings.TrimSpace(entity)
+	if entity != "" && !ledger.IsTracked(entity) {
+		return nil, apperror.Invalid("entity", "entity must be one of: "+strings.Join(ledger.Entities(), ", "))
+	}
+
+	if limit <= 0 || limit > maxLedgerEventLimit {
+		limit = defaultLedgerEventLimit
+	}
+
+	return uc.eventRepo.Find(ledger.Filter{
+		Entity:  entity,
+		AfterID: afterID,
+		Limit:   limit,
+	})
+}
+
+// GetMonthlySummaryAt rebuilds the monthly summary as it was at a point in time
+// asOf is a timestamp (RFC 3339) or a date (YYYY-MM-DD), meaning the end of
+// that day in the business timezone; an empty asOf rebuilds the current summary
+func (uc *HistoryUseCase) GetMonthlySummaryAt(monthParam string, asOf string) (*dto.MonthlySummaryDTO, error) {
+	month, err := civil.ParseMonth(monthParam)
+	if err != nil {
+		return nil, apperror.Invalid("month", "invalid month format, must be YYYY-MM")
+	}
+
+	until, err := uc.parseAsOf(asOf)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := uc.eventRepo.Find(ledger.Filter{
+		Entities: summaryLedgerEntities,
+		Month:    month,
+		Until:    until,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := projectedMonthEntries(ledger.Replay(events), month)
+	if err != nil {
+		return nil, err
+	}
+
+	return entries.summary(month), nil
+}
+
+// CheckConsistency replays the whole event log and compares every ledger
+// entity with its current row. Rows changed outside the application are
+// reported as discrepancies; so may be changes made while the check runs
+func (uc *HistoryUseCase) CheckConsistency() (*ledger.ConsistencyReport, error) {
+	checkedAt := uc.clock.Now()
+
+	events, err := uc.eventRepo.Find(ledger.Filter{})
+	if err != nil {
+		return nil, err
+	}
+	projection := ledger.Replay(events)
+
+	report := &ledger.ConsistencyReport{
+		CheckedAt:   checkedAt,
+		Events:      projection.Events(),
+		LastEventID: projection.LastEventID(),
+	}
+	for _, entity := range ledger.Entities() {
+		current, err := uc.eventRepo.CurrentStates(entity)
+		if err != nil {
+			return nil, err
+		}
+
+		compared, discrepancies, err := projection.Compare(entity, current)
+		if err != nil {
+			return nil, err
+		}
+		report.Entities += compared
+		report.Discrepancies = append(report.Discrepancies, discrepancies...)
+	}
+
+	return report, nil
+}
+
+// parseAsOf returns the last instant whose events are replayed for asOf
+func (uc *HistoryUseCase) parseAsOf(asOf string) (time.Time, error) {
+	now := uc.clock.Now()
+
+	asOf = strings.TrimSpace(asOf)
+	if asOf == "" {
+		return now, nil
+	}
+
+	// Events are stored in the business timezone, so the instant is compared in it too
+	if timestamp, err := time.Parse(time.RFC3339, asOf); err == nil {
+		return timestamp.In(now.Location()), nil
+	}
+
+	date, err := civil.ParseDate(asOf)
+	if err != nil {
+		return time.Time{}, apperror.Invalid("as_of", "invalid as_of format, must be YYYY-MM-DD or an RFC 3339 timestamp")
+	}
+	return date.AddDays(1).In(now.Location()).Add(-time.Nanosecond), nil
+}
+
+// projectedMonthEntries selects the ledger entries of a month from a projection,
+// related the way the repositories load them for the summary
+func projectedMonthEntries(projection *ledger.Projection, month civil.Month) (monthEntries, error) {
+	var entries monthEntries
+
+	salaries, err := projection.Salaries()
+	if err != nil {
+		return entries, err
+	}
+	for i := range salaries {
+		if salaries[i].Month == month {
+			entries.salary = &salaries[i]
+		}
+	}
+
+	configs, err := projection.DailyExpenseConfigs()
+	if err != nil {
+		return entries, err
+	}
+	for i := range configs {
+		if configs[i].Month == month {
+			entries.dailyConfig = &configs[i]
+		}
+	}
+
+	entries.fixedExpenses, err = projectedFixedExpenses(projection, month)
+	if err != nil {
+		return entries, err
+	}
+
+	dailyExpenses, err := projection.DailyExpenses()
+	if err != nil {
+		return entries, err
+	}
+	for _, expense := range dailyExpenses {
+		if month.Contains(expense.Date) {
+			entries.dailyExpenses = append(entries.dailyExpenses, expense)
+		}
+	}
+
+	transfers, err := projection.Transfers()
+	if err != nil {
+		return entries, err
+	}
+	for _, t := range transfers {
+		if month.Contains(t.Date) {
+			entries.transfers = append(entries.transfers, t)
+		}
+	}
+
+	entries.receivables, err = projectedReceivables(projection, entries.fixedExpenses, entries.dailyExpenses)
+	if err != nil {
+		return entries, err
+	}
+
+	return entries, nil
+}
+
+// projectedFixedExpenses returns the fixed expenses of a month with their
+// pockets and payments, ordered by payment day and concept like the repository
+func projectedFixedExpenses(projection *ledger.Projection, month civil.Month) ([]fixed_expense.FixedExpense, error) {
+	pockets, err := projection.Pockets()
+	if err != nil {
+		return nil, err
+	}
+	pocketNames := make(map[uint]string, len(pockets))
+	for _, p := range pockets {
+		pocketNames[p.ID] = p.Name
+	}
+
+	payments, err := projection.FixedExpensePayments()
+	if err != nil {
+		return nil, err
+	}
+	paymentsByExpense := make(map[uint][]fixed_expense.Payment)
+	for _, payment := range payments {
+		paymentsByExpense[payment.FixedExpenseID] = append(paymentsByExpense[payment.FixedExpenseID], payment)
+	}
+
+	expenses, err := projection.FixedExpenses()
+	if err != nil {
+		return nil, err
+	}
+
+	var monthExpenses []fixed_expense.FixedExpense
+	for _, expense := range expenses {
+		if expense.Month != month {
+			continue
+		}
+		if name, ok := pocketNames[expense.PocketID]; ok {
+			expense.Pocket = &fixed_expense.Pocket{ID: expense.PocketID, Name: name}
+		}
+		expense.Payments = paymentsByExpense[expense.ID]
+		monthExpenses = append(monthExpenses, expense)
+	}
+
+	sort.SliceStable(monthExpenses, func(i, j int) bool {
+		if monthExpenses[i].PaymentDay != monthExpenses[j].PaymentDay {
+			return monthExpenses[i].PaymentDay < monthExpenses[j].PaymentDay
+		}
+		return monthExpenses[i].ConceptName < monthExpenses[j].ConceptName
+	})
+	return monthExpenses, nil
+}
+
+// projectedReceivables returns the receivables of the given expenses with their reimbursements
+func projectedReceivables(projection *ledger.Projection, fixedExpenses []fixed_expense.FixedExpense, dailyExpenses []daily_expense.DailyExpense) ([]receivable.Receivable, error) {
+	expenses := make(map[string]map[uint]bool)
+	expenses[receivable.ExpenseTypeFixed] = make(map[uint]bool, len(fixedExpenses))
+	for _, expense := range fixedExpenses {
+		expenses[receivable.ExpenseTypeFixed][expense.ID] = true
+	}
+	expenses[receivable.ExpenseTypeDaily] = make(map[uint]bool, len(dailyExpenses))
+	for _, expense := range dailyExpenses {
+		expenses[receivable.ExpenseTypeDaily][expense.ID] = true
+	}
+
+	reimbursements, err := projection.Reimbursements()
+	if err != nil {
+		return nil, err
+	}
+	reimbursementsByReceivable := make(map[uint][]receivable.Reimbursement)
+	for _, r := range reimbursements {
+		reimbursementsByReceivable[r.ReceivableID] = append(reimbursementsByReceivable[r.ReceivableID], r)
+	}
+
+	receivables, err := projection.Receivables()
+	if err != nil {
+		return nil, err
+	}
+
+	var expenseReceivables []receivable.Receivable
+	for _, r := range receivables {
+		if !expenses[r.ExpenseType][r.ExpenseID] {
+			continue
+		}
+		r.Reimbursements = reimbursementsByReceivable[r.ID]
+		expenseReceivables = append(expenseReceivables, r)
+	}
+	return expenseReceivables, nil
+}
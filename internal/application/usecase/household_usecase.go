@@ -0,0 +1,384 @@
+package usecase
+
+import (
+	"context"
+	"expenses-api/internal/application/port"
+	"expenses-api/internal/domain/apperror"
+	"expenses-api/internal/domain/civil"
+	"expenses-api/internal/domain/household"
+	"strings"
+)
+
+// HouseholdUseCase handles household members, shared expenses and settling up between members
+type HouseholdUseCase struct {
+	memberRepo       port.HouseholdMemberRepository
+	shareRepo        port.ExpenseShareRepository
+	dailyExpenseRepo port.DailyExpenseRepository
+	fixedExpenseRepo port.FixedExpenseRepository
+}
+
+// NewHouseholdUseCase creates a new household use case instance
+func NewHouseholdUseCase(
+	memberRepo port.HouseholdMemberRepository,
+	shareRepo port.ExpenseShareRepository,
+	dailyExpenseRepo port.DailyExpenseRepository,
+	fixedExpenseRepo port.FixedExpenseRepository,
+) *HouseholdUseCase {
+	return &HouseholdUseCase{
+		memberRepo:       memberRepo,
+		shareRepo:        shareRepo,
+		dailyExpenseRepo: dailyExpenseRepo,
+		fixedExpenseRepo: fixedExpenseRepo,
+	}
+}
+
+// GetMembers retrieves all household members
+func (uc *HouseholdUseCase) GetMembers() ([]household.Member, error) {
+	return uc.memberRepo.GetAll()
+}
+
+// CreateMember adds a new member to the household
+func (uc *HouseholdUseCase) CreateMember(ctx context.Context, name, email string) (*household.Member, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, apperror.Invalid("name", "member name is required")
+	}
+
+	if err := uc.ensureMemberNameAvailable(name, 0); err != nil {
+		return nil, err
+	}
+
+	member := &household.Member{
+		Name:     name,
+		Email:    email,
+		IsActive: true,
+	}
+
+	if err := uc.memberRepo.Create(ctx, member); err != nil {
+		return nil, err
+	}
+
+	return member, nil
+}
+
+// UpdateMember renames a member or changes whether they take part in new shared expenses
+// A nil isActive keeps the current state
+func (uc *HouseholdUseCase) UpdateMember(ctx context.Context, id uint, name, email string, isActive *bool) (*household.Member, error) {
+	if id == 0 {
+		return nil, apperror.Invalid("id", "member ID is required")
+	}
+
+	member, err := uc.memberRepo.GetByID(id)
+	if err != nil {
+		return nil, apperror.NotFound("member not found")
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, apperror.Invalid("name", "member name is required")
+	}
+
+	if err := uc.ensureMemberNameAvailable(name, id); err != nil {
+		return nil, err
+	}
+
+	member.Name = name
+	member.Email = email
+	if isActive != nil {
+		member.IsActive = *isActive
+	}
+
+	if err := uc.memberRepo.Update(ctx, member); err != nil {
+		return nil, err
+	}
+
+	return member, nil
+}
+
+// DeleteMember removes a member that never took part in a shared expense
+// Members with history must be deactivated instead so past balances stay intact
+func (uc *HouseholdUseCase) DeleteMember(ctx context.Context, id uint) error {
+	if id == 0 {
+		return apperror.Invalid("id", "member ID is required")
+	}
+
+	if _, err := uc.memberRepo.GetByID(id); err != nil {
+		return apperror.NotFound("member not found")
+	}
+
+	hasShares, err := uc.memberRepo.HasShares(id)
+	if err != nil {
+		return err
+	}
+	if hasShares {
+		return apperror.Conflict("member has shared expenses, deactivate it instead")
+	}
+
+	return uc.memberRepo.Delete(ctx, id)
+}
+
+// GetSharesByMonth retrieves the shared daily and fixed expenses of a month
+func (uc *HouseholdUseCase) GetSharesByMonth(monthParam string) ([]household.Share, error) {
+	month, err := civil.ParseMonth(monthParam)
+	if err != nil {
+		return nil, apperror.Invalid("month", "invalid month format, must be YYYY-MM")
+	}
+
+	fixedExpenses, err := uc.fixedExpenseRepo.GetByMonth(month)
+	if err != nil {
+		return nil, err
+	}
+
+	dailyExpenses, err := uc.dailyExpenseRepo.GetByMonth(month)
+	if err != nil {
+		return nil, err
+	}
+
+	fixedIDs := make([]uint, 0, len(fixedExpenses))
+	for _, expense := range fixedExpenses {
+		fixedIDs = append(fixedIDs, expense.ID)
+	}
+
+	dailyIDs := make([]uint, 0, len(dailyExpenses))
+	for _, expense := range dailyExpenses {
+		dailyIDs = append(dailyIDs, expense.ID)
+	}
+
+	fixedShares, err := uc.shareRepo.GetByExpenses(household.ExpenseTypeFixed, fixedIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	dailyShares, err := uc.shareRepo.GetByExpenses(household.ExpenseTypeDaily, dailyIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	shares := append(fixedShares, dailyShares...)
+	for i := range shares {
+		uc.fillExpense(&shares[i])
+	}
+
+	return shares, nil
+}
+
+// GetShareByID retrieves a shared expense with its payer, participants and expense details
+func (uc *HouseholdUseCase) GetShareByID(id uint) (*household.Share, error) {
+	if id == 0 {
+		return nil, apperror.Invalid("id", "share ID is required")
+	}
+
+	share, err := uc.shareRepo.GetByID(id)
+	if err != nil {
+		return nil, apperror.NotFound("share not found")
+	}
+
+	uc.fillExpense(share)
+	return share, nil
+}
+
+// CreateShare records who paid an expense and how it is split
+// An equal split without participants is shared by every active member
+func (uc *HouseholdUseCase) CreateShare(ctx context.Context, expenseType string, expenseID, payerID uint, method string, parts []household.SharePart) (*household.Share, error) {
+	if !household.IsValidExpenseType(expenseType) {
+		return nil, apperror.Invalid("expense_type", "expense type must be daily or fixed")
+	}
+
+	if expenseID == 0 {
+		return nil, apperror.Invalid("expense_id", "expense ID is required")
+	}
+
+	expenseAmount, err := uc.expenseAmount(expenseType, expenseID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := uc.shareRepo.GetByExpense(expenseType, expenseID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, apperror.Conflict("expense is already shared")
+	}
+
+	share := &household.Share{
+		ExpenseType: expenseType,
+		ExpenseID:   expenseID,
+		PayerID:     payerID,
+		Method:      method,
+	}
+
+	if err := uc.prepareShare(share, parts, expenseAmount); err != nil {
+		return nil, err
+	}
+
+	if err := uc.shareRepo.Create(ctx, share); err != nil {
+		return nil, err
+	}
+
+	return uc.GetShareByID(share.ID)
+}
+
+// UpdateShare changes the payer, the split method or the participants of a shared expense
+func (uc *HouseholdUseCase) UpdateShare(ctx context.Context, id, payerID uint, method string, parts []household.SharePart) (*household.Share, error) {
+	share, err := uc.GetShareByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	expenseAmount, err := uc.expenseAmount(share.ExpenseType, share.ExpenseID)
+	if err != nil {
+		return nil, err
+	}
+
+	share.PayerID = payerID
+	share.Method = method
+	share.Payer = nil
+
+	if err := uc.prepareShare(share, parts, expenseAmount); err != nil {
+		return nil, err
+	}
+
+	if err := uc.shareRepo.Update(ctx, share); err != nil {
+		return nil, err
+	}
+
+	return uc.GetShareByID(id)
+}
+
+// DeleteShare stops sharing an expense; it counts again as the payer's own expense
+func (uc *HouseholdUseCase) DeleteShare(ctx context.Context, id uint) error {
+	if _, err := uc.GetShareByID(id); err != nil {
+		return err
+	}
+
+	return uc.shareRepo.Delete(ctx, id)
+}
+
+// SettleUp computes each member's balance for the month's shared expenses and
+// the minimal transfers that bring everyone back to zero
+func (uc *HouseholdUseCase) SettleUp(month string) ([]household.Balance, []household.Settlement, error) {
+	shares, err := uc.GetSharesByMonth(month)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	members, err := uc.memberRepo.GetAll()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Every member is reported, even without shared expenses this month
+	balances := make([]household.Balance, 0, len(members))
+	index := make(map[uint]int, len(members))
+	for _, member := range members {
+		index[member.ID] = len(balances)
+		balances = append(balances, household.Balance{
+			MemberID:   member.ID,
+			MemberName: member.Name,
+		})
+	}
+
+	for i := range shares {
+		share := &shares[i]
+		if pos, ok := index[share.PayerID]; ok {
+			balances[pos].Paid += share.ExpenseAmount
+		}
+		for memberID, owed := range share.GetOwedAmounts(share.ExpenseAmount) {
+			if pos, ok := index[memberID]; ok {
+				balances[pos].Owed += owed
+			}
+		}
+	}
+
+	return balances, household.Settle(balances), nil
+}
+
+// prepareShare checks the payer and participants and validates the split against the expense
+func (uc *HouseholdUseCase) prepareShare(share *household.Share, parts []household.SharePart, expenseAmount float64) error {
+	if share.PayerID == 0 {
+		return apperror.Invalid("payer_id", "payer is required")
+	}
+
+	payer, err := uc.memberRepo.GetByID(share.PayerID)
+	if err != nil {
+		return apperror.Invalid("payer_id", "payer not found")
+	}
+	if !payer.IsActive {
+		return apperror.Invalid("payer_id", "payer is not an active member")
+	}
+
+	if len(parts) == 0 && share.Method == household.MethodEqual {
+		members, err := uc.memberRepo.GetAll()
+		if err != nil {
+			return err
+		}
+		for _, member := range members {
+			if member.IsActive {
+				parts = append(parts, household.SharePart{MemberID: member.ID})
+			}
+		}
+	}
+
+	for _, part := range parts {
+		member, err := uc.memberRepo.GetByID(part.MemberID)
+		if err != nil {
+			return apperror.Invalid("participants", "participant member not found")
+		}
+		if !member.IsActive {
+			return apperror.Invalid("participants", "participant is not an active member")
+		}
+	}
+
+	share.Parts = parts
+	return share.Validate(expenseAmount)
+}
+
+// expenseAmount returns the amount of the expense a share points to
+// Fixed expenses use the actual billed amount when it was recorded
+func (uc *HouseholdUseCase) expenseAmount(expenseType string, expenseID uint) (float64, error) {
+	if expenseType == household.ExpenseTypeDaily {
+		expense, err := uc.dailyExpenseRepo.GetByID(expenseID)
+		if err != nil {
+			return 0, apperror.NotFound("expense not found")
+		}
+		return expense.Amount, nil
+	}
+
+	expense, err := uc.fixedExpenseRepo.GetByID(expenseID)
+	if err != nil {
+		return 0, apperror.NotFound("expense not found")
+	}
+	return expense.GetDueAmount(), nil
+}
+
+// fillExpense copies the description, amount and month of the expense into the share
+// Missing expenses leave the fields empty
+func (uc *HouseholdUseCase) fillExpense(share *household.Share) {
+	if share.ExpenseType == household.ExpenseTypeDaily {
+		if expense, err := uc.dailyExpenseRepo.GetByID(share.ExpenseID); err == nil {
+			share.ExpenseDescription = expense.Description
+			share.ExpenseAmount = expense.Amount
+			share.ExpenseMonth = expense.GetMonth()
+		}
+		return
+	}
+
+	if expense, err := uc.fixedExpenseRepo.GetByID(share.ExpenseID); err == nil {
+		share.ExpenseDescription = expense.ConceptName
+		share.ExpenseAmount = expense.GetDueAmount()
+		share.ExpenseMonth = expense.Month
+	}
+}
+
+// ensureMemberNameAvailable checks that no other member uses the name
+func (uc *HouseholdUseCase) ensureMemberNameAvailable(name string, id uint) error {
+	existing, err := uc.memberRepo.GetByName(name)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.ID != id {
+		return apperror.Conflict("a member with this name already exists")
+	}
+	return nil
+}
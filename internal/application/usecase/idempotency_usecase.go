@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"expenses-api/internal/application/port"
+	"expenses-api/internal/domain/apperror"
+	"expenses-api/internal/domain/idempotency"
+	"log"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultIdempotencyTTL is how long a key is replayed when no TTL is configured
+const defaultIdempotencyTTL = 24 * time.Hour
+
+// idempotencyPurgeInterval is the minimum time between two purges of expired keys
+const idempotencyPurgeInterval = time.Hour
+
+// IdempotencyUseCase lets retried POST requests replay the first response
+// instead of repeating the change, based on the Idempotency-Key header
+type IdempotencyUseCase struct {
+	repo  port.IdempotencyRepository
+	clock port.Clock
+	ttl   time.Duration
+
+	mu         sync.Mutex
+	lastPurged time.Time
+}
+
+// NewIdempotencyUseCase creates a new idempotency use case instance
+// ttl is how long a key and its response are kept
+func NewIdempotencyUseCase(repo port.IdempotencyRepository, clock port.Clock, ttl time.Duration) *IdempotencyUseCase {
+	if ttl <= 0 {
+		ttl = defaultIdempotencyTTL
+	}
+
+	return &IdempotencyUseCase{
+		repo:  repo,
+		clock: clock,
+		ttl:   ttl,
+	}
+}
+
+// Begin reserves a key for the request identified by fingerprint
+// It returns nil when the key was reserved: the caller must run the request and
+// then Complete or Release the key. It returns the stored record when the same
+// request already completed, so the caller replays its response
+func (uc *IdempotencyUseCase) Begin(key, fingerprint string) (*idempotency.Record, error) {
+	key = strings.TrimSpace(key)
+	if key == "" || len(key) > idempotency.MaxKeyLength {
+		return nil, apperror.Invalid("Idempotency-Key", "Idempotency-Key must have between 1 and 255 characters")
+	}
+
+	now := uc.clock.Now()
+	uc.purgeExpired(now)
+
+	// A second attempt covers a key that expired or was released meanwhile
+	for attempt := 0; attempt < 2; attempt++ {
+		reserved, err := uc.repo.Reserve(&idempotency.Record{
+			Key:         key,
+			Fingerprint: fingerprint,
+			ExpiresAt:   now.Add(uc.ttl),
+		})
+		if err != nil {
+			return nil, err
+		}
+		if reserved {
+			return nil, nil
+		}
+
+		stored, err := uc.repo.GetByKey(key)
+		if err != nil {
+			continue
+		}
+		if stored.IsExpired(now) {
+			if err := uc.repo.Delete(key); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if stored.Fingerprint != fingerprint {
+			return nil, apperror.Invalid("Idempotency-Key", "Idempotency-Key was already used for a different request")
+		}
+		if !stored.IsCompleted() {
+			return nil, apperror.Conflict("a request with this Idempotency-Key is still in progress")
+		}
+		return stored, nil
+	}
+
+	return nil, apperror.Conflict("a request with this Idempotency-Key is still in progress")
+}
+
+// Complete stores the response of the request that reserved the key
+func (uc *IdempotencyUseCase) Complete(key string, statusCode int, contentType, body string) error {
+	return uc.repo.Complete(strings.TrimSpace(key), statusCode, contentType, body)
+}
+
+// Release frees a reserved key whose request failed, so it can be retried
+func (uc *IdempotencyUseCase) Release(key string) error {
+	return uc.repo.Delete(strings.TrimSpace(key))
+}
+
+// purgeExpired removes expired keys at most once per purge interval, logging failures
+func (uc *IdempotencyUseCase) purgeExpired(now time.Time) {
+	uc.mu.Lock()
+	if now.Sub(uc.lastPurged) < idempotencyPurgeInterval {
+		uc.mu.Unlock()
+		return
+	}
+	uc.lastPurged = now
+	uc.mu.Unlock()
+
+	if _, err := uc.repo.DeleteExpired(now); err != nil {
+		log.Printf("Purging expired idempotency keys failed: %v", err)
+	}
+}
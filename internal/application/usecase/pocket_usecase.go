@@ -1,8 +1,9 @@
 package usecase
 
 import (
-	"errors"
+	"context"
 	"expenses-api/internal/application/port"
+	"expenses-api/internal/domain/apperror"
 	"expenses-api/internal/domain/pocket"
 	"strings"
 )
@@ -27,37 +28,44 @@ func (uc *PocketUseCase) GetAll() ([]pocket.Pocket, error) {
 // GetByID retrieves a pocket by ID
 func (uc *PocketUseCase) GetByID(id uint) (*pocket.Pocket, error) {
 	if id == 0 {
-		return nil, errors.New("pocket ID is required")
+		return nil, apperror.Invalid("id", "pocket ID is required")
 	}
 	
 	return uc.pocketRepo.GetByID(id)
 }
 
 // Create creates a new pocket
-func (uc *PocketUseCase) Create(name, description string) (*pocket.Pocket, error) {
+// An empty rollover policy defaults to rolling unspent money into next month
+func (uc *PocketUseCase) Create(ctx context.Context, name, description, rolloverPolicy string) (*pocket.Pocket, error) {
 	// Validate input
 	name = strings.TrimSpace(name)
 	if name == "" {
-		return nil, errors.New("pocket name is required")
+		return nil, apperror.Invalid("name", "pocket name is required")
 	}
 	
 	if len(name) > 255 {
-		return nil, errors.New("pocket name cannot exceed 255 characters")
+		return nil, apperror.Invalid("name", "pocket name cannot exceed 255 characters")
 	}
 	
 	// Check if name already exists by trying to get it
 	existing, err := uc.pocketRepo.GetByName(name)
 	if err == nil && existing != nil {
-		return nil, errors.New("pocket with this name already exists")
+		return nil, apperror.Conflict("pocket with this name already exists")
 	}
 	
+	rolloverPolicy, err = normalizeRolloverPolicy(rolloverPolicy)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create pocket
 	p := &pocket.Pocket{
-		Name:        name,
-		Description: strings.TrimSpace(description),
+		Name:           name,
+		Description:    strings.TrimSpace(description),
+		RolloverPolicy: rolloverPolicy,
 	}
 	
-	if err := uc.pocketRepo.Create(p); err != nil {
+	if err := uc.pocketRepo.Create(ctx, p); err != nil {
 		return nil, err
 	}
 	
@@ -65,19 +73,20 @@ func (uc *PocketUseCase) Create(name, description string) (*pocket.Pocket, error
 }
 
 // Update updates an existing pocket
-func (uc *PocketUseCase) Update(id uint, name, description string) (*pocket.Pocket, error) {
+// An empty rollover policy keeps the current one; a non-zero version must match the stored one
+func (uc *PocketUseCase) Update(ctx context.Context, id uint, name, description, rolloverPolicy string, version uint) (*pocket.Pocket, error) {
 	if id == 0 {
-		return nil, errors.New("pocket ID is required")
+		return nil, apperror.Invalid("id", "pocket ID is required")
 	}
 	
 	// Validate input
 	name = strings.TrimSpace(name)
 	if name == "" {
-		return nil, errors.New("pocket name is required")
+		return nil, apperror.Invalid("name", "pocket name is required")
 	}
 	
 	if len(name) > 255 {
-		return nil, errors.New("pocket name cannot exceed 255 characters")
+		return nil, apperror.Invalid("name", "pocket name cannot exceed 255 characters")
 	}
 	
 	// Get existing pocket
@@ -85,50 +94,81 @@ func (uc *PocketUseCase) Update(id uint, name, description string) (*pocket.Pock
 	if err != nil {
 		return nil, err
 	}
+	if err := checkVersion("pocket", version, existingPocket.Version); err != nil {
+		return nil, err
+	}
 	
 	// Check if name already exists (excluding current pocket)
 	if existingPocket.Name != name {
 		existing, err := uc.pocketRepo.GetByName(name)
 		if err == nil && existing != nil && existing.ID != id {
-			return nil, errors.New("pocket with this name already exists")
+			return nil, apperror.Conflict("pocket with this name already exists")
 		}
 	}
 	
 	// Update pocket
 	existingPocket.Name = name
 	existingPocket.Description = strings.TrimSpace(description)
+	if strings.TrimSpace(rolloverPolicy) != "" {
+		rolloverPolicy, err = normalizeRolloverPolicy(rolloverPolicy)
+		if err != nil {
+			return nil, err
+		}
+		existingPocket.RolloverPolicy = rolloverPolicy
+	}
 	
-	if err := uc.pocketRepo.Update(existingPocket); err != nil {
-		return nil, err
+	if err := uc.pocketRepo.Update(ctx, existingPocket); err != nil {
+		return nil, saveConflict("pocket", err)
 	}
 	
 	return existingPocket, nil
 }
 
 // Delete deletes a pocket
-func (uc *PocketUseCase) Delete(id uint) error {
+// A non-zero version must match the stored one
+func (uc *PocketUseCase) Delete(ctx context.Context, id uint, version uint) error {
 	if id == 0 {
-		return errors.New("pocket ID is required")
+		return apperror.Invalid("id", "pocket ID is required")
 	}
 	
 	// Check if pocket exists
-	_, err := uc.pocketRepo.GetByID(id)
+	existingPocket, err := uc.pocketRepo.GetByID(id)
 	if err != nil {
 		return err
 	}
+	if err := checkVersion("pocket", version, existingPocket.Version); err != nil {
+		return err
+	}
 	
 	// Note: In a real implementation, we should check for associated expenses
 	// For now, we'll allow deletion and let the database constraints handle it
 	
-	return uc.pocketRepo.Delete(id)
+	if err := uc.pocketRepo.Delete(ctx, id, version); err != nil {
+		return saveConflict("pocket", err)
+	}
+	return nil
 }
 
 // GetByName retrieves a pocket by name
 func (uc *PocketUseCase) GetByName(name string) (*pocket.Pocket, error) {
 	name = strings.TrimSpace(name)
 	if name == "" {
-		return nil, errors.New("pocket name is required")
+		return nil, apperror.Invalid("name", "pocket name is required")
 	}
 	
 	return uc.pocketRepo.GetByName(name)
 }
+
+// normalizeRolloverPolicy validates a rollover policy, defaulting to rollover when empty
+func normalizeRolloverPolicy(policy string) (string, error) {
+	policy = strings.ToLower(strings.TrimSpace(policy))
+	if policy == "" {
+		return pocket.RolloverPolicyRollover, nil
+	}
+
+	if !pocket.IsValidRolloverPolicy(policy) {
+		return "", apperror.Invalid("rollover_policy", "rollover policy must be 'rollover' or 'sweep'")
+	}
+
+	return policy, nil
+}
@@ -0,0 +1,303 @@
+package usecase
+
+import (
+	"context"
+	"expenses-api/internal/application/port"
+	"expenses-api/internal/domain/apperror"
+	"expenses-api/internal/domain/civil"
+	"expenses-api/internal/domain/receivable"
+	"strings"
+)
+
+// ReceivableUseCase handles reimbursable expenses and the reimbursements received for them
+type ReceivableUseCase struct {
+	receivableRepo    port.ReceivableRepository
+	reimbursementRepo port.ReimbursementRepository
+	dailyExpenseRepo  port.DailyExpenseRepository
+	fixedExpenseRepo  port.FixedExpenseRepository
+	clock             port.Clock
+}
+
+// NewReceivableUseCase creates a new receivable use case instance
+func NewReceivableUseCase(
+	receivableRepo port.ReceivableRepository,
+	reimbursementRepo port.ReimbursementRepository,
+	dailyExpenseRepo port.DailyExpenseRepository,
+	fixedExpenseRepo port.FixedExpenseRepository,
+	clock port.Clock,
+) *ReceivableUseCase {
+	return &ReceivableUseCase{
+		receivableRepo:    receivableRepo,
+		reimbursementRepo: reimbursementRepo,
+		dailyExpenseRepo:  dailyExpenseRepo,
+		fixedExpenseRepo:  fixedExpenseRepo,
+		clock:             clock,
+	}
+}
+
+// GetAll retrieves the receivables, optionally filtered by status and counterparty
+// The counterparty filter is case-insensitive
+func (uc *ReceivableUseCase) GetAll(status, counterparty string) ([]receivable.Receivable, error) {
+	if status != "" &&
+		status != receivable.StatusOutstanding &&
+		status != receivable.StatusPartial &&
+		status != receivable.StatusSettled {
+		return nil, apperror.Invalid("status", "status must be outstanding, partial or settled")
+	}
+
+	receivables, err := uc.receivableRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	counterparty = strings.TrimSpace(counterparty)
+	filtered := make([]receivable.Receivable, 0, len(receivables))
+	for _, r := range receivables {
+		if status != "" && r.GetStatus() != status {
+			continue
+		}
+		if counterparty != "" && !strings.EqualFold(r.Counterparty, counterparty) {
+			continue
+		}
+		uc.fillExpense(&r)
+		filtered = append(filtered, r)
+	}
+
+	return filtered, nil
+}
+
+// GetOutstanding retrieves the receivables that are not fully paid back
+// together with the balance owed by each counterparty
+func (uc *ReceivableUseCase) GetOutstanding() ([]receivable.Receivable, []receivable.Balance, error) {
+	receivables, err := uc.receivableRepo.GetAll()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	outstanding := make([]receivable.Receivable, 0, len(receivables))
+	for _, r := range receivables {
+		if r.IsSettled() {
+			continue
+		}
+		uc.fillExpense(&r)
+		outstanding = append(outstanding, r)
+	}
+
+	return outstanding, receivable.GetBalances(outstanding), nil
+}
+
+// Create flags an expense as reimbursable by a counterparty
+// A nil amount expects the whole expense back; a partial amount cannot exceed it
+func (uc *ReceivableUseCase) Create(ctx context.Context, expenseType string, expenseID uint, counterparty string, amount *float64, note string) (*receivable.Receivable, error) {
+	if !receivable.IsValidExpenseType(expenseType) {
+		return nil, apperror.Invalid("expense_type", "expense type must be daily or fixed")
+	}
+
+	if expenseID == 0 {
+		return nil, apperror.Invalid("id", "expense ID is required")
+	}
+
+	expenseAmount, err := uc.expenseAmount(expenseType, expenseID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := uc.receivableRepo.GetByExpense(expenseType, expenseID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, apperror.Conflict("expense is already reimbursable")
+	}
+
+	rec := &receivable.Receivable{
+		ExpenseType:  expenseType,
+		ExpenseID:    expenseID,
+		Counterparty: counterparty,
+		Amount:       expenseAmount,
+		Note:         note,
+	}
+
+	if amount != nil {
+		rec.Amount = *amount
+	}
+
+	if err := receivable.ValidateAmount(rec.Amount, expenseAmount, 0); err != nil {
+		return nil, err
+	}
+
+	if err := uc.receivableRepo.Create(ctx, rec); err != nil {
+		return nil, err
+	}
+
+	return uc.GetByID(rec.ID)
+}
+
+// GetByID retrieves a receivable with its reimbursements and expense details
+func (uc *ReceivableUseCase) GetByID(id uint) (*receivable.Receivable, error) {
+	if id == 0 {
+		return nil, apperror.Invalid("id", "receivable ID is required")
+	}
+
+	rec, err := uc.receivableRepo.GetByID(id)
+	if err != nil {
+		return nil, apperror.NotFound("receivable not found")
+	}
+
+	uc.fillExpense(rec)
+	return rec, nil
+}
+
+// Update changes the counterparty, expected amount or note of a receivable
+// A nil amount keeps the current one; the amount cannot drop below what was already reimbursed
+func (uc *ReceivableUseCase) Update(ctx context.Context, id uint, counterparty string, amount *float64, note string) (*receivable.Receivable, error) {
+	rec, err := uc.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if amount != nil {
+		expenseAmount, err := uc.expenseAmount(rec.ExpenseType, rec.ExpenseID)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := receivable.ValidateAmount(*amount, expenseAmount, rec.GetReimbursedAmount()); err != nil {
+			return nil, err
+		}
+		rec.Amount = *amount
+	}
+
+	rec.Counterparty = counterparty
+	rec.Note = note
+
+	if err := uc.receivableRepo.Update(ctx, rec); err != nil {
+		return nil, err
+	}
+
+	return uc.GetByID(id)
+}
+
+// Delete removes the reimbursable flag of an expense together with its reimbursements
+func (uc *ReceivableUseCase) Delete(ctx context.Context, id uint) error {
+	if _, err := uc.GetByID(id); err != nil {
+		return err
+	}
+
+	return uc.receivableRepo.Delete(ctx, id)
+}
+
+// AddReimbursement records money received back for a receivable
+// An empty received date means today; the amount cannot exceed what is still outstanding
+func (uc *ReceivableUseCase) AddReimbursement(ctx context.Context, id uint, amount float64, receivedDate, note string) (*receivable.Receivable, error) {
+	rec, err := uc.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if amount <= 0 {
+		return nil, apperror.Invalid("amount", "reimbursement amount must be greater than 0")
+	}
+
+	if err := rec.CheckReimbursement(amount); err != nil {
+		return nil, err
+	}
+
+	date, err := uc.resolveReceivedDate(receivedDate)
+	if err != nil {
+		return nil, err
+	}
+
+	reimbursement := &receivable.Reimbursement{
+		ReceivableID: id,
+		Amount:       amount,
+		ReceivedDate: date,
+		Note:         note,
+	}
+
+	if err := uc.reimbursementRepo.Create(ctx, reimbursement); err != nil {
+		return nil, err
+	}
+
+	return uc.GetByID(id)
+}
+
+// DeleteReimbursement removes a reimbursement recorded by mistake
+func (uc *ReceivableUseCase) DeleteReimbursement(ctx context.Context, id uint, reimbursementID uint) (*receivable.Receivable, error) {
+	if reimbursementID == 0 {
+		return nil, apperror.Invalid("reimbursement_id", "reimbursement ID is required")
+	}
+
+	if _, err := uc.GetByID(id); err != nil {
+		return nil, err
+	}
+
+	reimbursement, err := uc.reimbursementRepo.GetByID(reimbursementID)
+	if err != nil || reimbursement.ReceivableID != id {
+		return nil, apperror.NotFound("reimbursement not found")
+	}
+
+	if err := uc.reimbursementRepo.Delete(ctx, reimbursementID); err != nil {
+		return nil, err
+	}
+
+	return uc.GetByID(id)
+}
+
+// expenseAmount returns the amount of the expense a receivable points to
+// Fixed expenses use the actual billed amount when it was recorded
+func (uc *ReceivableUseCase) expenseAmount(expenseType string, expenseID uint) (float64, error) {
+	if expenseType == receivable.ExpenseTypeDaily {
+		expense, err := uc.dailyExpenseRepo.GetByID(expenseID)
+		if err != nil {
+			return 0, apperror.NotFound("expense not found")
+		}
+		return expense.Amount, nil
+	}
+
+	expense, err := uc.fixedExpenseRepo.GetByID(expenseID)
+	if err != nil {
+		return 0, apperror.NotFound("expense not found")
+	}
+	return expense.GetDueAmount(), nil
+}
+
+// fillExpense copies the description, amount and month of the expense into the receivable
+// Missing expenses leave the fields empty
+func (uc *ReceivableUseCase) fillExpense(rec *receivable.Receivable) {
+	if rec.ExpenseType == receivable.ExpenseTypeDaily {
+		if expense, err := uc.dailyExpenseRepo.GetByID(rec.ExpenseID); err == nil {
+			rec.ExpenseDescription = expense.Description
+			rec.ExpenseAmount = expense.Amount
+			rec.ExpenseMonth = expense.GetMonth()
+		}
+		return
+	}
+
+	if expense, err := uc.fixedExpenseRepo.GetByID(rec.ExpenseID); err == nil {
+		rec.ExpenseDescription = expense.ConceptName
+		rec.ExpenseAmount = expense.GetDueAmount()
+		rec.ExpenseMonth = expense.Month
+	}
+}
+
+// resolveReceivedDate returns the received date of a reimbursement, defaulting to today
+func (uc *ReceivableUseCase) resolveReceivedDate(receivedDate string) (civil.Date, error) {
+	now := uc.clock.Now()
+	if receivedDate == "" {
+		return civil.DateOf(now), nil
+	}
+
+	// Validate date format
+	date, err := civil.ParseDate(receivedDate)
+	if err != nil {
+		return civil.Date{}, apperror.Invalid("received_date", "invalid received date format, must be YYYY-MM-DD")
+	}
+
+	// Don't allow future dates beyond today
+	if isFutureDate(date, now) {
+		return civil.Date{}, apperror.Invalid("received_date", "received date cannot be in the future")
+	}
+
+	return date, nil
+}
@@ -0,0 +1,162 @@
+package usecase
+
+import (
+	"errors"
+	"expenses-api/internal/application/port"
+	"expenses-api/internal/domain/civil"
+	"expenses-api/internal/domain/fixed_expense"
+	"expenses-api/internal/domain/reminder"
+	"fmt"
+	"log"
+	"math"
+	"time"
+)
+
+// ReminderUseCase emits due-date reminders and overdue notifications for fixed expenses
+type ReminderUseCase struct {
+	fixedExpenseRepo port.FixedExpenseRepository
+	reminderLogRepo  port.ReminderLogRepository
+	notifiers        []port.Notifier
+	daysBefore       int
+	overdueMonths    int
+}
+
+// NewReminderUseCase creates a new reminder use case instance
+// daysBefore is how many days before each payment day the reminder is sent and
+// overdueMonths how many months before the current one are checked for overdue expenses
+func NewReminderUseCase(
+	fixedExpenseRepo port.FixedExpenseRepository,
+	reminderLogRepo port.ReminderLogRepository,
+	notifiers []port.Notifier,
+	daysBefore int,
+	overdueMonths int,
+) *ReminderUseCase {
+	if daysBefore < 0 {
+		daysBefore = 0
+	}
+	if overdueMonths < 0 {
+		overdueMonths = 0
+	}
+
+	return &ReminderUseCase{
+		fixedExpenseRepo: fixedExpenseRepo,
+		reminderLogRepo:  reminderLogRepo,
+		notifiers:        notifiers,
+		daysBefore:       daysBefore,
+		overdueMonths:    overdueMonths,
+	}
+}
+
+// CheckDueDates notifies about unpaid fixed expenses that are due within the
+// configured window or already overdue. The window may reach into the next
+// month, and overdue expenses of up to overdueMonths earlier months are
+// notified too. Each expense is notified at most once per reminder kind.
+func (uc *ReminderUseCase) CheckDueDates(now time.Time) error {
+	if len(uc.notifiers) == 0 {
+		return nil
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	firstMonth := civil.MonthOf(today).AddMonths(-uc.overdueMonths)
+	lastMonth := civil.MonthOf(today.AddDate(0, 0, uc.daysBefore))
+
+	unpaid, err := uc.fixedExpenseRepo.GetUnpaidInMonthRange(firstMonth, lastMonth)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for i := range unpaid {
+		expense := &unpaid[i]
+
+		dueDate := paymentDate(expense, now.Location())
+		daysUntilDue := daysBetween(today, dueDate)
+
+		var notification reminder.Notification
+		switch {
+		case daysUntilDue < 0:
+			// Overdue: unpaid and past its payment day
+			notification = reminder.NewOverdueNotification(
+				expense.ID,
+				expense.ConceptName,
+				fixedExpensePocketName(expense),
+				expense.GetRemainingAmount(),
+				dueDate.Format("2006-01-02"),
+				daysUntilDue,
+			)
+		case daysUntilDue <= uc.daysBefore:
+			// Due within the reminder window
+			notification = reminder.NewDueSoonNotification(
+				expense.ID,
+				expense.ConceptName,
+				fixedExpensePocketName(expense),
+				expense.GetRemainingAmount(),
+				dueDate.Format("2006-01-02"),
+				daysUntilDue,
+			)
+		default:
+			continue
+		}
+
+		if err := uc.notifyOnce(expense.ID, notification); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// notifyOnce sends a notification through every notifier that has not delivered it yet.
+// Each delivery is recorded per notifier, so a failed channel is retried on the
+// next check without repeating the reminder on the channels that succeeded.
+func (uc *ReminderUseCase) notifyOnce(fixedExpenseID uint, notification reminder.Notification) error {
+	var errs []error
+	for _, notifier := range uc.notifiers {
+		sent, err := uc.reminderLogRepo.Exists(fixedExpenseID, notification.Kind, notifier.Name())
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if sent {
+			continue
+		}
+
+		if err := notifier.Notify(notification); err != nil {
+			errs = append(errs, fmt.Errorf("%s notifier: %w", notifier.Name(), err))
+			continue
+		}
+
+		log.Printf("Sent %s reminder for fixed expense %d (%s) via %s", notification.Kind, fixedExpenseID, notification.ConceptName, notifier.Name())
+		if err := uc.reminderLogRepo.Create(&reminder.ReminderLog{
+			FixedExpenseID: fixedExpenseID,
+			Kind:           notification.Kind,
+			Channel:        notifier.Name(),
+		}); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// paymentDate returns the due date of a fixed expense, clamping the payment
+// day to the last day of shorter months
+func paymentDate(expense *fixed_expense.FixedExpense, loc *time.Location) time.Time {
+	if expense.Month.IsZero() {
+		return time.Time{}
+	}
+	return expense.Month.Day(expense.PaymentDay).In(loc)
+}
+
+// daysBetween returns the number of calendar days from one date to another
+func daysBetween(from, to time.Time) int {
+	return int(math.Round(to.Sub(from).Hours() / 24))
+}
+
+// fixedExpensePocketName returns the preloaded pocket name of a fixed expense
+func fixedExpensePocketName(expense *fixed_expense.FixedExpense) string {
+	if expense.Pocket != nil {
+		return expense.Pocket.Name
+	}
+	return ""
+}
@@ -1,48 +1,52 @@
 package usecase
 
 import (
-	"errors"
+	"context"
 	"expenses-api/internal/application/port"
+	"expenses-api/internal/domain/apperror"
+	"expenses-api/internal/domain/civil"
 	"expenses-api/internal/domain/salary"
-	"time"
 )
 
 // SalaryUseCase handles salary-related business logic
 type SalaryUseCase struct {
 	salaryRepo port.SalaryRepository
+	clock      port.Clock
 }
 
 // NewSalaryUseCase creates a new salary use case instance
-func NewSalaryUseCase(salaryRepo port.SalaryRepository) *SalaryUseCase {
+func NewSalaryUseCase(salaryRepo port.SalaryRepository, clock port.Clock) *SalaryUseCase {
 	return &SalaryUseCase{
 		salaryRepo: salaryRepo,
+		clock:      clock,
 	}
 }
 
 // GetByMonth retrieves salary configuration for a specific month
-func (uc *SalaryUseCase) GetByMonth(month string) (*salary.Salary, error) {
-	if month == "" {
-		return nil, errors.New("month is required")
+func (uc *SalaryUseCase) GetByMonth(monthParam string) (*salary.Salary, error) {
+	if monthParam == "" {
+		return nil, apperror.Invalid("month", "month is required")
 	}
 
 	// Validate month format
-	if _, err := time.Parse("2006-01", month); err != nil {
-		return nil, errors.New("invalid month format, must be YYYY-MM")
+	month, err := civil.ParseMonth(monthParam)
+	if err != nil {
+		return nil, apperror.Invalid("month", "invalid month format, must be YYYY-MM")
 	}
 
 	return uc.salaryRepo.GetByMonth(month)
 }
 
 // GetByMonthWithInheritance obtiene el salario de un mes, heredando del anterior si no existe
-func (uc *SalaryUseCase) GetByMonthWithInheritance(month string) (*salary.Salary, error) {
-	if month == "" {
-		return nil, errors.New("month is required")
+func (uc *SalaryUseCase) GetByMonthWithInheritance(monthParam string) (*salary.Salary, error) {
+	if monthParam == "" {
+		return nil, apperror.Invalid("month", "month is required")
 	}
 
 	// Validate month format
-	date, err := time.Parse("2006-01", month)
+	month, err := civil.ParseMonth(monthParam)
 	if err != nil {
-		return nil, errors.New("invalid month format, must be YYYY-MM")
+		return nil, apperror.Invalid("month", "invalid month format, must be YYYY-MM")
 	}
 
 	// Intentar obtener configuración del mes actual
@@ -52,11 +56,11 @@ func (uc *SalaryUseCase) GetByMonthWithInheritance(month string) (*salary.Salary
 	}
 
 	// Si no existe, buscar mes anterior
-	previousMonth := date.AddDate(0, -1, 0).Format("2006-01")
+	previousMonth := month.AddMonths(-1)
 	previousSalary, err := uc.salaryRepo.GetByMonth(previousMonth)
 	if err != nil {
 		// No hay configuración anterior, retornar error para que handler use valores por defecto
-		return nil, errors.New("no configuration found")
+		return nil, apperror.NotFound("no configuration found")
 	}
 
 	// Heredar configuración adaptando el mes
@@ -70,29 +74,36 @@ func (uc *SalaryUseCase) GetByMonthWithInheritance(month string) (*salary.Salary
 
 // GetCurrentMonth retrieves salary for the current month
 func (uc *SalaryUseCase) GetCurrentMonth() (*salary.Salary, error) {
-	currentMonth := salary.GetCurrentMonth()
+	currentMonth := salary.GetCurrentMonth(uc.clock.Now())
 	return uc.salaryRepo.GetByMonth(currentMonth)
 }
 
 // UpdateSalary updates or creates salary configuration for a month
-func (uc *SalaryUseCase) UpdateSalary(monthlyAmount float64, month string) error {
-	if month == "" {
-		return errors.New("month is required")
+// A non-zero version must match the stored configuration of the month
+func (uc *SalaryUseCase) UpdateSalary(ctx context.Context, monthlyAmount float64, monthParam string, version uint) (*salary.Salary, error) {
+	if monthParam == "" {
+		return nil, apperror.Invalid("month", "month is required")
 	}
 
 	// Validate month format
-	if _, err := time.Parse("2006-01", month); err != nil {
-		return errors.New("invalid month format, must be YYYY-MM")
+	month, err := civil.ParseMonth(monthParam)
+	if err != nil {
+		return nil, apperror.Invalid("month", "invalid month format, must be YYYY-MM")
 	}
 
 	if monthlyAmount < 0 {
-		return errors.New("monthly amount cannot be negative")
+		return nil, apperror.Invalid("monthly_amount", "monthly amount cannot be negative")
 	}
 
 	salaryConfig := &salary.Salary{
 		MonthlyAmount: monthlyAmount,
 		Month:         month,
+		Version:       version,
+	}
+
+	if err := uc.salaryRepo.CreateOrUpdate(ctx, salaryConfig); err != nil {
+		return nil, saveConflict("income configuration", err)
 	}
 
-	return uc.salaryRepo.CreateOrUpdate(salaryConfig)
+	return salaryConfig, nil
 }
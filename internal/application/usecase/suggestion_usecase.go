@@ -0,0 +1,139 @@
+package usecase
+
+import (
+	"expenses-api/internal/application/port"
+	"expenses-api/internal/domain/apperror"
+	"expenses-api/internal/domain/civil"
+	"expenses-api/internal/domain/daily_expense"
+	"expenses-api/internal/domain/suggestion"
+	"strings"
+	"sync"
+)
+
+// SuggestionUseCase suggests a pocket for new daily expenses with a naive Bayes
+// model learned from past descriptions. Everything runs in-process
+type SuggestionUseCase struct {
+	dailyExpenseRepo port.DailyExpenseRepository
+	pocketRepo       port.PocketRepository
+	historyMonths    int
+	clock            port.Clock
+
+	mu    sync.RWMutex
+	model *suggestion.Model
+}
+
+// NewSuggestionUseCase creates a new suggestion use case instance
+// The model is trained on the last historyMonths months the first time it is needed
+func NewSuggestionUseCase(
+	dailyExpenseRepo port.DailyExpenseRepository,
+	pocketRepo port.PocketRepository,
+	historyMonths int,
+	clock port.Clock,
+) *SuggestionUseCase {
+	return &SuggestionUseCase{
+		dailyExpenseRepo: dailyExpenseRepo,
+		pocketRepo:       pocketRepo,
+		historyMonths:    historyMonths,
+		clock:            clock,
+	}
+}
+
+// SuggestPocket returns up to limit pockets for a description, most likely first
+// Pockets deleted since the last training are skipped
+func (uc *SuggestionUseCase) SuggestPocket(description string, limit int) ([]suggestion.Suggestion, *suggestion.Model, error) {
+	description = strings.TrimSpace(description)
+	if description == "" {
+		return nil, nil, apperror.Invalid("description", "description is required")
+	}
+
+	if limit <= 0 || limit > 10 {
+		return nil, nil, apperror.Invalid("limit", "limit must be between 1 and 10")
+	}
+
+	model, err := uc.currentModel()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Ask for every pocket so deleted ones can be dropped without losing results
+	candidates := model.Predict(description, model.Pockets())
+	suggestions := make([]suggestion.Suggestion, 0, limit)
+	for _, candidate := range candidates {
+		p, err := uc.pocketRepo.GetByID(candidate.PocketID)
+		if err != nil {
+			continue
+		}
+
+		candidate.PocketName = p.Name
+		suggestions = append(suggestions, candidate)
+		if len(suggestions) == limit {
+			break
+		}
+	}
+
+	return suggestions, model, nil
+}
+
+// Retrain rebuilds the model from the daily expenses of the history window
+// Single-pocket expenses count once; split expenses teach every pocket in
+// proportion to its share of the amount
+func (uc *SuggestionUseCase) Retrain() (*suggestion.Model, error) {
+	now := uc.clock.Now()
+	startDate := civil.DateOf(now.AddDate(0, -uc.historyMonths, 0))
+	endDate := civil.DateOf(now)
+
+	expenses, err := uc.dailyExpenseRepo.GetByDateRange(startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	examples := make([]suggestion.Example, 0, len(expenses))
+	for i := range expenses {
+		examples = append(examples, trainingExamples(&expenses[i])...)
+	}
+
+	model := suggestion.Train(examples, now)
+
+	uc.mu.Lock()
+	uc.model = model
+	uc.mu.Unlock()
+
+	return model, nil
+}
+
+// currentModel returns the trained model, training it on first use
+func (uc *SuggestionUseCase) currentModel() (*suggestion.Model, error) {
+	uc.mu.RLock()
+	model := uc.model
+	uc.mu.RUnlock()
+
+	if model != nil {
+		return model, nil
+	}
+	return uc.Retrain()
+}
+
+// trainingExamples turns a daily expense into labeled examples
+func trainingExamples(expense *daily_expense.DailyExpense) []suggestion.Example {
+	if expense.IsSplit() {
+		examples := make([]suggestion.Example, 0, len(expense.Splits))
+		for pocketID, amount := range expense.GetPocketAmounts() {
+			examples = append(examples, suggestion.Example{
+				Description: expense.Description,
+				PocketID:    pocketID,
+				Weight:      amount / expense.Amount,
+			})
+		}
+		return examples
+	}
+
+	if expense.PocketID == nil {
+		return nil
+	}
+
+	return []suggestion.Example{{
+		Description: expense.Description,
+		PocketID:    *expense.PocketID,
+		Weight:      1,
+	}}
+}
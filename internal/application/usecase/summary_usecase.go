@@ -1,95 +1,186 @@
 package usecase
 
 import (
-	"errors"
-	"expenses-api/internal/application/port"
 	"expenses-api/internal/api/dto"
-	"time"
+	"expenses-api/internal/application/port"
+	"expenses-api/internal/domain/apperror"
+	"expenses-api/internal/domain/civil"
+	"expenses-api/internal/domain/daily_expense"
+	"expenses-api/internal/domain/daily_expense_config"
+	"expenses-api/internal/domain/fixed_expense"
+	"expenses-api/internal/domain/receivable"
+	"expenses-api/internal/domain/salary"
+	"expenses-api/internal/domain/transfer"
 )
 
 // SummaryUseCase handles summary-related business logic
 type SummaryUseCase struct {
-	salaryRepo            port.SalaryRepository
-	fixedExpenseRepo      port.FixedExpenseRepository
-	dailyExpenseRepo      port.DailyExpenseRepository
+	salaryRepo             port.SalaryRepository
+	fixedExpenseRepo       port.FixedExpenseRepository
+	dailyExpenseRepo       port.DailyExpenseRepository
 	dailyExpenseConfigRepo port.DailyExpenseConfigRepository
+	transferRepo           port.TransferRepository
+	receivableRepo         port.ReceivableRepository
+	clock                  port.Clock
 }
 
 // NewSummaryUseCase creates a new summary use case instance
+// The receivable repository is optional; when nil reimbursements are not netted out
 func NewSummaryUseCase(
 	salaryRepo port.SalaryRepository,
 	fixedExpenseRepo port.FixedExpenseRepository,
 	dailyExpenseRepo port.DailyExpenseRepository,
 	dailyExpenseConfigRepo port.DailyExpenseConfigRepository,
+	transferRepo port.TransferRepository,
+	receivableRepo port.ReceivableRepository,
+	clock port.Clock,
 ) *SummaryUseCase {
 	return &SummaryUseCase{
-		salaryRepo:            salaryRepo,
-		fixedExpenseRepo:      fixedExpenseRepo,
-		dailyExpenseRepo:      dailyExpenseRepo,
+		salaryRepo:             salaryRepo,
+		fixedExpenseRepo:       fixedExpenseRepo,
+		dailyExpenseRepo:       dailyExpenseRepo,
 		dailyExpenseConfigRepo: dailyExpenseConfigRepo,
+		transferRepo:           transferRepo,
+		receivableRepo:         receivableRepo,
+		clock:                  clock,
 	}
 }
 
 // GetMonthlySummary calculates and returns the monthly financial summary
-func (uc *SummaryUseCase) GetMonthlySummary(month string) (*dto.MonthlySummaryDTO, error) {
-	if month == "" {
-		return nil, errors.New("month is required")
+func (uc *SummaryUseCase) GetMonthlySummary(monthParam string) (*dto.MonthlySummaryDTO, error) {
+	if monthParam == "" {
+		return nil, apperror.Invalid("month", "month is required")
 	}
-	
+
 	// Validate month format
-	if _, err := time.Parse("2006-01", month); err != nil {
-		return nil, errors.New("invalid month format, must be YYYY-MM")
+	month, err := civil.ParseMonth(monthParam)
+	if err != nil {
+		return nil, apperror.Invalid("month", "invalid month format, must be YYYY-MM")
 	}
-	
+
+	entries, err := uc.monthEntries(month)
+	if err != nil {
+		return nil, err
+	}
+
+	return entries.summary(month), nil
+}
+
+// monthEntries loads the ledger entries of a month
+func (uc *SummaryUseCase) monthEntries(month civil.Month) (monthEntries, error) {
+	var entries monthEntries
+
 	// Get salary for the month
 	salary, err := uc.salaryRepo.GetByMonth(month)
-	var totalIncome float64 = 0
 	if err == nil && salary != nil {
-		totalIncome = salary.MonthlyAmount
+		entries.salary = salary
 	}
-	
+
 	// Get fixed expenses for the month
-	fixedExpenses, err := uc.fixedExpenseRepo.GetByMonth(month)
+	entries.fixedExpenses, err = uc.fixedExpenseRepo.GetByMonth(month)
 	if err != nil {
-		return nil, err
+		return entries, err
 	}
-	
+
+	// Get daily expenses for the month
+	entries.dailyExpenses, err = uc.dailyExpenseRepo.GetByMonth(month)
+	if err != nil {
+		return entries, err
+	}
+
+	// Get daily expense config for the month
+	dailyConfig, err := uc.dailyExpenseConfigRepo.GetByMonth(month)
+	if err == nil && dailyConfig != nil {
+		entries.dailyConfig = dailyConfig
+	}
+
+	// Get transfers for the month
+	entries.transfers, err = uc.transferRepo.GetByMonth(month)
+	if err != nil {
+		return entries, err
+	}
+
+	// Get the receivables of the month's expenses
+	entries.receivables, err = uc.receivables(entries.fixedExpenses, entries.dailyExpenses)
+	if err != nil {
+		return entries, err
+	}
+
+	return entries, nil
+}
+
+// monthEntries are the ledger entries a monthly summary is computed from,
+// whether loaded from the repositories or rebuilt from the event log
+type monthEntries struct {
+	salary        *salary.Salary                           // Nil when the month has no income configured
+	dailyConfig   *daily_expense_config.DailyExpenseConfig // Nil when the month has no daily budget
+	fixedExpenses []fixed_expense.FixedExpense             // With their pockets and payments
+	dailyExpenses []daily_expense.DailyExpense
+	transfers     []transfer.Transfer
+	receivables   []receivable.Receivable // Of the month's expenses, with their reimbursements
+}
+
+// summary calculates the monthly financial summary of the entries
+func (e monthEntries) summary(month civil.Month) *dto.MonthlySummaryDTO {
+	var totalIncome float64 = 0
+	if e.salary != nil {
+		totalIncome = e.salary.MonthlyAmount
+	}
+
 	// Calculate fixed expenses totals
 	var totalFixedExpenses float64 = 0
 	var fixedExpensesPaid int = 0
-	var fixedExpensesTotal int = len(fixedExpenses)
-	
-	for _, expense := range fixedExpenses {
+	var fixedExpensesTotal int = len(e.fixedExpenses)
+
+	// Planned vs. actual fixed spending per concept
+	var totalFixedActual float64 = 0
+	var fixedVariance float64 = 0
+	fixedExpenseVariances := make([]dto.FixedExpenseVarianceDTO, 0, len(e.fixedExpenses))
+
+	for i := range e.fixedExpenses {
+		expense := &e.fixedExpenses[i]
 		totalFixedExpenses += expense.Amount
 		if expense.IsPaid {
 			fixedExpensesPaid++
 		}
+
+		variance := fixedExpenseVariance(expense)
+		totalFixedActual += variance.Actual
+		fixedVariance += variance.Variance
+		fixedExpenseVariances = append(fixedExpenseVariances, variance)
 	}
-	
-	// Get daily expenses for the month
-	dailyExpenses, err := uc.dailyExpenseRepo.GetByMonth(month)
-	if err != nil {
-		return nil, err
-	}
-	
+
 	// Calculate daily expenses total
 	var totalDailyExpenses float64 = 0
-	for _, expense := range dailyExpenses {
+	for _, expense := range e.dailyExpenses {
 		totalDailyExpenses += expense.Amount
 	}
-	
-	// Get daily expense config for the month
-	dailyConfig, err := uc.dailyExpenseConfigRepo.GetByMonth(month)
+
 	var dailyBudgetTotal float64 = 0
-	if err == nil && dailyConfig != nil {
-		dailyBudgetTotal = dailyConfig.MonthlyBudget
+	if e.dailyConfig != nil {
+		dailyBudgetTotal = e.dailyConfig.MonthlyBudget
+	}
+
+	// Transfers only move money between accounts and pockets, so they are
+	// reported separately and never counted as spending
+	var totalTransfers float64 = 0
+	for _, t := range e.transfers {
+		totalTransfers += t.Amount
 	}
-	
+
+	// Reimbursements received for the month's expenses are netted out of spending
+	var totalReimbursed, pendingReimbursements float64
+	for i := range e.receivables {
+		totalReimbursed += e.receivables[i].GetReimbursedAmount()
+		pendingReimbursements += e.receivables[i].GetOutstandingAmount()
+	}
+	netExpenses := totalFixedExpenses + totalDailyExpenses - totalReimbursed
+
 	// Calculate remaining budget
-	remainingBudget := totalIncome - totalFixedExpenses - totalDailyExpenses
-	
-	summary := &dto.MonthlySummaryDTO{
-		Month:              month,
+	remainingBudget := totalIncome - netExpenses
+
+	return &dto.MonthlySummaryDTO{
+		Month:              month.String(),
 		TotalIncome:        totalIncome,
 		TotalFixedExpenses: totalFixedExpenses,
 		TotalDailyExpenses: totalDailyExpenses,
@@ -98,13 +189,76 @@ func (uc *SummaryUseCase) GetMonthlySummary(month string) (*dto.MonthlySummaryDT
 		FixedExpensesTotal: fixedExpensesTotal,
 		DailyBudgetUsed:    totalDailyExpenses,
 		DailyBudgetTotal:   dailyBudgetTotal,
+		TotalTransfers:     totalTransfers,
+
+		TotalFixedActual:      totalFixedActual,
+		FixedVariance:         fixedVariance,
+		FixedExpenseVariances: fixedExpenseVariances,
+
+		TotalReimbursed:       totalReimbursed,
+		PendingReimbursements: pendingReimbursements,
+		NetExpenses:           netExpenses,
 	}
-	
-	return summary, nil
 }
 
 // GetCurrentMonthlySummary returns summary for the current month
 func (uc *SummaryUseCase) GetCurrentMonthlySummary() (*dto.MonthlySummaryDTO, error) {
-	currentMonth := time.Now().Format("2006-01")
-	return uc.GetMonthlySummary(currentMonth)
+	currentMonth := civil.MonthOf(uc.clock.Now())
+	return uc.GetMonthlySummary(currentMonth.String())
+}
+
+// receivables retrieves the receivables of the given expenses with their reimbursements
+func (uc *SummaryUseCase) receivables(fixedExpenses []fixed_expense.FixedExpense, dailyExpenses []daily_expense.DailyExpense) ([]receivable.Receivable, error) {
+	if uc.receivableRepo == nil {
+		return nil, nil
+	}
+
+	fixedIDs := make([]uint, 0, len(fixedExpenses))
+	for _, expense := range fixedExpenses {
+		fixedIDs = append(fixedIDs, expense.ID)
+	}
+
+	dailyIDs := make([]uint, 0, len(dailyExpenses))
+	for _, expense := range dailyExpenses {
+		dailyIDs = append(dailyIDs, expense.ID)
+	}
+
+	fixedReceivables, err := uc.receivableRepo.GetByExpenses(receivable.ExpenseTypeFixed, fixedIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	dailyReceivables, err := uc.receivableRepo.GetByExpenses(receivable.ExpenseTypeDaily, dailyIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(fixedReceivables, dailyReceivables...), nil
+}
+
+// fixedExpenseVariance compares the planned amount of a fixed expense with what was actually paid
+// The variance is only reported once the expense is paid, so pending bills don't look like savings
+func fixedExpenseVariance(expense *fixed_expense.FixedExpense) dto.FixedExpenseVarianceDTO {
+	pocketName := ""
+	if expense.Pocket != nil {
+		pocketName = expense.Pocket.Name
+	}
+
+	status := expense.GetPaymentStatus()
+	actual := expense.GetActualSpent()
+
+	variance := 0.0
+	if status == fixed_expense.PaymentStatusPaid || status == fixed_expense.PaymentStatusOverpaid {
+		variance = actual - expense.Amount
+	}
+
+	return dto.FixedExpenseVarianceDTO{
+		FixedExpenseID: int(expense.ID),
+		ConceptName:    expense.ConceptName,
+		PocketName:     pocketName,
+		Planned:        expense.Amount,
+		Actual:         actual,
+		Variance:       variance,
+		PaymentStatus:  status,
+	}
 }
@@ -0,0 +1,181 @@
+package usecase
+
+import (
+	"context"
+	"expenses-api/internal/application/port"
+	"expenses-api/internal/domain/apperror"
+	"expenses-api/internal/domain/civil"
+	"expenses-api/internal/domain/tag"
+)
+
+// TagUseCase handles expense tag-related business logic
+type TagUseCase struct {
+	tagRepo port.TagRepository
+}
+
+// NewTagUseCase creates a new tag use case instance
+func NewTagUseCase(tagRepo port.TagRepository) *TagUseCase {
+	return &TagUseCase{
+		tagRepo: tagRepo,
+	}
+}
+
+// GetAll retrieves all tags
+func (uc *TagUseCase) GetAll() ([]tag.Tag, error) {
+	return uc.tagRepo.GetAll()
+}
+
+// Create creates a new tag
+func (uc *TagUseCase) Create(ctx context.Context, name, color string) (*tag.Tag, error) {
+	name = tag.NormalizeName(name)
+	if name == "" {
+		return nil, apperror.Invalid("name", "tag name is required")
+	}
+
+	if err := uc.ensureNameAvailable(name, 0); err != nil {
+		return nil, err
+	}
+
+	t := &tag.Tag{
+		Name:  name,
+		Color: color,
+	}
+
+	if err := uc.tagRepo.Create(ctx, t); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// Update renames or recolors an existing tag
+func (uc *TagUseCase) Update(ctx context.Context, id uint, name, color string) (*tag.Tag, error) {
+	if id == 0 {
+		return nil, apperror.Invalid("id", "tag ID is required")
+	}
+
+	t, err := uc.tagRepo.GetByID(id)
+	if err != nil {
+		return nil, apperror.NotFound("tag not found")
+	}
+
+	name = tag.NormalizeName(name)
+	if name == "" {
+		return nil, apperror.Invalid("name", "tag name is required")
+	}
+
+	if err := uc.ensureNameAvailable(name, id); err != nil {
+		return nil, err
+	}
+
+	t.Name = name
+	t.Color = color
+
+	if err := uc.tagRepo.Update(ctx, t); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// Delete deletes a tag, removing it from every expense
+func (uc *TagUseCase) Delete(ctx context.Context, id uint) error {
+	if id == 0 {
+		return apperror.Invalid("id", "tag ID is required")
+	}
+
+	if _, err := uc.tagRepo.GetByID(id); err != nil {
+		return apperror.NotFound("tag not found")
+	}
+
+	return uc.tagRepo.Delete(ctx, id)
+}
+
+// ResolveIDs returns the IDs of the named tags, creating the ones that do not exist yet
+func (uc *TagUseCase) ResolveIDs(ctx context.Context, names []string) ([]uint, error) {
+	names = tag.NormalizeNames(names)
+	if len(names) == 0 {
+		return []uint{}, nil
+	}
+
+	// Validate every name before creating any tag
+	for _, name := range names {
+		if err := (&tag.Tag{Name: name}).Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	existing, err := uc.tagRepo.GetByNames(names)
+	if err != nil {
+		return nil, err
+	}
+
+	idsByName := make(map[string]uint, len(existing))
+	for _, t := range existing {
+		idsByName[t.Name] = t.ID
+	}
+
+	ids := make([]uint, 0, len(names))
+	for _, name := range names {
+		id, ok := idsByName[name]
+		if !ok {
+			created := &tag.Tag{Name: name}
+			if err := uc.tagRepo.Create(ctx, created); err != nil {
+				return nil, err
+			}
+			id = created.ID
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// SetFixedExpenseTags replaces the tags of a fixed expense
+func (uc *TagUseCase) SetFixedExpenseTags(ctx context.Context, fixedExpenseID uint, tagIDs []uint) error {
+	return uc.tagRepo.SetFixedExpenseTags(ctx, fixedExpenseID, tagIDs)
+}
+
+// SetCategorizationRuleTags replaces the tags a categorization rule assigns
+func (uc *TagUseCase) SetCategorizationRuleTags(ctx context.Context, ruleID uint, tagIDs []uint) error {
+	return uc.tagRepo.SetCategorizationRuleTags(ctx, ruleID, tagIDs)
+}
+
+// GetTotals retrieves the spending per tag between two dates (inclusive)
+func (uc *TagUseCase) GetTotals(startDate, endDate string) ([]tag.Total, error) {
+	if startDate == "" || endDate == "" {
+		return nil, apperror.Validation("start date and end date are required")
+	}
+
+	start, err := civil.ParseDate(startDate)
+	if err != nil {
+		return nil, apperror.Invalid("start_date", "invalid start date format, must be YYYY-MM-DD")
+	}
+
+	end, err := civil.ParseDate(endDate)
+	if err != nil {
+		return nil, apperror.Invalid("end_date", "invalid end date format, must be YYYY-MM-DD")
+	}
+
+	if end.Before(start) {
+		return nil, apperror.Invalid("end_date", "end date cannot be before start date")
+	}
+
+	return uc.tagRepo.GetTotals(start, end)
+}
+
+// ensureNameAvailable checks that no other tag uses the name
+func (uc *TagUseCase) ensureNameAvailable(name string, id uint) error {
+	existing, err := uc.tagRepo.GetByNames([]string{name})
+	if err != nil {
+		return err
+	}
+
+	for _, t := range existing {
+		if t.ID != id {
+			return apperror.Conflict("a tag with this name already exists")
+		}
+	}
+
+	return nil
+}
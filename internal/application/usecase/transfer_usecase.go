@@ -0,0 +1,238 @@
+package usecase
+
+import (
+	"context"
+	"expenses-api/internal/api/dto"
+	"expenses-api/internal/application/port"
+	"expenses-api/internal/domain/apperror"
+	"expenses-api/internal/domain/civil"
+	"expenses-api/internal/domain/transfer"
+	"sort"
+	"strings"
+)
+
+// TransferUseCase handles transfer-related business logic
+type TransferUseCase struct {
+	transferRepo port.TransferRepository
+	pocketRepo   port.PocketRepository
+	clock        port.Clock
+}
+
+// NewTransferUseCase creates a new transfer use case instance
+func NewTransferUseCase(transferRepo port.TransferRepository, pocketRepo port.PocketRepository, clock port.Clock) *TransferUseCase {
+	return &TransferUseCase{
+		transferRepo: transferRepo,
+		pocketRepo:   pocketRepo,
+		clock:        clock,
+	}
+}
+
+// GetByMonth retrieves all transfers for a specific month
+func (uc *TransferUseCase) GetByMonth(monthParam string) ([]transfer.Transfer, error) {
+	if monthParam == "" {
+		return nil, apperror.Invalid("month", "month is required")
+	}
+
+	// Validate month format
+	month, err := civil.ParseMonth(monthParam)
+	if err != nil {
+		return nil, apperror.Invalid("month", "invalid month format, must be YYYY-MM")
+	}
+
+	return uc.transferRepo.GetByMonth(month)
+}
+
+// GetByID retrieves a transfer by ID
+func (uc *TransferUseCase) GetByID(id uint) (*transfer.Transfer, error) {
+	if id == 0 {
+		return nil, apperror.Invalid("id", "transfer ID is required")
+	}
+
+	return uc.transferRepo.GetByID(id)
+}
+
+// Create records a new transfer between accounts and pockets
+func (uc *TransferUseCase) Create(ctx context.Context, t *transfer.Transfer) (*transfer.Transfer, error) {
+	if t == nil {
+		return nil, apperror.Validation("transfer is required")
+	}
+
+	if err := uc.validate(t); err != nil {
+		return nil, err
+	}
+
+	if err := uc.transferRepo.Create(ctx, t); err != nil {
+		return nil, err
+	}
+
+	// Reload to include pocket names
+	return uc.transferRepo.GetByID(t.ID)
+}
+
+// Update updates an existing transfer
+// A non-zero updated.Version must match the stored one
+func (uc *TransferUseCase) Update(ctx context.Context, id uint, updated *transfer.Transfer) (*transfer.Transfer, error) {
+	if id == 0 {
+		return nil, apperror.Invalid("id", "transfer ID is required")
+	}
+	if updated == nil {
+		return nil, apperror.Validation("transfer data is required")
+	}
+
+	// Get existing transfer
+	existing, err := uc.transferRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkVersion("transfer", updated.Version, existing.Version); err != nil {
+		return nil, err
+	}
+
+	// If date is empty, keep the original date
+	if updated.Date.IsZero() {
+		updated.Date = existing.Date
+	}
+
+	if err := uc.validate(updated); err != nil {
+		return nil, err
+	}
+
+	// Update fields
+	existing.SourceType = updated.SourceType
+	existing.SourcePocketID = updated.SourcePocketID
+	existing.SourceAccount = updated.SourceAccount
+	existing.DestinationType = updated.DestinationType
+	existing.DestinationPocketID = updated.DestinationPocketID
+	existing.DestinationAccount = updated.DestinationAccount
+	existing.Amount = updated.Amount
+	existing.Date = updated.Date
+	existing.Description = updated.Description
+
+	if err := uc.transferRepo.Update(ctx, existing); err != nil {
+		return nil, saveConflict("transfer", err)
+	}
+
+	return uc.transferRepo.GetByID(id)
+}
+
+// Delete deletes a transfer
+// A non-zero version must match the stored one
+func (uc *TransferUseCase) Delete(ctx context.Context, id uint, version uint) error {
+	if id == 0 {
+		return apperror.Invalid("id", "transfer ID is required")
+	}
+
+	// Verify transfer exists
+	existing, err := uc.transferRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if err := checkVersion("transfer", version, existing.Version); err != nil {
+		return err
+	}
+
+	if err := uc.transferRepo.Delete(ctx, id, version); err != nil {
+		return saveConflict("transfer", err)
+	}
+	return nil
+}
+
+// GetBalances calculates the net balance moved into every account and pocket by transfers
+func (uc *TransferUseCase) GetBalances() ([]dto.TransferBalanceDTO, error) {
+	transfers, err := uc.transferRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	balances := make(map[string]*dto.TransferBalanceDTO)
+	apply := func(endpointType string, pocketID *uint, account, name string, amount float64) {
+		key := transfer.EndpointKey(endpointType, pocketID, account)
+		balance, ok := balances[key]
+		if !ok {
+			balance = &dto.TransferBalanceDTO{
+				Type: endpointType,
+				Name: name,
+			}
+			if pocketID != nil {
+				balance.PocketID = int(*pocketID)
+			}
+			balances[key] = balance
+		}
+		balance.Balance += amount
+	}
+
+	for i := range transfers {
+		t := &transfers[i]
+		apply(t.SourceType, t.SourcePocketID, t.SourceAccount, t.SourceName(), -t.Amount)
+		apply(t.DestinationType, t.DestinationPocketID, t.DestinationAccount, t.DestinationName(), t.Amount)
+	}
+
+	result := make([]dto.TransferBalanceDTO, 0, len(balances))
+	for _, balance := range balances {
+		result = append(result, *balance)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Type != result[j].Type {
+			return result[i].Type < result[j].Type
+		}
+		return result[i].Name < result[j].Name
+	})
+
+	return result, nil
+}
+
+// validate checks transfer input and verifies referenced pockets exist
+func (uc *TransferUseCase) validate(t *transfer.Transfer) error {
+	t.SourceAccount = strings.TrimSpace(t.SourceAccount)
+	t.DestinationAccount = strings.TrimSpace(t.DestinationAccount)
+	t.Description = strings.TrimSpace(t.Description)
+
+	if t.Amount <= 0 {
+		return apperror.Invalid("amount", "amount must be greater than zero")
+	}
+
+	if t.Date.IsZero() {
+		return apperror.Invalid("date", "date is required")
+	}
+
+	// Don't allow future dates beyond today
+	if isFutureDate(t.Date, uc.clock.Now()) {
+		return apperror.Invalid("date", "transfer date cannot be in the future")
+	}
+
+	if err := uc.validateEndpoint("source", t.SourceType, &t.SourcePocketID, &t.SourceAccount); err != nil {
+		return err
+	}
+	if err := uc.validateEndpoint("destination", t.DestinationType, &t.DestinationPocketID, &t.DestinationAccount); err != nil {
+		return err
+	}
+
+	if t.SourceKey() == t.DestinationKey() {
+		return apperror.Validation("source and destination must be different")
+	}
+
+	return nil
+}
+
+// validateEndpoint checks one side of a transfer, clearing the field that does not apply
+func (uc *TransferUseCase) validateEndpoint(side, endpointType string, pocketID **uint, account *string) error {
+	switch endpointType {
+	case transfer.EndpointPocket:
+		if *pocketID == nil || **pocketID == 0 {
+			return apperror.Invalid(side+"_pocket_id", side+" pocket ID is required")
+		}
+		if _, err := uc.pocketRepo.GetByID(**pocketID); err != nil {
+			return apperror.Invalid(side+"_pocket_id", side+" pocket not found")
+		}
+		*account = ""
+	case transfer.EndpointAccount:
+		if *account == "" {
+			return apperror.Invalid(side+"_account", side+" account is required")
+		}
+		*pocketID = nil
+	default:
+		return apperror.Invalid(side+"_type", side+" type must be 'account' or 'pocket'")
+	}
+	return nil
+}
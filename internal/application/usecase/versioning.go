@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	"errors"
+	"expenses-api/internal/application/port"
+	"expenses-api/internal/domain/apperror"
+)
+
+// checkVersion rejects a change made against a stale version of an entity
+// A zero expected version means the caller did not ask for the check
+func checkVersion(entity string, expected, current uint) error {
+	if expected != 0 && expected != current {
+		return versionConflict(entity)
+	}
+	return nil
+}
+
+// saveConflict reports a repository version conflict as a typed conflict
+func saveConflict(entity string, err error) error {
+	if errors.Is(err, port.ErrVersionConflict) {
+		return versionConflict(entity)
+	}
+	return err
+}
+
+// versionConflict reports an entity that someone else changed since the caller read it
+func versionConflict(entity string) error {
+	return apperror.Conflict(entity + " was modified by someone else, reload it and try again").WithCause(port.ErrVersionConflict)
+}
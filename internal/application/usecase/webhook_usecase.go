@@ -0,0 +1,135 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"expenses-api/internal/application/port"
+	"expenses-api/internal/domain/apperror"
+	"expenses-api/internal/domain/webhook"
+	"strings"
+)
+
+// defaultDeliveryLimit is the number of delivery attempts returned when no limit is given
+const defaultDeliveryLimit = 50
+
+// WebhookUseCase handles webhook subscription-related business logic
+type WebhookUseCase struct {
+	subscriptionRepo port.WebhookSubscriptionRepository
+	deliveryRepo     port.WebhookDeliveryRepository
+}
+
+// NewWebhookUseCase creates a new webhook use case instance
+func NewWebhookUseCase(
+	subscriptionRepo port.WebhookSubscriptionRepository,
+	deliveryRepo port.WebhookDeliveryRepository,
+) *WebhookUseCase {
+	return &WebhookUseCase{
+		subscriptionRepo: subscriptionRepo,
+		deliveryRepo:     deliveryRepo,
+	}
+}
+
+// GetAll retrieves all webhook subscriptions
+func (uc *WebhookUseCase) GetAll() ([]webhook.Subscription, error) {
+	return uc.subscriptionRepo.GetAll()
+}
+
+// GetByID retrieves a webhook subscription by ID
+func (uc *WebhookUseCase) GetByID(id uint) (*webhook.Subscription, error) {
+	if id == 0 {
+		return nil, apperror.Invalid("id", "subscription ID is required")
+	}
+
+	return uc.subscriptionRepo.GetByID(id)
+}
+
+// Create registers a new webhook subscription
+// A random secret is generated when none is given; the caller must show it once to the user
+func (uc *WebhookUseCase) Create(ctx context.Context, url string, events []string, description string, secret string) (*webhook.Subscription, error) {
+	secret = strings.TrimSpace(secret)
+	if secret == "" {
+		generated, err := generateSecret()
+		if err != nil {
+			return nil, err
+		}
+		secret = generated
+	}
+
+	subscription := &webhook.Subscription{
+		URL:         url,
+		Secret:      secret,
+		Events:      strings.Join(events, ","),
+		Active:      true,
+		Description: description,
+	}
+
+	if err := uc.subscriptionRepo.Create(ctx, subscription); err != nil {
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+// Update updates an existing webhook subscription, keeping its secret
+func (uc *WebhookUseCase) Update(ctx context.Context, id uint, url string, events []string, active bool, description string) (*webhook.Subscription, error) {
+	if id == 0 {
+		return nil, apperror.Invalid("id", "subscription ID is required")
+	}
+
+	existing, err := uc.subscriptionRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	existing.URL = url
+	existing.Events = strings.Join(events, ",")
+	existing.Active = active
+	existing.Description = description
+
+	if err := uc.subscriptionRepo.Update(ctx, existing); err != nil {
+		return nil, err
+	}
+
+	return existing, nil
+}
+
+// Delete deletes a webhook subscription and its delivery log
+func (uc *WebhookUseCase) Delete(ctx context.Context, id uint) error {
+	if id == 0 {
+		return apperror.Invalid("id", "subscription ID is required")
+	}
+
+	// Verify subscription exists
+	if _, err := uc.subscriptionRepo.GetByID(id); err != nil {
+		return err
+	}
+
+	return uc.subscriptionRepo.Delete(ctx, id)
+}
+
+// GetDeliveries retrieves the most recent delivery attempts of a subscription
+func (uc *WebhookUseCase) GetDeliveries(id uint, limit int) ([]webhook.Delivery, error) {
+	if id == 0 {
+		return nil, apperror.Invalid("id", "subscription ID is required")
+	}
+
+	if _, err := uc.subscriptionRepo.GetByID(id); err != nil {
+		return nil, apperror.NotFound("subscription not found").WithCause(err)
+	}
+
+	if limit <= 0 || limit > 500 {
+		limit = defaultDeliveryLimit
+	}
+
+	return uc.deliveryRepo.GetBySubscription(id, limit)
+}
+
+// generateSecret creates a random 256-bit hexadecimal signing secret
+func generateSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,157 @@
+package alert
+
+import (
+	"expenses-api/internal/domain/apperror"
+	"expenses-api/internal/domain/civil"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Rule kinds
+const (
+	KindDailyBudget = "daily_budget" // Total daily spending vs. the monthly daily budget
+	KindPocket      = "pocket"       // Pocket spending vs. its monthly allocation
+)
+
+// Pocket represents the pocket relationship (to avoid circular imports)
+type Pocket struct {
+	ID   uint   `gorm:"primaryKey" json:"id"`
+	Name string `gorm:"size:255;not null" json:"name"`
+}
+
+// TableName specifies the table name for GORM
+func (Pocket) TableName() string {
+	return "pockets"
+}
+
+// Rule represents a configurable budget threshold alert rule
+// Maps to frontend interface: AlertRule { id?, kind, pocket_id?, threshold_percent, active }
+type Rule struct {
+	ID               uint      `gorm:"primaryKey" json:"id"`
+	Kind             string    `gorm:"size:20;not null;index" json:"kind"`
+	PocketID         *uint     `gorm:"index" json:"pocket_id"` // Only for pocket rules
+	ThresholdPercent float64   `gorm:"type:decimal(6,2);not null" json:"threshold_percent"`
+	Active           bool      `gorm:"default:true" json:"active"`
+	CreatedAt        time.Time `gorm:"autoCreateTime" json:"created_at"`
+
+	// Relationship
+	Pocket *Pocket `gorm:"foreignKey:PocketID" json:"pocket,omitempty"`
+}
+
+// TableName specifies the table name for GORM
+func (Rule) TableName() string {
+	return "alert_rules"
+}
+
+// BeforeCreate hook to validate data before creation
+func (r *Rule) BeforeCreate(tx *gorm.DB) error {
+	return r.validate()
+}
+
+// BeforeUpdate hook to validate data before update
+func (r *Rule) BeforeUpdate(tx *gorm.DB) error {
+	return r.validate()
+}
+
+// validate performs validation
+func (r *Rule) validate() error {
+	switch r.Kind {
+	case KindDailyBudget:
+		r.PocketID = nil
+	case KindPocket:
+		if r.PocketID == nil || *r.PocketID == 0 {
+			return apperror.Invalid("pocket_id", "pocket rules require a pocket")
+		}
+	default:
+		return apperror.Invalid("kind", "kind must be daily_budget or pocket")
+	}
+
+	if r.ThresholdPercent <= 0 || r.ThresholdPercent > 1000 {
+		return apperror.Invalid("threshold_percent", "threshold percent must be between 0 and 1000")
+	}
+
+	return nil
+}
+
+// GetPocketName returns the pocket name of pocket rules
+func (r *Rule) GetPocketName() string {
+	if r.Pocket != nil {
+		return r.Pocket.Name
+	}
+	return ""
+}
+
+// IsReached checks if the spent amount reaches the rule threshold for the given budget
+// Rules never fire against a missing or zero budget
+func (r *Rule) IsReached(spent, budget float64) bool {
+	if budget <= 0 {
+		return false
+	}
+	return spent*100 >= budget*r.ThresholdPercent
+}
+
+// Alert represents a fired alert rule for a month
+// Maps to frontend interface: Alert { id, rule_id, month, kind, pocket_id?, threshold_percent, budget, spent, message, acknowledged, acknowledged_at? }
+type Alert struct {
+	ID               uint        `gorm:"primaryKey" json:"id"`
+	RuleID           uint        `gorm:"not null;uniqueIndex:idx_alert_rule_month" json:"rule_id"`
+	Month            civil.Month `gorm:"not null;uniqueIndex:idx_alert_rule_month;index" json:"month"` // Format: "2024-01"
+	Kind             string      `gorm:"size:20;not null" json:"kind"`
+	PocketID         *uint       `json:"pocket_id"`
+	ThresholdPercent float64     `gorm:"type:decimal(6,2);not null" json:"threshold_percent"`
+	Budget           float64     `gorm:"type:decimal(15,2);not null" json:"budget"`
+	Spent            float64     `gorm:"type:decimal(15,2);not null" json:"spent"`
+	Message          string      `gorm:"size:500;not null" json:"message"`
+	Acknowledged     bool        `gorm:"default:false;index" json:"acknowledged"`
+	AcknowledgedAt   *time.Time  `json:"acknowledged_at"`
+	CreatedAt        time.Time   `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (Alert) TableName() string {
+	return "alerts"
+}
+
+// NewAlert creates the alert fired by a rule
+func NewAlert(rule *Rule, month civil.Month, budget, spent float64) *Alert {
+	return &Alert{
+		RuleID:           rule.ID,
+		Month:            month,
+		Kind:             rule.Kind,
+		PocketID:         rule.PocketID,
+		ThresholdPercent: rule.ThresholdPercent,
+		Budget:           budget,
+		Spent:            spent,
+		Message:          buildMessage(rule, month, budget, spent),
+	}
+}
+
+// GetPercentUsed returns the spent amount as a percentage of the budget
+func (a *Alert) GetPercentUsed() float64 {
+	if a.Budget <= 0 {
+		return 0
+	}
+	return a.Spent / a.Budget * 100
+}
+
+// Acknowledge marks the alert as seen
+func (a *Alert) Acknowledge(at time.Time) {
+	a.Acknowledged = true
+	a.AcknowledgedAt = &at
+}
+
+// buildMessage builds the human readable alert message
+func buildMessage(rule *Rule, month civil.Month, budget, spent float64) string {
+	subject := "El gasto diario"
+	if rule.Kind == KindPocket {
+		subject = "El bolsillo"
+		if name := rule.GetPocketName(); name != "" {
+			subject += " " + name
+		}
+	}
+
+	return fmt.Sprintf("%s de %s alcanzó el %.0f%% del presupuesto (%.2f de %.2f)",
+		subject, month, spent/budget*100, spent, budget)
+}
@@ -0,0 +1,113 @@
+// Package apperror defines the typed errors returned by the domain and the use
+// cases. Each error carries a stable machine-readable code that the HTTP layer
+// maps to a status code; the message is the human-readable explanation
+package apperror
+
+import "errors"
+
+// Code is the stable machine-readable identifier of an error kind
+type Code string
+
+const (
+	CodeNotFound   Code = "not_found"        // The requested resource does not exist
+	CodeValidation Code = "validation_error" // The input is missing or invalid
+	CodeConflict   Code = "conflict"         // The change clashes with the current state
+	CodeForbidden  Code = "forbidden"        // The operation is not allowed
+	CodeInternal   Code = "internal_error"   // Any untyped error
+)
+
+// FieldError describes why a single input field is invalid
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Error is a domain error with a code, a message and optional field details
+type Error struct {
+	Code    Code
+	Message string
+	Fields  []FieldError
+	Current interface{} // Current state of the resource a conflict clashed with, if any
+	Err     error       // Underlying cause, if any
+}
+
+// Error returns the human-readable message
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Unwrap returns the underlying cause so errors.Is and errors.As see through it
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// WithCause returns a copy of the error wrapping the underlying cause
+func (e *Error) WithCause(err error) *Error {
+	wrapped := *e
+	wrapped.Err = err
+	return &wrapped
+}
+
+// WithCurrent returns a copy of the error carrying the current state of the resource
+func (e *Error) WithCurrent(current interface{}) *Error {
+	withState := *e
+	withState.Current = current
+	return &withState
+}
+
+// NotFound reports a missing resource, e.g. NotFound("pocket not found")
+func NotFound(message string) *Error {
+	return &Error{Code: CodeNotFound, Message: message}
+}
+
+// Validation reports invalid input with optional per-field details
+func Validation(message string, fields ...FieldError) *Error {
+	return &Error{Code: CodeValidation, Message: message, Fields: fields}
+}
+
+// Invalid reports a single invalid field; the message is also the field detail
+func Invalid(field, message string) *Error {
+	return Validation(message, FieldError{Field: field, Message: message})
+}
+
+// Conflict reports a change that clashes with the current state, like a duplicate name
+func Conflict(message string) *Error {
+	return &Error{Code: CodeConflict, Message: message}
+}
+
+// Forbidden reports an operation that is not allowed
+func Forbidden(message string) *Error {
+	return &Error{Code: CodeForbidden, Message: message}
+}
+
+// CodeOf returns the code of the first typed error in the chain, or CodeInternal
+func CodeOf(err error) Code {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr.Code
+	}
+	return CodeInternal
+}
+
+// FieldsOf returns the field details of the first typed error in the chain
+func FieldsOf(err error) []FieldError {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr.Fields
+	}
+	return nil
+}
+
+// CurrentOf returns the current resource state of the first typed error in the chain
+func CurrentOf(err error) interface{} {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr.Current
+	}
+	return nil
+}
+
+// IsNotFound reports whether err is a typed not found error
+func IsNotFound(err error) bool {
+	return CodeOf(err) == CodeNotFound
+}
@@ -0,0 +1,130 @@
+package attachment
+
+import (
+	"expenses-api/internal/domain/apperror"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Expense types an attachment can belong to
+const (
+	ExpenseTypeDaily = "daily"
+	ExpenseTypeFixed = "fixed"
+)
+
+// Content types accepted as receipts
+var allowedContentTypes = map[string]string{
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"image/gif":       ".gif",
+	"application/pdf": ".pdf",
+}
+
+// Attachment represents a receipt or document attached to a daily or fixed expense
+// The file content lives in a blob store keyed by its SHA-256 hash, so the same
+// file uploaded several times is stored only once
+// Maps to frontend interface: Attachment { id, expense_type, expense_id, file_name, content_type, size, has_thumbnail, created_at }
+type Attachment struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	ExpenseType  string    `gorm:"size:10;not null;index:idx_attachment_expense,priority:1" json:"expense_type"`
+	ExpenseID    uint      `gorm:"not null;index:idx_attachment_expense,priority:2" json:"expense_id"`
+	FileName     string    `gorm:"size:255;not null" json:"file_name"`
+	ContentType  string    `gorm:"size:100;not null" json:"content_type"`
+	Size         int64     `gorm:"not null" json:"size"`
+	SHA256       string    `gorm:"column:sha256;size:64;not null;index" json:"sha256"`
+	StorageKey   string    `gorm:"size:255;not null" json:"-"`
+	ThumbnailKey string    `gorm:"size:255" json:"-"`
+	CreatedAt    time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (Attachment) TableName() string {
+	return "attachments"
+}
+
+// BeforeCreate hook to validate data before creation
+func (a *Attachment) BeforeCreate(tx *gorm.DB) error {
+	return a.validate()
+}
+
+// BeforeUpdate hook to validate data before update
+func (a *Attachment) BeforeUpdate(tx *gorm.DB) error {
+	return a.validate()
+}
+
+// validate performs validation and data cleaning
+func (a *Attachment) validate() error {
+	if !IsValidExpenseType(a.ExpenseType) {
+		return apperror.Invalid("expense_type", "expense type must be daily or fixed")
+	}
+
+	if a.ExpenseID == 0 {
+		return apperror.Invalid("id", "expense ID is required")
+	}
+
+	a.FileName = CleanFileName(a.FileName)
+
+	if !IsAllowedContentType(a.ContentType) {
+		return apperror.Invalid("file", "only JPEG, PNG, GIF images and PDF documents are allowed")
+	}
+
+	if a.Size <= 0 {
+		return apperror.Invalid("file", "file cannot be empty")
+	}
+
+	if len(a.SHA256) != 64 {
+		return apperror.Validation("invalid content hash")
+	}
+
+	if a.StorageKey == "" {
+		return apperror.Validation("storage key is required")
+	}
+
+	return nil
+}
+
+// HasThumbnail checks if a thumbnail was generated for the attachment
+func (a *Attachment) HasThumbnail() bool {
+	return a.ThumbnailKey != ""
+}
+
+// IsImage checks if the attachment is an image
+func (a *Attachment) IsImage() bool {
+	return strings.HasPrefix(a.ContentType, "image/")
+}
+
+// IsValidExpenseType checks if the expense type is supported
+func IsValidExpenseType(expenseType string) bool {
+	return expenseType == ExpenseTypeDaily || expenseType == ExpenseTypeFixed
+}
+
+// IsAllowedContentType checks if the content type is accepted as a receipt
+func IsAllowedContentType(contentType string) bool {
+	_, ok := allowedContentTypes[contentType]
+	return ok
+}
+
+// BlobKey returns the content-addressed storage key of a file
+func BlobKey(sha256, contentType string) string {
+	return "blobs/" + sha256[:2] + "/" + sha256 + allowedContentTypes[contentType]
+}
+
+// ThumbnailKey returns the storage key of the thumbnail of a file
+func ThumbnailKey(sha256 string) string {
+	return "thumbnails/" + sha256[:2] + "/" + sha256 + ".jpg"
+}
+
+// CleanFileName strips any directory from an uploaded file name
+func CleanFileName(name string) string {
+	name = strings.TrimSpace(filepath.Base(strings.ReplaceAll(name, "\\", "/")))
+	if name == "" || name == "." || name == "/" {
+		return "attachment"
+	}
+	if len(name) > 255 {
+		name = name[len(name)-255:]
+	}
+	return name
+}
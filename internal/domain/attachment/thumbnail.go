@@ -0,0 +1,83 @@
+package attachment
+
+import (
+	"bytes"
+	"image"
+	"image/draw"
+	"image/jpeg"
+
+	// Register decoders for the accepted image formats
+	_ "image/gif"
+	_ "image/png"
+)
+
+// ThumbnailMaxSize is the maximum width or height of a thumbnail in pixels
+const ThumbnailMaxSize = 256
+
+// Thumbnail decodes an image and returns a JPEG scaled down to fit within
+// maxSize x maxSize, keeping the aspect ratio. Smaller images are not enlarged.
+func Thumbnail(data []byte, maxSize int) ([]byte, error) {
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	targetWidth, targetHeight := width, height
+	if width > maxSize || height > maxSize {
+		if width >= height {
+			targetWidth = maxSize
+			targetHeight = max(1, height*maxSize/width)
+		} else {
+			targetHeight = maxSize
+			targetWidth = max(1, width*maxSize/height)
+		}
+	}
+
+	// Flatten onto a white background so transparent PNG/GIF look right as JPEG
+	dst := image.NewRGBA(image.Rect(0, 0, targetWidth, targetHeight))
+	draw.Draw(dst, dst.Bounds(), image.White, image.Point{}, draw.Src)
+	scale(dst, src)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 80}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// scale draws src over dst averaging the source pixels covered by each destination pixel
+func scale(dst *image.RGBA, src image.Image) {
+	sb := src.Bounds()
+	db := dst.Bounds()
+	sw, sh := sb.Dx(), sb.Dy()
+	dw, dh := db.Dx(), db.Dy()
+
+	for y := 0; y < dh; y++ {
+		y0 := sb.Min.Y + y*sh/dh
+		y1 := max(y0+1, sb.Min.Y+(y+1)*sh/dh)
+		for x := 0; x < dw; x++ {
+			x0 := sb.Min.X + x*sw/dw
+			x1 := max(x0+1, sb.Min.X+(x+1)*sw/dw)
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r, g, b, a = r+uint64(cr), g+uint64(cg), b+uint64(cb), a+uint64(ca)
+					n++
+				}
+			}
+
+			// Composite the averaged (premultiplied) color over the white background
+			r, g, b, a = r/n, g/n, b/n, a/n
+			bg := 0xffff - a
+			i := dst.PixOffset(x, y)
+			dst.Pix[i+0] = uint8((r + bg) >> 8)
+			dst.Pix[i+1] = uint8((g + bg) >> 8)
+			dst.Pix[i+2] = uint8((b + bg) >> 8)
+			dst.Pix[i+3] = 0xff
+		}
+	}
+}
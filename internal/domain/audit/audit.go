@@ -0,0 +1,71 @@
+package audit
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// Actions recorded in the audit log
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+)
+
+// SystemActor is recorded for changes made without a known actor, such as background jobs
+const SystemActor = "system"
+
+// MaxActorLength is the longest actor name stored, in characters; longer names are truncated
+const MaxActorLength = 100
+
+// Entry is one change to a stored entity in the append-only audit log
+// Before and After hold the row as JSON keyed by column name
+type Entry struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	Entity    string    `gorm:"size:64;not null;index:idx_audit_logs_entity_created,priority:1" json:"entity"` // Table name, e.g. "daily_expenses"
+	EntityID  uint      `gorm:"not null" json:"entity_id"`
+	Action    string    `gorm:"size:10;not null" json:"action"`
+	Before    *string   `gorm:"column:before_state;type:text" json:"before"` // Nil on create
+	After     *string   `gorm:"column:after_state;type:text" json:"after"`   // Nil on delete
+	Actor     string    `gorm:"size:100;not null" json:"actor"`
+	CreatedAt time.Time `gorm:"not null;index:idx_audit_logs_entity_created,priority:2;index" json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (Entry) TableName() string {
+	return "audit_logs"
+}
+
+// Filter selects audit log entries; zero fields do not filter
+type Filter struct {
+	Entity   string
+	EntityID uint
+	From     time.Time // Inclusive
+	To       time.Time // Exclusive
+	Limit    int
+}
+
+type actorKey struct{}
+
+// WithActor returns a copy of ctx carrying who makes the changes done with it
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFrom returns the actor carried by ctx, or SystemActor when there is none
+func ActorFrom(ctx context.Context) string {
+	if ctx == nil {
+		return SystemActor
+	}
+
+	actor, _ := ctx.Value(actorKey{}).(string)
+	actor = strings.TrimSpace(actor)
+	if actor == "" {
+		return SystemActor
+	}
+	if runes := []rune(actor); len(runes) > MaxActorLength {
+		actor = string(runes[:MaxActorLength])
+	}
+	return actor
+}
@@ -0,0 +1,23 @@
+package categorization
+
+import "expenses-api/internal/domain/civil"
+
+// Change describes what applying the rules does to an existing daily expense
+type Change struct {
+	ExpenseID      uint
+	Description    string
+	Amount         float64
+	Date           civil.Date
+	RuleID         uint
+	RuleName       string
+	FromPocketID   *uint
+	FromPocketName string
+	ToPocketID     *uint
+	ToPocketName   string
+	AddedTags      []string
+}
+
+// ChangesPocket checks if the change assigns a different pocket
+func (c *Change) ChangesPocket() bool {
+	return c.ToPocketID != nil
+}
@@ -0,0 +1,176 @@
+package categorization
+
+import (
+	"expenses-api/internal/domain/apperror"
+	"expenses-api/internal/domain/money"
+	"regexp"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Supported ways of matching the description of an expense
+const (
+	MatchContains = "contains" // Case and accent insensitive substring
+	MatchRegex    = "regex"    // Case insensitive regular expression
+)
+
+// Rule auto-assigns a pocket and tags to daily expenses whose description
+// (and optionally amount) matches it
+// Maps to frontend interface: CategorizationRule { id?, name, match_type, pattern, min_amount?, max_amount?, pocket_id?, tags?, priority, active }
+type Rule struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	Name      string    `gorm:"size:100;not null" json:"name"`
+	MatchType string    `gorm:"size:20;not null" json:"match_type"`
+	Pattern   string    `gorm:"size:255;not null" json:"pattern"`
+	MinAmount *float64  `gorm:"type:decimal(15,2)" json:"min_amount"` // Inclusive
+	MaxAmount *float64  `gorm:"type:decimal(15,2)" json:"max_amount"` // Inclusive
+	PocketID  *uint     `gorm:"index" json:"pocket_id"`
+	Priority  int       `gorm:"not null;index" json:"priority"` // Lower runs first
+	Active    bool      `gorm:"default:true;index" json:"active"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+
+	// Relationship - will be loaded when needed
+	Pocket *Pocket `gorm:"foreignKey:PocketID" json:"pocket,omitempty"`
+	Tags   []Tag   `gorm:"many2many:categorization_rule_tags" json:"tags,omitempty"`
+
+	// Compiled pattern of regex rules, built on first use
+	compiled *regexp.Regexp `gorm:"-"`
+}
+
+// Pocket represents the relationship to avoid circular imports
+type Pocket struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+}
+
+// Tag represents the relationship to avoid circular imports
+type Tag struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+}
+
+// TableName specifies the table name for GORM
+func (Rule) TableName() string {
+	return "categorization_rules"
+}
+
+// BeforeCreate hook to validate data before creation
+func (r *Rule) BeforeCreate(tx *gorm.DB) error {
+	return r.Validate()
+}
+
+// BeforeUpdate hook to validate data before update
+func (r *Rule) BeforeUpdate(tx *gorm.DB) error {
+	return r.Validate()
+}
+
+// Validate performs validation and data cleaning
+func (r *Rule) Validate() error {
+	r.Name = strings.TrimSpace(r.Name)
+	if r.Name == "" {
+		return apperror.Invalid("name", "rule name cannot be empty")
+	}
+
+	if len(r.Name) > 100 {
+		return apperror.Invalid("name", "rule name cannot exceed 100 characters")
+	}
+
+	if r.MatchType != MatchContains && r.MatchType != MatchRegex {
+		return apperror.Invalid("match_type", "match type must be contains or regex")
+	}
+
+	r.Pattern = strings.TrimSpace(r.Pattern)
+	if r.Pattern == "" {
+		return apperror.Invalid("pattern", "rule pattern cannot be empty")
+	}
+
+	if len(r.Pattern) > 255 {
+		return apperror.Invalid("pattern", "rule pattern cannot exceed 255 characters")
+	}
+
+	if r.MatchType == MatchRegex {
+		compiled, err := regexp.Compile("(?i)" + r.Pattern)
+		if err != nil {
+			return apperror.Invalid("pattern", "rule pattern is not a valid regular expression")
+		}
+		r.compiled = compiled
+	}
+
+	if r.MinAmount != nil && *r.MinAmount < 0 {
+		return apperror.Invalid("min_amount", "minimum amount cannot be negative")
+	}
+
+	if r.MaxAmount != nil && *r.MaxAmount < 0 {
+		return apperror.Invalid("max_amount", "maximum amount cannot be negative")
+	}
+
+	if r.MinAmount != nil && r.MaxAmount != nil && money.ToCents(*r.MinAmount) > money.ToCents(*r.MaxAmount) {
+		return apperror.Invalid("min_amount", "minimum amount cannot be greater than maximum amount")
+	}
+
+	return nil
+}
+
+// Matches checks if an expense description and amount satisfy the rule
+func (r *Rule) Matches(description string, amount float64) bool {
+	if r.MinAmount != nil && money.ToCents(amount) < money.ToCents(*r.MinAmount) {
+		return false
+	}
+
+	if r.MaxAmount != nil && money.ToCents(amount) > money.ToCents(*r.MaxAmount) {
+		return false
+	}
+
+	if r.MatchType == MatchRegex {
+		if r.compiled == nil {
+			compiled, err := regexp.Compile("(?i)" + r.Pattern)
+			if err != nil {
+				return false
+			}
+			r.compiled = compiled
+		}
+		return r.compiled.MatchString(description)
+	}
+
+	return strings.Contains(Fold(description), Fold(r.Pattern))
+}
+
+// GetTagNames returns the names of the tags the rule assigns
+func (r *Rule) GetTagNames() []string {
+	names := make([]string, 0, len(r.Tags))
+	for _, t := range r.Tags {
+		names = append(names, t.Name)
+	}
+	return names
+}
+
+// GetPocketName returns the pocket name or empty string if not loaded
+func (r *Rule) GetPocketName() string {
+	if r.Pocket != nil {
+		return r.Pocket.Name
+	}
+	return ""
+}
+
+// Match returns the first active rule matching the expense, or nil
+// Rules are expected in evaluation order (priority, then ID)
+func Match(rules []Rule, description string, amount float64) *Rule {
+	for i := range rules {
+		if rules[i].Active && rules[i].Matches(description, amount) {
+			return &rules[i]
+		}
+	}
+	return nil
+}
+
+// accentReplacer strips the Spanish accents so "Éxito" matches "exito"
+var accentReplacer = strings.NewReplacer(
+	"á", "a", "é", "e", "í", "i", "ó", "o", "ú", "u", "ü", "u", "ñ", "n",
+)
+
+// Fold lowercases text and removes accents for contains matching
+func Fold(text string) string {
+	return accentReplacer.Replace(strings.ToLower(strings.TrimSpace(text)))
+}
@@ -0,0 +1,162 @@
+// Package civil provides calendar date and month values without a time of day
+// or timezone. They are stored in DATE columns and marshal to JSON in the
+// formats the API has always used: "2024-01-15" for dates and "2024-01" for months
+package civil
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"time"
+)
+
+// DateLayout is the text format of a Date
+const DateLayout = "2006-01-02"
+
+// Date is a calendar date; the zero value is an unset date
+type Date struct {
+	year  int
+	month time.Month
+	day   int
+}
+
+// NewDate returns the date of year, month and day, normalizing overflows like time.Date
+func NewDate(year int, month time.Month, day int) Date {
+	return DateOf(time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
+}
+
+// DateOf returns the calendar date of t in its own location
+// Convert t to the business timezone first so the day boundary is the expected one
+func DateOf(t time.Time) Date {
+	year, month, day := t.Date()
+	return Date{year: year, month: month, day: day}
+}
+
+// ParseDate parses a date in YYYY-MM-DD format
+func ParseDate(s string) (Date, error) {
+	t, err := time.Parse(DateLayout, s)
+	if err != nil {
+		return Date{}, fmt.Errorf("date must be in YYYY-MM-DD format: %q", s)
+	}
+	return DateOf(t), nil
+}
+
+// MustParseDate is like ParseDate but panics on invalid input, for constants and tests
+func MustParseDate(s string) Date {
+	d, err := ParseDate(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// IsZero reports whether the date is unset
+func (d Date) IsZero() bool {
+	return d == Date{}
+}
+
+// String returns the date in YYYY-MM-DD format, or "" when unset
+func (d Date) String() string {
+	if d.IsZero() {
+		return ""
+	}
+	return d.time().Format(DateLayout)
+}
+
+// Day returns the day of the month
+func (d Date) Day() int {
+	return d.day
+}
+
+// Month returns the month the date belongs to
+func (d Date) Month() Month {
+	return Month{year: d.year, month: d.month}
+}
+
+// Weekday returns the day of the week
+func (d Date) Weekday() time.Weekday {
+	return d.time().Weekday()
+}
+
+// AddDays returns the date n days later, or earlier when n is negative
+func (d Date) AddDays(n int) Date {
+	return DateOf(d.time().AddDate(0, 0, n))
+}
+
+// Before reports whether d is earlier than other
+func (d Date) Before(other Date) bool {
+	return d.time().Before(other.time())
+}
+
+// After reports whether d is later than other
+func (d Date) After(other Date) bool {
+	return d.time().After(other.time())
+}
+
+// In returns midnight of the date in loc
+func (d Date) In(loc *time.Location) time.Time {
+	return time.Date(d.year, d.month, d.day, 0, 0, 0, 0, loc)
+}
+
+// MarshalText encodes the date as YYYY-MM-DD, which JSON uses as a string
+func (d Date) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText decodes a YYYY-MM-DD date; an empty string is the unset date
+func (d *Date) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*d = Date{}
+		return nil
+	}
+
+	parsed, err := ParseDate(string(text))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// Value stores the date as YYYY-MM-DD, which both MySQL and SQLite accept for DATE columns
+func (d Date) Value() (driver.Value, error) {
+	if d.IsZero() {
+		return nil, nil
+	}
+	return d.String(), nil
+}
+
+// Scan reads a DATE column, returned as time.Time by MySQL and as text by SQLite
+func (d *Date) Scan(src interface{}) error {
+	switch value := src.(type) {
+	case nil:
+		*d = Date{}
+		return nil
+	case time.Time:
+		*d = DateOf(value)
+		return nil
+	case string:
+		return d.scanText(value)
+	case []byte:
+		return d.scanText(string(value))
+	default:
+		return fmt.Errorf("cannot scan %T into civil.Date", src)
+	}
+}
+
+// GormDataType declares the column type used by GORM
+func (Date) GormDataType() string {
+	return "date"
+}
+
+// scanText reads the date part of a stored YYYY-MM-DD or timestamp text
+func (d *Date) scanText(text string) error {
+	if len(text) > len(DateLayout) {
+		text = text[:len(DateLayout)]
+	}
+	return d.UnmarshalText([]byte(text))
+}
+
+// time returns midnight UTC of the date for calendar arithmetic
+func (d Date) time() time.Time {
+	return d.In(time.UTC)
+}
@@ -0,0 +1,131 @@
+package civil_test
+
+import (
+	"encoding/json"
+	"expenses-api/internal/domain/civil"
+	"testing"
+	"time"
+)
+
+func TestParseDate(t *testing.T) {
+	got, err := civil.ParseDate("2024-02-29")
+	if err != nil {
+		t.Fatalf("ParseDate() error = %v", err)
+	}
+	if got.String() != "2024-02-29" || got.Day() != 29 || got.Month() != civil.MustParseMonth("2024-02") {
+		t.Errorf("ParseDate() = %s", got)
+	}
+
+	for _, s := range []string{"", "2023-02-29", "2024-13-01", "15/01/2024", "2024-1-5"} {
+		if _, err := civil.ParseDate(s); err == nil {
+			t.Errorf("ParseDate(%q) succeeded, want an error", s)
+		}
+	}
+}
+
+func TestDateScan(t *testing.T) {
+	bogota := time.FixedZone("COT", -5*60*60)
+
+	tests := []struct {
+		name string
+		src  interface{}
+		want civil.Date
+	}{
+		{name: "nil", src: nil, want: civil.Date{}},
+		{name: "time.Time", src: time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC), want: civil.MustParseDate("2024-01-15")},
+		{name: "time.Time keeps its own day", src: time.Date(2024, time.January, 15, 23, 30, 0, 0, bogota), want: civil.MustParseDate("2024-01-15")},
+		{name: "string", src: "2024-01-15", want: civil.MustParseDate("2024-01-15")},
+		{name: "timestamp string", src: "2024-01-15 00:00:00", want: civil.MustParseDate("2024-01-15")},
+		{name: "bytes", src: []byte("2024-01-15"), want: civil.MustParseDate("2024-01-15")},
+		{name: "RFC 3339 bytes", src: []byte("2024-01-15T00:00:00Z"), want: civil.MustParseDate("2024-01-15")},
+		{name: "empty string", src: "", want: civil.Date{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got civil.Date
+			if err := got.Scan(tt.src); err != nil {
+				t.Fatalf("Scan(%v) error = %v", tt.src, err)
+			}
+			if got != tt.want {
+				t.Errorf("Scan(%v) = %s, want %s", tt.src, got, tt.want)
+			}
+		})
+	}
+
+	var d civil.Date
+	for _, src := range []interface{}{42, "15/01/2024"} {
+		if err := d.Scan(src); err == nil {
+			t.Errorf("Scan(%v) succeeded, want an error", src)
+		}
+	}
+}
+
+func TestDateValue(t *testing.T) {
+	value, err := civil.MustParseDate("2024-01-05").Value()
+	if err != nil || value != "2024-01-05" {
+		t.Errorf("Value() = %v, %v, want 2024-01-05", value, err)
+	}
+
+	value, err = civil.Date{}.Value()
+	if err != nil || value != nil {
+		t.Errorf("zero Value() = %v, %v, want nil", value, err)
+	}
+}
+
+func TestDateText(t *testing.T) {
+	text, err := civil.MustParseDate("2024-01-05").MarshalText()
+	if err != nil || string(text) != "2024-01-05" {
+		t.Errorf("MarshalText() = %q, %v, want 2024-01-05", text, err)
+	}
+
+	var d civil.Date
+	if err := d.UnmarshalText([]byte("2024-03-31")); err != nil || d != civil.MustParseDate("2024-03-31") {
+		t.Errorf("UnmarshalText() = %s, %v, want 2024-03-31", d, err)
+	}
+	if err := d.UnmarshalText(nil); err != nil || !d.IsZero() {
+		t.Errorf("UnmarshalText(empty) = %s, %v, want the zero date", d, err)
+	}
+	if err := d.UnmarshalText([]byte("2024-02-30")); err == nil {
+		t.Error("UnmarshalText(2024-02-30) succeeded, want an error")
+	}
+}
+
+func TestDateJSON(t *testing.T) {
+	type payload struct {
+		Date civil.Date  `json:"date"`
+		Paid *civil.Date `json:"paid,omitempty"`
+	}
+
+	data, err := json.Marshal(payload{Date: civil.MustParseDate("2024-01-15")})
+	if err != nil || string(data) != `{"date":"2024-01-15"}` {
+		t.Fatalf("Marshal() = %s, %v", data, err)
+	}
+
+	var decoded payload
+	if err := json.Unmarshal([]byte(`{"date":"2024-01-15","paid":"2024-01-20"}`), &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if decoded.Date != civil.MustParseDate("2024-01-15") || decoded.Paid == nil || *decoded.Paid != civil.MustParseDate("2024-01-20") {
+		t.Errorf("Unmarshal() = %+v", decoded)
+	}
+}
+
+func TestDateArithmetic(t *testing.T) {
+	d := civil.MustParseDate("2024-12-31")
+	if got := d.AddDays(1); got != civil.MustParseDate("2025-01-01") {
+		t.Errorf("AddDays(1) = %s, want 2025-01-01", got)
+	}
+	if got := civil.MustParseDate("2024-03-01").AddDays(-1); got != civil.MustParseDate("2024-02-29") {
+		t.Errorf("AddDays(-1) = %s, want 2024-02-29", got)
+	}
+	if got := civil.NewDate(2023, time.February, 29); got != civil.MustParseDate("2023-03-01") {
+		t.Errorf("NewDate(2023, 2, 29) = %s, want 2023-03-01", got)
+	}
+	if !civil.MustParseDate("2024-01-31").Before(civil.MustParseDate("2024-02-01")) || d.Before(d) {
+		t.Error("Before() is wrong")
+	}
+	if got := civil.MustParseDate("2024-01-15").Weekday(); got != time.Monday {
+		t.Errorf("Weekday() = %s, want Monday", got)
+	}
+}
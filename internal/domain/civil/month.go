@@ -0,0 +1,157 @@
+package civil
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"time"
+)
+
+// MonthLayout is the text format of a Month
+const MonthLayout = "2006-01"
+
+// Month is a calendar month; the zero value is an unset month
+// It is stored as the DATE of its first day so month columns can be range queried
+type Month struct {
+	year  int
+	month time.Month
+}
+
+// NewMonth returns the month of year, normalizing overflows like time.Date
+func NewMonth(year int, month time.Month) Month {
+	return NewDate(year, month, 1).Month()
+}
+
+// MonthOf returns the calendar month of t in its own location
+func MonthOf(t time.Time) Month {
+	return DateOf(t).Month()
+}
+
+// ParseMonth parses a month in YYYY-MM format
+func ParseMonth(s string) (Month, error) {
+	t, err := time.Parse(MonthLayout, s)
+	if err != nil {
+		return Month{}, fmt.Errorf("month must be in YYYY-MM format: %q", s)
+	}
+	return MonthOf(t), nil
+}
+
+// MustParseMonth is like ParseMonth but panics on invalid input, for constants and tests
+func MustParseMonth(s string) Month {
+	m, err := ParseMonth(s)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// IsZero reports whether the month is unset
+func (m Month) IsZero() bool {
+	return m == Month{}
+}
+
+// String returns the month in YYYY-MM format, or "" when unset
+func (m Month) String() string {
+	if m.IsZero() {
+		return ""
+	}
+	return m.FirstDay().In(time.UTC).Format(MonthLayout)
+}
+
+// Year returns the year of the month
+func (m Month) Year() int {
+	return m.year
+}
+
+// Number returns the month of the year
+func (m Month) Number() time.Month {
+	return m.month
+}
+
+// FirstDay returns the first day of the month
+func (m Month) FirstDay() Date {
+	return Date{year: m.year, month: m.month, day: 1}
+}
+
+// LastDay returns the last day of the month
+func (m Month) LastDay() Date {
+	return m.AddMonths(1).FirstDay().AddDays(-1)
+}
+
+// Days returns the number of days in the month
+func (m Month) Days() int {
+	return m.LastDay().Day()
+}
+
+// Day returns the given day of the month, clamped to its first and last day
+func (m Month) Day(day int) Date {
+	if day < 1 {
+		day = 1
+	}
+	if day > m.Days() {
+		day = m.Days()
+	}
+	return Date{year: m.year, month: m.month, day: day}
+}
+
+// AddMonths returns the month n months later, or earlier when n is negative
+func (m Month) AddMonths(n int) Month {
+	return NewMonth(m.year, m.month+time.Month(n))
+}
+
+// Contains reports whether the date falls in the month
+func (m Month) Contains(d Date) bool {
+	return d.Month() == m
+}
+
+// Before reports whether m is earlier than other
+func (m Month) Before(other Month) bool {
+	return m.FirstDay().Before(other.FirstDay())
+}
+
+// After reports whether m is later than other
+func (m Month) After(other Month) bool {
+	return m.FirstDay().After(other.FirstDay())
+}
+
+// MarshalText encodes the month as YYYY-MM, which JSON uses as a string
+func (m Month) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalText decodes a YYYY-MM month; an empty string is the unset month
+func (m *Month) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*m = Month{}
+		return nil
+	}
+
+	parsed, err := ParseMonth(string(text))
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// Value stores the month as the DATE of its first day
+func (m Month) Value() (driver.Value, error) {
+	if m.IsZero() {
+		return nil, nil
+	}
+	return m.FirstDay().Value()
+}
+
+// Scan reads the DATE of the first day of the month
+func (m *Month) Scan(src interface{}) error {
+	var d Date
+	if err := d.Scan(src); err != nil {
+		return fmt.Errorf("cannot scan %T into civil.Month: %w", src, err)
+	}
+	*m = d.Month()
+	return nil
+}
+
+// GormDataType declares the column type used by GORM
+func (Month) GormDataType() string {
+	return "date"
+}
@@ -0,0 +1,182 @@
+package civil_test
+
+import (
+	"encoding/json"
+	"expenses-api/internal/domain/civil"
+	"testing"
+	"time"
+)
+
+func TestParseMonth(t *testing.T) {
+	got, err := civil.ParseMonth("2024-02")
+	if err != nil {
+		t.Fatalf("ParseMonth() error = %v", err)
+	}
+	if got.String() != "2024-02" || got.Year() != 2024 || got.Number() != time.February {
+		t.Errorf("ParseMonth() = %s", got)
+	}
+
+	for _, s := range []string{"", "2024-13", "2024-1", "02-2024", "2024-02-01"} {
+		if _, err := civil.ParseMonth(s); err == nil {
+			t.Errorf("ParseMonth(%q) succeeded, want an error", s)
+		}
+	}
+}
+
+func TestMonthAddMonths(t *testing.T) {
+	tests := []struct {
+		month string
+		n     int
+		want  string
+	}{
+		{month: "2024-01", n: 1, want: "2024-02"},
+		{month: "2024-12", n: 1, want: "2025-01"},
+		{month: "2024-01", n: -1, want: "2023-12"},
+		{month: "2024-11", n: 14, want: "2026-01"},
+		{month: "2024-03", n: -27, want: "2021-12"},
+		{month: "2024-06", n: 0, want: "2024-06"},
+	}
+
+	for _, tt := range tests {
+		got := civil.MustParseMonth(tt.month).AddMonths(tt.n)
+		if got.String() != tt.want {
+			t.Errorf("%s.AddMonths(%d) = %s, want %s", tt.month, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestMonthDays(t *testing.T) {
+	tests := []struct {
+		month string
+		days  int
+		last  string
+	}{
+		{month: "2024-01", days: 31, last: "2024-01-31"},
+		{month: "2024-02", days: 29, last: "2024-02-29"},
+		{month: "2023-02", days: 28, last: "2023-02-28"},
+		{month: "2024-04", days: 30, last: "2024-04-30"},
+	}
+
+	for _, tt := range tests {
+		m := civil.MustParseMonth(tt.month)
+		if got := m.Days(); got != tt.days {
+			t.Errorf("%s.Days() = %d, want %d", tt.month, got, tt.days)
+		}
+		if got := m.LastDay(); got != civil.MustParseDate(tt.last) {
+			t.Errorf("%s.LastDay() = %s, want %s", tt.month, got, tt.last)
+		}
+		if got := m.FirstDay(); got != civil.MustParseDate(tt.month+"-01") {
+			t.Errorf("%s.FirstDay() = %s", tt.month, got)
+		}
+	}
+}
+
+func TestMonthDayClamps(t *testing.T) {
+	tests := []struct {
+		month string
+		day   int
+		want  string
+	}{
+		{month: "2024-01", day: 15, want: "2024-01-15"},
+		{month: "2024-02", day: 31, want: "2024-02-29"},
+		{month: "2023-02", day: 30, want: "2023-02-28"},
+		{month: "2024-04", day: 31, want: "2024-04-30"},
+		{month: "2024-04", day: 0, want: "2024-04-01"},
+	}
+
+	for _, tt := range tests {
+		got := civil.MustParseMonth(tt.month).Day(tt.day)
+		if got != civil.MustParseDate(tt.want) {
+			t.Errorf("%s.Day(%d) = %s, want %s", tt.month, tt.day, got, tt.want)
+		}
+	}
+
+	// Adding months keeps the payment day of a later month clamped the same way
+	if got := civil.MustParseMonth("2024-01").AddMonths(1).Day(31); got != civil.MustParseDate("2024-02-29") {
+		t.Errorf("2024-01 plus one month, day 31 = %s, want 2024-02-29", got)
+	}
+}
+
+func TestMonthComparisons(t *testing.T) {
+	dec := civil.MustParseMonth("2023-12")
+	jan := civil.MustParseMonth("2024-01")
+
+	if !dec.Before(jan) || jan.Before(dec) || dec.Before(dec) {
+		t.Error("Before() is wrong")
+	}
+	if !jan.After(dec) || dec.After(jan) {
+		t.Error("After() is wrong")
+	}
+	if !jan.Contains(civil.MustParseDate("2024-01-31")) || jan.Contains(civil.MustParseDate("2023-01-15")) {
+		t.Error("Contains() is wrong")
+	}
+	if got := civil.MonthOf(time.Date(2024, time.January, 31, 23, 0, 0, 0, time.UTC)); got != jan {
+		t.Errorf("MonthOf() = %s, want 2024-01", got)
+	}
+}
+
+func TestMonthScan(t *testing.T) {
+	tests := []struct {
+		name string
+		src  interface{}
+		want civil.Month
+	}{
+		{name: "nil", src: nil, want: civil.Month{}},
+		{name: "time.Time", src: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC), want: civil.MustParseMonth("2024-01")},
+		{name: "string", src: "2024-01-01", want: civil.MustParseMonth("2024-01")},
+		{name: "timestamp string", src: "2024-01-01 00:00:00", want: civil.MustParseMonth("2024-01")},
+		{name: "bytes", src: []byte("2024-01-01"), want: civil.MustParseMonth("2024-01")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got civil.Month
+			if err := got.Scan(tt.src); err != nil {
+				t.Fatalf("Scan(%v) error = %v", tt.src, err)
+			}
+			if got != tt.want {
+				t.Errorf("Scan(%v) = %s, want %s", tt.src, got, tt.want)
+			}
+		})
+	}
+
+	var m civil.Month
+	if err := m.Scan(3.5); err == nil {
+		t.Error("Scan(3.5) succeeded, want an error")
+	}
+}
+
+func TestMonthValue(t *testing.T) {
+	value, err := civil.MustParseMonth("2024-03").Value()
+	if err != nil || value != "2024-03-01" {
+		t.Errorf("Value() = %v, %v, want 2024-03-01", value, err)
+	}
+
+	value, err = civil.Month{}.Value()
+	if err != nil || value != nil {
+		t.Errorf("zero Value() = %v, %v, want nil", value, err)
+	}
+}
+
+func TestMonthText(t *testing.T) {
+	text, err := civil.MustParseMonth("2024-03").MarshalText()
+	if err != nil || string(text) != "2024-03" {
+		t.Errorf("MarshalText() = %q, %v, want 2024-03", text, err)
+	}
+
+	var m civil.Month
+	if err := m.UnmarshalText([]byte("2025-11")); err != nil || m != civil.MustParseMonth("2025-11") {
+		t.Errorf("UnmarshalText() = %s, %v, want 2025-11", m, err)
+	}
+	if err := m.UnmarshalText(nil); err != nil || !m.IsZero() {
+		t.Errorf("UnmarshalText(empty) = %s, %v, want the zero month", m, err)
+	}
+	if err := m.UnmarshalText([]byte("2025-00")); err == nil {
+		t.Error("UnmarshalText(2025-00) succeeded, want an error")
+	}
+
+	data, err := json.Marshal(map[string]civil.Month{"month": civil.MustParseMonth("2024-03")})
+	if err != nil || string(data) != `{"month":"2024-03"}` {
+		t.Errorf("Marshal() = %s, %v", data, err)
+	}
+}
@@ -1,7 +1,8 @@
 package daily_expense
 
 import (
-	"errors"
+	"expenses-api/internal/domain/apperror"
+	"expenses-api/internal/domain/civil"
 	"strings"
 	"time"
 
@@ -9,13 +10,32 @@ import (
 )
 
 // DailyExpense represents daily expenses
-// Maps to frontend interface: DailyExpense { id?, description, amount, date, created_at? }
+// Maps to frontend interface: DailyExpense { id?, description, amount, date, pocket_id?, splits?, tags?, created_at? }
 type DailyExpense struct {
-	ID          uint      `gorm:"primaryKey" json:"id"`
-	Description string    `gorm:"size:500;not null" json:"description"`
-	Amount      float64   `gorm:"type:decimal(15,2);not null" json:"amount"`
-	Date        string    `gorm:"size:10;not null;index" json:"date"` // Format: "2024-01-15"
-	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
+	ID          uint       `gorm:"primaryKey" json:"id"`
+	Description string     `gorm:"size:500;not null" json:"description"`
+	Amount      float64    `gorm:"type:decimal(15,2);not null" json:"amount"`
+	Date        civil.Date `gorm:"not null;index" json:"date"` // Format: "2024-01-15"
+	PocketID    *uint      `gorm:"index" json:"pocket_id"`     // Optional envelope the expense draws from
+	CreatedAt   time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	Version     uint       `gorm:"not null;default:1" json:"version"` // Incremented on every update for optimistic locking
+
+	// Relationship - will be loaded when needed
+	Pocket *Pocket `gorm:"foreignKey:PocketID" json:"pocket,omitempty"`
+	Tags   []Tag   `gorm:"many2many:daily_expense_tags" json:"tags,omitempty"`
+	Splits []Split `gorm:"foreignKey:DailyExpenseID" json:"splits,omitempty"`
+}
+
+// Pocket represents the relationship to avoid circular imports
+type Pocket struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+}
+
+// Tag represents the relationship to avoid circular imports
+type Tag struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
 }
 
 // TableName specifies the table name for GORM
@@ -25,6 +45,9 @@ func (DailyExpense) TableName() string {
 
 // BeforeCreate hook to validate data before creation
 func (de *DailyExpense) BeforeCreate(tx *gorm.DB) error {
+	// New records start at version 1
+	de.Version = 1
+
 	return de.validate()
 }
 
@@ -38,76 +61,87 @@ func (de *DailyExpense) validate() error {
 	// Clean and validate description
 	de.Description = strings.TrimSpace(de.Description)
 	if de.Description == "" {
-		return errors.New("description cannot be empty")
+		return apperror.Invalid("description", "description cannot be empty")
 	}
 
 	if len(de.Description) > 500 {
-		return errors.New("description cannot exceed 500 characters")
+		return apperror.Invalid("description", "description cannot exceed 500 characters")
 	}
 
 	// Validate amount
 	if de.Amount <= 0 {
-		return errors.New("amount must be greater than zero")
-	}
-
-	// Validate date format (YYYY-MM-DD)
-	if len(de.Date) != 10 {
-		return errors.New("date must be in YYYY-MM-DD format")
+		return apperror.Invalid("amount", "amount must be greater than zero")
 	}
 
-	// Try to parse the date to ensure it's valid
-	_, err := time.Parse("2006-01-02", de.Date)
-	if err != nil {
-		return errors.New("invalid date format, must be YYYY-MM-DD")
+	// Validate date is set
+	if de.Date.IsZero() {
+		return apperror.Invalid("date", "date must be in YYYY-MM-DD format")
 	}
 
 	return nil
 }
 
-// GetMonth returns the month of the expense in YYYY-MM format
-func (de *DailyExpense) GetMonth() string {
-	if len(de.Date) >= 7 {
-		return de.Date[:7]
+// GetPocketName returns the name of the associated pocket, if loaded
+func (de *DailyExpense) GetPocketName() string {
+	if de.Pocket != nil {
+		return de.Pocket.Name
 	}
 	return ""
 }
 
+// GetMonth returns the month of the expense
+func (de *DailyExpense) GetMonth() civil.Month {
+	return de.Date.Month()
+}
+
 // GetDayName returns the day name of the expense date
 func (de *DailyExpense) GetDayName() string {
-	date, err := time.Parse("2006-01-02", de.Date)
-	if err != nil {
+	if de.Date.IsZero() {
 		return ""
 	}
-	return date.Weekday().String()
+	return de.Date.Weekday().String()
 }
 
 // GetDayNumber returns the day number of the expense date
 func (de *DailyExpense) GetDayNumber() int {
-	date, err := time.Parse("2006-01-02", de.Date)
-	if err != nil {
-		return 0
-	}
-	return date.Day()
+	return de.Date.Day()
+}
+
+// IsToday checks if the expense is from the day of now
+func (de *DailyExpense) IsToday(now time.Time) bool {
+	return de.Date == GetCurrentDate(now)
 }
 
-// IsToday checks if the expense is from today
-func (de *DailyExpense) IsToday() bool {
-	today := time.Now().Format("2006-01-02")
-	return de.Date == today
+// IsThisMonth checks if the expense is from the month of now
+func (de *DailyExpense) IsThisMonth(now time.Time) bool {
+	return de.GetMonth() == GetCurrentMonth(now)
 }
 
-// IsThisMonth checks if the expense is from the current month
-func (de *DailyExpense) IsThisMonth() bool {
-	currentMonth := time.Now().Format("2006-01")
-	return de.GetMonth() == currentMonth
+// GetCurrentDate returns the date of now
+func GetCurrentDate(now time.Time) civil.Date {
+	return civil.DateOf(now)
 }
 
-// GetCurrentDate returns the current date in YYYY-MM-DD format
-func GetCurrentDate() string {
-	return time.Now().Format("2006-01-02")
+// GetCurrentMonth returns the month of now
+func GetCurrentMonth(now time.Time) civil.Month {
+	return civil.MonthOf(now)
 }
 
-// GetCurrentMonth returns the current month in YYYY-MM format
-func GetCurrentMonth() string {
-	return time.Now().Format("2006-01")
+// GetTagNames returns the names of the associated tags, if loaded
+func (de *DailyExpense) GetTagNames() []string {
+	names := make([]string, 0, len(de.Tags))
+	for _, tag := range de.Tags {
+		names = append(names, tag.Name)
+	}
+	return names
+}
+
+// HasTag checks if the expense carries the given tag, if loaded
+func (de *DailyExpense) HasTag(name string) bool {
+	for _, tag := range de.Tags {
+		if tag.Name == name {
+			return true
+		}
+	}
+	return false
 }
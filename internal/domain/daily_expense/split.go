@@ -0,0 +1,108 @@
+package daily_expense
+
+import (
+	"expenses-api/internal/domain/apperror"
+	"expenses-api/internal/domain/money"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// Split represents one line of a daily expense divided across several pockets
+// e.g. a supermarket receipt covering food, cleaning and pharmacy
+// Maps to frontend interface: DailyExpenseSplit { id?, pocket_id, pocket_name?, amount, note? }
+type Split struct {
+	ID             uint    `gorm:"primaryKey" json:"id"`
+	DailyExpenseID uint    `gorm:"not null;index" json:"daily_expense_id"`
+	PocketID       uint    `gorm:"not null;index" json:"pocket_id"`
+	Amount         float64 `gorm:"type:decimal(15,2);not null" json:"amount"`
+	Note           string  `gorm:"size:255" json:"note"`
+
+	// Relationship - will be loaded when needed
+	Pocket *Pocket `gorm:"foreignKey:PocketID" json:"pocket,omitempty"`
+}
+
+// TableName specifies the table name for GORM
+func (Split) TableName() string {
+	return "daily_expense_splits"
+}
+
+// BeforeCreate hook to validate data before creation
+func (s *Split) BeforeCreate(tx *gorm.DB) error {
+	return s.validate()
+}
+
+// BeforeUpdate hook to validate data before update
+func (s *Split) BeforeUpdate(tx *gorm.DB) error {
+	return s.validate()
+}
+
+// validate performs validation and data cleaning
+func (s *Split) validate() error {
+	if s.PocketID == 0 {
+		return apperror.Invalid("splits", "split pocket is required")
+	}
+
+	if s.Amount <= 0 {
+		return apperror.Invalid("splits", "split amount must be greater than zero")
+	}
+
+	s.Note = strings.TrimSpace(s.Note)
+	if len(s.Note) > 255 {
+		return apperror.Invalid("splits", "split note cannot exceed 255 characters")
+	}
+
+	return nil
+}
+
+// GetPocketName returns the name of the associated pocket, if loaded
+func (s *Split) GetPocketName() string {
+	if s.Pocket != nil {
+		return s.Pocket.Name
+	}
+	return ""
+}
+
+// ValidateSplits checks that split lines are well formed and add up to the expense amount
+func ValidateSplits(amount float64, splits []Split) error {
+	if len(splits) == 0 {
+		return nil
+	}
+
+	var total int64
+	for i := range splits {
+		if err := splits[i].validate(); err != nil {
+			return err
+		}
+		total += money.ToCents(splits[i].Amount)
+	}
+
+	if total != money.ToCents(amount) {
+		return apperror.Invalid("splits", "split amounts must add up to the expense amount")
+	}
+
+	return nil
+}
+
+// IsSplit checks if the expense is divided across several pockets
+func (de *DailyExpense) IsSplit() bool {
+	return len(de.Splits) > 0
+}
+
+// GetPocketAmounts returns how much of the expense each pocket pays:
+// the split lines when the expense is split, otherwise the whole amount
+// for its pocket. Expenses without a pocket return an empty map.
+func (de *DailyExpense) GetPocketAmounts() map[uint]float64 {
+	amounts := make(map[uint]float64)
+	if de.IsSplit() {
+		for _, split := range de.Splits {
+			amounts[split.PocketID] += split.Amount
+		}
+		return amounts
+	}
+
+	if de.PocketID != nil {
+		amounts[*de.PocketID] = de.Amount
+	}
+	return amounts
+}
@@ -1,7 +1,8 @@
 package daily_expense_config
 
 import (
-	"errors"
+	"expenses-api/internal/domain/apperror"
+	"expenses-api/internal/domain/civil"
 	"time"
 
 	"gorm.io/gorm"
@@ -10,9 +11,10 @@ import (
 // DailyExpenseConfig represents monthly budget configuration for daily expenses
 // Maps to frontend interface: DailyExpensesConfig { id?, monthly_budget, month }
 type DailyExpenseConfig struct {
-	ID            uint    `gorm:"primaryKey" json:"id"`
-	MonthlyBudget float64 `gorm:"type:decimal(15,2);not null" json:"monthly_budget"`
-	Month         string  `gorm:"size:7;not null;uniqueIndex" json:"month"` // Format: "2024-01"
+	ID            uint        `gorm:"primaryKey" json:"id"`
+	MonthlyBudget float64     `gorm:"type:decimal(15,2);not null" json:"monthly_budget"`
+	Month         civil.Month `gorm:"not null;uniqueIndex" json:"month"` // Format: "2024-01"
+	Version       uint        `gorm:"not null;default:1" json:"version"` // Incremented on every update for optimistic locking
 }
 
 // TableName specifies the table name for GORM
@@ -22,6 +24,9 @@ func (DailyExpenseConfig) TableName() string {
 
 // BeforeCreate hook to validate data before creation
 func (dec *DailyExpenseConfig) BeforeCreate(tx *gorm.DB) error {
+	// New records start at version 1
+	dec.Version = 1
+
 	return dec.validate()
 }
 
@@ -34,18 +39,12 @@ func (dec *DailyExpenseConfig) BeforeUpdate(tx *gorm.DB) error {
 func (dec *DailyExpenseConfig) validate() error {
 	// Validate monthly budget is not negative
 	if dec.MonthlyBudget < 0 {
-		return errors.New("monthly budget cannot be negative")
-	}
-
-	// Validate month format (YYYY-MM)
-	if len(dec.Month) != 7 {
-		return errors.New("month must be in YYYY-MM format")
+		return apperror.Invalid("monthly_budget", "monthly budget cannot be negative")
 	}
 
-	// Try to parse the month to ensure it's valid
-	_, err := time.Parse("2006-01", dec.Month)
-	if err != nil {
-		return errors.New("invalid month format, must be YYYY-MM")
+	// Validate month is set
+	if dec.Month.IsZero() {
+		return apperror.Invalid("month", "month must be in YYYY-MM format")
 	}
 
 	return nil
@@ -53,52 +52,29 @@ func (dec *DailyExpenseConfig) validate() error {
 
 // GetDailyBudget calculates the daily budget based on the monthly budget
 func (dec *DailyExpenseConfig) GetDailyBudget() float64 {
-	if dec.MonthlyBudget <= 0 {
-		return 0
-	}
-
-	// Parse month to get the number of days
-	date, err := time.Parse("2006-01", dec.Month)
-	if err != nil {
+	if dec.MonthlyBudget <= 0 || dec.Month.IsZero() {
 		return 0
 	}
 
-	// Get the last day of the month
-	lastDay := time.Date(date.Year(), date.Month()+1, 0, 0, 0, 0, 0, date.Location())
-	daysInMonth := lastDay.Day()
-
-	return dec.MonthlyBudget / float64(daysInMonth)
+	return dec.MonthlyBudget / float64(dec.Month.Days())
 }
 
-// GetRemainingDays calculates remaining days in the month
-func (dec *DailyExpenseConfig) GetRemainingDays() int {
-	// Parse month
-	date, err := time.Parse("2006-01", dec.Month)
-	if err != nil {
-		return 0
-	}
-
-	// Get current date
-	now := time.Now()
-
+// GetRemainingDays calculates the days left in the month as of now, today included
+func (dec *DailyExpenseConfig) GetRemainingDays(now time.Time) int {
 	// If it's not the current month, return 0
-	if now.Format("2006-01") != dec.Month {
+	if !dec.IsCurrentMonth(now) {
 		return 0
 	}
 
-	// Get the last day of the month
-	lastDay := time.Date(date.Year(), date.Month()+1, 0, 0, 0, 0, 0, date.Location())
-
-	return lastDay.Day() - now.Day() + 1
+	return dec.Month.Days() - now.Day() + 1
 }
 
-// IsCurrentMonth checks if this config is for the current month
-func (dec *DailyExpenseConfig) IsCurrentMonth() bool {
-	currentMonth := time.Now().Format("2006-01")
-	return dec.Month == currentMonth
+// IsCurrentMonth checks if this config is for the month of now
+func (dec *DailyExpenseConfig) IsCurrentMonth(now time.Time) bool {
+	return dec.Month == GetCurrentMonth(now)
 }
 
-// GetCurrentMonth returns the current month in YYYY-MM format
-func GetCurrentMonth() string {
-	return time.Now().Format("2006-01")
+// GetCurrentMonth returns the month of now
+func GetCurrentMonth(now time.Time) civil.Month {
+	return civil.MonthOf(now)
 }
@@ -0,0 +1,74 @@
+package event
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+)
+
+// Ledger event names published by the use cases
+const (
+	ExpenseCreated   = "expense.created"
+	ExpenseUpdated   = "expense.updated"
+	ExpenseDeleted   = "expense.deleted"
+	FixedExpensePaid = "fixed_expense.paid"
+	BudgetExceeded   = "budget.exceeded"
+	AlertTriggered   = "alert.triggered"
+)
+
+// Names returns every supported event name
+func Names() []string {
+	return []string{
+		ExpenseCreated,
+		ExpenseUpdated,
+		ExpenseDeleted,
+		FixedExpensePaid,
+		BudgetExceeded,
+		AlertTriggered,
+	}
+}
+
+// IsKnown checks if the given name is a supported event
+func IsKnown(name string) bool {
+	for _, known := range Names() {
+		if known == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Event represents something that happened in the ledger
+type Event struct {
+	ID         string      `json:"id"`
+	Name       string      `json:"event"`
+	OccurredAt time.Time   `json:"occurred_at"`
+	Data       interface{} `json:"data"`
+}
+
+// New creates a new event with a random identifier that occurred at occurredAt
+func New(name string, data interface{}, occurredAt time.Time) Event {
+	return Event{
+		ID:         newID(),
+		Name:       name,
+		OccurredAt: occurredAt,
+		Data:       data,
+	}
+}
+
+// BudgetExceededData is the payload of the budget.exceeded event
+type BudgetExceededData struct {
+	Month      string  `json:"month"`
+	Budget     float64 `json:"budget"`
+	Spent      float64 `json:"spent"`
+	ExceededBy float64 `json:"exceeded_by"`
+}
+
+// newID generates a random 128-bit hexadecimal identifier
+func newID() string {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return time.Now().Format("20060102150405.000000000")
+	}
+	return hex.EncodeToString(buf)
+}
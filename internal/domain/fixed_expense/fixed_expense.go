@@ -1,7 +1,8 @@
 package fixed_expense
 
 import (
-	"errors"
+	"expenses-api/internal/domain/apperror"
+	"expenses-api/internal/domain/civil"
 	"strings"
 	"time"
 
@@ -11,17 +12,23 @@ import (
 // FixedExpense represents monthly fixed expenses
 // Maps to frontend interface: FixedExpense { id?, pocket_name, concept_name, amount, payment_day, is_paid, month, paid_date?, created_at? }
 type FixedExpense struct {
-	ID          uint    `gorm:"primaryKey" json:"id"`
-	PocketID    uint    `gorm:"not null;index:idx_pocket_month,priority:1" json:"pocket_id"`
-	ConceptName string  `gorm:"size:255;not null" json:"concept_name"`
-	Amount      float64 `gorm:"type:decimal(15,2);not null" json:"amount"`
-	PaymentDay  int     `gorm:"not null;check:payment_day >= 1 AND payment_day <= 31" json:"payment_day"`
-	IsPaid      bool    `gorm:"default:false;index" json:"is_paid"`
-	Month       string  `gorm:"size:7;not null;index:idx_pocket_month,priority:2" json:"month"` // Format: "2024-01"
-	PaidDate    *string `gorm:"size:10" json:"paid_date"`                                       // Format: "2024-01-15"
+	ID          uint        `gorm:"primaryKey" json:"id"`
+	PocketID    uint        `gorm:"not null;index:idx_pocket_month,priority:1" json:"pocket_id"`
+	ConceptName string      `gorm:"size:255;not null" json:"concept_name"`
+	Amount      float64     `gorm:"type:decimal(15,2);not null" json:"amount"`
+	PaymentDay  int         `gorm:"not null;check:payment_day >= 1 AND payment_day <= 31" json:"payment_day"`
+	IsPaid      bool        `gorm:"default:false;index" json:"is_paid"`
+	Month       civil.Month `gorm:"not null;index:idx_pocket_month,priority:2" json:"month"` // Format: "2024-01"
+	PaidDate    *civil.Date `json:"paid_date"`                                               // Format: "2024-01-15"
+	Version     uint        `gorm:"not null;default:1" json:"version"`                       // Incremented on every update for optimistic locking
+
+	// ActualAmount is the amount really billed when it differs from the planned Amount
+	ActualAmount *float64 `gorm:"type:decimal(15,2)" json:"actual_amount"`
 
 	// Relationship - will be loaded when needed
-	Pocket *Pocket `gorm:"foreignKey:PocketID" json:"pocket,omitempty"`
+	Pocket   *Pocket   `gorm:"foreignKey:PocketID" json:"pocket,omitempty"`
+	Payments []Payment `gorm:"foreignKey:FixedExpenseID" json:"payments,omitempty"`
+	Tags     []Tag     `gorm:"many2many:fixed_expense_tags" json:"tags,omitempty"`
 }
 
 // Pocket represents the relationship to avoid circular imports
@@ -30,6 +37,12 @@ type Pocket struct {
 	Name string `json:"name"`
 }
 
+// Tag represents the relationship to avoid circular imports
+type Tag struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+}
+
 // TableName specifies the table name for GORM
 func (FixedExpense) TableName() string {
 	return "fixed_expenses"
@@ -37,6 +50,9 @@ func (FixedExpense) TableName() string {
 
 // BeforeCreate hook to validate data before creation
 func (fe *FixedExpense) BeforeCreate(tx *gorm.DB) error {
+	// New records start at version 1
+	fe.Version = 1
+
 	return fe.validate()
 }
 
@@ -50,40 +66,45 @@ func (fe *FixedExpense) validate() error {
 	// Clean and validate concept name
 	fe.ConceptName = strings.TrimSpace(fe.ConceptName)
 	if fe.ConceptName == "" {
-		return errors.New("concept name cannot be empty")
+		return apperror.Invalid("concept_name", "concept name cannot be empty")
 	}
 
 	if len(fe.ConceptName) > 255 {
-		return errors.New("concept name cannot exceed 255 characters")
+		return apperror.Invalid("concept_name", "concept name cannot exceed 255 characters")
 	}
 
 	// Validate amount
 	if fe.Amount < 0 {
-		return errors.New("amount cannot be negative")
+		return apperror.Invalid("amount", "amount cannot be negative")
 	}
 
 	// Validate payment day
 	if fe.PaymentDay < 1 || fe.PaymentDay > 31 {
-		return errors.New("payment day must be between 1 and 31")
+		return apperror.Invalid("payment_day", "payment day must be between 1 and 31")
 	}
 
-	// Validate month format (YYYY-MM)
-	if len(fe.Month) != 7 {
-		return errors.New("month must be in YYYY-MM format")
+	// Validate month is set
+	if fe.Month.IsZero() {
+		return apperror.Invalid("month", "month must be in YYYY-MM format")
 	}
 
-	// Validate paid date format if provided
-	if fe.PaidDate != nil && len(*fe.PaidDate) != 10 {
-		return errors.New("paid date must be in YYYY-MM-DD format")
+	// Validate actual amount if provided
+	if fe.ActualAmount != nil && *fe.ActualAmount < 0 {
+		return apperror.Invalid("actual_amount", "actual amount cannot be negative")
+	}
+
+	// Validate paid date is set if provided
+	if fe.PaidDate != nil && fe.PaidDate.IsZero() {
+		return apperror.Invalid("paid_date", "paid date must be in YYYY-MM-DD format")
 	}
 
 	return nil
 }
 
-// MarkAsPaid marks the expense as paid with the current date
-func (fe *FixedExpense) MarkAsPaid() {
+// MarkAsPaid marks the expense as paid on the date of now
+func (fe *FixedExpense) MarkAsPaid(now time.Time) {
 	fe.IsPaid = true
-	currentDate := time.Now().Format("2006-01-02")
+	currentDate := civil.DateOf(now)
 	fe.PaidDate = &currentDate
 }
 
@@ -93,22 +114,59 @@ func (fe *FixedExpense) MarkAsUnpaid() {
 	fe.PaidDate = nil
 }
 
-// GetStatus returns the current status of the fixed expense
-func (fe *FixedExpense) GetStatus() string {
+// GetDueAmount returns the amount that must be paid: the actual billed amount
+// when it was recorded, otherwise the planned amount
+func (fe *FixedExpense) GetDueAmount() float64 {
+	if fe.ActualAmount != nil {
+		return *fe.ActualAmount
+	}
+	return fe.Amount
+}
+
+// GetActualSpent returns how much was really spent on the expense so far
+// Expenses marked as paid before payments were tracked count their due amount
+func (fe *FixedExpense) GetActualSpent() float64 {
+	if len(fe.Payments) == 0 && fe.IsPaid {
+		return fe.GetDueAmount()
+	}
+	return fe.GetPaidAmount()
+}
+
+// GetStatus returns the status of the fixed expense as of now
+// now should be in the business timezone so the day boundary is the expected one
+func (fe *FixedExpense) GetStatus(now time.Time) string {
 	if fe.IsPaid {
 		return "paid"
 	}
 
 	// Check if overdue (only for current month)
-	currentMonth := time.Now().Format("2006-01")
-	if fe.Month == currentMonth && time.Now().Day() > fe.PaymentDay {
+	if fe.Month == GetCurrentMonth(now) && now.Day() > fe.PaymentDay {
 		return "overdue"
 	}
 
 	return "pending"
 }
 
-// GetCurrentMonth returns the current month in YYYY-MM format
-func GetCurrentMonth() string {
-	return time.Now().Format("2006-01")
+// GetCurrentMonth returns the month of now
+func GetCurrentMonth(now time.Time) civil.Month {
+	return civil.MonthOf(now)
+}
+
+// GetTagNames returns the names of the associated tags, if loaded
+func (fe *FixedExpense) GetTagNames() []string {
+	names := make([]string, 0, len(fe.Tags))
+	for _, tag := range fe.Tags {
+		names = append(names, tag.Name)
+	}
+	return names
+}
+
+// HasTag checks if the expense carries the given tag, if loaded
+func (fe *FixedExpense) HasTag(name string) bool {
+	for _, tag := range fe.Tags {
+		if tag.Name == name {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,151 @@
+package fixed_expense
+
+import (
+	"expenses-api/internal/domain/apperror"
+	"expenses-api/internal/domain/civil"
+	"expenses-api/internal/domain/money"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Payment statuses derived from the recorded payments
+const (
+	PaymentStatusUnpaid   = "unpaid"
+	PaymentStatusPartial  = "partial"
+	PaymentStatusPaid     = "paid"
+	PaymentStatusOverpaid = "overpaid"
+)
+
+// Payment represents a payment recorded against a fixed expense
+// A fixed expense may be paid in several parts
+// Maps to frontend interface: FixedExpensePayment { id?, amount, paid_date, method?, note?, created_at? }
+type Payment struct {
+	ID             uint       `gorm:"primaryKey" json:"id"`
+	FixedExpenseID uint       `gorm:"not null;index" json:"fixed_expense_id"`
+	Amount         float64    `gorm:"type:decimal(15,2);not null" json:"amount"`
+	PaidDate       civil.Date `gorm:"not null" json:"paid_date"` // Format: "2024-01-15"
+	Method         string     `gorm:"size:50" json:"method"`     // e.g. "cash", "transfer", "card"
+	Note           string     `gorm:"size:500" json:"note"`
+	CreatedAt      time.Time  `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (Payment) TableName() string {
+	return "fixed_expense_payments"
+}
+
+// BeforeCreate hook to validate data before creation
+func (p *Payment) BeforeCreate(tx *gorm.DB) error {
+	return p.validate()
+}
+
+// BeforeUpdate hook to validate data before update
+func (p *Payment) BeforeUpdate(tx *gorm.DB) error {
+	return p.validate()
+}
+
+// validate performs validation and data cleaning
+func (p *Payment) validate() error {
+	if p.FixedExpenseID == 0 {
+		return apperror.Validation("fixed expense is required")
+	}
+
+	if p.Amount <= 0 {
+		return apperror.Invalid("amount", "payment amount must be greater than zero")
+	}
+
+	if p.PaidDate.IsZero() {
+		return apperror.Invalid("paid_date", "paid date must be in YYYY-MM-DD format")
+	}
+
+	p.Method = strings.ToLower(strings.TrimSpace(p.Method))
+	if len(p.Method) > 50 {
+		return apperror.Invalid("method", "payment method cannot exceed 50 characters")
+	}
+
+	p.Note = strings.TrimSpace(p.Note)
+	if len(p.Note) > 500 {
+		return apperror.Invalid("note", "payment note cannot exceed 500 characters")
+	}
+
+	return nil
+}
+
+// GetPaidAmount returns the sum of the recorded payments
+func (fe *FixedExpense) GetPaidAmount() float64 {
+	total := 0.0
+	for _, payment := range fe.Payments {
+		total += payment.Amount
+	}
+	return total
+}
+
+// GetRemainingAmount returns how much is still owed, never negative
+func (fe *FixedExpense) GetRemainingAmount() float64 {
+	if len(fe.Payments) == 0 && fe.IsPaid {
+		return 0
+	}
+
+	remaining := fe.GetDueAmount() - fe.GetPaidAmount()
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+// GetPaymentStatus derives unpaid, partial, paid or overpaid from the recorded payments
+// Expenses marked as paid before payments were tracked have no payments and are reported as paid
+func (fe *FixedExpense) GetPaymentStatus() string {
+	if len(fe.Payments) == 0 {
+		if fe.IsPaid {
+			return PaymentStatusPaid
+		}
+		return PaymentStatusUnpaid
+	}
+
+	return paymentStatus(fe.GetPaidAmount(), fe.GetDueAmount())
+}
+
+// GetLastPaymentDate returns the most recent payment date, or nil without payments
+func (fe *FixedExpense) GetLastPaymentDate() *civil.Date {
+	var last *civil.Date
+	for i := range fe.Payments {
+		if last == nil || fe.Payments[i].PaidDate.After(*last) {
+			date := fe.Payments[i].PaidDate
+			last = &date
+		}
+	}
+	return last
+}
+
+// SyncPaymentStatus updates IsPaid and PaidDate from the recorded payments
+// The expense counts as paid once the payments cover its due amount; PaidDate is the last payment
+func (fe *FixedExpense) SyncPaymentStatus() {
+	status := paymentStatus(fe.GetPaidAmount(), fe.GetDueAmount())
+	if len(fe.Payments) > 0 && (status == PaymentStatusPaid || status == PaymentStatusOverpaid) {
+		fe.IsPaid = true
+		fe.PaidDate = fe.GetLastPaymentDate()
+		return
+	}
+
+	fe.MarkAsUnpaid()
+}
+
+// paymentStatus compares the paid amount with the expected amount, rounding to cents
+func paymentStatus(paid, amount float64) string {
+	paidCents := money.ToCents(paid)
+	amountCents := money.ToCents(amount)
+
+	switch {
+	case paidCents <= 0:
+		return PaymentStatusUnpaid
+	case paidCents < amountCents:
+		return PaymentStatusPartial
+	case paidCents == amountCents:
+		return PaymentStatusPaid
+	default:
+		return PaymentStatusOverpaid
+	}
+}
@@ -0,0 +1,53 @@
+package household
+
+import (
+	"expenses-api/internal/domain/apperror"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Member represents a person sharing expenses in the household (e.g. a roommate)
+// Maps to frontend interface: HouseholdMember { id?, name, email?, is_active, created_at? }
+type Member struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	Name      string    `gorm:"size:100;not null;uniqueIndex" json:"name"`
+	Email     string    `gorm:"size:255" json:"email"`
+	IsActive  bool      `gorm:"default:true" json:"is_active"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (Member) TableName() string {
+	return "household_members"
+}
+
+// BeforeCreate hook to validate data before creation
+func (m *Member) BeforeCreate(tx *gorm.DB) error {
+	return m.validate()
+}
+
+// BeforeUpdate hook to validate data before update
+func (m *Member) BeforeUpdate(tx *gorm.DB) error {
+	return m.validate()
+}
+
+// validate performs validation and data cleaning
+func (m *Member) validate() error {
+	m.Name = strings.TrimSpace(m.Name)
+	if m.Name == "" {
+		return apperror.Invalid("name", "member name cannot be empty")
+	}
+
+	if len(m.Name) > 100 {
+		return apperror.Invalid("name", "member name cannot exceed 100 characters")
+	}
+
+	m.Email = strings.TrimSpace(m.Email)
+	if m.Email != "" && !strings.Contains(m.Email, "@") {
+		return apperror.Invalid("email", "member email is not valid")
+	}
+
+	return nil
+}
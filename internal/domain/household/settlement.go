@@ -0,0 +1,92 @@
+package household
+
+import (
+	"expenses-api/internal/domain/money"
+	"sort"
+)
+
+// Balance is what a member paid for shared expenses against what they owe
+// A positive net means the member is owed money; a negative net means they owe
+type Balance struct {
+	MemberID   uint
+	MemberName string
+	Paid       float64
+	Owed       float64
+}
+
+// GetNet returns paid minus owed
+func (b *Balance) GetNet() float64 {
+	return b.Paid - b.Owed
+}
+
+// Settlement is a transfer that moves money from a debtor to a creditor
+type Settlement struct {
+	FromMemberID uint
+	FromName     string
+	ToMemberID   uint
+	ToName       string
+	Amount       float64
+}
+
+// Settle computes the transfers that leave every balance at zero
+// It repeatedly matches the largest debtor with the largest creditor, which
+// needs at most one transfer fewer than the members with a non-zero balance
+func Settle(balances []Balance) []Settlement {
+	type position struct {
+		memberID uint
+		name     string
+		cents    int64
+	}
+
+	var debtors, creditors []position
+	for _, b := range balances {
+		net := money.ToCents(b.GetNet())
+		switch {
+		case net < 0:
+			debtors = append(debtors, position{b.MemberID, b.MemberName, -net})
+		case net > 0:
+			creditors = append(creditors, position{b.MemberID, b.MemberName, net})
+		}
+	}
+
+	// Largest amounts first; ties keep member order so the result is stable
+	byAmount := func(p []position) func(i, j int) bool {
+		return func(i, j int) bool {
+			if p[i].cents != p[j].cents {
+				return p[i].cents > p[j].cents
+			}
+			return p[i].memberID < p[j].memberID
+		}
+	}
+
+	settlements := make([]Settlement, 0)
+	for len(debtors) > 0 && len(creditors) > 0 {
+		sort.SliceStable(debtors, byAmount(debtors))
+		sort.SliceStable(creditors, byAmount(creditors))
+
+		debtor, creditor := &debtors[0], &creditors[0]
+		amount := debtor.cents
+		if creditor.cents < amount {
+			amount = creditor.cents
+		}
+
+		settlements = append(settlements, Settlement{
+			FromMemberID: debtor.memberID,
+			FromName:     debtor.name,
+			ToMemberID:   creditor.memberID,
+			ToName:       creditor.name,
+			Amount:       float64(amount) / 100,
+		})
+
+		debtor.cents -= amount
+		creditor.cents -= amount
+		if debtor.cents == 0 {
+			debtors = debtors[1:]
+		}
+		if creditor.cents == 0 {
+			creditors = creditors[1:]
+		}
+	}
+
+	return settlements
+}
@@ -0,0 +1,108 @@
+package household_test
+
+import (
+	"expenses-api/internal/domain/household"
+	"reflect"
+	"testing"
+)
+
+func TestSettle(t *testing.T) {
+	tests := []struct {
+		name     string
+		balances []household.Balance
+		want     []household.Settlement
+	}{
+		{
+			name:     "no balances",
+			balances: nil,
+			want:     []household.Settlement{},
+		},
+		{
+			name: "everyone is even",
+			balances: []household.Balance{
+				{MemberID: 1, MemberName: "Ana", Paid: 50, Owed: 50},
+				{MemberID: 2, MemberName: "Luis", Paid: 0, Owed: 0},
+			},
+			want: []household.Settlement{},
+		},
+		{
+			name: "two members",
+			balances: []household.Balance{
+				{MemberID: 1, MemberName: "Ana", Paid: 100, Owed: 50},
+				{MemberID: 2, MemberName: "Luis", Paid: 0, Owed: 50},
+			},
+			want: []household.Settlement{
+				{FromMemberID: 2, FromName: "Luis", ToMemberID: 1, ToName: "Ana", Amount: 50},
+			},
+		},
+		{
+			name: "uneven three-way split paid by one member",
+			balances: []household.Balance{
+				{MemberID: 1, MemberName: "Ana", Paid: 100, Owed: 33.34},
+				{MemberID: 2, MemberName: "Luis", Paid: 0, Owed: 33.33},
+				{MemberID: 3, MemberName: "Sara", Paid: 0, Owed: 33.33},
+			},
+			want: []household.Settlement{
+				{FromMemberID: 2, FromName: "Luis", ToMemberID: 1, ToName: "Ana", Amount: 33.33},
+				{FromMemberID: 3, FromName: "Sara", ToMemberID: 1, ToName: "Ana", Amount: 33.33},
+			},
+		},
+		{
+			name: "largest debtor pays the largest creditor first",
+			balances: []household.Balance{
+				{MemberID: 1, MemberName: "Ana", Paid: 90, Owed: 30},
+				{MemberID: 2, MemberName: "Luis", Paid: 0, Owed: 30},
+				{MemberID: 3, MemberName: "Sara", Paid: 10, Owed: 30},
+				{MemberID: 4, MemberName: "Pedro", Paid: 0, Owed: 10},
+			},
+			want: []household.Settlement{
+				{FromMemberID: 2, FromName: "Luis", ToMemberID: 1, ToName: "Ana", Amount: 30},
+				{FromMemberID: 3, FromName: "Sara", ToMemberID: 1, ToName: "Ana", Amount: 20},
+				{FromMemberID: 4, FromName: "Pedro", ToMemberID: 1, ToName: "Ana", Amount: 10},
+			},
+		},
+		{
+			name: "one debtor splits the payment between creditors",
+			balances: []household.Balance{
+				{MemberID: 1, MemberName: "Ana", Paid: 0, Owed: 50.5},
+				{MemberID: 2, MemberName: "Luis", Paid: 30.25, Owed: 0},
+				{MemberID: 3, MemberName: "Sara", Paid: 20.25, Owed: 0},
+			},
+			want: []household.Settlement{
+				{FromMemberID: 1, FromName: "Ana", ToMemberID: 2, ToName: "Luis", Amount: 30.25},
+				{FromMemberID: 1, FromName: "Ana", ToMemberID: 3, ToName: "Sara", Amount: 20.25},
+			},
+		},
+		{
+			name: "ties go to the lower member ID",
+			balances: []household.Balance{
+				{MemberID: 3, MemberName: "Sara", Paid: 25, Owed: 0},
+				{MemberID: 1, MemberName: "Ana", Paid: 0, Owed: 50},
+				{MemberID: 2, MemberName: "Luis", Paid: 25, Owed: 0},
+			},
+			want: []household.Settlement{
+				{FromMemberID: 1, FromName: "Ana", ToMemberID: 2, ToName: "Luis", Amount: 25},
+				{FromMemberID: 1, FromName: "Ana", ToMemberID: 3, ToName: "Sara", Amount: 25},
+			},
+		},
+		{
+			name: "floating point noise is rounded to cents",
+			balances: []household.Balance{
+				{MemberID: 1, MemberName: "Ana", Paid: 0.1 + 0.2, Owed: 0},
+				{MemberID: 2, MemberName: "Luis", Paid: 0, Owed: 0.3},
+			},
+			want: []household.Settlement{
+				{FromMemberID: 2, FromName: "Luis", ToMemberID: 1, ToName: "Ana", Amount: 0.3},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := household.Settle(tt.balances)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Settle() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
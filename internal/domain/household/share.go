@@ -0,0 +1,221 @@
+package household
+
+import (
+	"expenses-api/internal/domain/apperror"
+	"expenses-api/internal/domain/civil"
+	"expenses-api/internal/domain/money"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Expense types a share can belong to
+const (
+	ExpenseTypeDaily = "daily"
+	ExpenseTypeFixed = "fixed"
+)
+
+// Split methods of a shared expense
+const (
+	MethodEqual      = "equal"      // Every participant owes the same
+	MethodPercentage = "percentage" // Each participant owes a percentage; they add up to 100
+	MethodExact      = "exact"      // Each participant owes a fixed amount; they add up to the expense
+)
+
+// Share records who paid a daily or fixed expense and how it is split between members
+// Maps to frontend interface: ExpenseShare { id?, expense_type, expense_id, payer_id, method, parts, created_at? }
+type Share struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	ExpenseType string    `gorm:"size:10;not null;uniqueIndex:idx_share_expense,priority:1" json:"expense_type"`
+	ExpenseID   uint      `gorm:"not null;uniqueIndex:idx_share_expense,priority:2" json:"expense_id"`
+	PayerID     uint      `gorm:"not null;index" json:"payer_id"`
+	Method      string    `gorm:"size:20;not null" json:"method"`
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
+
+	// Expense details filled by the use case, not persisted
+	ExpenseDescription string      `gorm:"-" json:"expense_description"`
+	ExpenseAmount      float64     `gorm:"-" json:"expense_amount"`
+	ExpenseMonth       civil.Month `gorm:"-" json:"expense_month"` // Format: "2024-01"
+
+	// Relationships - will be loaded when needed
+	Payer *Member     `gorm:"foreignKey:PayerID" json:"payer,omitempty"`
+	Parts []SharePart `gorm:"foreignKey:ShareID" json:"parts,omitempty"`
+}
+
+// SharePart is a participant of a shared expense
+// Percentage is used by the percentage method and Amount by the exact method
+type SharePart struct {
+	ID         uint     `gorm:"primaryKey" json:"id"`
+	ShareID    uint     `gorm:"not null;index" json:"share_id"`
+	MemberID   uint     `gorm:"not null;index" json:"member_id"`
+	Percentage *float64 `gorm:"type:decimal(5,2)" json:"percentage"`
+	Amount     *float64 `gorm:"type:decimal(15,2)" json:"amount"`
+
+	// Relationship - will be loaded when needed
+	Member *Member `gorm:"foreignKey:MemberID" json:"member,omitempty"`
+}
+
+// TableName specifies the table name for GORM
+func (Share) TableName() string {
+	return "expense_shares"
+}
+
+// TableName specifies the table name for GORM
+func (SharePart) TableName() string {
+	return "expense_share_parts"
+}
+
+// BeforeCreate hook to validate data before creation
+func (s *Share) BeforeCreate(tx *gorm.DB) error {
+	return s.validate()
+}
+
+// BeforeUpdate hook to validate data before update
+func (s *Share) BeforeUpdate(tx *gorm.DB) error {
+	return s.validate()
+}
+
+// validate performs validation of the share header; parts are checked by Validate
+func (s *Share) validate() error {
+	if !IsValidExpenseType(s.ExpenseType) {
+		return apperror.Invalid("expense_type", "expense type must be daily or fixed")
+	}
+
+	if s.ExpenseID == 0 {
+		return apperror.Invalid("expense_id", "expense ID is required")
+	}
+
+	if s.PayerID == 0 {
+		return apperror.Invalid("payer_id", "payer is required")
+	}
+
+	if !IsValidMethod(s.Method) {
+		return apperror.Invalid("method", "split method must be equal, percentage or exact")
+	}
+
+	return nil
+}
+
+// IsValidExpenseType checks if the expense type is supported
+func IsValidExpenseType(expenseType string) bool {
+	return expenseType == ExpenseTypeDaily || expenseType == ExpenseTypeFixed
+}
+
+// IsValidMethod checks if the split method is supported
+func IsValidMethod(method string) bool {
+	return method == MethodEqual || method == MethodPercentage || method == MethodExact
+}
+
+// Validate checks the parts of the share against the expense amount
+func (s *Share) Validate(expenseAmount float64) error {
+	if err := s.validate(); err != nil {
+		return err
+	}
+
+	if len(s.Parts) == 0 {
+		return apperror.Invalid("participants", "a shared expense needs at least one participant")
+	}
+
+	seen := make(map[uint]bool, len(s.Parts))
+	var totalPercentage, totalAmount int64
+	for _, part := range s.Parts {
+		if part.MemberID == 0 {
+			return apperror.Invalid("participants", "participant member is required")
+		}
+		if seen[part.MemberID] {
+			return apperror.Invalid("participants", "a member can only participate once")
+		}
+		seen[part.MemberID] = true
+
+		switch s.Method {
+		case MethodPercentage:
+			if part.Percentage == nil || *part.Percentage <= 0 {
+				return apperror.Invalid("participants", "each participant needs a percentage greater than zero")
+			}
+			totalPercentage += money.ToCents(*part.Percentage)
+		case MethodExact:
+			if part.Amount == nil || *part.Amount <= 0 {
+				return apperror.Invalid("participants", "each participant needs an amount greater than zero")
+			}
+			totalAmount += money.ToCents(*part.Amount)
+		}
+	}
+
+	if s.Method == MethodPercentage && totalPercentage != 100*100 {
+		return apperror.Invalid("participants", "participant percentages must add up to 100")
+	}
+
+	if s.Method == MethodExact && totalAmount != money.ToCents(expenseAmount) {
+		return apperror.Invalid("participants", "participant amounts must add up to the expense amount")
+	}
+
+	return nil
+}
+
+// GetOwedAmounts returns how much each participant owes of the expense amount
+// Equal and percentage splits are rounded to cents and the leftover cents go to
+// the first participants, so the parts always add up to the expense. Exact splits
+// that no longer add up (the expense was edited afterwards) assign the difference to the payer
+func (s *Share) GetOwedAmounts(expenseAmount float64) map[uint]float64 {
+	owed := make(map[uint]float64, len(s.Parts)+1)
+	if len(s.Parts) == 0 {
+		return owed
+	}
+
+	total := money.ToCents(expenseAmount)
+	cents := make([]int64, len(s.Parts))
+	var assigned int64
+
+	for i, part := range s.Parts {
+		switch s.Method {
+		case MethodEqual:
+			cents[i] = total / int64(len(s.Parts))
+		case MethodPercentage:
+			if part.Percentage != nil {
+				cents[i] = int64(float64(total) * *part.Percentage / 100)
+			}
+		case MethodExact:
+			if part.Amount != nil {
+				cents[i] = money.ToCents(*part.Amount)
+			}
+		}
+		assigned += cents[i]
+	}
+
+	if s.Method == MethodExact {
+		for i, part := range s.Parts {
+			owed[part.MemberID] += float64(cents[i]) / 100
+		}
+		if diff := total - assigned; diff != 0 {
+			owed[s.PayerID] += float64(diff) / 100
+		}
+		return owed
+	}
+
+	// Hand out the rounding leftover one cent at a time
+	for i := 0; assigned < total; i = (i + 1) % len(cents) {
+		cents[i]++
+		assigned++
+	}
+
+	for i, part := range s.Parts {
+		owed[part.MemberID] += float64(cents[i]) / 100
+	}
+	return owed
+}
+
+// GetPayerName returns the payer's name or empty string if not loaded
+func (s *Share) GetPayerName() string {
+	if s.Payer != nil {
+		return s.Payer.Name
+	}
+	return ""
+}
+
+// GetMemberName returns the participant's name or empty string if not loaded
+func (p *SharePart) GetMemberName() string {
+	if p.Member != nil {
+		return p.Member.Name
+	}
+	return ""
+}
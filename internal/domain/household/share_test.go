@@ -0,0 +1,166 @@
+package household_test
+
+import (
+	"expenses-api/internal/domain/apperror"
+	"expenses-api/internal/domain/household"
+	"reflect"
+	"testing"
+)
+
+func float(v float64) *float64 {
+	return &v
+}
+
+func TestShareGetOwedAmounts(t *testing.T) {
+	tests := []struct {
+		name   string
+		share  household.Share
+		amount float64
+		want   map[uint]float64
+	}{
+		{
+			name:   "no participants",
+			share:  household.Share{PayerID: 1, Method: household.MethodEqual},
+			amount: 100,
+			want:   map[uint]float64{},
+		},
+		{
+			name: "equal split in two",
+			share: household.Share{PayerID: 1, Method: household.MethodEqual, Parts: []household.SharePart{
+				{MemberID: 1}, {MemberID: 2},
+			}},
+			amount: 100,
+			want:   map[uint]float64{1: 50, 2: 50},
+		},
+		{
+			name: "uneven equal split gives the leftover cent to the first participant",
+			share: household.Share{PayerID: 2, Method: household.MethodEqual, Parts: []household.SharePart{
+				{MemberID: 1}, {MemberID: 2}, {MemberID: 3},
+			}},
+			amount: 100,
+			want:   map[uint]float64{1: 33.34, 2: 33.33, 3: 33.33},
+		},
+		{
+			name: "two leftover cents go to the first two participants",
+			share: household.Share{PayerID: 1, Method: household.MethodEqual, Parts: []household.SharePart{
+				{MemberID: 3}, {MemberID: 1}, {MemberID: 2},
+			}},
+			amount: 100.01,
+			want:   map[uint]float64{3: 33.34, 1: 33.34, 2: 33.33},
+		},
+		{
+			name: "payer outside the split owes nothing",
+			share: household.Share{PayerID: 9, Method: household.MethodEqual, Parts: []household.SharePart{
+				{MemberID: 1}, {MemberID: 2},
+			}},
+			amount: 0.03,
+			want:   map[uint]float64{1: 0.02, 2: 0.01},
+		},
+		{
+			name: "percentage split",
+			share: household.Share{PayerID: 1, Method: household.MethodPercentage, Parts: []household.SharePart{
+				{MemberID: 1, Percentage: float(60)}, {MemberID: 2, Percentage: float(40)},
+			}},
+			amount: 250000,
+			want:   map[uint]float64{1: 150000, 2: 100000},
+		},
+		{
+			name: "uneven percentage split adds up to the expense",
+			share: household.Share{PayerID: 1, Method: household.MethodPercentage, Parts: []household.SharePart{
+				{MemberID: 1, Percentage: float(33.33)}, {MemberID: 2, Percentage: float(33.33)}, {MemberID: 3, Percentage: float(33.34)},
+			}},
+			amount: 10,
+			want:   map[uint]float64{1: 3.34, 2: 3.33, 3: 3.33},
+		},
+		{
+			name: "exact split",
+			share: household.Share{PayerID: 1, Method: household.MethodExact, Parts: []household.SharePart{
+				{MemberID: 1, Amount: float(70.5)}, {MemberID: 2, Amount: float(29.5)},
+			}},
+			amount: 100,
+			want:   map[uint]float64{1: 70.5, 2: 29.5},
+		},
+		{
+			name: "exact split gives a raised amount to the payer",
+			share: household.Share{PayerID: 1, Method: household.MethodExact, Parts: []household.SharePart{
+				{MemberID: 1, Amount: float(50)}, {MemberID: 2, Amount: float(50)},
+			}},
+			amount: 120,
+			want:   map[uint]float64{1: 70, 2: 50},
+		},
+		{
+			name: "exact split takes a lowered amount from a payer outside the split",
+			share: household.Share{PayerID: 3, Method: household.MethodExact, Parts: []household.SharePart{
+				{MemberID: 1, Amount: float(50)}, {MemberID: 2, Amount: float(50)},
+			}},
+			amount: 90,
+			want:   map[uint]float64{1: 50, 2: 50, 3: -10},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.share.GetOwedAmounts(tt.amount)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetOwedAmounts(%v) = %v, want %v", tt.amount, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestShareValidate(t *testing.T) {
+	valid := func() household.Share {
+		return household.Share{
+			ExpenseType: household.ExpenseTypeDaily,
+			ExpenseID:   7,
+			PayerID:     1,
+			Method:      household.MethodPercentage,
+			Parts: []household.SharePart{
+				{MemberID: 1, Percentage: float(33.33)}, {MemberID: 2, Percentage: float(33.33)}, {MemberID: 3, Percentage: float(33.34)},
+			},
+		}
+	}
+
+	share := valid()
+	if err := share.Validate(100); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		change func(s *household.Share)
+		field  string
+	}{
+		{name: "unknown expense type", change: func(s *household.Share) { s.ExpenseType = "other" }, field: "expense_type"},
+		{name: "missing expense", change: func(s *household.Share) { s.ExpenseID = 0 }, field: "expense_id"},
+		{name: "missing payer", change: func(s *household.Share) { s.PayerID = 0 }, field: "payer_id"},
+		{name: "unknown method", change: func(s *household.Share) { s.Method = "random" }, field: "method"},
+		{name: "no participants", change: func(s *household.Share) { s.Parts = nil }, field: "participants"},
+		{name: "repeated participant", change: func(s *household.Share) { s.Parts[1].MemberID = 1 }, field: "participants"},
+		{name: "percentages below 100", change: func(s *household.Share) { s.Parts[2].Percentage = float(33.33) }, field: "participants"},
+		{
+			name: "exact amounts that do not add up",
+			change: func(s *household.Share) {
+				s.Method = household.MethodExact
+				s.Parts = []household.SharePart{{MemberID: 1, Amount: float(50)}, {MemberID: 2, Amount: float(49.99)}}
+			},
+			field: "participants",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			share := valid()
+			tt.change(&share)
+
+			err := share.Validate(100)
+			if code := apperror.CodeOf(err); code != apperror.CodeValidation {
+				t.Fatalf("Validate() error = %v, want a validation error", err)
+			}
+			fields := apperror.FieldsOf(err)
+			if len(fields) != 1 || fields[0].Field != tt.field {
+				t.Errorf("Validate() fields = %+v, want %s", fields, tt.field)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package idempotency
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// MaxKeyLength is the longest Idempotency-Key accepted
+const MaxKeyLength = 255
+
+// Record keeps the first response to a POST sent with an Idempotency-Key header,
+// so that retries of the same request replay it instead of repeating the change
+type Record struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	Key          string    `gorm:"column:idempotency_key;size:255;not null;uniqueIndex" json:"key"`
+	Fingerprint  string    `gorm:"size:64;not null" json:"fingerprint"`   // SHA-256 of the method, path and body
+	StatusCode   int       `gorm:"not null;default:0" json:"status_code"` // 0 while the first request is in progress
+	ContentType  string    `gorm:"size:255" json:"content_type"`
+	ResponseBody string    `gorm:"type:text" json:"response_body"`
+	CreatedAt    time.Time `gorm:"autoCreateTime" json:"created_at"`
+	ExpiresAt    time.Time `gorm:"not null;index" json:"expires_at"`
+}
+
+// TableName specifies the table name for GORM
+func (Record) TableName() string {
+	return "idempotency_keys"
+}
+
+// IsCompleted reports whether the first request finished and its response was stored
+func (r *Record) IsCompleted() bool {
+	return r.StatusCode != 0
+}
+
+// IsExpired reports whether the key can no longer be replayed at now
+func (r *Record) IsExpired(now time.Time) bool {
+	return !now.Before(r.ExpiresAt)
+}
+
+// Fingerprint identifies a request by its method, path and body, so that a key
+// reused for a different request can be told apart from a retry
+func Fingerprint(method, path string, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(method + " " + path + "\n"))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
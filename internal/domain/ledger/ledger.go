@@ -0,0 +1,142 @@
+package ledger
+
+import (
+	"encoding/json"
+	"expenses-api/internal/domain/civil"
+	"expenses-api/internal/domain/daily_expense"
+	"expenses-api/internal/domain/daily_expense_config"
+	"expenses-api/internal/domain/fixed_expense"
+	"expenses-api/internal/domain/pocket"
+	"expenses-api/internal/domain/pocket_allocation"
+	"expenses-api/internal/domain/receivable"
+	"expenses-api/internal/domain/salary"
+	"expenses-api/internal/domain/transfer"
+	"sort"
+	"time"
+)
+
+// Kinds of change, used as the suffix of the event names
+const (
+	Created = "created"
+	Updated = "updated"
+	Deleted = "deleted"
+)
+
+// Event is one change of a ledger entity in the append-only event log
+// Data holds the whole entity after the change as JSON keyed by column name,
+// which the entities also use as their JSON names
+type Event struct {
+	ID         uint        `gorm:"primaryKey" json:"id"`                                                     // Replay order
+	Name       string      `gorm:"size:100;not null" json:"name"`                                            // e.g. "daily_expense.created"
+	Entity     string      `gorm:"size:64;not null;index:idx_ledger_events_entity,priority:1" json:"entity"` // Table name, e.g. "daily_expenses"
+	EntityID   uint        `gorm:"not null;index:idx_ledger_events_entity,priority:2" json:"entity_id"`
+	Data       *string     `gorm:"type:text" json:"data"`                      // Nil when the entity was deleted
+	Month      civil.Month `gorm:"index:idx_ledger_events_month" json:"month"` // Month of the entity after the change, for entities kept by month
+	OccurredAt time.Time   `gorm:"not null;index" json:"occurred_at"`
+}
+
+// TableName specifies the table name for GORM
+func (Event) TableName() string {
+	return "ledger_events"
+}
+
+// Filter selects events in replay order; zero fields do not filter
+type Filter struct {
+	Entity   string
+	Entities []string    // Only events of these tables
+	Month    civil.Month // Only the entities kept by month that were in it at some point; others are kept
+	AfterID  uint        // Only events after this one
+	Until    time.Time   // Inclusive
+	Limit    int
+}
+
+// stream describes the events of one ledger entity
+type stream struct {
+	name       string             // Prefix of the event names
+	newModel   func() interface{} // Pointer to a new entity, to decode its events
+	monthField string             // Column holding the month or date the entity belongs to, if any
+}
+
+// streams lists the entities whose changes are recorded, by table name:
+// the expenses and the configuration the monthly summary is built from
+var streams = map[string]stream{
+	salary.Salary{}.TableName():                           {"salary", func() interface{} { return &salary.Salary{} }, "month"},
+	daily_expense_config.DailyExpenseConfig{}.TableName(): {"daily_budget", func() interface{} { return &daily_expense_config.DailyExpenseConfig{} }, "month"},
+	pocket.Pocket{}.TableName():                           {"pocket", func() interface{} { return &pocket.Pocket{} }, ""},
+	pocket_allocation.PocketAllocation{}.TableName():      {"pocket_allocation", func() interface{} { return &pocket_allocation.PocketAllocation{} }, ""},
+	fixed_expense.FixedExpense{}.TableName():              {"fixed_expense", func() interface{} { return &fixed_expense.FixedExpense{} }, "month"},
+	fixed_expense.Payment{}.TableName():                   {"fixed_expense_payment", func() interface{} { return &fixed_expense.Payment{} }, ""},
+	daily_expense.DailyExpense{}.TableName():              {"daily_expense", func() interface{} { return &daily_expense.DailyExpense{} }, "date"},
+	daily_expense.Split{}.TableName():                     {"daily_expense_split", func() interface{} { return &daily_expense.Split{} }, ""},
+	transfer.Transfer{}.TableName():                       {"transfer", func() interface{} { return &transfer.Transfer{} }, "date"},
+	receivable.Receivable{}.TableName():                   {"receivable", func() interface{} { return &receivable.Receivable{} }, ""},
+	receivable.Reimbursement{}.TableName():                {"reimbursement", func() interface{} { return &receivable.Reimbursement{} }, ""},
+}
+
+// Entities returns the table names of every entity recorded in the event log, sorted
+func Entities() []string {
+	entities := make([]string, 0, len(streams))
+	for entity := range streams {
+		entities = append(entities, entity)
+	}
+	sort.Strings(entities)
+	return entities
+}
+
+// IsTracked reports whether changes of the table are recorded in the event log
+func IsTracked(entity string) bool {
+	_, ok := streams[entity]
+	return ok
+}
+
+// EventName returns the name of a change of the given kind to an entity of the table,
+// e.g. "daily_expense.created"
+func EventName(entity, kind string) string {
+	return streams[entity].name + "." + kind
+}
+
+// NewModel returns a pointer to a new entity of the table, or nil if it is not tracked
+func NewModel(entity string) interface{} {
+	s, ok := streams[entity]
+	if !ok {
+		return nil
+	}
+	return s.newModel()
+}
+
+// MonthKeyed returns the table names of the entities kept by month, sorted
+func MonthKeyed() []string {
+	var entities []string
+	for entity, s := range streams {
+		if s.monthField != "" {
+			entities = append(entities, entity)
+		}
+	}
+	sort.Strings(entities)
+	return entities
+}
+
+// MonthOf returns the month an entity of the table belongs to given its
+// state, or the zero month when it is not kept by month or was deleted
+func MonthOf(entity string, data *string) civil.Month {
+	field := streams[entity].monthField
+	if field == "" || data == nil {
+		return civil.Month{}
+	}
+
+	var columns map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(*data), &columns); err != nil {
+		return civil.Month{}
+	}
+
+	// A month column holds "YYYY-MM" and a date column "YYYY-MM-DD"
+	var value string
+	if err := json.Unmarshal(columns[field], &value); err != nil || len(value) < len(civil.MonthLayout) {
+		return civil.Month{}
+	}
+	month, err := civil.ParseMonth(value[:len(civil.MonthLayout)])
+	if err != nil {
+		return civil.Month{}
+	}
+	return month
+}
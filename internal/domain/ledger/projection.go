@@ -0,0 +1,314 @@
+package ledger
+
+import (
+	"encoding/json"
+	"expenses-api/internal/domain/daily_expense"
+	"expenses-api/internal/domain/daily_expense_config"
+	"expenses-api/internal/domain/fixed_expense"
+	"expenses-api/internal/domain/pocket"
+	"expenses-api/internal/domain/receivable"
+	"expenses-api/internal/domain/salary"
+	"expenses-api/internal/domain/transfer"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Kinds of discrepancy between the projection and the current tables
+const (
+	DiscrepancyMismatch   = "mismatch"    // The entity differs from its last event
+	DiscrepancyMissingRow = "missing_row" // The events have an entity the table lacks
+	DiscrepancyUntracked  = "untracked"   // The table has a row no event recorded
+)
+
+// Projection is the state of the ledger entities rebuilt by replaying the
+// event log in order; it holds the JSON of every entity by table and ID
+type Projection struct {
+	states      map[string]map[uint]string
+	events      int
+	lastEventID uint
+}
+
+// NewProjection creates an empty projection, the ledger before any event
+func NewProjection() *Projection {
+	return &Projection{states: make(map[string]map[uint]string)}
+}
+
+// Replay builds the projection of the given events, in replay order
+func Replay(events []Event) *Projection {
+	projection := NewProjection()
+	for _, event := range events {
+		projection.Apply(event)
+	}
+	return projection
+}
+
+// Apply replays one event: the entity takes the state in the event, or is removed when deleted
+func (p *Projection) Apply(event Event) {
+	states, ok := p.states[event.Entity]
+	if !ok {
+		states = make(map[uint]string)
+		p.states[event.Entity] = states
+	}
+
+	if event.Data == nil {
+		delete(states, event.EntityID)
+	} else {
+		states[event.EntityID] = *event.Data
+	}
+
+	p.events++
+	p.lastEventID = event.ID
+}
+
+// Events returns the number of events replayed
+func (p *Projection) Events() int {
+	return p.events
+}
+
+// LastEventID returns the ID of the last event replayed, 0 when none was
+func (p *Projection) LastEventID() uint {
+	return p.lastEventID
+}
+
+// Salaries returns the projected income configurations
+func (p *Projection) Salaries() ([]salary.Salary, error) {
+	var salaries []salary.Salary
+	err := p.decode(salary.Salary{}.TableName(), func(data []byte) error {
+		var s salary.Salary
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		salaries = append(salaries, s)
+		return nil
+	})
+	return salaries, err
+}
+
+// DailyExpenseConfigs returns the projected daily budget configurations
+func (p *Projection) DailyExpenseConfigs() ([]daily_expense_config.DailyExpenseConfig, error) {
+	var configs []daily_expense_config.DailyExpenseConfig
+	err := p.decode(daily_expense_config.DailyExpenseConfig{}.TableName(), func(data []byte) error {
+		var config daily_expense_config.DailyExpenseConfig
+		if err := json.Unmarshal(data, &config); err != nil {
+			return err
+		}
+		configs = append(configs, config)
+		return nil
+	})
+	return configs, err
+}
+
+// Pockets returns the projected pockets
+func (p *Projection) Pockets() ([]pocket.Pocket, error) {
+	var pockets []pocket.Pocket
+	err := p.decode(pocket.Pocket{}.TableName(), func(data []byte) error {
+		var po pocket.Pocket
+		if err := json.Unmarshal(data, &po); err != nil {
+			return err
+		}
+		pockets = append(pockets, po)
+		return nil
+	})
+	return pockets, err
+}
+
+// FixedExpenses returns the projected fixed expenses, without their pockets and payments
+func (p *Projection) FixedExpenses() ([]fixed_expense.FixedExpense, error) {
+	var expenses []fixed_expense.FixedExpense
+	err := p.decode(fixed_expense.FixedExpense{}.TableName(), func(data []byte) error {
+		var expense fixed_expense.FixedExpense
+		if err := json.Unmarshal(data, &expense); err != nil {
+			return err
+		}
+		expenses = append(expenses, expense)
+		return nil
+	})
+	return expenses, err
+}
+
+// FixedExpensePayments returns the projected payments of fixed expenses
+func (p *Projection) FixedExpensePayments() ([]fixed_expense.Payment, error) {
+	var payments []fixed_expense.Payment
+	err := p.decode(fixed_expense.Payment{}.TableName(), func(data []byte) error {
+		var payment fixed_expense.Payment
+		if err := json.Unmarshal(data, &payment); err != nil {
+			return err
+		}
+		payments = append(payments, payment)
+		return nil
+	})
+	return payments, err
+}
+
+// DailyExpenses returns the projected daily expenses, without their pockets, tags and splits
+func (p *Projection) DailyExpenses() ([]daily_expense.DailyExpense, error) {
+	var expenses []daily_expense.DailyExpense
+	err := p.decode(daily_expense.DailyExpense{}.TableName(), func(data []byte) error {
+		var expense daily_expense.DailyExpense
+		if err := json.Unmarshal(data, &expense); err != nil {
+			return err
+		}
+		expenses = append(expenses, expense)
+		return nil
+	})
+	return expenses, err
+}
+
+// Transfers returns the projected transfers, without their pockets
+func (p *Projection) Transfers() ([]transfer.Transfer, error) {
+	var transfers []transfer.Transfer
+	err := p.decode(transfer.Transfer{}.TableName(), func(data []byte) error {
+		var t transfer.Transfer
+		if err := json.Unmarshal(data, &t); err != nil {
+			return err
+		}
+		transfers = append(transfers, t)
+		return nil
+	})
+	return transfers, err
+}
+
+// Receivables returns the projected receivables, without their reimbursements
+func (p *Projection) Receivables() ([]receivable.Receivable, error) {
+	var receivables []receivable.Receivable
+	err := p.decode(receivable.Receivable{}.TableName(), func(data []byte) error {
+		var r receivable.Receivable
+		if err := json.Unmarshal(data, &r); err != nil {
+			return err
+		}
+		receivables = append(receivables, r)
+		return nil
+	})
+	return receivables, err
+}
+
+// Reimbursements returns the projected reimbursements of receivables
+func (p *Projection) Reimbursements() ([]receivable.Reimbursement, error) {
+	var reimbursements []receivable.Reimbursement
+	err := p.decode(receivable.Reimbursement{}.TableName(), func(data []byte) error {
+		var r receivable.Reimbursement
+		if err := json.Unmarshal(data, &r); err != nil {
+			return err
+		}
+		reimbursements = append(reimbursements, r)
+		return nil
+	})
+	return reimbursements, err
+}
+
+// decode calls fn with the JSON of every projected entity of the table, in ID order
+func (p *Projection) decode(entity string, fn func(data []byte) error) error {
+	states := p.states[entity]
+	for _, id := range sortedIDs(states) {
+		if err := fn([]byte(states[id])); err != nil {
+			return fmt.Errorf("decoding %s %d: %w", entity, id, err)
+		}
+	}
+	return nil
+}
+
+// Discrepancy is an entity whose projected state differs from its current row
+type Discrepancy struct {
+	Entity    string
+	EntityID  uint
+	Kind      string
+	Projected *string // Nil when no event recorded the entity
+	Current   *string // Nil when the table lacks the row
+}
+
+// ConsistencyReport is the result of comparing the projection with the current tables
+type ConsistencyReport struct {
+	CheckedAt     time.Time
+	Events        int
+	LastEventID   uint
+	Entities      int // Entities compared, projected or current
+	Discrepancies []Discrepancy
+}
+
+// IsConsistent reports whether every entity matches its projection
+func (r *ConsistencyReport) IsConsistent() bool {
+	return len(r.Discrepancies) == 0
+}
+
+// Compare checks the projected entities of a table against its current rows,
+// given as JSON by ID. Both sides are decoded into the entity before comparing,
+// so only the stored columns count and the key order does not matter
+// It returns the number of entities compared and the ones that differ
+func (p *Projection) Compare(entity string, current map[uint]string) (int, []Discrepancy, error) {
+	projected := p.states[entity]
+
+	ids := sortedIDs(projected)
+	for id := range current {
+		if _, ok := projected[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	var discrepancies []Discrepancy
+	for _, id := range ids {
+		projectedState, inProjection := projected[id]
+		currentState, inTable := current[id]
+
+		discrepancy := Discrepancy{Entity: entity, EntityID: id}
+		switch {
+		case !inTable:
+			discrepancy.Kind = DiscrepancyMissingRow
+			discrepancy.Projected = &projectedState
+		case !inProjection:
+			discrepancy.Kind = DiscrepancyUntracked
+			discrepancy.Current = &currentState
+		default:
+			equal, err := sameEntity(entity, projectedState, currentState)
+			if err != nil {
+				return 0, nil, fmt.Errorf("comparing %s %d: %w", entity, id, err)
+			}
+			if equal {
+				continue
+			}
+			discrepancy.Kind = DiscrepancyMismatch
+			discrepancy.Projected = &projectedState
+			discrepancy.Current = &currentState
+		}
+		discrepancies = append(discrepancies, discrepancy)
+	}
+
+	return len(ids), discrepancies, nil
+}
+
+// sameEntity reports whether two JSON states describe the same entity of the table
+func sameEntity(entity, a, b string) (bool, error) {
+	normalizedA, err := normalize(entity, a)
+	if err != nil {
+		return false, err
+	}
+	normalizedB, err := normalize(entity, b)
+	if err != nil {
+		return false, err
+	}
+	return normalizedA == normalizedB, nil
+}
+
+// normalize decodes a JSON state into its entity and encodes it again
+func normalize(entity, state string) (string, error) {
+	model := NewModel(entity)
+	if model == nil {
+		return state, nil
+	}
+	if err := json.Unmarshal([]byte(state), model); err != nil {
+		return "", err
+	}
+	data, err := json.Marshal(model)
+	return string(data), err
+}
+
+// sortedIDs returns the IDs of the states in ascending order
+func sortedIDs(states map[uint]string) []uint {
+	ids := make([]uint, 0, len(states))
+	for id := range states {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
@@ -0,0 +1,12 @@
+// Package money holds the helpers shared by the domain to compare and add
+// amounts of money, which are stored as decimals with two places
+package money
+
+// ToCents converts an amount to integer cents, rounding half away from zero,
+// to compare and add money without float errors
+func ToCents(amount float64) int64 {
+	if amount < 0 {
+		return int64(amount*100 - 0.5)
+	}
+	return int64(amount*100 + 0.5)
+}
@@ -1,18 +1,27 @@
 package pocket
 
 import (
-	"errors"
+	"expenses-api/internal/domain/apperror"
 	"strings"
 
 	"gorm.io/gorm"
 )
 
+// Rollover policies for unspent envelope money at the end of a month
+const (
+	RolloverPolicyRollover = "rollover" // Unspent money rolls into next month
+	RolloverPolicySweep    = "sweep"    // Unspent money is swept to savings
+)
+
 // Pocket represents organizational categories for expenses
-// Maps to frontend interface: Pocket { id?, name, description?, created_at? }
+// Each pocket works as an envelope with a monthly allocation
+// Maps to frontend interface: Pocket { id?, name, description?, rollover_policy?, created_at? }
 type Pocket struct {
-	ID          uint   `gorm:"primaryKey" json:"id"`
-	Name        string `gorm:"size:255;not null;uniqueIndex" json:"name"`
-	Description string `gorm:"type:text" json:"description"`
+	ID             uint   `gorm:"primaryKey" json:"id"`
+	Name           string `gorm:"size:255;not null;uniqueIndex" json:"name"`
+	Description    string `gorm:"type:text" json:"description"`
+	RolloverPolicy string `gorm:"size:20;not null;default:rollover" json:"rollover_policy"`
+	Version        uint   `gorm:"not null;default:1" json:"version"` // Incremented on every update for optimistic locking
 }
 
 // TableName specifies the table name for GORM
@@ -22,6 +31,9 @@ func (Pocket) TableName() string {
 
 // BeforeCreate hook to validate and clean data before creation
 func (p *Pocket) BeforeCreate(tx *gorm.DB) error {
+	// New records start at version 1
+	p.Version = 1
+
 	return p.validate()
 }
 
@@ -35,19 +47,37 @@ func (p *Pocket) validate() error {
 	// Clean and validate name
 	p.Name = strings.TrimSpace(p.Name)
 	if p.Name == "" {
-		return errors.New("pocket name cannot be empty")
+		return apperror.Invalid("name", "pocket name cannot be empty")
 	}
 
 	if len(p.Name) > 255 {
-		return errors.New("pocket name cannot exceed 255 characters")
+		return apperror.Invalid("name", "pocket name cannot exceed 255 characters")
 	}
 
 	// Clean description
 	p.Description = strings.TrimSpace(p.Description)
 
+	// Validate rollover policy, defaulting to rollover
+	if p.RolloverPolicy == "" {
+		p.RolloverPolicy = RolloverPolicyRollover
+	}
+	if !IsValidRolloverPolicy(p.RolloverPolicy) {
+		return apperror.Invalid("rollover_policy", "rollover policy must be 'rollover' or 'sweep'")
+	}
+
 	return nil
 }
 
+// IsValidRolloverPolicy checks if the given rollover policy is supported
+func IsValidRolloverPolicy(policy string) bool {
+	return policy == RolloverPolicyRollover || policy == RolloverPolicySweep
+}
+
+// SweepsToSavings checks if unspent money is swept to savings at month end
+func (p *Pocket) SweepsToSavings() bool {
+	return p.RolloverPolicy == RolloverPolicySweep
+}
+
 // IsEmpty checks if the pocket has no associated expenses
 func (p *Pocket) IsEmpty(db *gorm.DB) bool {
 	var count int64
@@ -0,0 +1,51 @@
+package pocket_allocation
+
+import (
+	"expenses-api/internal/domain/apperror"
+	"expenses-api/internal/domain/civil"
+
+	"gorm.io/gorm"
+)
+
+// PocketAllocation represents the money assigned to a pocket envelope for a month
+// Maps to frontend interface: PocketAllocation { id?, pocket_id, month, amount }
+type PocketAllocation struct {
+	ID       uint        `gorm:"primaryKey" json:"id"`
+	PocketID uint        `gorm:"not null;uniqueIndex:idx_pocket_allocation_month,priority:1" json:"pocket_id"`
+	Month    civil.Month `gorm:"not null;uniqueIndex:idx_pocket_allocation_month,priority:2;index" json:"month"` // Format: "2024-01"
+	Amount   float64     `gorm:"type:decimal(15,2);not null" json:"amount"`
+}
+
+// TableName specifies the table name for GORM
+func (PocketAllocation) TableName() string {
+	return "pocket_allocations"
+}
+
+// BeforeCreate hook to validate data before creation
+func (pa *PocketAllocation) BeforeCreate(tx *gorm.DB) error {
+	return pa.validate()
+}
+
+// BeforeUpdate hook to validate data before update
+func (pa *PocketAllocation) BeforeUpdate(tx *gorm.DB) error {
+	return pa.validate()
+}
+
+// validate performs validation
+func (pa *PocketAllocation) validate() error {
+	if pa.PocketID == 0 {
+		return apperror.Invalid("pocket_id", "pocket ID is required")
+	}
+
+	// Validate amount is not negative
+	if pa.Amount < 0 {
+		return apperror.Invalid("amount", "allocation amount cannot be negative")
+	}
+
+	// Validate month is set
+	if pa.Month.IsZero() {
+		return apperror.Invalid("month", "month must be in YYYY-MM format")
+	}
+
+	return nil
+}
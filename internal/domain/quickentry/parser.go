@@ -0,0 +1,215 @@
+package quickentry
+
+import (
+	"expenses-api/internal/domain/apperror"
+	"expenses-api/internal/domain/civil"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Entry is a daily expense written as free text, e.g. "taxi 18.500 ayer @transporte #trabajo"
+type Entry struct {
+	Description string
+	Amount      float64
+	Date        civil.Date
+	Pocket      string   // Pocket name written after @, empty when not given
+	Tags        []string // Tag names written after #
+}
+
+// amountPattern accepts Colombian amounts: "18500", "18.500", "1.200.000", "$25000",
+// "12,50" and the thousands suffixes "25k" and "25mil"
+var amountPattern = regexp.MustCompile(`^\$?(\d{1,3}(?:\.\d{3})+|\d{1,3}(?:,\d{3})+|\d+)(?:,(\d{1,2}))?(k|mil)?$`)
+
+// datePattern accepts day-first dates with an optional year: "15/01", "15-01-2026", "15/1/26"
+var datePattern = regexp.MustCompile(`^(\d{1,2})[/-](\d{1,2})(?:[/-](\d{2}|\d{4}))?$`)
+
+// weekdays maps Spanish day names to time.Weekday
+var weekdays = map[string]time.Weekday{
+	"domingo":   time.Sunday,
+	"lunes":     time.Monday,
+	"martes":    time.Tuesday,
+	"miercoles": time.Wednesday,
+	"jueves":    time.Thursday,
+	"viernes":   time.Friday,
+	"sabado":    time.Saturday,
+}
+
+// accentReplacer strips the Spanish accents so "miércoles" reads as "miercoles"
+var accentReplacer = strings.NewReplacer(
+	"á", "a", "é", "e", "í", "i", "ó", "o", "ú", "u", "ü", "u", "ñ", "n",
+)
+
+// Parse extracts a daily expense from free text
+// The last amount in the text is the expense amount; earlier numbers stay in the
+// description ("2 empanadas 5000"). Dates may be relative ("hoy", "ayer", "anteayer",
+// a weekday meaning its latest occurrence) or absolute ("2026-01-15", "15/01").
+// Without a date the expense is for today
+func Parse(text string, today time.Time) (*Entry, error) {
+	words := strings.Fields(text)
+	if len(words) == 0 {
+		return nil, apperror.Invalid("text", "text is required")
+	}
+
+	entry := &Entry{Date: civil.DateOf(today)}
+	amountIndex := -1
+	amountWords := 0
+	dateFound := false
+	consumed := make([]bool, len(words))
+
+	for i, word := range words {
+		switch {
+		case strings.HasPrefix(word, "#") && len(word) > 1:
+			entry.Tags = append(entry.Tags, word[1:])
+			consumed[i] = true
+
+		case strings.HasPrefix(word, "@") && len(word) > 1:
+			if entry.Pocket != "" {
+				return nil, apperror.Invalid("text", "only one pocket can be given")
+			}
+			entry.Pocket = strings.NewReplacer("_", " ", "-", " ").Replace(word[1:])
+			consumed[i] = true
+
+		default:
+			date, ok, err := parseDate(word, today)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				if dateFound {
+					return nil, apperror.Invalid("text", "only one date can be given")
+				}
+				entry.Date = date
+				dateFound = true
+				consumed[i] = true
+
+				// Drop the article in "almuerzo el lunes"
+				if i > 0 && !consumed[i-1] && isArticle(words[i-1]) {
+					consumed[i-1] = true
+				}
+				continue
+			}
+
+			if amount, ok := parseAmount(word); ok {
+				n := 1
+				// "25 mil" is written as two words
+				if i+1 < len(words) && fold(words[i+1]) == "mil" && !strings.HasSuffix(fold(word), "mil") && !strings.HasSuffix(fold(word), "k") {
+					amount *= 1000
+					n = 2
+				}
+				entry.Amount = amount
+				amountIndex = i
+				amountWords = n
+			}
+		}
+	}
+
+	if amountIndex < 0 {
+		return nil, apperror.Invalid("text", "amount not found in text")
+	}
+	for i := amountIndex; i < amountIndex+amountWords; i++ {
+		consumed[i] = true
+	}
+
+	description := make([]string, 0, len(words))
+	for i, word := range words {
+		if !consumed[i] {
+			description = append(description, word)
+		}
+	}
+
+	entry.Description = strings.Join(description, " ")
+	if entry.Description == "" {
+		return nil, apperror.Invalid("text", "description not found in text")
+	}
+
+	return entry, nil
+}
+
+// parseAmount reads a word as an amount, returning false when it is not one
+func parseAmount(word string) (float64, bool) {
+	match := amountPattern.FindStringSubmatch(strings.ToLower(word))
+	if match == nil {
+		return 0, false
+	}
+
+	digits := strings.NewReplacer(".", "", ",", "").Replace(match[1])
+	if match[2] != "" {
+		digits += "." + match[2]
+	}
+
+	amount, err := strconv.ParseFloat(digits, 64)
+	if err != nil || amount <= 0 {
+		return 0, false
+	}
+
+	if match[3] != "" {
+		amount *= 1000
+	}
+	return amount, true
+}
+
+// parseDate reads a word as a date relative to today, returning false when it is not one
+func parseDate(word string, today time.Time) (civil.Date, bool, error) {
+	today = time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, today.Location())
+
+	switch folded := fold(word); folded {
+	case "hoy":
+		return civil.DateOf(today), true, nil
+	case "ayer":
+		return civil.DateOf(today.AddDate(0, 0, -1)), true, nil
+	case "anteayer", "antier":
+		return civil.DateOf(today.AddDate(0, 0, -2)), true, nil
+	default:
+		if weekday, ok := weekdays[folded]; ok {
+			days := (int(today.Weekday()) - int(weekday) + 7) % 7
+			return civil.DateOf(today.AddDate(0, 0, -days)), true, nil
+		}
+	}
+
+	if date, err := civil.ParseDate(word); err == nil {
+		return date, true, nil
+	}
+
+	match := datePattern.FindStringSubmatch(word)
+	if match == nil {
+		return civil.Date{}, false, nil
+	}
+
+	day, _ := strconv.Atoi(match[1])
+	month, _ := strconv.Atoi(match[2])
+	year := today.Year()
+	if match[3] != "" {
+		year, _ = strconv.Atoi(match[3])
+		if year < 100 {
+			year += 2000
+		}
+	}
+
+	date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, today.Location())
+	if date.Day() != day || int(date.Month()) != month {
+		return civil.Date{}, false, apperror.Invalid("text", "invalid date: "+word)
+	}
+
+	// Without a year a date after today refers to last year
+	if match[3] == "" && date.After(today) {
+		date = date.AddDate(-1, 0, 0)
+	}
+
+	return civil.DateOf(date), true, nil
+}
+
+// isArticle reports whether a word is the article placed before a day name
+func isArticle(word string) bool {
+	switch fold(word) {
+	case "el", "del":
+		return true
+	}
+	return false
+}
+
+// fold lowercases a word and removes accents
+func fold(word string) string {
+	return accentReplacer.Replace(strings.ToLower(word))
+}
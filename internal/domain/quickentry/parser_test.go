@@ -0,0 +1,193 @@
+package quickentry_test
+
+import (
+	"expenses-api/internal/domain/apperror"
+	"expenses-api/internal/domain/civil"
+	"expenses-api/internal/domain/quickentry"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// today is a Wednesday in a non-leap year
+var today = time.Date(2026, time.March, 11, 15, 30, 0, 0, time.UTC)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want quickentry.Entry
+	}{
+		{
+			name: "plain amount",
+			text: "almuerzo 18500",
+			want: quickentry.Entry{Description: "almuerzo", Amount: 18500, Date: civil.MustParseDate("2026-03-11")},
+		},
+		{
+			name: "thousands with dots",
+			text: "taxi 18.500",
+			want: quickentry.Entry{Description: "taxi", Amount: 18500, Date: civil.MustParseDate("2026-03-11")},
+		},
+		{
+			name: "millions with dots",
+			text: "arriendo 1.200.000",
+			want: quickentry.Entry{Description: "arriendo", Amount: 1200000, Date: civil.MustParseDate("2026-03-11")},
+		},
+		{
+			name: "thousands with commas",
+			text: "arriendo 1,200,000",
+			want: quickentry.Entry{Description: "arriendo", Amount: 1200000, Date: civil.MustParseDate("2026-03-11")},
+		},
+		{
+			name: "decimal comma",
+			text: "café 12,50",
+			want: quickentry.Entry{Description: "café", Amount: 12.5, Date: civil.MustParseDate("2026-03-11")},
+		},
+		{
+			name: "dollar sign",
+			text: "libro $45000",
+			want: quickentry.Entry{Description: "libro", Amount: 45000, Date: civil.MustParseDate("2026-03-11")},
+		},
+		{
+			name: "k suffix",
+			text: "mercado 25k",
+			want: quickentry.Entry{Description: "mercado", Amount: 25000, Date: civil.MustParseDate("2026-03-11")},
+		},
+		{
+			name: "mil suffix",
+			text: "mercado 25mil",
+			want: quickentry.Entry{Description: "mercado", Amount: 25000, Date: civil.MustParseDate("2026-03-11")},
+		},
+		{
+			name: "mil as a separate word",
+			text: "mercado 25 mil",
+			want: quickentry.Entry{Description: "mercado", Amount: 25000, Date: civil.MustParseDate("2026-03-11")},
+		},
+		{
+			name: "quantity stays in the description",
+			text: "2 empanadas 5000",
+			want: quickentry.Entry{Description: "2 empanadas", Amount: 5000, Date: civil.MustParseDate("2026-03-11")},
+		},
+		{
+			name: "last amount wins",
+			text: "taxi 10.000 15.000",
+			want: quickentry.Entry{Description: "taxi 10.000", Amount: 15000, Date: civil.MustParseDate("2026-03-11")},
+		},
+		{
+			name: "pocket and tags",
+			text: "taxi 18.500 @transporte_publico #trabajo #viaje",
+			want: quickentry.Entry{
+				Description: "taxi",
+				Amount:      18500,
+				Date:        civil.MustParseDate("2026-03-11"),
+				Pocket:      "transporte publico",
+				Tags:        []string{"trabajo", "viaje"},
+			},
+		},
+		{
+			name: "hoy",
+			text: "almuerzo 20000 hoy",
+			want: quickentry.Entry{Description: "almuerzo", Amount: 20000, Date: civil.MustParseDate("2026-03-11")},
+		},
+		{
+			name: "ayer",
+			text: "taxi 18.500 ayer",
+			want: quickentry.Entry{Description: "taxi", Amount: 18500, Date: civil.MustParseDate("2026-03-10")},
+		},
+		{
+			name: "anteayer",
+			text: "cine 30000 anteayer",
+			want: quickentry.Entry{Description: "cine", Amount: 30000, Date: civil.MustParseDate("2026-03-09")},
+		},
+		{
+			name: "antier",
+			text: "cine 30000 antier",
+			want: quickentry.Entry{Description: "cine", Amount: 30000, Date: civil.MustParseDate("2026-03-09")},
+		},
+		{
+			name: "earlier weekday with article",
+			text: "almuerzo el lunes 20000",
+			want: quickentry.Entry{Description: "almuerzo", Amount: 20000, Date: civil.MustParseDate("2026-03-09")},
+		},
+		{
+			name: "later weekday means last week",
+			text: "almuerzo viernes 20000",
+			want: quickentry.Entry{Description: "almuerzo", Amount: 20000, Date: civil.MustParseDate("2026-03-06")},
+		},
+		{
+			name: "today's weekday with accent",
+			text: "almuerzo Miércoles 20000",
+			want: quickentry.Entry{Description: "almuerzo", Amount: 20000, Date: civil.MustParseDate("2026-03-11")},
+		},
+		{
+			name: "day and month",
+			text: "farmacia 15/02 32000",
+			want: quickentry.Entry{Description: "farmacia", Amount: 32000, Date: civil.MustParseDate("2026-02-15")},
+		},
+		{
+			name: "day and month after today means last year",
+			text: "regalo 24/12 80000",
+			want: quickentry.Entry{Description: "regalo", Amount: 80000, Date: civil.MustParseDate("2025-12-24")},
+		},
+		{
+			name: "day, month and short year",
+			text: "regalo 5/1/26 80000",
+			want: quickentry.Entry{Description: "regalo", Amount: 80000, Date: civil.MustParseDate("2026-01-05")},
+		},
+		{
+			name: "day, month and year with dashes",
+			text: "regalo 15-01-2026 80000",
+			want: quickentry.Entry{Description: "regalo", Amount: 80000, Date: civil.MustParseDate("2026-01-15")},
+		},
+		{
+			name: "leap day with its year",
+			text: "regalo 29/02/2024 80000",
+			want: quickentry.Entry{Description: "regalo", Amount: 80000, Date: civil.MustParseDate("2024-02-29")},
+		},
+		{
+			name: "ISO date",
+			text: "regalo 2026-01-15 80000",
+			want: quickentry.Entry{Description: "regalo", Amount: 80000, Date: civil.MustParseDate("2026-01-15")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := quickentry.Parse(tt.text, today)
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", tt.text, err)
+			}
+			if !reflect.DeepEqual(*got, tt.want) {
+				t.Errorf("Parse(%q) = %+v, want %+v", tt.text, *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRejectsInvalidText(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+	}{
+		{name: "empty", text: "   "},
+		{name: "no amount", text: "almuerzo ayer"},
+		{name: "no description", text: "18.500 ayer #trabajo"},
+		{name: "two pockets", text: "taxi 18.500 @transporte @trabajo"},
+		{name: "two dates", text: "taxi 18.500 ayer 05/03"},
+		{name: "leap day in a non-leap year", text: "regalo 29/02 80000"},
+		{name: "day out of month", text: "regalo 31/04 80000"},
+		{name: "month out of year", text: "regalo 10/13 80000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := quickentry.Parse(tt.text, today)
+			if err == nil {
+				t.Fatalf("Parse(%q) = %+v, want an error", tt.text, *got)
+			}
+			if code := apperror.CodeOf(err); code != apperror.CodeValidation {
+				t.Errorf("Parse(%q) error code = %s, want %s", tt.text, code, apperror.CodeValidation)
+			}
+		})
+	}
+}
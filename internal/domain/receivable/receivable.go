@@ -0,0 +1,261 @@
+package receivable
+
+import (
+	"expenses-api/internal/domain/apperror"
+	"expenses-api/internal/domain/civil"
+	"expenses-api/internal/domain/money"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Expense types a receivable can belong to
+const (
+	ExpenseTypeDaily = "daily"
+	ExpenseTypeFixed = "fixed"
+)
+
+// Receivable statuses derived from the recorded reimbursements
+const (
+	StatusOutstanding = "outstanding"
+	StatusPartial     = "partial"
+	StatusSettled     = "settled"
+)
+
+// Receivable flags an expense paid on behalf of someone else (a friend, an employer)
+// that will be paid back. Reimbursements received are recorded against it
+// Maps to frontend interface: Receivable { id?, expense_type, expense_id, counterparty, amount, note?, reimbursements?, created_at? }
+type Receivable struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	ExpenseType  string    `gorm:"size:10;not null;uniqueIndex:idx_receivable_expense,priority:1" json:"expense_type"`
+	ExpenseID    uint      `gorm:"not null;uniqueIndex:idx_receivable_expense,priority:2" json:"expense_id"`
+	Counterparty string    `gorm:"size:255;not null;index" json:"counterparty"`
+	Amount       float64   `gorm:"type:decimal(15,2);not null" json:"amount"` // Amount expected back; may be less than the expense
+	Note         string    `gorm:"size:500" json:"note"`
+	CreatedAt    time.Time `gorm:"autoCreateTime" json:"created_at"`
+
+	// Expense details filled by the use case, not persisted
+	ExpenseDescription string      `gorm:"-" json:"expense_description"`
+	ExpenseAmount      float64     `gorm:"-" json:"expense_amount"`
+	ExpenseMonth       civil.Month `gorm:"-" json:"expense_month"` // Format: "2024-01"
+
+	// Relationship - will be loaded when needed
+	Reimbursements []Reimbursement `gorm:"foreignKey:ReceivableID" json:"reimbursements,omitempty"`
+}
+
+// Reimbursement represents money received back for a receivable
+// Maps to frontend interface: Reimbursement { id?, amount, received_date, note?, created_at? }
+type Reimbursement struct {
+	ID           uint       `gorm:"primaryKey" json:"id"`
+	ReceivableID uint       `gorm:"not null;index" json:"receivable_id"`
+	Amount       float64    `gorm:"type:decimal(15,2);not null" json:"amount"`
+	ReceivedDate civil.Date `gorm:"not null" json:"received_date"` // Format: "2024-01-15"
+	Note         string     `gorm:"size:500" json:"note"`
+	CreatedAt    time.Time  `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (Receivable) TableName() string {
+	return "receivables"
+}
+
+// TableName specifies the table name for GORM
+func (Reimbursement) TableName() string {
+	return "reimbursements"
+}
+
+// BeforeCreate hook to validate data before creation
+func (r *Receivable) BeforeCreate(tx *gorm.DB) error {
+	return r.validate()
+}
+
+// BeforeUpdate hook to validate data before update
+func (r *Receivable) BeforeUpdate(tx *gorm.DB) error {
+	return r.validate()
+}
+
+// validate performs validation and data cleaning
+func (r *Receivable) validate() error {
+	if !IsValidExpenseType(r.ExpenseType) {
+		return apperror.Invalid("expense_type", "expense type must be daily or fixed")
+	}
+
+	if r.ExpenseID == 0 {
+		return apperror.Invalid("id", "expense ID is required")
+	}
+
+	r.Counterparty = strings.TrimSpace(r.Counterparty)
+	if r.Counterparty == "" {
+		return apperror.Invalid("counterparty", "counterparty cannot be empty")
+	}
+
+	if len(r.Counterparty) > 255 {
+		return apperror.Invalid("counterparty", "counterparty cannot exceed 255 characters")
+	}
+
+	if r.Amount <= 0 {
+		return apperror.Invalid("amount", "receivable amount must be greater than zero")
+	}
+
+	r.Note = strings.TrimSpace(r.Note)
+	if len(r.Note) > 500 {
+		return apperror.Invalid("note", "note cannot exceed 500 characters")
+	}
+
+	return nil
+}
+
+// BeforeCreate hook to validate data before creation
+func (r *Reimbursement) BeforeCreate(tx *gorm.DB) error {
+	return r.validate()
+}
+
+// BeforeUpdate hook to validate data before update
+func (r *Reimbursement) BeforeUpdate(tx *gorm.DB) error {
+	return r.validate()
+}
+
+// validate performs validation and data cleaning
+func (r *Reimbursement) validate() error {
+	if r.ReceivableID == 0 {
+		return apperror.Validation("receivable is required")
+	}
+
+	if r.Amount <= 0 {
+		return apperror.Invalid("amount", "reimbursement amount must be greater than zero")
+	}
+
+	if r.ReceivedDate.IsZero() {
+		return apperror.Invalid("received_date", "received date must be in YYYY-MM-DD format")
+	}
+
+	r.Note = strings.TrimSpace(r.Note)
+	if len(r.Note) > 500 {
+		return apperror.Invalid("note", "reimbursement note cannot exceed 500 characters")
+	}
+
+	return nil
+}
+
+// IsValidExpenseType checks if the expense type is supported
+func IsValidExpenseType(expenseType string) bool {
+	return expenseType == ExpenseTypeDaily || expenseType == ExpenseTypeFixed
+}
+
+// GetReimbursedAmount returns the sum of the reimbursements received
+func (r *Receivable) GetReimbursedAmount() float64 {
+	total := 0.0
+	for _, reimbursement := range r.Reimbursements {
+		total += reimbursement.Amount
+	}
+	return total
+}
+
+// GetOutstandingAmount returns how much is still owed, never negative
+func (r *Receivable) GetOutstandingAmount() float64 {
+	outstanding := r.Amount - r.GetReimbursedAmount()
+	if money.ToCents(outstanding) <= 0 {
+		return 0
+	}
+	return outstanding
+}
+
+// GetStatus derives outstanding, partial or settled from the reimbursements received
+func (r *Receivable) GetStatus() string {
+	reimbursed := money.ToCents(r.GetReimbursedAmount())
+	switch {
+	case reimbursed <= 0:
+		return StatusOutstanding
+	case reimbursed < money.ToCents(r.Amount):
+		return StatusPartial
+	default:
+		return StatusSettled
+	}
+}
+
+// CheckReimbursement verifies a new reimbursement does not exceed what is still outstanding
+func (r *Receivable) CheckReimbursement(amount float64) error {
+	if money.ToCents(amount) > money.ToCents(r.GetOutstandingAmount()) {
+		return apperror.Invalid("amount", "reimbursement exceeds the outstanding amount")
+	}
+	return nil
+}
+
+// ValidateAmount checks the amount expected back against the expense amount
+// and the reimbursements already received
+func ValidateAmount(amount, expenseAmount, reimbursed float64) error {
+	if amount <= 0 {
+		return apperror.Invalid("amount", "receivable amount must be greater than zero")
+	}
+
+	if money.ToCents(amount) > money.ToCents(expenseAmount) {
+		return apperror.Invalid("amount", "receivable amount cannot exceed the expense amount")
+	}
+
+	if money.ToCents(amount) < money.ToCents(reimbursed) {
+		return apperror.Invalid("amount", "receivable amount cannot be less than the amount already reimbursed")
+	}
+
+	return nil
+}
+
+// IsSettled checks if the receivable was fully paid back
+func (r *Receivable) IsSettled() bool {
+	return r.GetStatus() == StatusSettled
+}
+
+// GetLastReimbursementDate returns the most recent reimbursement date, or nil without reimbursements
+func (r *Receivable) GetLastReimbursementDate() *civil.Date {
+	var last *civil.Date
+	for i := range r.Reimbursements {
+		if last == nil || r.Reimbursements[i].ReceivedDate.After(*last) {
+			date := r.Reimbursements[i].ReceivedDate
+			last = &date
+		}
+	}
+	return last
+}
+
+// Balance groups what a counterparty still owes
+type Balance struct {
+	Counterparty string
+	Amount       float64
+	Reimbursed   float64
+	Outstanding  float64
+	Count        int
+}
+
+// GetBalances totals the receivables that are not settled per counterparty,
+// largest outstanding amount first. Counterparty names are matched case-insensitively
+func GetBalances(receivables []Receivable) []Balance {
+	balances := make([]Balance, 0)
+	index := make(map[string]int)
+
+	for i := range receivables {
+		r := &receivables[i]
+		if r.IsSettled() {
+			continue
+		}
+
+		key := strings.ToLower(r.Counterparty)
+		pos, ok := index[key]
+		if !ok {
+			pos = len(balances)
+			index[key] = pos
+			balances = append(balances, Balance{Counterparty: r.Counterparty})
+		}
+
+		balances[pos].Amount += r.Amount
+		balances[pos].Reimbursed += r.GetReimbursedAmount()
+		balances[pos].Outstanding += r.GetOutstandingAmount()
+		balances[pos].Count++
+	}
+
+	sort.SliceStable(balances, func(i, j int) bool {
+		return money.ToCents(balances[i].Outstanding) > money.ToCents(balances[j].Outstanding)
+	})
+
+	return balances
+}
@@ -0,0 +1,98 @@
+package reminder
+
+import (
+	"expenses-api/internal/domain/apperror"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Reminder kinds emitted for fixed expenses
+const (
+	KindDueSoon = "due_soon"
+	KindOverdue = "overdue"
+)
+
+// ReminderLog records that a reminder was already sent for a fixed expense
+// through one channel, so the scheduler never notifies the same bill twice
+// for the same reason on a channel that already delivered it
+type ReminderLog struct {
+	ID             uint      `gorm:"primaryKey" json:"id"`
+	FixedExpenseID uint      `gorm:"not null;uniqueIndex:idx_reminder_expense_kind_channel,priority:1" json:"fixed_expense_id"`
+	Kind           string    `gorm:"size:20;not null;uniqueIndex:idx_reminder_expense_kind_channel,priority:2" json:"kind"`    // "due_soon" or "overdue"
+	Channel        string    `gorm:"size:20;not null;uniqueIndex:idx_reminder_expense_kind_channel,priority:3" json:"channel"` // Name of the notifier, e.g. "smtp"
+	SentAt         time.Time `gorm:"autoCreateTime" json:"sent_at"`
+}
+
+// TableName specifies the table name for GORM
+func (ReminderLog) TableName() string {
+	return "reminder_logs"
+}
+
+// BeforeCreate hook to validate data before creation
+func (rl *ReminderLog) BeforeCreate(tx *gorm.DB) error {
+	if rl.FixedExpenseID == 0 {
+		return apperror.Invalid("fixed_expense_id", "fixed expense ID is required")
+	}
+	if rl.Kind != KindDueSoon && rl.Kind != KindOverdue {
+		return apperror.Validation("kind must be 'due_soon' or 'overdue'")
+	}
+	if rl.Channel == "" {
+		return apperror.Invalid("channel", "channel is required")
+	}
+	return nil
+}
+
+// Notification is the message delivered by notifiers about a fixed expense
+type Notification struct {
+	Kind           string  `json:"kind"`
+	Subject        string  `json:"subject"`
+	Message        string  `json:"message"`
+	FixedExpenseID uint    `json:"fixed_expense_id"`
+	ConceptName    string  `json:"concept_name"`
+	PocketName     string  `json:"pocket_name,omitempty"`
+	Amount         float64 `json:"amount"`
+	DueDate        string  `json:"due_date"` // Format: "2024-01-15"
+	DaysUntilDue   int     `json:"days_until_due"`
+}
+
+// NewDueSoonNotification builds the notification for a bill due in the next days
+func NewDueSoonNotification(fixedExpenseID uint, conceptName, pocketName string, amount float64, dueDate string, daysUntilDue int) Notification {
+	var when string
+	switch daysUntilDue {
+	case 0:
+		when = "hoy"
+	case 1:
+		when = "mañana"
+	default:
+		when = fmt.Sprintf("en %d días", daysUntilDue)
+	}
+
+	return Notification{
+		Kind:           KindDueSoon,
+		Subject:        fmt.Sprintf("Recordatorio: %s vence %s", conceptName, when),
+		Message:        fmt.Sprintf("El gasto fijo %s por $%.2f vence %s (%s).", conceptName, amount, when, dueDate),
+		FixedExpenseID: fixedExpenseID,
+		ConceptName:    conceptName,
+		PocketName:     pocketName,
+		Amount:         amount,
+		DueDate:        dueDate,
+		DaysUntilDue:   daysUntilDue,
+	}
+}
+
+// NewOverdueNotification builds the notification for an unpaid bill past its payment day
+func NewOverdueNotification(fixedExpenseID uint, conceptName, pocketName string, amount float64, dueDate string, daysUntilDue int) Notification {
+	return Notification{
+		Kind:           KindOverdue,
+		Subject:        fmt.Sprintf("Vencido: %s", conceptName),
+		Message:        fmt.Sprintf("El gasto fijo %s por $%.2f venció el %s y sigue sin pagar.", conceptName, amount, dueDate),
+		FixedExpenseID: fixedExpenseID,
+		ConceptName:    conceptName,
+		PocketName:     pocketName,
+		Amount:         amount,
+		DueDate:        dueDate,
+		DaysUntilDue:   daysUntilDue,
+	}
+}
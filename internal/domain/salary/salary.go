@@ -1,7 +1,8 @@
 package salary
 
 import (
-	"errors"
+	"expenses-api/internal/domain/apperror"
+	"expenses-api/internal/domain/civil"
 	"time"
 
 	"gorm.io/gorm"
@@ -10,9 +11,10 @@ import (
 // Salary represents monthly salary configuration
 // Maps to frontend interface: Salary { id?, monthly_amount, month, created_at? }
 type Salary struct {
-	ID            uint    `gorm:"primaryKey" json:"id"`
-	MonthlyAmount float64 `gorm:"type:decimal(15,2);not null" json:"monthly_amount"`
-	Month         string  `gorm:"size:7;not null;uniqueIndex" json:"month"` // Format: "2024-01"
+	ID            uint        `gorm:"primaryKey" json:"id"`
+	MonthlyAmount float64     `gorm:"type:decimal(15,2);not null" json:"monthly_amount"`
+	Month         civil.Month `gorm:"not null;uniqueIndex" json:"month"` // Format: "2024-01"
+	Version       uint        `gorm:"not null;default:1" json:"version"` // Incremented on every update for optimistic locking
 }
 
 // TableName specifies the table name for GORM
@@ -22,20 +24,23 @@ func (Salary) TableName() string {
 
 // BeforeCreate hook to validate data before creation
 func (s *Salary) BeforeCreate(tx *gorm.DB) error {
-	// Validate month format (YYYY-MM)
-	if len(s.Month) != 7 {
-		return errors.New("month must be in YYYY-MM format")
+	// New records start at version 1
+	s.Version = 1
+
+	// Validate month is set
+	if s.Month.IsZero() {
+		return apperror.Invalid("month", "month must be in YYYY-MM format")
 	}
 
 	// Validate monthly amount is not negative
 	if s.MonthlyAmount < 0 {
-		return errors.New("monthly amount cannot be negative")
+		return apperror.Invalid("monthly_amount", "monthly amount cannot be negative")
 	}
 
 	return nil
 }
 
-// GetCurrentMonth returns the current month in YYYY-MM format
-func GetCurrentMonth() string {
-	return time.Now().Format("2006-01")
+// GetCurrentMonth returns the month of now
+func GetCurrentMonth(now time.Time) civil.Month {
+	return civil.MonthOf(now)
 }
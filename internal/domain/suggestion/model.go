@@ -0,0 +1,183 @@
+package suggestion
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Example is a past daily expense description labeled with the pocket it was charged to
+// Weight is the share of the expense that went to the pocket (1 unless it was split)
+type Example struct {
+	Description string
+	PocketID    uint
+	Weight      float64
+}
+
+// Suggestion is a pocket proposed for a description with the model's confidence (0-1)
+type Suggestion struct {
+	PocketID   uint
+	PocketName string // Filled by the use case
+	Confidence float64
+}
+
+// Model is a multinomial naive Bayes classifier over description tokens
+// It is trained from scratch on every retrain and is safe for concurrent reads
+type Model struct {
+	TrainedAt time.Time
+	Examples  int
+
+	// Per pocket: weighted number of examples and token counts
+	docCounts   map[uint]float64
+	tokenCounts map[uint]map[string]float64
+	tokenTotals map[uint]float64
+	vocabulary  map[string]bool
+	totalDocs   float64
+}
+
+// Train builds a model from labeled examples
+// Examples without a pocket or without usable tokens are ignored
+func Train(examples []Example, trainedAt time.Time) *Model {
+	m := &Model{
+		TrainedAt:   trainedAt,
+		docCounts:   make(map[uint]float64),
+		tokenCounts: make(map[uint]map[string]float64),
+		tokenTotals: make(map[uint]float64),
+		vocabulary:  make(map[string]bool),
+	}
+
+	for _, example := range examples {
+		tokens := Tokenize(example.Description)
+		if example.PocketID == 0 || example.Weight <= 0 || len(tokens) == 0 {
+			continue
+		}
+
+		m.Examples++
+		m.totalDocs += example.Weight
+		m.docCounts[example.PocketID] += example.Weight
+
+		counts, ok := m.tokenCounts[example.PocketID]
+		if !ok {
+			counts = make(map[string]float64)
+			m.tokenCounts[example.PocketID] = counts
+		}
+
+		for _, token := range tokens {
+			counts[token] += example.Weight
+			m.tokenTotals[example.PocketID] += example.Weight
+			m.vocabulary[token] = true
+		}
+	}
+
+	return m
+}
+
+// IsEmpty checks if the model learned anything
+func (m *Model) IsEmpty() bool {
+	return m == nil || m.totalDocs == 0
+}
+
+// Pockets returns how many pockets the model can suggest
+func (m *Model) Pockets() int {
+	if m == nil {
+		return 0
+	}
+	return len(m.docCounts)
+}
+
+// Predict returns up to limit pockets for a description, most likely first
+// Descriptions with no known token return no suggestions, since the answer
+// would only reflect how often each pocket is used
+func (m *Model) Predict(description string, limit int) []Suggestion {
+	suggestions := make([]Suggestion, 0)
+	if m.IsEmpty() || limit <= 0 {
+		return suggestions
+	}
+
+	tokens := Tokenize(description)
+	known := false
+	for _, token := range tokens {
+		if m.vocabulary[token] {
+			known = true
+			break
+		}
+	}
+	if !known {
+		return suggestions
+	}
+
+	// Log posterior per pocket with Laplace smoothing
+	vocabularySize := float64(len(m.vocabulary))
+	scores := make(map[uint]float64, len(m.docCounts))
+	maxScore := math.Inf(-1)
+	for pocketID, docs := range m.docCounts {
+		score := math.Log(docs / m.totalDocs)
+		denominator := m.tokenTotals[pocketID] + vocabularySize
+		for _, token := range tokens {
+			score += math.Log((m.tokenCounts[pocketID][token] + 1) / denominator)
+		}
+		scores[pocketID] = score
+		if score > maxScore {
+			maxScore = score
+		}
+	}
+
+	// Normalize into probabilities (log-sum-exp)
+	sum := 0.0
+	for _, score := range scores {
+		sum += math.Exp(score - maxScore)
+	}
+	for pocketID, score := range scores {
+		suggestions = append(suggestions, Suggestion{
+			PocketID:   pocketID,
+			Confidence: math.Exp(score-maxScore) / sum,
+		})
+	}
+
+	sort.Slice(suggestions, func(i, j int) bool {
+		if suggestions[i].Confidence != suggestions[j].Confidence {
+			return suggestions[i].Confidence > suggestions[j].Confidence
+		}
+		return suggestions[i].PocketID < suggestions[j].PocketID
+	})
+
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+	return suggestions
+}
+
+// accentReplacer strips the Spanish accents so "Éxito" and "exito" are the same token
+var accentReplacer = strings.NewReplacer(
+	"á", "a", "é", "e", "í", "i", "ó", "o", "ú", "u", "ü", "u", "ñ", "n",
+)
+
+// Tokenize splits a description into lowercase, accent-free words
+// Numbers and one-letter words carry no meaning for the pocket and are dropped
+func Tokenize(description string) []string {
+	folded := accentReplacer.Replace(strings.ToLower(description))
+	words := strings.FieldsFunc(folded, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	tokens := make([]string, 0, len(words))
+	for _, word := range words {
+		if len(word) < 2 || isNumber(word) {
+			continue
+		}
+		tokens = append(tokens, word)
+	}
+	return tokens
+}
+
+// isNumber checks if a word only has digits
+func isNumber(word string) bool {
+	for _, r := range word {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,36 @@
+package tag
+
+// DailyExpenseTag links a daily expense to one of its tags
+// Links are written through this model rather than GORM's many2many
+// association so that the audit log records them
+type DailyExpenseTag struct {
+	DailyExpenseID uint `gorm:"primaryKey" json:"daily_expense_id"`
+	TagID          uint `gorm:"primaryKey" json:"tag_id"`
+}
+
+// TableName specifies the table name for GORM
+func (DailyExpenseTag) TableName() string {
+	return "daily_expense_tags"
+}
+
+// FixedExpenseTag links a fixed expense to one of its tags
+type FixedExpenseTag struct {
+	FixedExpenseID uint `gorm:"primaryKey" json:"fixed_expense_id"`
+	TagID          uint `gorm:"primaryKey" json:"tag_id"`
+}
+
+// TableName specifies the table name for GORM
+func (FixedExpenseTag) TableName() string {
+	return "fixed_expense_tags"
+}
+
+// CategorizationRuleTag links a categorization rule to a tag it assigns
+type CategorizationRuleTag struct {
+	RuleID uint `gorm:"primaryKey" json:"rule_id"`
+	TagID  uint `gorm:"primaryKey" json:"tag_id"`
+}
+
+// TableName specifies the table name for GORM
+func (CategorizationRuleTag) TableName() string {
+	return "categorization_rule_tags"
+}
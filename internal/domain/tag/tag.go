@@ -0,0 +1,106 @@
+package tag
+
+import (
+	"expenses-api/internal/domain/apperror"
+	"regexp"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// namePattern allows lowercase letters, digits and separators, e.g. "vacation-2026"
+var namePattern = regexp.MustCompile(`^[\p{Ll}\p{Nd}][\p{Ll}\p{Nd}_.-]*$`)
+
+// colorPattern allows hex colors like "#1e88e5"
+var colorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// Tag represents a cross-cutting label for daily and fixed expenses
+// Unlike pockets, an expense can carry any number of tags
+// Maps to frontend interface: Tag { id?, name, color?, created_at? }
+type Tag struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	Name      string    `gorm:"size:50;not null;uniqueIndex" json:"name"`
+	Color     string    `gorm:"size:7" json:"color"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (Tag) TableName() string {
+	return "tags"
+}
+
+// BeforeCreate hook to validate and clean data before creation
+func (t *Tag) BeforeCreate(tx *gorm.DB) error {
+	return t.Validate()
+}
+
+// BeforeUpdate hook to validate and clean data before update
+func (t *Tag) BeforeUpdate(tx *gorm.DB) error {
+	return t.Validate()
+}
+
+// Validate performs validation and data cleaning
+func (t *Tag) Validate() error {
+	t.Name = NormalizeName(t.Name)
+	if t.Name == "" {
+		return apperror.Invalid("name", "tag name cannot be empty")
+	}
+
+	if len(t.Name) > 50 {
+		return apperror.Invalid("name", "tag name cannot exceed 50 characters")
+	}
+
+	if !namePattern.MatchString(t.Name) {
+		return apperror.Invalid("name", "tag name may only contain letters, digits, '-', '_' and '.'")
+	}
+
+	t.Color = strings.TrimSpace(t.Color)
+	if t.Color != "" && !colorPattern.MatchString(t.Color) {
+		return apperror.Invalid("color", "tag color must be a hex color like #1e88e5")
+	}
+
+	return nil
+}
+
+// NormalizeName lowercases a tag name and joins its words with dashes,
+// so "Vacation 2026" and "vacation-2026" refer to the same tag
+func NormalizeName(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(name)), "-")
+}
+
+// NormalizeNames normalizes a list of tag names, dropping empty and duplicate entries
+func NormalizeNames(names []string) []string {
+	seen := make(map[string]bool, len(names))
+	normalized := make([]string, 0, len(names))
+	for _, name := range names {
+		name = NormalizeName(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		normalized = append(normalized, name)
+	}
+	return normalized
+}
+
+// Total aggregates the spending of a tag within a date range
+// An expense with several tags counts towards each of them
+type Total struct {
+	TagID       uint    `json:"tag_id"`
+	Name        string  `json:"name"`
+	DailyAmount float64 `json:"daily_amount"`
+	DailyCount  int     `json:"daily_count"`
+	FixedAmount float64 `json:"fixed_amount"`
+	FixedCount  int     `json:"fixed_count"`
+}
+
+// GetTotalAmount returns the combined daily and fixed spending of the tag
+func (t *Total) GetTotalAmount() float64 {
+	return t.DailyAmount + t.FixedAmount
+}
+
+// GetTotalCount returns the number of tagged daily and fixed expenses
+func (t *Total) GetTotalCount() int {
+	return t.DailyCount + t.FixedCount
+}
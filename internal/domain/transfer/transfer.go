@@ -0,0 +1,166 @@
+package transfer
+
+import (
+	"expenses-api/internal/domain/apperror"
+	"expenses-api/internal/domain/civil"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Endpoint types for the source and destination of a transfer
+const (
+	EndpointAccount = "account"
+	EndpointPocket  = "pocket"
+)
+
+// Transfer represents money moved between accounts and pockets
+// Transfers move balances but are never counted as spending
+type Transfer struct {
+	ID                  uint       `gorm:"primaryKey" json:"id"`
+	SourceType          string     `gorm:"size:20;not null" json:"source_type"` // "account" or "pocket"
+	SourcePocketID      *uint      `gorm:"index" json:"source_pocket_id"`
+	SourceAccount       string     `gorm:"size:255" json:"source_account"`
+	DestinationType     string     `gorm:"size:20;not null" json:"destination_type"` // "account" or "pocket"
+	DestinationPocketID *uint      `gorm:"index" json:"destination_pocket_id"`
+	DestinationAccount  string     `gorm:"size:255" json:"destination_account"`
+	Amount              float64    `gorm:"type:decimal(15,2);not null" json:"amount"`
+	Date                civil.Date `gorm:"not null;index" json:"date"` // Format: "2024-01-15"
+	Description         string     `gorm:"size:500" json:"description"`
+	CreatedAt           time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	Version             uint       `gorm:"not null;default:1" json:"version"` // Incremented on every update for optimistic locking
+
+	// Relationships - will be loaded when needed
+	SourcePocket      *Pocket `gorm:"foreignKey:SourcePocketID" json:"source_pocket,omitempty"`
+	DestinationPocket *Pocket `gorm:"foreignKey:DestinationPocketID" json:"destination_pocket,omitempty"`
+}
+
+// Pocket represents the relationship to avoid circular imports
+type Pocket struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+}
+
+// TableName specifies the table name for GORM
+func (Transfer) TableName() string {
+	return "transfers"
+}
+
+// BeforeCreate hook to validate data before creation
+func (t *Transfer) BeforeCreate(tx *gorm.DB) error {
+	// New records start at version 1
+	t.Version = 1
+
+	return t.validate()
+}
+
+// BeforeUpdate hook to validate data before update
+func (t *Transfer) BeforeUpdate(tx *gorm.DB) error {
+	return t.validate()
+}
+
+// validate performs validation and data cleaning
+func (t *Transfer) validate() error {
+	t.SourceAccount = strings.TrimSpace(t.SourceAccount)
+	t.DestinationAccount = strings.TrimSpace(t.DestinationAccount)
+	t.Description = strings.TrimSpace(t.Description)
+
+	if err := validateEndpoint("source", t.SourceType, t.SourcePocketID, t.SourceAccount); err != nil {
+		return err
+	}
+
+	if err := validateEndpoint("destination", t.DestinationType, t.DestinationPocketID, t.DestinationAccount); err != nil {
+		return err
+	}
+
+	if t.SourceKey() == t.DestinationKey() {
+		return apperror.Validation("source and destination must be different")
+	}
+
+	// Validate amount
+	if t.Amount <= 0 {
+		return apperror.Invalid("amount", "amount must be greater than zero")
+	}
+
+	if len(t.Description) > 500 {
+		return apperror.Invalid("description", "description cannot exceed 500 characters")
+	}
+
+	// Validate date is set
+	if t.Date.IsZero() {
+		return apperror.Invalid("date", "invalid date format, must be YYYY-MM-DD")
+	}
+
+	return nil
+}
+
+// validateEndpoint checks that one side of the transfer is fully specified
+func validateEndpoint(side, endpointType string, pocketID *uint, account string) error {
+	switch endpointType {
+	case EndpointPocket:
+		if pocketID == nil || *pocketID == 0 {
+			return apperror.Invalid(side+"_pocket_id", side+" pocket ID is required")
+		}
+	case EndpointAccount:
+		if account == "" {
+			return apperror.Invalid(side+"_account", side+" account is required")
+		}
+		if len(account) > 255 {
+			return apperror.Invalid(side+"_account", side+" account cannot exceed 255 characters")
+		}
+	default:
+		return apperror.Invalid(side+"_type", side+" type must be 'account' or 'pocket'")
+	}
+	return nil
+}
+
+// SourceKey returns a stable identifier for the source endpoint
+func (t *Transfer) SourceKey() string {
+	return EndpointKey(t.SourceType, t.SourcePocketID, t.SourceAccount)
+}
+
+// DestinationKey returns a stable identifier for the destination endpoint
+func (t *Transfer) DestinationKey() string {
+	return EndpointKey(t.DestinationType, t.DestinationPocketID, t.DestinationAccount)
+}
+
+// SourceName returns a human readable name for the source endpoint
+func (t *Transfer) SourceName() string {
+	if t.SourceType == EndpointPocket {
+		if t.SourcePocket != nil {
+			return t.SourcePocket.Name
+		}
+		return ""
+	}
+	return t.SourceAccount
+}
+
+// DestinationName returns a human readable name for the destination endpoint
+func (t *Transfer) DestinationName() string {
+	if t.DestinationType == EndpointPocket {
+		if t.DestinationPocket != nil {
+			return t.DestinationPocket.Name
+		}
+		return ""
+	}
+	return t.DestinationAccount
+}
+
+// GetMonth returns the month of the transfer
+func (t *Transfer) GetMonth() civil.Month {
+	return t.Date.Month()
+}
+
+// EndpointKey builds a stable identifier for an account or pocket endpoint
+// Account names are compared case-insensitively
+func EndpointKey(endpointType string, pocketID *uint, account string) string {
+	if endpointType == EndpointPocket {
+		if pocketID == nil {
+			return "pocket:0"
+		}
+		return "pocket:" + strconv.FormatUint(uint64(*pocketID), 10)
+	}
+	return "account:" + strings.ToLower(strings.TrimSpace(account))
+}
@@ -0,0 +1,150 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"expenses-api/internal/domain/apperror"
+	"expenses-api/internal/domain/event"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// AllEvents subscribes to every event
+const AllEvents = "*"
+
+// Subscription represents an outbound webhook registered to receive ledger events
+// Maps to frontend interface: WebhookSubscription { id?, url, events, active, description?, created_at? }
+type Subscription struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	URL         string    `gorm:"size:2048;not null" json:"url"`
+	Secret      string    `gorm:"size:255;not null" json:"-"`       // HMAC key, only returned on creation
+	Events      string    `gorm:"size:1000;not null" json:"events"` // Comma separated event names or "*"
+	Active      bool      `gorm:"default:true;index" json:"active"`
+	Description string    `gorm:"size:500" json:"description"`
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (Subscription) TableName() string {
+	return "webhook_subscriptions"
+}
+
+// BeforeCreate hook to validate data before creation
+func (s *Subscription) BeforeCreate(tx *gorm.DB) error {
+	return s.validate()
+}
+
+// BeforeUpdate hook to validate data before update
+func (s *Subscription) BeforeUpdate(tx *gorm.DB) error {
+	return s.validate()
+}
+
+// validate performs validation and data cleaning
+func (s *Subscription) validate() error {
+	s.URL = strings.TrimSpace(s.URL)
+	if s.URL == "" {
+		return apperror.Invalid("url", "url cannot be empty")
+	}
+
+	parsed, err := url.Parse(s.URL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return apperror.Invalid("url", "url must be an absolute http or https URL")
+	}
+
+	if s.Secret == "" {
+		return apperror.Invalid("secret", "secret cannot be empty")
+	}
+
+	s.Events = NormalizeEvents(s.Events)
+	if s.Events == "" {
+		return apperror.Invalid("events", "at least one event is required")
+	}
+	for _, name := range s.EventList() {
+		if name != AllEvents && !event.IsKnown(name) {
+			return apperror.Invalid("events", "unknown event: "+name)
+		}
+	}
+
+	s.Description = strings.TrimSpace(s.Description)
+	return nil
+}
+
+// EventList returns the subscribed event names
+func (s *Subscription) EventList() []string {
+	if s.Events == "" {
+		return nil
+	}
+	return strings.Split(s.Events, ",")
+}
+
+// Matches checks if the subscription wants to receive the given event
+func (s *Subscription) Matches(name string) bool {
+	if !s.Active {
+		return false
+	}
+	for _, subscribed := range s.EventList() {
+		if subscribed == AllEvents || subscribed == name {
+			return true
+		}
+	}
+	return false
+}
+
+// NormalizeEvents trims, lowercases and deduplicates a comma separated event list
+func NormalizeEvents(events string) string {
+	seen := make(map[string]bool)
+	var result []string
+	for _, name := range strings.Split(events, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		result = append(result, name)
+	}
+	return strings.Join(result, ",")
+}
+
+// MaxErrorLength is the longest delivery error stored, in characters; it matches the error column size
+const MaxErrorLength = 1000
+
+// Delivery records one attempt to deliver an event to a subscription
+type Delivery struct {
+	ID             uint      `gorm:"primaryKey" json:"id"`
+	SubscriptionID uint      `gorm:"not null;index" json:"subscription_id"`
+	EventID        string    `gorm:"size:64;not null;index" json:"event_id"`
+	Event          string    `gorm:"size:100;not null" json:"event"`
+	Payload        string    `gorm:"type:text;not null" json:"payload"`
+	Attempt        int       `gorm:"not null" json:"attempt"`
+	StatusCode     int       `json:"status_code"`
+	Success        bool      `gorm:"default:false" json:"success"`
+	Error          string    `gorm:"size:1000" json:"error"`
+	DurationMs     int64     `json:"duration_ms"`
+	CreatedAt      time.Time `gorm:"autoCreateTime;index" json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (Delivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+// Sign computes the HMAC-SHA256 signature of a payload sent at the given unix timestamp.
+// The signed content is "<timestamp>.<body>" so receivers can reject replayed requests.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks a signature produced by Sign in constant time
+func Verify(secret string, timestamp int64, body []byte, signature string) bool {
+	expected := Sign(secret, timestamp, body)
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
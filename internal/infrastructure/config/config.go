@@ -6,6 +6,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -16,13 +17,21 @@ type Config struct {
 	Port string
 	Env  string
 
+	// IANA timezone where dates, months and overdue checks are computed
+	BusinessTimezone string
+
 	// Database
+	DBDriver   string // "mysql" or "sqlite"
+	DBPath     string // SQLite database file
 	DBHost     string
 	DBUser     string
 	DBPassword string
 	DBName     string
 	DBDSN      string // For GCP Cloud SQL
 
+	// Apply pending schema migrations at startup
+	DBAutoMigrate bool
+
 	// Security
 	JWTSecret string
 
@@ -32,6 +41,36 @@ type Config struct {
 	// Debug
 	Debug    bool
 	LogLevel string
+
+	// Reminders
+	ReminderEnabled       bool
+	ReminderDaysBefore    int
+	ReminderOverdueMonths int
+	ReminderInterval      time.Duration
+	ReminderWebhookURL    string
+
+	// SMTP (reminder emails)
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUsername string
+	SMTPPassword string
+	SMTPFrom     string
+	SMTPTo       []string
+
+	// Outbound webhooks
+	WebhookMaxAttempts  int
+	WebhookRetryBackoff time.Duration
+	WebhookTimeout      time.Duration
+
+	// Attachments
+	AttachmentStoragePath string
+	AttachmentMaxSizeMB   int
+
+	// Pocket suggestions
+	SuggestionHistoryMonths int
+
+	// Idempotency keys
+	IdempotencyKeyTTL time.Duration
 }
 
 var AppConfig *Config
@@ -48,13 +87,21 @@ func LoadConfig() *Config {
 		Port: getEnvOrDefault("PORT", "8080"),
 		Env:  getEnvOrDefault("ENV", "development"),
 
+		// Business timezone
+		BusinessTimezone: getEnvOrDefault("BUSINESS_TIMEZONE", "America/Bogota"),
+
 		// Database configuration
+		DBDriver:   strings.ToLower(getEnvOrDefault("DB_DRIVER", "mysql")),
+		DBPath:     getEnvOrDefault("DB_PATH", "./data/expenses.db"),
 		DBHost:     getEnvOrDefault("DB_HOST", "localhost:3306"),
 		DBUser:     getEnvOrDefault("DB_USER", "root"),
 		DBPassword: getEnvOrDefault("DB_PASSWORD", ""),
 		DBName:     getEnvOrDefault("DB_NAME_EXPENSES", "expenses_db"),
 		DBDSN:      getEnvOrDefault("DB_DSN", ""), // For GCP
 
+		// Schema migrations
+		DBAutoMigrate: getEnvAsBool("DB_AUTO_MIGRATE", false),
+
 		// Security
 		JWTSecret: getEnvOrDefault("JWT_SECRET", "default-secret-change-in-production"),
 
@@ -64,6 +111,36 @@ func LoadConfig() *Config {
 		// Debug
 		Debug:    getEnvAsBool("DEBUG", true),
 		LogLevel: getEnvOrDefault("LOG_LEVEL", "info"),
+
+		// Reminders
+		ReminderEnabled:       getEnvAsBool("REMINDER_ENABLED", false),
+		ReminderDaysBefore:    getEnvAsInt("REMINDER_DAYS_BEFORE", 3),
+		ReminderOverdueMonths: getEnvAsInt("REMINDER_OVERDUE_MONTHS", 1),
+		ReminderInterval:      getEnvAsDuration("REMINDER_INTERVAL", time.Hour),
+		ReminderWebhookURL:    getEnvOrDefault("REMINDER_WEBHOOK_URL", ""),
+
+		// SMTP
+		SMTPHost:     getEnvOrDefault("SMTP_HOST", ""),
+		SMTPPort:     getEnvOrDefault("SMTP_PORT", "587"),
+		SMTPUsername: getEnvOrDefault("SMTP_USERNAME", ""),
+		SMTPPassword: getEnvOrDefault("SMTP_PASSWORD", ""),
+		SMTPFrom:     getEnvOrDefault("SMTP_FROM", ""),
+		SMTPTo:       getEnvAsList("SMTP_TO"),
+
+		// Outbound webhooks
+		WebhookMaxAttempts:  getEnvAsInt("WEBHOOK_MAX_ATTEMPTS", 5),
+		WebhookRetryBackoff: getEnvAsDuration("WEBHOOK_RETRY_BACKOFF", 2*time.Second),
+		WebhookTimeout:      getEnvAsDuration("WEBHOOK_TIMEOUT", 10*time.Second),
+
+		// Attachments
+		AttachmentStoragePath: getEnvOrDefault("ATTACHMENT_STORAGE_PATH", "./data/attachments"),
+		AttachmentMaxSizeMB:   getEnvAsInt("ATTACHMENT_MAX_SIZE_MB", 10),
+
+		// Pocket suggestions
+		SuggestionHistoryMonths: getEnvAsInt("SUGGESTION_HISTORY_MONTHS", 24),
+
+		// Idempotency keys
+		IdempotencyKeyTTL: getEnvAsDuration("IDEMPOTENCY_KEY_TTL", 24*time.Hour),
 	}
 
 	// Validate required configuration
@@ -87,6 +164,15 @@ func (c *Config) GetDSN() string {
 		c.DBUser, c.DBPassword, c.DBHost, c.DBName)
 }
 
+// BusinessLocation returns the business timezone, UTC when unset or invalid
+func (c *Config) BusinessLocation() *time.Location {
+	location, err := time.LoadLocation(c.BusinessTimezone)
+	if err != nil {
+		return time.UTC
+	}
+	return location
+}
+
 // IsProduction returns true if running in production environment
 func (c *Config) IsProduction() bool {
 	return strings.ToLower(c.Env) == "production"
@@ -103,8 +189,22 @@ func (c *Config) validate() error {
 		return fmt.Errorf("PORT is required")
 	}
 
+	if _, err := time.LoadLocation(c.BusinessTimezone); err != nil {
+		return fmt.Errorf("BUSINESS_TIMEZONE is not a valid timezone: %s", c.BusinessTimezone)
+	}
+
 	// Database validation
-	if c.DBDSN == "" {
+	switch c.DBDriver {
+	case "mysql":
+	case "sqlite":
+		if c.DBPath == "" {
+			return fmt.Errorf("DB_PATH is required when DB_DRIVER is sqlite")
+		}
+	default:
+		return fmt.Errorf("DB_DRIVER must be mysql or sqlite")
+	}
+
+	if c.DBDriver == "mysql" && c.DBDSN == "" {
 		// If no DSN, validate individual components
 		if c.DBPassword == "" {
 			return fmt.Errorf("DB_PASSWORD is required")
@@ -114,6 +214,34 @@ func (c *Config) validate() error {
 		}
 	}
 
+	// Reminder validation
+	if c.ReminderEnabled && c.ReminderDaysBefore < 0 {
+		return fmt.Errorf("REMINDER_DAYS_BEFORE cannot be negative")
+	}
+	if c.ReminderEnabled && c.ReminderOverdueMonths < 0 {
+		return fmt.Errorf("REMINDER_OVERDUE_MONTHS cannot be negative")
+	}
+
+	// Webhook validation
+	if c.WebhookMaxAttempts < 1 {
+		return fmt.Errorf("WEBHOOK_MAX_ATTEMPTS must be at least 1")
+	}
+
+	// Attachment validation
+	if c.AttachmentMaxSizeMB < 1 {
+		return fmt.Errorf("ATTACHMENT_MAX_SIZE_MB must be at least 1")
+	}
+
+	// Suggestion validation
+	if c.SuggestionHistoryMonths < 1 {
+		return fmt.Errorf("SUGGESTION_HISTORY_MONTHS must be at least 1")
+	}
+
+	// Idempotency validation
+	if c.IdempotencyKeyTTL <= 0 {
+		return fmt.Errorf("IDEMPOTENCY_KEY_TTL must be a positive duration")
+	}
+
 	// Security validation
 	if c.IsProduction() && c.JWTSecret == "default-secret-change-in-production" {
 		return fmt.Errorf("JWT_SECRET must be set in production")
@@ -139,3 +267,34 @@ func getEnvAsBool(key string, defaultValue bool) bool {
 	}
 	return defaultValue
 }
+
+// getEnvAsInt gets environment variable as integer
+func getEnvAsInt(key string, defaultValue int) int {
+	if value := os.Getenv(key); value != "" {
+		if parsed, err := strconv.Atoi(value); err == nil {
+			return parsed
+		}
+	}
+	return defaultValue
+}
+
+// getEnvAsDuration gets environment variable as duration (e.g. "30m", "1h")
+func getEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if parsed, err := time.ParseDuration(value); err == nil {
+			return parsed
+		}
+	}
+	return defaultValue
+}
+
+// getEnvAsList gets a comma separated environment variable as a list of trimmed values
+func getEnvAsList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
@@ -1,10 +1,19 @@
 package container
 
 import (
+	"expenses-api/internal/application/port"
 	"expenses-api/internal/application/usecase"
+	"expenses-api/internal/infrastructure/clock"
+	"expenses-api/internal/infrastructure/config"
 	"expenses-api/internal/infrastructure/database"
+	"expenses-api/internal/infrastructure/dispatcher"
 	"expenses-api/internal/infrastructure/handler"
+	"expenses-api/internal/infrastructure/notifier"
 	"expenses-api/internal/infrastructure/repository"
+	"expenses-api/internal/infrastructure/scheduler"
+	"expenses-api/internal/infrastructure/storage"
+	"log"
+	"net/http"
 
 	"gorm.io/gorm"
 )
@@ -14,12 +23,39 @@ type Container struct {
 	// Database
 	DB *gorm.DB
 
+	// Current time in the business timezone
+	Clock port.Clock
+
 	// Repositories
-	SalaryRepo             *repository.SalaryRepository
-	PocketRepo             *repository.PocketRepository
-	FixedExpenseRepo       *repository.FixedExpenseRepository
-	DailyExpenseRepo       *repository.DailyExpenseRepository
-	DailyExpenseConfigRepo *repository.DailyExpenseConfigRepository
+	SalaryRepo              *repository.SalaryRepository
+	PocketRepo              *repository.PocketRepository
+	FixedExpenseRepo        *repository.FixedExpenseRepository
+	FixedExpensePaymentRepo *repository.FixedExpensePaymentRepository
+	DailyExpenseRepo        *repository.DailyExpenseRepository
+	DailyExpenseConfigRepo  *repository.DailyExpenseConfigRepository
+	TransferRepo            *repository.TransferRepository
+	PocketAllocationRepo    *repository.PocketAllocationRepository
+	ReminderLogRepo         *repository.ReminderLogRepository
+	WebhookSubscriptionRepo *repository.WebhookSubscriptionRepository
+	WebhookDeliveryRepo     *repository.WebhookDeliveryRepository
+	AlertRuleRepo           *repository.AlertRuleRepository
+	AlertRepo               *repository.AlertRepository
+	AttachmentRepo          *repository.AttachmentRepository
+	TagRepo                 *repository.TagRepository
+	ReceivableRepo          *repository.ReceivableRepository
+	ReimbursementRepo       *repository.ReimbursementRepository
+	HouseholdMemberRepo     *repository.HouseholdMemberRepository
+	ExpenseShareRepo        *repository.ExpenseShareRepository
+	CategorizationRuleRepo  *repository.CategorizationRuleRepository
+	IdempotencyRepo         *repository.IdempotencyRepository
+	AuditRepo               *repository.AuditRepository
+	LedgerEventRepo         *repository.LedgerEventRepository
+
+	// Event publishing
+	WebhookDispatcher *dispatcher.WebhookDispatcher
+
+	// Attachment storage
+	BlobStore port.BlobStore
 
 	// Use Cases
 	SalaryUseCase             *usecase.SalaryUseCase
@@ -28,35 +64,164 @@ type Container struct {
 	DailyExpenseUseCase       *usecase.DailyExpenseUseCase
 	DailyExpenseConfigUseCase *usecase.DailyExpenseConfigUseCase
 	SummaryUseCase            *usecase.SummaryUseCase
+	TransferUseCase           *usecase.TransferUseCase
+	EnvelopeUseCase           *usecase.EnvelopeUseCase
+	ReminderUseCase           *usecase.ReminderUseCase
+	WebhookUseCase            *usecase.WebhookUseCase
+	AlertUseCase              *usecase.AlertUseCase
+	AttachmentUseCase         *usecase.AttachmentUseCase
+	TagUseCase                *usecase.TagUseCase
+	ReceivableUseCase         *usecase.ReceivableUseCase
+	HouseholdUseCase          *usecase.HouseholdUseCase
+	CategorizationUseCase     *usecase.CategorizationUseCase
+	SuggestionUseCase         *usecase.SuggestionUseCase
+	IdempotencyUseCase        *usecase.IdempotencyUseCase
+	AuditUseCase              *usecase.AuditUseCase
+	HistoryUseCase            *usecase.HistoryUseCase
 
 	// Handlers
-	ConfigHandler       *handler.ConfigHandler
-	SummaryHandler      *handler.SummaryHandler
-	FixedExpenseHandler *handler.FixedExpenseHandler
-	DailyExpenseHandler *handler.DailyExpenseHandler
+	ConfigHandler         *handler.ConfigHandler
+	SummaryHandler        *handler.SummaryHandler
+	FixedExpenseHandler   *handler.FixedExpenseHandler
+	DailyExpenseHandler   *handler.DailyExpenseHandler
+	TransferHandler       *handler.TransferHandler
+	EnvelopeHandler       *handler.EnvelopeHandler
+	WebhookHandler        *handler.WebhookHandler
+	AlertHandler          *handler.AlertHandler
+	AttachmentHandler     *handler.AttachmentHandler
+	TagHandler            *handler.TagHandler
+	ReceivableHandler     *handler.ReceivableHandler
+	HouseholdHandler      *handler.HouseholdHandler
+	CategorizationHandler *handler.CategorizationHandler
+	SuggestionHandler     *handler.SuggestionHandler
+	AuditHandler          *handler.AuditHandler
+	HistoryHandler        *handler.HistoryHandler
+
+	// Background jobs (nil when disabled)
+	ReminderScheduler *scheduler.ReminderScheduler
 }
 
 // NewContainer creates and initializes all dependencies
-func NewContainer() (*Container, error) {
+// A nil clock uses the system clock in the configured business timezone
+func NewContainer(clk port.Clock) (*Container, error) {
 	container := &Container{}
 
-	// Initialize database connection
+	cfg := config.AppConfig
+	if cfg == nil {
+		cfg = &config.Config{}
+	}
+
+	if clk == nil {
+		clk = clock.NewSystemClock(cfg.BusinessLocation())
+	}
+	container.Clock = clk
+
+	// Initialize database connection; GORM timestamps follow the same clock
 	db := database.GetDB()
+	db.Config.NowFunc = clk.Now
 	container.DB = db
 
+	// Refuse to start on a schema the domain models do not match
+	if err := database.PrepareSchema(db, cfg.DBAutoMigrate); err != nil {
+		return nil, err
+	}
+
 	// Initialize repositories
 	container.SalaryRepo = repository.NewSalaryRepository(db)
 	container.PocketRepo = repository.NewPocketRepository(db)
 	container.FixedExpenseRepo = repository.NewFixedExpenseRepository(db)
+	container.FixedExpensePaymentRepo = repository.NewFixedExpensePaymentRepository(db)
 	container.DailyExpenseRepo = repository.NewDailyExpenseRepository(db)
 	container.DailyExpenseConfigRepo = repository.NewDailyExpenseConfigRepository(db)
+	container.TransferRepo = repository.NewTransferRepository(db)
+	container.PocketAllocationRepo = repository.NewPocketAllocationRepository(db)
+	container.ReminderLogRepo = repository.NewReminderLogRepository(db)
+	container.WebhookSubscriptionRepo = repository.NewWebhookSubscriptionRepository(db)
+	container.WebhookDeliveryRepo = repository.NewWebhookDeliveryRepository(db)
+	container.AlertRuleRepo = repository.NewAlertRuleRepository(db)
+	container.AlertRepo = repository.NewAlertRepository(db)
+	container.AttachmentRepo = repository.NewAttachmentRepository(db)
+	container.TagRepo = repository.NewTagRepository(db)
+	container.ReceivableRepo = repository.NewReceivableRepository(db)
+	container.ReimbursementRepo = repository.NewReimbursementRepository(db)
+	container.HouseholdMemberRepo = repository.NewHouseholdMemberRepository(db)
+	container.ExpenseShareRepo = repository.NewExpenseShareRepository(db)
+	container.CategorizationRuleRepo = repository.NewCategorizationRuleRepository(db)
+	container.IdempotencyRepo = repository.NewIdempotencyRepository(db)
+	container.AuditRepo = repository.NewAuditRepository(db)
+	container.LedgerEventRepo = repository.NewLedgerEventRepository(db)
+
+	// Ledger events are delivered to the registered webhook subscriptions
+	container.WebhookDispatcher = dispatcher.NewWebhookDispatcher(
+		container.WebhookSubscriptionRepo,
+		container.WebhookDeliveryRepo,
+		&http.Client{Timeout: cfg.WebhookTimeout},
+		cfg.WebhookMaxAttempts,
+		cfg.WebhookRetryBackoff,
+		clk,
+	)
+
+	// Receipts are stored on the local filesystem
+	blobStore, err := storage.NewLocalBlobStore(cfg.AttachmentStoragePath)
+	if err != nil {
+		return nil, err
+	}
+	container.BlobStore = blobStore
 
 	// Initialize use cases
-	container.SalaryUseCase = usecase.NewSalaryUseCase(container.SalaryRepo)
+	container.SalaryUseCase = usecase.NewSalaryUseCase(container.SalaryRepo, clk)
 	container.PocketUseCase = usecase.NewPocketUseCase(container.PocketRepo)
-	container.FixedExpenseUseCase = usecase.NewFixedExpenseUseCase(container.FixedExpenseRepo)
-	container.DailyExpenseUseCase = usecase.NewDailyExpenseUseCase(container.DailyExpenseRepo)
+	container.TagUseCase = usecase.NewTagUseCase(container.TagRepo)
+	container.FixedExpenseUseCase = usecase.NewFixedExpenseUseCase(
+		container.FixedExpenseRepo,
+		container.FixedExpensePaymentRepo,
+		container.WebhookDispatcher,
+		container.TagUseCase,
+		clk,
+	)
+
+	// Alert rules are evaluated after every daily expense change
+	container.AlertUseCase = usecase.NewAlertUseCase(
+		container.AlertRuleRepo,
+		container.AlertRepo,
+		container.PocketRepo,
+		container.DailyExpenseRepo,
+		container.FixedExpenseRepo,
+		container.DailyExpenseConfigRepo,
+		container.PocketAllocationRepo,
+		container.WebhookDispatcher,
+		clk,
+	)
+	// Categorization rules fill in the pocket and tags of new daily expenses
+	container.CategorizationUseCase = usecase.NewCategorizationUseCase(
+		container.CategorizationRuleRepo,
+		container.PocketRepo,
+		container.DailyExpenseRepo,
+		container.TagUseCase,
+	)
+	// Attachment use case stores receipts of daily and fixed expenses
+	container.AttachmentUseCase = usecase.NewAttachmentUseCase(
+		container.AttachmentRepo,
+		container.BlobStore,
+		container.DailyExpenseRepo,
+		container.FixedExpenseRepo,
+		int64(cfg.AttachmentMaxSizeMB)<<20,
+	)
+
+	container.DailyExpenseUseCase = usecase.NewDailyExpenseUseCase(
+		container.DailyExpenseRepo,
+		container.PocketRepo,
+		container.DailyExpenseConfigRepo,
+		container.WebhookDispatcher,
+		container.AlertUseCase,
+		container.TagUseCase,
+		container.CategorizationUseCase,
+		container.AttachmentUseCase,
+		clk,
+	)
 	container.DailyExpenseConfigUseCase = usecase.NewDailyExpenseConfigUseCase(container.DailyExpenseConfigRepo)
+	container.TransferUseCase = usecase.NewTransferUseCase(container.TransferRepo, container.PocketRepo, clk)
+	container.WebhookUseCase = usecase.NewWebhookUseCase(container.WebhookSubscriptionRepo, container.WebhookDeliveryRepo)
 
 	// Summary use case needs multiple repositories
 	container.SummaryUseCase = usecase.NewSummaryUseCase(
@@ -64,6 +229,55 @@ func NewContainer() (*Container, error) {
 		container.FixedExpenseRepo,
 		container.DailyExpenseRepo,
 		container.DailyExpenseConfigRepo,
+		container.TransferRepo,
+		container.ReceivableRepo,
+		clk,
+	)
+
+	// Envelope use case combines allocations, transfers and spending per pocket
+	container.EnvelopeUseCase = usecase.NewEnvelopeUseCase(
+		container.PocketRepo,
+		container.PocketAllocationRepo,
+		container.FixedExpenseRepo,
+		container.DailyExpenseRepo,
+		container.TransferRepo,
+	)
+
+	// Receivable use case tracks expenses paid on behalf of others
+	container.ReceivableUseCase = usecase.NewReceivableUseCase(
+		container.ReceivableRepo,
+		container.ReimbursementRepo,
+		container.DailyExpenseRepo,
+		container.FixedExpenseRepo,
+		clk,
+	)
+
+	// Household use case splits expenses between roommates and settles up
+	container.HouseholdUseCase = usecase.NewHouseholdUseCase(
+		container.HouseholdMemberRepo,
+		container.ExpenseShareRepo,
+		container.DailyExpenseRepo,
+		container.FixedExpenseRepo,
+	)
+
+	// Pocket suggestions are learned from the daily expense history
+	container.SuggestionUseCase = usecase.NewSuggestionUseCase(
+		container.DailyExpenseRepo,
+		container.PocketRepo,
+		cfg.SuggestionHistoryMonths,
+		clk,
+	)
+	container.IdempotencyUseCase = usecase.NewIdempotencyUseCase(container.IdempotencyRepo, clk, cfg.IdempotencyKeyTTL)
+	container.AuditUseCase = usecase.NewAuditUseCase(container.AuditRepo, clk)
+	container.HistoryUseCase = usecase.NewHistoryUseCase(container.LedgerEventRepo, clk)
+
+	// Reminder use case notifies through every configured channel
+	container.ReminderUseCase = usecase.NewReminderUseCase(
+		container.FixedExpenseRepo,
+		container.ReminderLogRepo,
+		newNotifiers(cfg, clk),
+		cfg.ReminderDaysBefore,
+		cfg.ReminderOverdueMonths,
 	)
 
 	// Initialize handlers
@@ -73,8 +287,52 @@ func NewContainer() (*Container, error) {
 		container.DailyExpenseConfigUseCase,
 	)
 	container.SummaryHandler = handler.NewSummaryHandler(container.SummaryUseCase)
-	container.FixedExpenseHandler = handler.NewFixedExpenseHandler(container.FixedExpenseUseCase)
-	container.DailyExpenseHandler = handler.NewDailyExpenseHandler(container.DailyExpenseUseCase)
+	container.FixedExpenseHandler = handler.NewFixedExpenseHandler(container.FixedExpenseUseCase, clk)
+	container.DailyExpenseHandler = handler.NewDailyExpenseHandler(container.DailyExpenseUseCase, clk)
+	container.TransferHandler = handler.NewTransferHandler(container.TransferUseCase, clk)
+	container.EnvelopeHandler = handler.NewEnvelopeHandler(container.EnvelopeUseCase)
+	container.WebhookHandler = handler.NewWebhookHandler(container.WebhookUseCase)
+	container.AlertHandler = handler.NewAlertHandler(container.AlertUseCase)
+	container.AttachmentHandler = handler.NewAttachmentHandler(container.AttachmentUseCase)
+	container.TagHandler = handler.NewTagHandler(container.TagUseCase)
+	container.ReceivableHandler = handler.NewReceivableHandler(container.ReceivableUseCase)
+	container.HouseholdHandler = handler.NewHouseholdHandler(container.HouseholdUseCase)
+	container.CategorizationHandler = handler.NewCategorizationHandler(container.CategorizationUseCase)
+	container.SuggestionHandler = handler.NewSuggestionHandler(container.SuggestionUseCase)
+	container.AuditHandler = handler.NewAuditHandler(container.AuditUseCase)
+	container.HistoryHandler = handler.NewHistoryHandler(container.HistoryUseCase)
+
+	// Initialize background jobs
+	if cfg.ReminderEnabled {
+		container.ReminderScheduler = scheduler.NewReminderScheduler(container.ReminderUseCase, cfg.ReminderInterval, clk)
+	}
 
 	return container, nil
 }
+
+// newNotifiers builds the reminder notifiers enabled in the configuration
+func newNotifiers(cfg *config.Config, clk port.Clock) []port.Notifier {
+	var notifiers []port.Notifier
+
+	if cfg.SMTPHost != "" {
+		notifiers = append(notifiers, notifier.NewSMTPNotifier(
+			cfg.SMTPHost,
+			cfg.SMTPPort,
+			cfg.SMTPUsername,
+			cfg.SMTPPassword,
+			cfg.SMTPFrom,
+			cfg.SMTPTo,
+			clk,
+		))
+	}
+
+	if cfg.ReminderWebhookURL != "" {
+		notifiers = append(notifiers, notifier.NewWebhookNotifier(cfg.ReminderWebhookURL, nil))
+	}
+
+	if cfg.ReminderEnabled && len(notifiers) == 0 {
+		log.Println("Reminders are enabled but no notifier is configured (set SMTP_HOST or REMINDER_WEBHOOK_URL)")
+	}
+
+	return notifiers
+}
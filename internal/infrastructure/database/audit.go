@@ -0,0 +1,330 @@
+package database
+
+import (
+	"encoding/json"
+	"expenses-api/internal/domain/audit"
+	"expenses-api/internal/domain/idempotency"
+	"expenses-api/internal/domain/ledger"
+	"expenses-api/internal/domain/reminder"
+	"expenses-api/internal/domain/webhook"
+	"fmt"
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// unaudited lists the tables whose changes are not recorded: the audit and
+// event logs themselves and the bookkeeping the application keeps on its own
+var unaudited = map[string]bool{
+	audit.Entry{}.TableName():          true,
+	ledger.Event{}.TableName():         true,
+	SchemaMigration{}.TableName():      true,
+	idempotency.Record{}.TableName():   true,
+	reminder.ReminderLog{}.TableName(): true,
+	webhook.Delivery{}.TableName():     true,
+}
+
+// auditBeforeKey stores the rows an update or delete is about to change
+const auditBeforeKey = "audit:before"
+
+// registerAuditCallbacks records every create, update and delete of an entity
+// in the audit log, and those of the ledger entities in the event log. Both
+// are written inside the transaction of the change, so a change is never
+// stored without its entry and a failed entry rolls the change back.
+// The actor comes from the context of the statement
+func registerAuditCallbacks(db *gorm.DB) error {
+	callbacks := db.Callback()
+	if err := callbacks.Create().After("gorm:create").Register("audit:after_create", auditCreate); err != nil {
+		return err
+	}
+	if err := callbacks.Update().Before("gorm:update").Register("audit:before_update", captureBefore); err != nil {
+		return err
+	}
+	if err := callbacks.Update().After("gorm:update").Register("audit:after_update", auditUpdate); err != nil {
+		return err
+	}
+	if err := callbacks.Delete().Before("gorm:delete").Register("audit:before_delete", captureBefore); err != nil {
+		return err
+	}
+	return callbacks.Delete().After("gorm:delete").Register("audit:after_delete", auditDelete)
+}
+
+// audited reports whether the statement changes an entity whose history is kept
+// Rows of join tables, such as tag links, are kept under the entity of their
+// first key column; tables without a primary key are not audited
+func audited(db *gorm.DB) bool {
+	stmt := db.Statement
+	return db.Error == nil &&
+		stmt.Schema != nil &&
+		len(stmt.Schema.PrimaryFields) > 0 &&
+		!unaudited[stmt.Table]
+}
+
+// captureBefore loads the rows an update or delete is about to change
+func captureBefore(db *gorm.DB) {
+	if !audited(db) {
+		return
+	}
+
+	conditions := changeConditions(db)
+	if len(conditions) == 0 {
+		// GORM refuses updates and deletes without conditions
+		return
+	}
+
+	rows, err := loadRows(db, conditions)
+	if err != nil {
+		db.AddError(err)
+		return
+	}
+	db.InstanceSet(auditBeforeKey, rows)
+}
+
+// auditCreate records the rows a create inserted
+func auditCreate(db *gorm.DB) {
+	if !audited(db) || db.RowsAffected == 0 {
+		return
+	}
+
+	keys := keyConditions(db, db.Statement.ReflectValue)
+	if len(keys) == 0 {
+		return
+	}
+
+	rows, err := loadRows(db, keys)
+	if err != nil {
+		db.AddError(err)
+		return
+	}
+
+	entries := make([]audit.Entry, 0, rows.Len())
+	for i := 0; i < rows.Len(); i++ {
+		id, after, err := snapshot(db, rows.Index(i))
+		if err != nil {
+			db.AddError(err)
+			return
+		}
+		entries = append(entries, newEntry(db, audit.ActionCreate, id, nil, &after))
+	}
+	record(db, entries)
+}
+
+// auditUpdate records the rows an update changed, skipping rows left as they were
+func auditUpdate(db *gorm.DB) {
+	before, ok := capturedRows(db)
+	if !ok || !audited(db) || db.RowsAffected == 0 {
+		return
+	}
+
+	rows, err := loadRows(db, keyConditions(db, before))
+	if err != nil {
+		db.AddError(err)
+		return
+	}
+
+	afterByKey := make(map[string]string, rows.Len())
+	for i := 0; i < rows.Len(); i++ {
+		_, after, err := snapshot(db, rows.Index(i))
+		if err != nil {
+			db.AddError(err)
+			return
+		}
+		afterByKey[rowKey(db, rows.Index(i))] = after
+	}
+
+	entries := make([]audit.Entry, 0, before.Len())
+	for i := 0; i < before.Len(); i++ {
+		id, previous, err := snapshot(db, before.Index(i))
+		if err != nil {
+			db.AddError(err)
+			return
+		}
+		after, found := afterByKey[rowKey(db, before.Index(i))]
+		if !found || after == previous {
+			continue
+		}
+		entries = append(entries, newEntry(db, audit.ActionUpdate, id, &previous, &after))
+	}
+	record(db, entries)
+}
+
+// auditDelete records the rows a delete removed
+func auditDelete(db *gorm.DB) {
+	before, ok := capturedRows(db)
+	if !ok || !audited(db) || db.RowsAffected == 0 {
+		return
+	}
+
+	entries := make([]audit.Entry, 0, before.Len())
+	for i := 0; i < before.Len(); i++ {
+		id, previous, err := snapshot(db, before.Index(i))
+		if err != nil {
+			db.AddError(err)
+			return
+		}
+		entries = append(entries, newEntry(db, audit.ActionDelete, id, &previous, nil))
+	}
+	record(db, entries)
+}
+
+// changeConditions returns the conditions that select the rows an update or
+// delete changes: its WHERE clause and the primary key of the model it was given
+func changeConditions(db *gorm.DB) []clause.Expression {
+	stmt := db.Statement
+
+	var conditions []clause.Expression
+	if c, ok := stmt.Clauses["WHERE"]; ok {
+		if where, ok := c.Expression.(clause.Where); ok {
+			conditions = append(conditions, where.Exprs...)
+		}
+	}
+	return append(conditions, keyConditions(db, stmt.ReflectValue)...)
+}
+
+// keyConditions returns the condition that selects the stored rows of a model
+// or a slice of models by primary key, or nil when none of them has one
+// Rows of join tables are matched on every column of their composite key
+func keyConditions(db *gorm.DB, value reflect.Value) []clause.Expression {
+	stmt := db.Statement
+	fields := stmt.Schema.PrimaryFields
+
+	var ids []interface{}
+	var rows []clause.Expression
+	add := func(model reflect.Value) {
+		for model.Kind() == reflect.Ptr {
+			model = model.Elem()
+		}
+		if model.Kind() != reflect.Struct || model.Type() != stmt.Schema.ModelType {
+			return
+		}
+
+		matches := make([]clause.Expression, 0, len(fields))
+		for _, field := range fields {
+			key, zero := field.ValueOf(stmt.Context, model)
+			if zero {
+				return
+			}
+			ids = append(ids, key)
+			matches = append(matches, clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: field.DBName}, Value: key})
+		}
+		rows = append(rows, clause.And(matches...))
+	}
+
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			add(value.Index(i))
+		}
+	default:
+		add(value)
+	}
+
+	switch {
+	case len(rows) == 0:
+		return nil
+	case len(fields) == 1:
+		return []clause.Expression{clause.IN{Column: clause.PrimaryColumn, Values: ids}}
+	default:
+		return []clause.Expression{clause.Or(rows...)}
+	}
+}
+
+// rowKey identifies a row by the values of its primary key columns
+func rowKey(db *gorm.DB, row reflect.Value) string {
+	stmt := db.Statement
+
+	values := make([]interface{}, 0, len(stmt.Schema.PrimaryFields))
+	for _, field := range stmt.Schema.PrimaryFields {
+		value, _ := field.ValueOf(stmt.Context, row)
+		values = append(values, value)
+	}
+	return fmt.Sprint(values...)
+}
+
+// loadRows reads the current rows of the statement's table that match the
+// conditions, inside the transaction of the change
+func loadRows(db *gorm.DB, conditions []clause.Expression) (reflect.Value, error) {
+	rows := reflect.New(reflect.SliceOf(db.Statement.Schema.ModelType))
+	err := db.Session(&gorm.Session{NewDB: true, SkipHooks: true}).
+		Table(db.Statement.Table).
+		Clauses(clause.Where{Exprs: conditions}).
+		Find(rows.Interface()).Error
+	return rows.Elem(), err
+}
+
+// capturedRows returns the rows captureBefore loaded for the statement
+func capturedRows(db *gorm.DB) (reflect.Value, bool) {
+	value, ok := db.InstanceGet(auditBeforeKey)
+	if !ok {
+		return reflect.Value{}, false
+	}
+	rows := value.(reflect.Value)
+	return rows, rows.Len() > 0
+}
+
+// snapshot returns the ID a row is recorded under and its columns as JSON: the
+// primary key, or the first key column for rows of join tables
+// Fields hidden from the API, such as webhook secrets, are left out
+func snapshot(db *gorm.DB, row reflect.Value) (uint, string, error) {
+	stmt := db.Statement
+
+	columns := make(map[string]interface{}, len(stmt.Schema.Fields))
+	for _, field := range stmt.Schema.Fields {
+		if field.DBName == "" || field.Tag.Get("json") == "-" {
+			continue
+		}
+		columns[field.DBName], _ = field.ValueOf(stmt.Context, row)
+	}
+
+	data, err := json.Marshal(columns)
+	if err != nil {
+		return 0, "", err
+	}
+
+	idField := stmt.Schema.PrioritizedPrimaryField
+	if idField == nil {
+		idField = stmt.Schema.PrimaryFields[0]
+	}
+	id, _ := idField.ValueOf(stmt.Context, row)
+	return toUint(id), string(data), nil
+}
+
+// newEntry builds the audit entry of one changed row
+func newEntry(db *gorm.DB, action string, id uint, before, after *string) audit.Entry {
+	return audit.Entry{
+		Entity:   db.Statement.Table,
+		EntityID: id,
+		Action:   action,
+		Before:   before,
+		After:    after,
+		Actor:    audit.ActorFrom(db.Statement.Context),
+	}
+}
+
+// record writes the entries of a change to the audit log and, for the
+// entities of the ledger, their events to the event log
+func record(db *gorm.DB, entries []audit.Entry) {
+	writeEntries(db, entries)
+	writeEvents(db, entries)
+}
+
+// writeEntries appends the entries to the audit log in the transaction of the change
+func writeEntries(db *gorm.DB, entries []audit.Entry) {
+	if len(entries) == 0 {
+		return
+	}
+	db.AddError(db.Session(&gorm.Session{NewDB: true, SkipHooks: true}).Create(&entries).Error)
+}
+
+// toUint converts an integer primary key to uint
+func toUint(id interface{}) uint {
+	value := reflect.ValueOf(id)
+	switch value.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return uint(value.Uint())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return uint(value.Int())
+	}
+	return 0
+}
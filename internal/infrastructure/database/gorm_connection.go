@@ -5,10 +5,12 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"path/filepath"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/glebarez/sqlite"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
@@ -43,24 +45,34 @@ func initializeGORM() (*gorm.DB, error) {
 		return nil, fmt.Errorf("configuration not loaded")
 	}
 
-	dsn := cfg.GetDSN()
-	log.Printf("Connecting to database with DSN: %s", maskPassword(dsn))
+	dialector, err := openDialector(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	location := cfg.BusinessLocation()
 
 	// Configure GORM
 	config := &gorm.Config{
 		Logger: getLoggerConfig(),
+		// Translate driver errors into gorm.ErrDuplicatedKey and gorm.ErrForeignKeyViolated
+		TranslateError: true,
 		NowFunc: func() time.Time {
-			// Use Colombia timezone
-			loc, _ := time.LoadLocation("America/Bogota")
-			return time.Now().In(loc)
+			// Timestamps in the business timezone; the container replaces this with its clock
+			return time.Now().In(location)
 		},
 	}
 
-	db, err := gorm.Open(mysql.Open(dsn), config)
+	db, err := gorm.Open(dialector, config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
+	// Record every change of an entity in the audit log
+	if err := registerAuditCallbacks(db); err != nil {
+		return nil, fmt.Errorf("failed to register audit callbacks: %w", err)
+	}
+
 	// Configure connection pool
 	sqlDB, err := db.DB()
 	if err != nil {
@@ -72,10 +84,37 @@ func initializeGORM() (*gorm.DB, error) {
 	sqlDB.SetMaxOpenConns(100)
 	sqlDB.SetConnMaxLifetime(time.Hour)
 
+	// SQLite allows a single writer; one connection serializes access instead of
+	// failing with "database is locked"
+	if cfg.DBDriver == "sqlite" {
+		sqlDB.SetMaxOpenConns(1)
+	}
+
 	log.Println("GORM database connection established successfully")
 	return db, nil
 }
 
+// openDialector returns the GORM dialector for the configured DB_DRIVER
+func openDialector(cfg *config.Config) (gorm.Dialector, error) {
+	switch cfg.DBDriver {
+	case "sqlite":
+		if err := os.MkdirAll(filepath.Dir(cfg.DBPath), 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create database directory: %w", err)
+		}
+
+		log.Printf("Opening SQLite database at %s", cfg.DBPath)
+		return sqlite.Open(cfg.DBPath + "?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)"), nil
+
+	case "mysql", "":
+		dsn := cfg.GetDSN()
+		log.Printf("Connecting to database with DSN: %s", maskPassword(dsn))
+		return mysql.Open(dsn), nil
+
+	default:
+		return nil, fmt.Errorf("unsupported database driver: %s", cfg.DBDriver)
+	}
+}
+
 // maskPassword masks the password in DSN for logging
 func maskPassword(dsn string) string {
 	// Simple password masking for logs
@@ -115,8 +154,8 @@ func (d *Database) Close() error {
 	return sqlDB.Close()
 }
 
-// Note: Database migrations are handled manually via SQL scripts
-// See sql/database/ directory for schema management
+// Note: The schema is managed by the versioned migrations embedded from
+// database/migrations; see migrator.go and the "migrate" subcommand
 
 // Transaction executes a function within a database transaction
 func (d *Database) Transaction(fn func(*gorm.DB) error) error {
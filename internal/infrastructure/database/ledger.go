@@ -0,0 +1,86 @@
+package database
+
+import (
+	"expenses-api/internal/domain/audit"
+	"expenses-api/internal/domain/ledger"
+	"reflect"
+
+	"gorm.io/gorm"
+)
+
+// eventKinds maps the audited actions to the kinds of ledger event
+var eventKinds = map[string]string{
+	audit.ActionCreate: ledger.Created,
+	audit.ActionUpdate: ledger.Updated,
+	audit.ActionDelete: ledger.Deleted,
+}
+
+// writeEvents appends the changes of a ledger entity to the event log in the
+// transaction of the change. The event keeps the entity as the audit entry
+// left it, so replaying the events rebuilds the ledger
+func writeEvents(db *gorm.DB, entries []audit.Entry) {
+	if len(entries) == 0 || !ledger.IsTracked(db.Statement.Table) {
+		return
+	}
+
+	occurredAt := db.NowFunc()
+	events := make([]ledger.Event, 0, len(entries))
+	for _, entry := range entries {
+		events = append(events, ledger.Event{
+			Name:       ledger.EventName(entry.Entity, eventKinds[entry.Action]),
+			Entity:     entry.Entity,
+			EntityID:   entry.EntityID,
+			Data:       entry.After,
+			Month:      ledger.MonthOf(entry.Entity, entry.After),
+			OccurredAt: occurredAt,
+		})
+	}
+	db.AddError(db.Session(&gorm.Session{NewDB: true, SkipHooks: true}).Create(&events).Error)
+}
+
+// seedLedgerEvents records a created event with the current state of every
+// ledger row no event recorded, such as the rows from before the event log,
+// so that they replay and pass the consistency check like the rest
+func seedLedgerEvents(tx *gorm.DB) error {
+	occurredAt := tx.NowFunc()
+	for _, entity := range ledger.Entities() {
+		model := ledger.NewModel(entity)
+		parsed := tx.Session(&gorm.Session{NewDB: true, SkipHooks: true})
+		if err := parsed.Statement.Parse(model); err != nil {
+			return err
+		}
+
+		rows := reflect.New(reflect.SliceOf(parsed.Statement.Schema.ModelType))
+		err := tx.Session(&gorm.Session{NewDB: true, SkipHooks: true}).
+			Table(entity).
+			Where("NOT EXISTS (SELECT 1 FROM ledger_events WHERE ledger_events.entity = ? AND ledger_events.entity_id = "+entity+".id)", entity).
+			Order("id").
+			Find(rows.Interface()).Error
+		if err != nil {
+			return err
+		}
+
+		events := make([]ledger.Event, 0, rows.Elem().Len())
+		for i := 0; i < rows.Elem().Len(); i++ {
+			id, data, err := snapshot(parsed, rows.Elem().Index(i))
+			if err != nil {
+				return err
+			}
+			events = append(events, ledger.Event{
+				Name:       ledger.EventName(entity, ledger.Created),
+				Entity:     entity,
+				EntityID:   id,
+				Data:       &data,
+				Month:      ledger.MonthOf(entity, &data),
+				OccurredAt: occurredAt,
+			})
+		}
+		if len(events) == 0 {
+			continue
+		}
+		if err := tx.Session(&gorm.Session{NewDB: true, SkipHooks: true}).CreateInBatches(&events, 100).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package database
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// RunMigrateCommand runs the "migrate" subcommand against the configured database
+//
+//	migrate up           apply every pending migration (default)
+//	migrate down [n]     revert the last n applied migrations (default 1)
+//	migrate status       list migrations and when they were applied
+//	migrate check        verify the schema matches the domain models
+func RunMigrateCommand(args []string) error {
+	action := "up"
+	if len(args) > 0 {
+		action = args[0]
+	}
+
+	db := GetDB()
+	migrator, err := NewMigrator(db)
+	if err != nil {
+		return err
+	}
+
+	switch action {
+	case "up":
+		applied, err := migrator.Up()
+		if err != nil {
+			return err
+		}
+		if len(applied) == 0 {
+			fmt.Println("Database schema is up to date")
+		}
+		return CheckSchema(db)
+
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil {
+				return fmt.Errorf("invalid number of steps: %s", args[1])
+			}
+		}
+		_, err := migrator.Down(steps)
+		return err
+
+	case "status":
+		statuses, err := migrator.Status()
+		if err != nil {
+			return err
+		}
+		for _, status := range statuses {
+			applied := "pending"
+			if status.AppliedAt != nil {
+				applied = status.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%-40s %s\n", status.ID(), applied)
+		}
+		return nil
+
+	case "check":
+		if err := PrepareSchema(db, false); err != nil {
+			return err
+		}
+		fmt.Println("Database schema matches the domain models")
+		return nil
+
+	default:
+		return fmt.Errorf("unknown migrate action %q, use up, down, status or check", action)
+	}
+}
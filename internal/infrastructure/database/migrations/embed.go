@@ -0,0 +1,11 @@
+// Package migrations embeds the versioned SQL schema migrations
+// Each supported database has its own directory with the same versions;
+// files are named {version}_{name}.up.sql and {version}_{name}.down.sql
+package migrations
+
+import "embed"
+
+// FS holds the migration files compiled into the binary, by dialect
+//
+//go:embed mysql/*.sql sqlite/*.sql
+var FS embed.FS
@@ -0,0 +1,474 @@
+-- =====================================================
+-- 0001 - ESQUEMA INICIAL
+-- =====================================================
+-- Tablas y vistas existentes antes de las migraciones versionadas.
+-- Usa IF NOT EXISTS para adoptar bases de datos creadas con los scripts
+-- manuales de sql/database/ y les agrega las columnas que les faltan.
+-- =====================================================
+
+-- 1. SALARIOS MENSUALES
+CREATE TABLE IF NOT EXISTS salaries (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    monthly_amount DECIMAL(15,2) NOT NULL,
+    month VARCHAR(7) NOT NULL, -- "2024-01" format
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    
+    INDEX idx_month (month)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- 2. BOLSILLOS
+CREATE TABLE IF NOT EXISTS pockets (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    name VARCHAR(255) NOT NULL UNIQUE,
+    description TEXT NULL,
+    rollover_policy VARCHAR(20) NOT NULL DEFAULT 'rollover', -- "rollover" | "sweep"
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- 3. GASTOS FIJOS MENSUALES
+CREATE TABLE IF NOT EXISTS fixed_expenses (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    pocket_id INT NOT NULL,
+    concept_name VARCHAR(255) NOT NULL,
+    amount DECIMAL(15,2) NOT NULL,
+    payment_day INT NOT NULL, -- día del mes (1-31)
+    is_paid BOOLEAN DEFAULT FALSE,
+    month VARCHAR(7) NOT NULL, -- "2024-01" format
+    paid_date VARCHAR(10) NULL, -- "2024-01-15" format
+    actual_amount DECIMAL(15,2) NULL, -- Monto real facturado, si difiere de amount
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    
+    INDEX idx_month (month),
+    INDEX idx_is_paid (is_paid),
+    INDEX idx_payment_day (payment_day),
+    INDEX idx_pocket_month (pocket_id, month),
+    
+    FOREIGN KEY (pocket_id) REFERENCES pockets(id) ON DELETE RESTRICT,
+    CONSTRAINT chk_payment_day CHECK (payment_day BETWEEN 1 AND 31)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- 4. GASTOS DIARIOS
+CREATE TABLE IF NOT EXISTS daily_expenses (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    description VARCHAR(500) NOT NULL,
+    amount DECIMAL(15,2) NOT NULL,
+    date VARCHAR(10) NOT NULL, -- "2024-01-15" format
+    pocket_id INT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    
+    INDEX idx_date (date),
+    INDEX idx_pocket_id (pocket_id),
+    
+    FOREIGN KEY (pocket_id) REFERENCES pockets(id) ON DELETE RESTRICT
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- 5. CONFIGURACIÓN DE PRESUPUESTO DIARIO MENSUAL
+CREATE TABLE IF NOT EXISTS daily_expenses_configs (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    monthly_budget DECIMAL(15,2) NOT NULL,
+    month VARCHAR(7) NOT NULL UNIQUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- 6. TRANSFERENCIAS ENTRE CUENTAS Y BOLSILLOS
+CREATE TABLE IF NOT EXISTS transfers (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    source_type VARCHAR(20) NOT NULL, -- "account" | "pocket"
+    source_pocket_id INT NULL,
+    source_account VARCHAR(255) NULL,
+    destination_type VARCHAR(20) NOT NULL, -- "account" | "pocket"
+    destination_pocket_id INT NULL,
+    destination_account VARCHAR(255) NULL,
+    amount DECIMAL(15,2) NOT NULL,
+    date VARCHAR(10) NOT NULL, -- "2024-01-15" format
+    description VARCHAR(500) NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    
+    INDEX idx_date (date),
+    INDEX idx_source_pocket (source_pocket_id),
+    INDEX idx_destination_pocket (destination_pocket_id),
+    
+    FOREIGN KEY (source_pocket_id) REFERENCES pockets(id) ON DELETE RESTRICT,
+    FOREIGN KEY (destination_pocket_id) REFERENCES pockets(id) ON DELETE RESTRICT
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- 7. ASIGNACIONES MENSUALES DE BOLSILLOS (SOBRES)
+CREATE TABLE IF NOT EXISTS pocket_allocations (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    pocket_id INT NOT NULL,
+    month VARCHAR(7) NOT NULL, -- "2024-01" format
+    amount DECIMAL(15,2) NOT NULL,
+    
+    UNIQUE KEY idx_pocket_allocation_month (pocket_id, month),
+    INDEX idx_month (month),
+    
+    FOREIGN KEY (pocket_id) REFERENCES pockets(id) ON DELETE RESTRICT
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- 8. RECORDATORIOS ENVIADOS DE GASTOS FIJOS
+CREATE TABLE IF NOT EXISTS reminder_logs (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    fixed_expense_id INT NOT NULL,
+    kind VARCHAR(20) NOT NULL, -- "due_soon" | "overdue"
+    sent_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    
+    UNIQUE KEY idx_reminder_expense_kind (fixed_expense_id, kind),
+    
+    FOREIGN KEY (fixed_expense_id) REFERENCES fixed_expenses(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- 9. WEBHOOKS SALIENTES
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    url VARCHAR(2048) NOT NULL,
+    secret VARCHAR(255) NOT NULL, -- Clave HMAC-SHA256
+    events VARCHAR(1000) NOT NULL, -- "expense.created,fixed_expense.paid" | "*"
+    active BOOLEAN DEFAULT TRUE,
+    description VARCHAR(500) NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    
+    INDEX idx_active (active)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    subscription_id INT NOT NULL,
+    event_id VARCHAR(64) NOT NULL,
+    event VARCHAR(100) NOT NULL,
+    payload TEXT NOT NULL,
+    attempt INT NOT NULL,
+    status_code INT NULL,
+    success BOOLEAN DEFAULT FALSE,
+    error VARCHAR(1000) NULL,
+    duration_ms BIGINT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    
+    INDEX idx_subscription_id (subscription_id),
+    INDEX idx_event_id (event_id),
+    INDEX idx_created_at (created_at),
+    
+    FOREIGN KEY (subscription_id) REFERENCES webhook_subscriptions(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- 10. ALERTAS DE PRESUPUESTO
+CREATE TABLE IF NOT EXISTS alert_rules (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    kind VARCHAR(20) NOT NULL, -- "daily_budget" | "pocket"
+    pocket_id INT NULL, -- Solo para reglas "pocket"
+    threshold_percent DECIMAL(6,2) NOT NULL,
+    active BOOLEAN DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    
+    INDEX idx_kind (kind),
+    INDEX idx_pocket_id (pocket_id),
+    
+    FOREIGN KEY (pocket_id) REFERENCES pockets(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS alerts (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    rule_id INT NOT NULL,
+    month VARCHAR(7) NOT NULL, -- "2024-01" format
+    kind VARCHAR(20) NOT NULL,
+    pocket_id INT NULL,
+    threshold_percent DECIMAL(6,2) NOT NULL,
+    budget DECIMAL(15,2) NOT NULL,
+    spent DECIMAL(15,2) NOT NULL,
+    message VARCHAR(500) NOT NULL,
+    acknowledged BOOLEAN DEFAULT FALSE,
+    acknowledged_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    
+    UNIQUE KEY idx_alert_rule_month (rule_id, month),
+    INDEX idx_month (month),
+    INDEX idx_acknowledged (acknowledged),
+    
+    FOREIGN KEY (rule_id) REFERENCES alert_rules(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- 11. PAGOS DE GASTOS FIJOS (TOTALES O PARCIALES)
+CREATE TABLE IF NOT EXISTS fixed_expense_payments (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    fixed_expense_id INT NOT NULL,
+    amount DECIMAL(15,2) NOT NULL,
+    paid_date VARCHAR(10) NOT NULL, -- "2024-01-15" format
+    method VARCHAR(50) NULL, -- "cash" | "transfer" | "card" | ...
+    note VARCHAR(500) NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    
+    INDEX idx_fixed_expense_id (fixed_expense_id),
+    
+    FOREIGN KEY (fixed_expense_id) REFERENCES fixed_expenses(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- 13. COMPROBANTES ADJUNTOS A GASTOS
+CREATE TABLE IF NOT EXISTS attachments (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    expense_type VARCHAR(10) NOT NULL, -- "daily" | "fixed"
+    expense_id INT NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size BIGINT NOT NULL,
+    sha256 CHAR(64) NOT NULL, -- Hash del contenido (deduplicación)
+    storage_key VARCHAR(255) NOT NULL,
+    thumbnail_key VARCHAR(255) NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    
+    INDEX idx_attachment_expense (expense_type, expense_id),
+    INDEX idx_sha256 (sha256)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- 14. ETIQUETAS DE GASTOS
+CREATE TABLE IF NOT EXISTS tags (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    name VARCHAR(50) NOT NULL UNIQUE, -- "vacaciones-2026"
+    color VARCHAR(7) NULL, -- "#1e88e5"
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS daily_expense_tags (
+    daily_expense_id INT NOT NULL,
+    tag_id INT NOT NULL,
+    
+    PRIMARY KEY (daily_expense_id, tag_id),
+    INDEX idx_tag_id (tag_id),
+    
+    FOREIGN KEY (daily_expense_id) REFERENCES daily_expenses(id) ON DELETE CASCADE,
+    FOREIGN KEY (tag_id) REFERENCES tags(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS fixed_expense_tags (
+    fixed_expense_id INT NOT NULL,
+    tag_id INT NOT NULL,
+    
+    PRIMARY KEY (fixed_expense_id, tag_id),
+    INDEX idx_tag_id (tag_id),
+    
+    FOREIGN KEY (fixed_expense_id) REFERENCES fixed_expenses(id) ON DELETE CASCADE,
+    FOREIGN KEY (tag_id) REFERENCES tags(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- 15. DIVISIÓN DE GASTOS DIARIOS ENTRE BOLSILLOS
+CREATE TABLE IF NOT EXISTS daily_expense_splits (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    daily_expense_id INT NOT NULL,
+    pocket_id INT NOT NULL,
+    amount DECIMAL(15,2) NOT NULL,
+    note VARCHAR(255) NULL,
+    
+    INDEX idx_daily_expense_id (daily_expense_id),
+    INDEX idx_pocket_id (pocket_id),
+    
+    FOREIGN KEY (daily_expense_id) REFERENCES daily_expenses(id) ON DELETE CASCADE,
+    FOREIGN KEY (pocket_id) REFERENCES pockets(id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- 16. GASTOS REEMBOLSABLES Y REEMBOLSOS
+CREATE TABLE IF NOT EXISTS receivables (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    expense_type VARCHAR(10) NOT NULL,
+    expense_id INT NOT NULL,
+    counterparty VARCHAR(255) NOT NULL,
+    amount DECIMAL(15,2) NOT NULL,
+    note VARCHAR(500) NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    
+    UNIQUE KEY idx_receivable_expense (expense_type, expense_id),
+    INDEX idx_counterparty (counterparty)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS reimbursements (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    receivable_id INT NOT NULL,
+    amount DECIMAL(15,2) NOT NULL,
+    received_date VARCHAR(10) NOT NULL,
+    note VARCHAR(500) NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    
+    INDEX idx_receivable_id (receivable_id),
+    
+    FOREIGN KEY (receivable_id) REFERENCES receivables(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- 17. GASTOS COMPARTIDOS ENTRE MIEMBROS DEL HOGAR
+CREATE TABLE IF NOT EXISTS household_members (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    name VARCHAR(100) NOT NULL UNIQUE,
+    email VARCHAR(255) NULL,
+    is_active BOOLEAN DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS expense_shares (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    expense_type VARCHAR(10) NOT NULL,
+    expense_id INT NOT NULL,
+    payer_id INT NOT NULL,
+    method VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    
+    UNIQUE KEY idx_share_expense (expense_type, expense_id),
+    INDEX idx_payer_id (payer_id),
+    
+    FOREIGN KEY (payer_id) REFERENCES household_members(id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS expense_share_parts (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    share_id INT NOT NULL,
+    member_id INT NOT NULL,
+    percentage DECIMAL(5,2) NULL,
+    amount DECIMAL(15,2) NULL,
+    
+    INDEX idx_share_id (share_id),
+    INDEX idx_member_id (member_id),
+    
+    FOREIGN KEY (share_id) REFERENCES expense_shares(id) ON DELETE CASCADE,
+    FOREIGN KEY (member_id) REFERENCES household_members(id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- 18. REGLAS DE CATEGORIZACIÓN DE GASTOS DIARIOS
+CREATE TABLE IF NOT EXISTS categorization_rules (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    name VARCHAR(100) NOT NULL,
+    match_type VARCHAR(20) NOT NULL,
+    pattern VARCHAR(255) NOT NULL,
+    min_amount DECIMAL(15,2) NULL,
+    max_amount DECIMAL(15,2) NULL,
+    pocket_id INT NULL,
+    priority INT NOT NULL DEFAULT 0,
+    active BOOLEAN DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    
+    INDEX idx_pocket_id (pocket_id),
+    INDEX idx_priority (priority),
+    INDEX idx_active (active),
+    
+    FOREIGN KEY (pocket_id) REFERENCES pockets(id) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS categorization_rule_tags (
+    rule_id INT NOT NULL,
+    tag_id INT NOT NULL,
+    
+    PRIMARY KEY (rule_id, tag_id),
+    INDEX idx_tag_id (tag_id),
+    
+    FOREIGN KEY (rule_id) REFERENCES categorization_rules(id) ON DELETE CASCADE,
+    FOREIGN KEY (tag_id) REFERENCES tags(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- 19. COLUMNAS AUSENTES EN TABLAS CREADAS CON sql/database/
+-- CREATE TABLE IF NOT EXISTS no modifica las tablas existentes, así que
+-- se agregan aquí las columnas que los scripts manuales no creaban.
+-- Debe ejecutarse antes de las vistas, que leen fe.created_at.
+SET @sql = IF((SELECT COUNT(*) FROM information_schema.COLUMNS WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = 'salaries' AND COLUMN_NAME = 'created_at') = 0, 'ALTER TABLE salaries ADD COLUMN created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP', 'SELECT 1');
+PREPARE stmt FROM @sql;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+SET @sql = IF((SELECT COUNT(*) FROM information_schema.COLUMNS WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = 'pockets' AND COLUMN_NAME = 'rollover_policy') = 0, 'ALTER TABLE pockets ADD COLUMN rollover_policy VARCHAR(20) NOT NULL DEFAULT ''rollover''', 'SELECT 1');
+PREPARE stmt FROM @sql;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+SET @sql = IF((SELECT COUNT(*) FROM information_schema.COLUMNS WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = 'pockets' AND COLUMN_NAME = 'created_at') = 0, 'ALTER TABLE pockets ADD COLUMN created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP', 'SELECT 1');
+PREPARE stmt FROM @sql;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+SET @sql = IF((SELECT COUNT(*) FROM information_schema.COLUMNS WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = 'fixed_expenses' AND COLUMN_NAME = 'actual_amount') = 0, 'ALTER TABLE fixed_expenses ADD COLUMN actual_amount DECIMAL(15,2) NULL', 'SELECT 1');
+PREPARE stmt FROM @sql;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+SET @sql = IF((SELECT COUNT(*) FROM information_schema.COLUMNS WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = 'fixed_expenses' AND COLUMN_NAME = 'created_at') = 0, 'ALTER TABLE fixed_expenses ADD COLUMN created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP', 'SELECT 1');
+PREPARE stmt FROM @sql;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+SET @sql = IF((SELECT COUNT(*) FROM information_schema.COLUMNS WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = 'daily_expenses' AND COLUMN_NAME = 'pocket_id') = 0, 'ALTER TABLE daily_expenses ADD COLUMN pocket_id INT NULL', 'SELECT 1');
+PREPARE stmt FROM @sql;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+SET @sql = IF((SELECT COUNT(*) FROM information_schema.STATISTICS WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = 'daily_expenses' AND COLUMN_NAME = 'pocket_id') = 0, 'ALTER TABLE daily_expenses ADD INDEX idx_pocket_id (pocket_id)', 'SELECT 1');
+PREPARE stmt FROM @sql;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+SET @sql = IF((SELECT COUNT(*) FROM information_schema.KEY_COLUMN_USAGE WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = 'daily_expenses' AND COLUMN_NAME = 'pocket_id' AND REFERENCED_TABLE_NAME = 'pockets') = 0, 'ALTER TABLE daily_expenses ADD FOREIGN KEY (pocket_id) REFERENCES pockets(id) ON DELETE RESTRICT', 'SELECT 1');
+PREPARE stmt FROM @sql;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+SET @sql = IF((SELECT COUNT(*) FROM information_schema.COLUMNS WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = 'daily_expenses_configs' AND COLUMN_NAME = 'created_at') = 0, 'ALTER TABLE daily_expenses_configs ADD COLUMN created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP', 'SELECT 1');
+PREPARE stmt FROM @sql;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+-- Vista: Gastos fijos con estado
+CREATE OR REPLACE VIEW v_fixed_expenses_with_status AS
+SELECT 
+    fe.id,
+    p.name as pocket_name,
+    fe.concept_name as name,
+    fe.amount,
+    fe.payment_day as due_date,
+    fe.is_paid,
+    fe.month,
+    fe.paid_date,
+    fe.created_at,
+    
+    -- Estado calculado
+    CASE 
+        WHEN fe.is_paid = TRUE THEN 'paid'
+        WHEN DAY(CURRENT_DATE) > fe.payment_day 
+             AND DATE_FORMAT(CURRENT_DATE, '%Y-%m') = fe.month THEN 'overdue'
+        ELSE 'pending'
+    END as status,
+    
+    fe.pocket_id,
+    p.description as pocket_description
+FROM fixed_expenses fe
+JOIN pockets p ON fe.pocket_id = p.id;
+
+-- Vista: Resumen mensual
+CREATE OR REPLACE VIEW v_monthly_summary AS
+SELECT 
+    months.month,
+    COALESCE(s.monthly_amount, 0) as total_income,
+    COALESCE(fe_summary.total_fixed_expenses, 0) as total_fixed_expenses,
+    COALESCE(fe_summary.fixed_expenses_paid, 0) as fixed_expenses_paid,
+    COALESCE(fe_summary.fixed_expenses_total, 0) as fixed_expenses_total,
+    COALESCE(de_summary.total_daily_expenses, 0) as total_daily_expenses,
+    COALESCE(dec.monthly_budget, 0) as daily_budget_total,
+    
+    (COALESCE(s.monthly_amount, 0) - 
+     COALESCE(fe_summary.total_fixed_expenses, 0) - 
+     COALESCE(de_summary.total_daily_expenses, 0)) as remaining_budget,
+     
+    CASE 
+        WHEN COALESCE(dec.monthly_budget, 0) > 0 THEN
+            ROUND((COALESCE(de_summary.total_daily_expenses, 0) / dec.monthly_budget) * 100, 2)
+        ELSE 0
+    END as daily_budget_used_percentage
+
+FROM (
+    SELECT DISTINCT month FROM salaries
+    UNION SELECT DISTINCT month FROM fixed_expenses
+    UNION SELECT DISTINCT DATE_FORMAT(STR_TO_DATE(date, '%Y-%m-%d'), '%Y-%m') FROM daily_expenses
+    UNION SELECT DISTINCT month FROM daily_expenses_configs
+) months
+
+LEFT JOIN salaries s ON months.month = s.month
+LEFT JOIN (
+    SELECT month, SUM(amount) as total_fixed_expenses, COUNT(*) as fixed_expenses_total,
+           SUM(CASE WHEN is_paid = TRUE THEN 1 ELSE 0 END) as fixed_expenses_paid
+    FROM fixed_expenses GROUP BY month
+) fe_summary ON months.month = fe_summary.month
+LEFT JOIN (
+    SELECT DATE_FORMAT(STR_TO_DATE(date, '%Y-%m-%d'), '%Y-%m') as month, SUM(amount) as total_daily_expenses
+    FROM daily_expenses GROUP BY DATE_FORMAT(STR_TO_DATE(date, '%Y-%m-%d'), '%Y-%m')
+) de_summary ON months.month = de_summary.month
+LEFT JOIN daily_expenses_configs dec ON months.month = dec.month;
@@ -0,0 +1,7 @@
+-- =====================================================
+-- 0002 - MES ÚNICO EN SALARIOS (REVERTIR)
+-- =====================================================
+
+ALTER TABLE salaries
+    DROP INDEX idx_salaries_month,
+    ADD INDEX idx_month (month);
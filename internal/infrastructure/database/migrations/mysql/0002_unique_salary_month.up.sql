@@ -0,0 +1,11 @@
+-- =====================================================
+-- 0002 - MES ÚNICO EN SALARIOS
+-- =====================================================
+-- 02_create_tables.sql solo creaba un índice simple en salaries.month,
+-- mientras el modelo exige un único salario por mes (uniqueIndex).
+-- Falla si ya existen meses duplicados; elimínelos antes de migrar.
+-- =====================================================
+
+ALTER TABLE salaries
+    DROP INDEX idx_month,
+    ADD UNIQUE INDEX idx_salaries_month (month);
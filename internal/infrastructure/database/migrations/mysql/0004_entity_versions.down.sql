@@ -0,0 +1,10 @@
+-- =====================================================
+-- 0004 - VERSIÓN PARA CONTROL DE CONCURRENCIA OPTIMISTA (REVERTIR)
+-- =====================================================
+
+ALTER TABLE transfers DROP COLUMN version;
+ALTER TABLE daily_expenses_configs DROP COLUMN version;
+ALTER TABLE daily_expenses DROP COLUMN version;
+ALTER TABLE fixed_expenses DROP COLUMN version;
+ALTER TABLE pockets DROP COLUMN version;
+ALTER TABLE salaries DROP COLUMN version;
@@ -0,0 +1,14 @@
+-- =====================================================
+-- 0004 - VERSIÓN PARA CONTROL DE CONCURRENCIA OPTIMISTA
+-- =====================================================
+-- Cada actualización incrementa version y solo se aplica si la fila
+-- conserva la versión leída, así dos ediciones simultáneas no se
+-- sobrescriben en silencio. Las filas existentes quedan en la versión 1.
+-- =====================================================
+
+ALTER TABLE salaries ADD COLUMN version INT UNSIGNED NOT NULL DEFAULT 1;
+ALTER TABLE pockets ADD COLUMN version INT UNSIGNED NOT NULL DEFAULT 1;
+ALTER TABLE fixed_expenses ADD COLUMN version INT UNSIGNED NOT NULL DEFAULT 1;
+ALTER TABLE daily_expenses ADD COLUMN version INT UNSIGNED NOT NULL DEFAULT 1;
+ALTER TABLE daily_expenses_configs ADD COLUMN version INT UNSIGNED NOT NULL DEFAULT 1;
+ALTER TABLE transfers ADD COLUMN version INT UNSIGNED NOT NULL DEFAULT 1;
@@ -0,0 +1,21 @@
+-- =====================================================
+-- 0005 - CLAVES DE IDEMPOTENCIA
+-- =====================================================
+-- Guarda la primera respuesta de cada POST enviado con el header
+-- Idempotency-Key, para que los reintentos la repitan en vez de
+-- crear registros duplicados. Las claves vencen en expires_at.
+-- =====================================================
+
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    idempotency_key VARCHAR(255) NOT NULL,
+    fingerprint CHAR(64) NOT NULL, -- SHA-256 del método, la ruta y el cuerpo
+    status_code INT NOT NULL DEFAULT 0, -- 0 mientras la primera petición está en curso
+    content_type VARCHAR(255) NULL,
+    response_body MEDIUMTEXT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL,
+    
+    UNIQUE KEY uk_idempotency_key (idempotency_key),
+    INDEX idx_expires_at (expires_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
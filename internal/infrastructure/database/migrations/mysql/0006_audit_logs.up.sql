@@ -0,0 +1,22 @@
+-- =====================================================
+-- 0006 - REGISTRO DE AUDITORÍA
+-- =====================================================
+-- Cada creación, modificación o eliminación de una entidad guarda
+-- una fila con el estado anterior y posterior en JSON, quién hizo
+-- el cambio y cuándo. Se escribe en la misma transacción que el
+-- cambio y la aplicación nunca modifica ni elimina sus filas.
+-- =====================================================
+
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    entity VARCHAR(64) NOT NULL, -- Tabla modificada, ej: "daily_expenses"
+    entity_id INT NOT NULL,
+    action VARCHAR(10) NOT NULL, -- create | update | delete
+    before_state MEDIUMTEXT NULL, -- NULL al crear
+    after_state MEDIUMTEXT NULL, -- NULL al eliminar
+    actor VARCHAR(100) NOT NULL, -- Header X-Actor, o "system" para los procesos internos
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    
+    INDEX idx_audit_logs_entity_created (entity, created_at),
+    INDEX idx_audit_logs_created_at (created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
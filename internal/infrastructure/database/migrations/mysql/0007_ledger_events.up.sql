@@ -0,0 +1,22 @@
+-- =====================================================
+-- 0007 - REGISTRO DE EVENTOS DEL LIBRO
+-- =====================================================
+-- Cada cambio de un gasto, pago, transferencia, reembolsable o de la
+-- configuración (ingresos, presupuesto diario, bolsillos) agrega un
+-- evento de dominio con el estado completo de la entidad. Reproducir
+-- los eventos en orden reconstruye el libro en cualquier momento.
+-- Se escribe en la misma transacción que el cambio y la aplicación
+-- nunca modifica ni elimina sus filas.
+-- =====================================================
+
+CREATE TABLE IF NOT EXISTS ledger_events (
+    id INT PRIMARY KEY AUTO_INCREMENT, -- Orden en que se reproducen los eventos
+    name VARCHAR(100) NOT NULL, -- ej: "daily_expense.created", "salary.updated"
+    entity VARCHAR(64) NOT NULL, -- Tabla de la entidad, ej: "daily_expenses"
+    entity_id INT NOT NULL,
+    data MEDIUMTEXT NULL, -- Estado de la entidad en JSON; NULL al eliminar
+    occurred_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    
+    INDEX idx_ledger_events_entity (entity, entity_id),
+    INDEX idx_ledger_events_occurred_at (occurred_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,16 @@
+-- =====================================================
+-- 0008 - RECORDATORIOS ENVIADOS POR CANAL (REVERTIR)
+-- =====================================================
+-- Conserva un registro por gasto y tipo de recordatorio.
+-- =====================================================
+
+DELETE r FROM reminder_logs r
+JOIN reminder_logs kept
+    ON kept.fixed_expense_id = r.fixed_expense_id
+    AND kept.kind = r.kind
+    AND kept.id < r.id;
+
+ALTER TABLE reminder_logs
+    ADD UNIQUE INDEX idx_reminder_expense_kind (fixed_expense_id, kind),
+    DROP INDEX idx_reminder_expense_kind_channel,
+    DROP COLUMN channel;
@@ -0,0 +1,21 @@
+-- =====================================================
+-- 0008 - RECORDATORIOS ENVIADOS POR CANAL
+-- =====================================================
+-- Cada notificador (smtp, webhook) registra su propio envío, así que un
+-- canal que falla se reintenta en la siguiente revisión sin repetir el
+-- recordatorio en los canales que sí lo entregaron.
+-- Los recordatorios ya registrados se marcan como enviados por ambos
+-- canales para no repetirlos.
+-- =====================================================
+
+ALTER TABLE reminder_logs
+    ADD COLUMN channel VARCHAR(20) NOT NULL DEFAULT 'smtp' AFTER kind;
+
+INSERT INTO reminder_logs (fixed_expense_id, kind, channel, sent_at)
+SELECT fixed_expense_id, kind, 'webhook', sent_at FROM reminder_logs;
+
+ALTER TABLE reminder_logs
+    ADD UNIQUE INDEX idx_reminder_expense_kind_channel (fixed_expense_id, kind, channel),
+    DROP INDEX idx_reminder_expense_kind;
+
+ALTER TABLE reminder_logs ALTER COLUMN channel DROP DEFAULT;
@@ -0,0 +1,10 @@
+-- =====================================================
+-- 0009 - MES DE LOS EVENTOS Y ESTADO INICIAL DEL LIBRO (REVERTIR)
+-- =====================================================
+-- Los eventos "created" agregados se conservan: registran el estado de
+-- las filas y volver a aplicar la migración no los duplica.
+-- =====================================================
+
+ALTER TABLE ledger_events
+    DROP INDEX idx_ledger_events_month,
+    DROP COLUMN month;
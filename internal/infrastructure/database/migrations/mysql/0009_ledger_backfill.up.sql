@@ -0,0 +1,23 @@
+-- =====================================================
+-- 0009 - MES DE LOS EVENTOS Y ESTADO INICIAL DEL LIBRO
+-- =====================================================
+-- Los eventos de ingresos, presupuestos diarios, gastos y transferencias
+-- guardan el mes al que pertenece la entidad, para reconstruir un
+-- resumen mensual sin leer todo el registro.
+-- Después de estas sentencias, la migración agrega un evento "created"
+-- con el estado actual de cada fila que ningún evento registró (las
+-- anteriores a 0007); los resúmenes a una fecha anterior a esta
+-- migración no las incluyen.
+-- =====================================================
+
+ALTER TABLE ledger_events
+    ADD COLUMN month DATE NULL AFTER data,
+    ADD INDEX idx_ledger_events_month (month);
+
+UPDATE ledger_events
+SET month = CONCAT(LEFT(JSON_UNQUOTE(JSON_EXTRACT(data, '$.month')), 7), '-01')
+WHERE entity IN ('salaries', 'daily_expenses_configs', 'fixed_expenses') AND data IS NOT NULL;
+
+UPDATE ledger_events
+SET month = CONCAT(LEFT(JSON_UNQUOTE(JSON_EXTRACT(data, '$.date')), 7), '-01')
+WHERE entity IN ('daily_expenses', 'transfers') AND data IS NOT NULL;
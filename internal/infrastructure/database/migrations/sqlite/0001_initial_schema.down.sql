@@ -0,0 +1,34 @@
+-- =====================================================
+-- 0001 - ESQUEMA INICIAL (SQLITE, REVERTIR)
+-- =====================================================
+-- Elimina todas las tablas y vistas; se pierden todos los datos.
+-- =====================================================
+
+DROP VIEW IF EXISTS v_monthly_summary;
+DROP VIEW IF EXISTS v_fixed_expenses_with_status;
+
+DROP TABLE IF EXISTS categorization_rule_tags;
+DROP TABLE IF EXISTS categorization_rules;
+DROP TABLE IF EXISTS expense_share_parts;
+DROP TABLE IF EXISTS expense_shares;
+DROP TABLE IF EXISTS household_members;
+DROP TABLE IF EXISTS reimbursements;
+DROP TABLE IF EXISTS receivables;
+DROP TABLE IF EXISTS daily_expense_splits;
+DROP TABLE IF EXISTS fixed_expense_tags;
+DROP TABLE IF EXISTS daily_expense_tags;
+DROP TABLE IF EXISTS tags;
+DROP TABLE IF EXISTS attachments;
+DROP TABLE IF EXISTS fixed_expense_payments;
+DROP TABLE IF EXISTS alerts;
+DROP TABLE IF EXISTS alert_rules;
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhook_subscriptions;
+DROP TABLE IF EXISTS reminder_logs;
+DROP TABLE IF EXISTS pocket_allocations;
+DROP TABLE IF EXISTS transfers;
+DROP TABLE IF EXISTS daily_expenses_configs;
+DROP TABLE IF EXISTS daily_expenses;
+DROP TABLE IF EXISTS fixed_expenses;
+DROP TABLE IF EXISTS pockets;
+DROP TABLE IF EXISTS salaries;
@@ -0,0 +1,430 @@
+-- =====================================================
+-- 0001 - ESQUEMA INICIAL (SQLITE)
+-- =====================================================
+-- Mismo esquema que mysql/0001_initial_schema.up.sql para desarrollo
+-- local y pruebas. Los índices se crean aparte porque en SQLite sus
+-- nombres son globales a la base de datos.
+-- =====================================================
+
+-- 1. SALARIOS MENSUALES
+CREATE TABLE IF NOT EXISTS salaries (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    monthly_amount DECIMAL(15,2) NOT NULL,
+    month VARCHAR(7) NOT NULL, -- "2024-01" format
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- 2. BOLSILLOS
+CREATE TABLE IF NOT EXISTS pockets (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name VARCHAR(255) NOT NULL UNIQUE,
+    description TEXT NULL,
+    rollover_policy VARCHAR(20) NOT NULL DEFAULT 'rollover', -- "rollover" | "sweep"
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- 3. GASTOS FIJOS MENSUALES
+CREATE TABLE IF NOT EXISTS fixed_expenses (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    pocket_id INT NOT NULL,
+    concept_name VARCHAR(255) NOT NULL,
+    amount DECIMAL(15,2) NOT NULL,
+    payment_day INT NOT NULL, -- día del mes (1-31)
+    is_paid BOOLEAN DEFAULT FALSE,
+    month VARCHAR(7) NOT NULL, -- "2024-01" format
+    paid_date VARCHAR(10) NULL, -- "2024-01-15" format
+    actual_amount DECIMAL(15,2) NULL, -- Monto real facturado, si difiere de amount
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    
+    FOREIGN KEY (pocket_id) REFERENCES pockets(id) ON DELETE RESTRICT,
+    CONSTRAINT chk_payment_day CHECK (payment_day BETWEEN 1 AND 31)
+);
+
+CREATE INDEX IF NOT EXISTS idx_fixed_expenses_month ON fixed_expenses (month);
+CREATE INDEX IF NOT EXISTS idx_fixed_expenses_is_paid ON fixed_expenses (is_paid);
+CREATE INDEX IF NOT EXISTS idx_fixed_expenses_payment_day ON fixed_expenses (payment_day);
+CREATE INDEX IF NOT EXISTS idx_fixed_expenses_pocket_id_month ON fixed_expenses (pocket_id, month);
+
+-- 4. GASTOS DIARIOS
+CREATE TABLE IF NOT EXISTS daily_expenses (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    description VARCHAR(500) NOT NULL,
+    amount DECIMAL(15,2) NOT NULL,
+    date VARCHAR(10) NOT NULL, -- "2024-01-15" format
+    pocket_id INT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    
+    FOREIGN KEY (pocket_id) REFERENCES pockets(id) ON DELETE RESTRICT
+);
+
+CREATE INDEX IF NOT EXISTS idx_daily_expenses_date ON daily_expenses (date);
+CREATE INDEX IF NOT EXISTS idx_daily_expenses_pocket_id ON daily_expenses (pocket_id);
+
+-- 5. CONFIGURACIÓN DE PRESUPUESTO DIARIO MENSUAL
+CREATE TABLE IF NOT EXISTS daily_expenses_configs (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    monthly_budget DECIMAL(15,2) NOT NULL,
+    month VARCHAR(7) NOT NULL UNIQUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- 6. TRANSFERENCIAS ENTRE CUENTAS Y BOLSILLOS
+CREATE TABLE IF NOT EXISTS transfers (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    source_type VARCHAR(20) NOT NULL, -- "account" | "pocket"
+    source_pocket_id INT NULL,
+    source_account VARCHAR(255) NULL,
+    destination_type VARCHAR(20) NOT NULL, -- "account" | "pocket"
+    destination_pocket_id INT NULL,
+    destination_account VARCHAR(255) NULL,
+    amount DECIMAL(15,2) NOT NULL,
+    date VARCHAR(10) NOT NULL, -- "2024-01-15" format
+    description VARCHAR(500) NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    
+    FOREIGN KEY (source_pocket_id) REFERENCES pockets(id) ON DELETE RESTRICT,
+    FOREIGN KEY (destination_pocket_id) REFERENCES pockets(id) ON DELETE RESTRICT
+);
+
+CREATE INDEX IF NOT EXISTS idx_transfers_date ON transfers (date);
+CREATE INDEX IF NOT EXISTS idx_transfers_source_pocket_id ON transfers (source_pocket_id);
+CREATE INDEX IF NOT EXISTS idx_transfers_destination_pocket_id ON transfers (destination_pocket_id);
+
+-- 7. ASIGNACIONES MENSUALES DE BOLSILLOS (SOBRES)
+CREATE TABLE IF NOT EXISTS pocket_allocations (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    pocket_id INT NOT NULL,
+    month VARCHAR(7) NOT NULL, -- "2024-01" format
+    amount DECIMAL(15,2) NOT NULL,
+    
+    UNIQUE (pocket_id, month),
+    
+    FOREIGN KEY (pocket_id) REFERENCES pockets(id) ON DELETE RESTRICT
+);
+
+CREATE INDEX IF NOT EXISTS idx_pocket_allocations_month ON pocket_allocations (month);
+
+-- 8. RECORDATORIOS ENVIADOS DE GASTOS FIJOS
+CREATE TABLE IF NOT EXISTS reminder_logs (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    fixed_expense_id INT NOT NULL,
+    kind VARCHAR(20) NOT NULL, -- "due_soon" | "overdue"
+    sent_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    
+    UNIQUE (fixed_expense_id, kind),
+    
+    FOREIGN KEY (fixed_expense_id) REFERENCES fixed_expenses(id) ON DELETE CASCADE
+);
+
+-- 9. WEBHOOKS SALIENTES
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    url VARCHAR(2048) NOT NULL,
+    secret VARCHAR(255) NOT NULL, -- Clave HMAC-SHA256
+    events VARCHAR(1000) NOT NULL, -- "expense.created,fixed_expense.paid" | "*"
+    active BOOLEAN DEFAULT TRUE,
+    description VARCHAR(500) NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_subscriptions_active ON webhook_subscriptions (active);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    subscription_id INT NOT NULL,
+    event_id VARCHAR(64) NOT NULL,
+    event VARCHAR(100) NOT NULL,
+    payload TEXT NOT NULL,
+    attempt INT NOT NULL,
+    status_code INT NULL,
+    success BOOLEAN DEFAULT FALSE,
+    error VARCHAR(1000) NULL,
+    duration_ms BIGINT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    
+    FOREIGN KEY (subscription_id) REFERENCES webhook_subscriptions(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_subscription_id ON webhook_deliveries (subscription_id);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_event_id ON webhook_deliveries (event_id);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_created_at ON webhook_deliveries (created_at);
+
+-- 10. ALERTAS DE PRESUPUESTO
+CREATE TABLE IF NOT EXISTS alert_rules (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    kind VARCHAR(20) NOT NULL, -- "daily_budget" | "pocket"
+    pocket_id INT NULL, -- Solo para reglas "pocket"
+    threshold_percent DECIMAL(6,2) NOT NULL,
+    active BOOLEAN DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    
+    FOREIGN KEY (pocket_id) REFERENCES pockets(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_alert_rules_kind ON alert_rules (kind);
+CREATE INDEX IF NOT EXISTS idx_alert_rules_pocket_id ON alert_rules (pocket_id);
+
+CREATE TABLE IF NOT EXISTS alerts (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    rule_id INT NOT NULL,
+    month VARCHAR(7) NOT NULL, -- "2024-01" format
+    kind VARCHAR(20) NOT NULL,
+    pocket_id INT NULL,
+    threshold_percent DECIMAL(6,2) NOT NULL,
+    budget DECIMAL(15,2) NOT NULL,
+    spent DECIMAL(15,2) NOT NULL,
+    message VARCHAR(500) NOT NULL,
+    acknowledged BOOLEAN DEFAULT FALSE,
+    acknowledged_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    
+    UNIQUE (rule_id, month),
+    
+    FOREIGN KEY (rule_id) REFERENCES alert_rules(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_alerts_month ON alerts (month);
+CREATE INDEX IF NOT EXISTS idx_alerts_acknowledged ON alerts (acknowledged);
+
+-- 11. PAGOS DE GASTOS FIJOS (TOTALES O PARCIALES)
+CREATE TABLE IF NOT EXISTS fixed_expense_payments (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    fixed_expense_id INT NOT NULL,
+    amount DECIMAL(15,2) NOT NULL,
+    paid_date VARCHAR(10) NOT NULL, -- "2024-01-15" format
+    method VARCHAR(50) NULL, -- "cash" | "transfer" | "card" | ...
+    note VARCHAR(500) NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    
+    FOREIGN KEY (fixed_expense_id) REFERENCES fixed_expenses(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_fixed_expense_payments_fixed_expense_id ON fixed_expense_payments (fixed_expense_id);
+
+-- 13. COMPROBANTES ADJUNTOS A GASTOS
+CREATE TABLE IF NOT EXISTS attachments (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    expense_type VARCHAR(10) NOT NULL, -- "daily" | "fixed"
+    expense_id INT NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size BIGINT NOT NULL,
+    sha256 CHAR(64) NOT NULL, -- Hash del contenido (deduplicación)
+    storage_key VARCHAR(255) NOT NULL,
+    thumbnail_key VARCHAR(255) NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_attachments_expense_type_expense_id ON attachments (expense_type, expense_id);
+CREATE INDEX IF NOT EXISTS idx_attachments_sha256 ON attachments (sha256);
+
+-- 14. ETIQUETAS DE GASTOS
+CREATE TABLE IF NOT EXISTS tags (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name VARCHAR(50) NOT NULL UNIQUE, -- "vacaciones-2026"
+    color VARCHAR(7) NULL, -- "#1e88e5"
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS daily_expense_tags (
+    daily_expense_id INT NOT NULL,
+    tag_id INT NOT NULL,
+    
+    PRIMARY KEY (daily_expense_id, tag_id),
+    
+    FOREIGN KEY (daily_expense_id) REFERENCES daily_expenses(id) ON DELETE CASCADE,
+    FOREIGN KEY (tag_id) REFERENCES tags(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_daily_expense_tags_tag_id ON daily_expense_tags (tag_id);
+
+CREATE TABLE IF NOT EXISTS fixed_expense_tags (
+    fixed_expense_id INT NOT NULL,
+    tag_id INT NOT NULL,
+    
+    PRIMARY KEY (fixed_expense_id, tag_id),
+    
+    FOREIGN KEY (fixed_expense_id) REFERENCES fixed_expenses(id) ON DELETE CASCADE,
+    FOREIGN KEY (tag_id) REFERENCES tags(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_fixed_expense_tags_tag_id ON fixed_expense_tags (tag_id);
+
+-- 15. DIVISIÓN DE GASTOS DIARIOS ENTRE BOLSILLOS
+CREATE TABLE IF NOT EXISTS daily_expense_splits (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    daily_expense_id INT NOT NULL,
+    pocket_id INT NOT NULL,
+    amount DECIMAL(15,2) NOT NULL,
+    note VARCHAR(255) NULL,
+    
+    FOREIGN KEY (daily_expense_id) REFERENCES daily_expenses(id) ON DELETE CASCADE,
+    FOREIGN KEY (pocket_id) REFERENCES pockets(id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_daily_expense_splits_daily_expense_id ON daily_expense_splits (daily_expense_id);
+CREATE INDEX IF NOT EXISTS idx_daily_expense_splits_pocket_id ON daily_expense_splits (pocket_id);
+
+-- 16. GASTOS REEMBOLSABLES Y REEMBOLSOS
+CREATE TABLE IF NOT EXISTS receivables (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    expense_type VARCHAR(10) NOT NULL,
+    expense_id INT NOT NULL,
+    counterparty VARCHAR(255) NOT NULL,
+    amount DECIMAL(15,2) NOT NULL,
+    note VARCHAR(500) NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    
+    UNIQUE (expense_type, expense_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_receivables_counterparty ON receivables (counterparty);
+
+CREATE TABLE IF NOT EXISTS reimbursements (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    receivable_id INT NOT NULL,
+    amount DECIMAL(15,2) NOT NULL,
+    received_date VARCHAR(10) NOT NULL,
+    note VARCHAR(500) NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    
+    FOREIGN KEY (receivable_id) REFERENCES receivables(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_reimbursements_receivable_id ON reimbursements (receivable_id);
+
+-- 17. GASTOS COMPARTIDOS ENTRE MIEMBROS DEL HOGAR
+CREATE TABLE IF NOT EXISTS household_members (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name VARCHAR(100) NOT NULL UNIQUE,
+    email VARCHAR(255) NULL,
+    is_active BOOLEAN DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS expense_shares (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    expense_type VARCHAR(10) NOT NULL,
+    expense_id INT NOT NULL,
+    payer_id INT NOT NULL,
+    method VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    
+    UNIQUE (expense_type, expense_id),
+    
+    FOREIGN KEY (payer_id) REFERENCES household_members(id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_expense_shares_payer_id ON expense_shares (payer_id);
+
+CREATE TABLE IF NOT EXISTS expense_share_parts (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    share_id INT NOT NULL,
+    member_id INT NOT NULL,
+    percentage DECIMAL(5,2) NULL,
+    amount DECIMAL(15,2) NULL,
+    
+    FOREIGN KEY (share_id) REFERENCES expense_shares(id) ON DELETE CASCADE,
+    FOREIGN KEY (member_id) REFERENCES household_members(id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_expense_share_parts_share_id ON expense_share_parts (share_id);
+CREATE INDEX IF NOT EXISTS idx_expense_share_parts_member_id ON expense_share_parts (member_id);
+
+-- 18. REGLAS DE CATEGORIZACIÓN DE GASTOS DIARIOS
+CREATE TABLE IF NOT EXISTS categorization_rules (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name VARCHAR(100) NOT NULL,
+    match_type VARCHAR(20) NOT NULL,
+    pattern VARCHAR(255) NOT NULL,
+    min_amount DECIMAL(15,2) NULL,
+    max_amount DECIMAL(15,2) NULL,
+    pocket_id INT NULL,
+    priority INT NOT NULL DEFAULT 0,
+    active BOOLEAN DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    
+    FOREIGN KEY (pocket_id) REFERENCES pockets(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_categorization_rules_pocket_id ON categorization_rules (pocket_id);
+CREATE INDEX IF NOT EXISTS idx_categorization_rules_priority ON categorization_rules (priority);
+CREATE INDEX IF NOT EXISTS idx_categorization_rules_active ON categorization_rules (active);
+
+CREATE TABLE IF NOT EXISTS categorization_rule_tags (
+    rule_id INT NOT NULL,
+    tag_id INT NOT NULL,
+    
+    PRIMARY KEY (rule_id, tag_id),
+    
+    FOREIGN KEY (rule_id) REFERENCES categorization_rules(id) ON DELETE CASCADE,
+    FOREIGN KEY (tag_id) REFERENCES tags(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_categorization_rule_tags_tag_id ON categorization_rule_tags (tag_id);
+
+-- Vista: Gastos fijos con estado
+CREATE VIEW IF NOT EXISTS v_fixed_expenses_with_status AS
+SELECT 
+    fe.id,
+    p.name as pocket_name,
+    fe.concept_name as name,
+    fe.amount,
+    fe.payment_day as due_date,
+    fe.is_paid,
+    fe.month,
+    fe.paid_date,
+    fe.created_at,
+    
+    -- Estado calculado
+    CASE 
+        WHEN fe.is_paid = TRUE THEN 'paid'
+        WHEN CAST(strftime('%d', 'now', 'localtime') AS INTEGER) > fe.payment_day 
+             AND strftime('%Y-%m', 'now', 'localtime') = fe.month THEN 'overdue'
+        ELSE 'pending'
+    END as status,
+    
+    fe.pocket_id,
+    p.description as pocket_description
+FROM fixed_expenses fe
+JOIN pockets p ON fe.pocket_id = p.id;
+
+-- Vista: Resumen mensual
+CREATE VIEW IF NOT EXISTS v_monthly_summary AS
+SELECT 
+    months.month,
+    COALESCE(s.monthly_amount, 0) as total_income,
+    COALESCE(fe_summary.total_fixed_expenses, 0) as total_fixed_expenses,
+    COALESCE(fe_summary.fixed_expenses_paid, 0) as fixed_expenses_paid,
+    COALESCE(fe_summary.fixed_expenses_total, 0) as fixed_expenses_total,
+    COALESCE(de_summary.total_daily_expenses, 0) as total_daily_expenses,
+    COALESCE(dec.monthly_budget, 0) as daily_budget_total,
+    
+    (COALESCE(s.monthly_amount, 0) - 
+     COALESCE(fe_summary.total_fixed_expenses, 0) - 
+     COALESCE(de_summary.total_daily_expenses, 0)) as remaining_budget,
+     
+    CASE 
+        WHEN COALESCE(dec.monthly_budget, 0) > 0 THEN
+            ROUND((COALESCE(de_summary.total_daily_expenses, 0) / dec.monthly_budget) * 100, 2)
+        ELSE 0
+    END as daily_budget_used_percentage
+
+FROM (
+    SELECT DISTINCT month FROM salaries
+    UNION SELECT DISTINCT month FROM fixed_expenses
+    UNION SELECT DISTINCT substr(date, 1, 7) FROM daily_expenses
+    UNION SELECT DISTINCT month FROM daily_expenses_configs
+) months
+
+LEFT JOIN salaries s ON months.month = s.month
+LEFT JOIN (
+    SELECT month, SUM(amount) as total_fixed_expenses, COUNT(*) as fixed_expenses_total,
+           SUM(CASE WHEN is_paid = TRUE THEN 1 ELSE 0 END) as fixed_expenses_paid
+    FROM fixed_expenses GROUP BY month
+) fe_summary ON months.month = fe_summary.month
+LEFT JOIN (
+    SELECT substr(date, 1, 7) as month, SUM(amount) as total_daily_expenses
+    FROM daily_expenses GROUP BY substr(date, 1, 7)
+) de_summary ON months.month = de_summary.month
+LEFT JOIN daily_expenses_configs dec ON months.month = dec.month;
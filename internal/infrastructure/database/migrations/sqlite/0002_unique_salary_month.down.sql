@@ -0,0 +1,5 @@
+-- =====================================================
+-- 0002 - MES ÚNICO EN SALARIOS (SQLITE, REVERTIR)
+-- =====================================================
+
+DROP INDEX IF EXISTS idx_salaries_month;
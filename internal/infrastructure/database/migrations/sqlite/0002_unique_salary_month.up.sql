@@ -0,0 +1,8 @@
+-- =====================================================
+-- 0002 - MES ÚNICO EN SALARIOS (SQLITE)
+-- =====================================================
+-- Equivalente a mysql/0002_unique_salary_month.up.sql; en SQLite la
+-- tabla se crea sin índice en month y aquí se agrega el índice único.
+-- =====================================================
+
+CREATE UNIQUE INDEX idx_salaries_month ON salaries (month);
@@ -0,0 +1,78 @@
+-- =====================================================
+-- 0003 - FECHAS Y MESES EN COLUMNAS DATE (SQLITE, REVERTIR)
+-- =====================================================
+
+DROP VIEW IF EXISTS v_monthly_summary;
+DROP VIEW IF EXISTS v_fixed_expenses_with_status;
+
+UPDATE salaries SET month = substr(month, 1, 7);
+UPDATE fixed_expenses SET month = substr(month, 1, 7);
+UPDATE daily_expenses_configs SET month = substr(month, 1, 7);
+UPDATE pocket_allocations SET month = substr(month, 1, 7);
+UPDATE alerts SET month = substr(month, 1, 7);
+
+-- Vista: Gastos fijos con estado
+CREATE VIEW IF NOT EXISTS v_fixed_expenses_with_status AS
+SELECT 
+    fe.id,
+    p.name as pocket_name,
+    fe.concept_name as name,
+    fe.amount,
+    fe.payment_day as due_date,
+    fe.is_paid,
+    fe.month,
+    fe.paid_date,
+    fe.created_at,
+    
+    -- Estado calculado
+    CASE 
+        WHEN fe.is_paid = TRUE THEN 'paid'
+        WHEN CAST(strftime('%d', 'now', 'localtime') AS INTEGER) > fe.payment_day 
+             AND strftime('%Y-%m', 'now', 'localtime') = fe.month THEN 'overdue'
+        ELSE 'pending'
+    END as status,
+    
+    fe.pocket_id,
+    p.description as pocket_description
+FROM fixed_expenses fe
+JOIN pockets p ON fe.pocket_id = p.id;
+
+-- Vista: Resumen mensual
+CREATE VIEW IF NOT EXISTS v_monthly_summary AS
+SELECT 
+    months.month,
+    COALESCE(s.monthly_amount, 0) as total_income,
+    COALESCE(fe_summary.total_fixed_expenses, 0) as total_fixed_expenses,
+    COALESCE(fe_summary.fixed_expenses_paid, 0) as fixed_expenses_paid,
+    COALESCE(fe_summary.fixed_expenses_total, 0) as fixed_expenses_total,
+    COALESCE(de_summary.total_daily_expenses, 0) as total_daily_expenses,
+    COALESCE(dec.monthly_budget, 0) as daily_budget_total,
+    
+    (COALESCE(s.monthly_amount, 0) - 
+     COALESCE(fe_summary.total_fixed_expenses, 0) - 
+     COALESCE(de_summary.total_daily_expenses, 0)) as remaining_budget,
+     
+    CASE 
+        WHEN COALESCE(dec.monthly_budget, 0) > 0 THEN
+            ROUND((COALESCE(de_summary.total_daily_expenses, 0) / dec.monthly_budget) * 100, 2)
+        ELSE 0
+    END as daily_budget_used_percentage
+
+FROM (
+    SELECT DISTINCT month FROM salaries
+    UNION SELECT DISTINCT month FROM fixed_expenses
+    UNION SELECT DISTINCT substr(date, 1, 7) FROM daily_expenses
+    UNION SELECT DISTINCT month FROM daily_expenses_configs
+) months
+
+LEFT JOIN salaries s ON months.month = s.month
+LEFT JOIN (
+    SELECT month, SUM(amount) as total_fixed_expenses, COUNT(*) as fixed_expenses_total,
+           SUM(CASE WHEN is_paid = TRUE THEN 1 ELSE 0 END) as fixed_expenses_paid
+    FROM fixed_expenses GROUP BY month
+) fe_summary ON months.month = fe_summary.month
+LEFT JOIN (
+    SELECT substr(date, 1, 7) as month, SUM(amount) as total_daily_expenses
+    FROM daily_expenses GROUP BY substr(date, 1, 7)
+) de_summary ON months.month = de_summary.month
+LEFT JOIN daily_expenses_configs dec ON months.month = dec.month;
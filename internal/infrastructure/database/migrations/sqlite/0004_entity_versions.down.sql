@@ -0,0 +1,12 @@
+-- =====================================================
+-- 0004 - VERSIÓN PARA CONTROL DE CONCURRENCIA OPTIMISTA (SQLITE, REVERTIR)
+-- =====================================================
+-- Requiere SQLite 3.35 o posterior (ALTER TABLE ... DROP COLUMN).
+-- =====================================================
+
+ALTER TABLE transfers DROP COLUMN version;
+ALTER TABLE daily_expenses_configs DROP COLUMN version;
+ALTER TABLE daily_expenses DROP COLUMN version;
+ALTER TABLE fixed_expenses DROP COLUMN version;
+ALTER TABLE pockets DROP COLUMN version;
+ALTER TABLE salaries DROP COLUMN version;
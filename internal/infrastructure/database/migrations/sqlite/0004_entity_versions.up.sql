@@ -0,0 +1,12 @@
+-- =====================================================
+-- 0004 - VERSIÓN PARA CONTROL DE CONCURRENCIA OPTIMISTA (SQLITE)
+-- =====================================================
+-- Equivalente a mysql/0004_entity_versions.up.sql.
+-- =====================================================
+
+ALTER TABLE salaries ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE pockets ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE fixed_expenses ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE daily_expenses ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE daily_expenses_configs ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE transfers ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
@@ -0,0 +1,5 @@
+-- =====================================================
+-- 0005 - CLAVES DE IDEMPOTENCIA (SQLITE, REVERTIR)
+-- =====================================================
+
+DROP TABLE IF EXISTS idempotency_keys;
@@ -0,0 +1,19 @@
+-- =====================================================
+-- 0005 - CLAVES DE IDEMPOTENCIA (SQLITE)
+-- =====================================================
+-- Equivalente a mysql/0005_idempotency_keys.up.sql.
+-- =====================================================
+
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    idempotency_key VARCHAR(255) NOT NULL,
+    fingerprint CHAR(64) NOT NULL, -- SHA-256 del método, la ruta y el cuerpo
+    status_code INT NOT NULL DEFAULT 0, -- 0 mientras la primera petición está en curso
+    content_type VARCHAR(255) NULL,
+    response_body TEXT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uk_idempotency_key ON idempotency_keys (idempotency_key);
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON idempotency_keys (expires_at);
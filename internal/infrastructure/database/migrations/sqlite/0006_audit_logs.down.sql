@@ -0,0 +1,5 @@
+-- =====================================================
+-- 0006 - REGISTRO DE AUDITORÍA (SQLITE, REVERTIR)
+-- =====================================================
+
+DROP TABLE IF EXISTS audit_logs;
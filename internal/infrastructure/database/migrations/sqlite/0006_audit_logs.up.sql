@@ -0,0 +1,19 @@
+-- =====================================================
+-- 0006 - REGISTRO DE AUDITORÍA (SQLITE)
+-- =====================================================
+-- Equivalente a mysql/0006_audit_logs.up.sql.
+-- =====================================================
+
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    entity VARCHAR(64) NOT NULL, -- Tabla modificada, ej: "daily_expenses"
+    entity_id INT NOT NULL,
+    action VARCHAR(10) NOT NULL, -- create | update | delete
+    before_state TEXT NULL, -- NULL al crear
+    after_state TEXT NULL, -- NULL al eliminar
+    actor VARCHAR(100) NOT NULL, -- Header X-Actor, o "system" para los procesos internos
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_logs_entity_created ON audit_logs (entity, created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_created_at ON audit_logs (created_at);
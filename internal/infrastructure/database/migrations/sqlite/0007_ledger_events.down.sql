@@ -0,0 +1,5 @@
+-- =====================================================
+-- 0007 - REGISTRO DE EVENTOS DEL LIBRO (SQLITE, REVERTIR)
+-- =====================================================
+
+DROP TABLE IF EXISTS ledger_events;
@@ -0,0 +1,17 @@
+-- =====================================================
+-- 0007 - REGISTRO DE EVENTOS DEL LIBRO (SQLITE)
+-- =====================================================
+-- Equivalente a mysql/0007_ledger_events.up.sql.
+-- =====================================================
+
+CREATE TABLE IF NOT EXISTS ledger_events (
+    id INTEGER PRIMARY KEY AUTOINCREMENT, -- Orden en que se reproducen los eventos
+    name VARCHAR(100) NOT NULL, -- ej: "daily_expense.created", "salary.updated"
+    entity VARCHAR(64) NOT NULL, -- Tabla de la entidad, ej: "daily_expenses"
+    entity_id INT NOT NULL,
+    data TEXT NULL, -- Estado de la entidad en JSON; NULL al eliminar
+    occurred_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_ledger_events_entity ON ledger_events (entity, entity_id);
+CREATE INDEX IF NOT EXISTS idx_ledger_events_occurred_at ON ledger_events (occurred_at);
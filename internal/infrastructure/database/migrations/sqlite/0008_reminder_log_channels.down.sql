@@ -0,0 +1,21 @@
+-- =====================================================
+-- 0008 - RECORDATORIOS ENVIADOS POR CANAL (SQLITE, REVERTIR)
+-- =====================================================
+
+CREATE TABLE reminder_logs_old (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    fixed_expense_id INT NOT NULL,
+    kind VARCHAR(20) NOT NULL, -- "due_soon" | "overdue"
+    sent_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    
+    UNIQUE (fixed_expense_id, kind),
+    
+    FOREIGN KEY (fixed_expense_id) REFERENCES fixed_expenses(id) ON DELETE CASCADE
+);
+
+INSERT INTO reminder_logs_old (fixed_expense_id, kind, sent_at)
+SELECT fixed_expense_id, kind, MIN(sent_at) FROM reminder_logs
+GROUP BY fixed_expense_id, kind;
+
+DROP TABLE reminder_logs;
+ALTER TABLE reminder_logs_old RENAME TO reminder_logs;
@@ -0,0 +1,27 @@
+-- =====================================================
+-- 0008 - RECORDATORIOS ENVIADOS POR CANAL (SQLITE)
+-- =====================================================
+-- Equivalente a mysql/0008_reminder_log_channels.up.sql. SQLite no
+-- puede eliminar la restricción UNIQUE de la tabla, así que se
+-- reconstruye con la nueva columna.
+-- =====================================================
+
+CREATE TABLE reminder_logs_new (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    fixed_expense_id INT NOT NULL,
+    kind VARCHAR(20) NOT NULL, -- "due_soon" | "overdue"
+    channel VARCHAR(20) NOT NULL, -- Notificador: "smtp" | "webhook"
+    sent_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    
+    UNIQUE (fixed_expense_id, kind, channel),
+    
+    FOREIGN KEY (fixed_expense_id) REFERENCES fixed_expenses(id) ON DELETE CASCADE
+);
+
+INSERT INTO reminder_logs_new (fixed_expense_id, kind, channel, sent_at)
+SELECT fixed_expense_id, kind, 'smtp', sent_at FROM reminder_logs
+UNION ALL
+SELECT fixed_expense_id, kind, 'webhook', sent_at FROM reminder_logs;
+
+DROP TABLE reminder_logs;
+ALTER TABLE reminder_logs_new RENAME TO reminder_logs;
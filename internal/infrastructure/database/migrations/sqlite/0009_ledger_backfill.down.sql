@@ -0,0 +1,8 @@
+-- =====================================================
+-- 0009 - MES DE LOS EVENTOS Y ESTADO INICIAL DEL LIBRO (SQLITE, REVERTIR)
+-- =====================================================
+-- Requiere SQLite 3.35 o posterior (ALTER TABLE ... DROP COLUMN).
+-- =====================================================
+
+DROP INDEX IF EXISTS idx_ledger_events_month;
+ALTER TABLE ledger_events DROP COLUMN month;
@@ -0,0 +1,17 @@
+-- =====================================================
+-- 0009 - MES DE LOS EVENTOS Y ESTADO INICIAL DEL LIBRO (SQLITE)
+-- =====================================================
+-- Equivalente a mysql/0009_ledger_backfill.up.sql.
+-- =====================================================
+
+ALTER TABLE ledger_events ADD COLUMN month DATE NULL;
+
+CREATE INDEX IF NOT EXISTS idx_ledger_events_month ON ledger_events (month);
+
+UPDATE ledger_events
+SET month = substr(json_extract(data, '$.month'), 1, 7) || '-01'
+WHERE entity IN ('salaries', 'daily_expenses_configs', 'fixed_expenses') AND data IS NOT NULL;
+
+UPDATE ledger_events
+SET month = substr(json_extract(data, '$.date'), 1, 7) || '-01'
+WHERE entity IN ('daily_expenses', 'transfers') AND data IS NOT NULL;
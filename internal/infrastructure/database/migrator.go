@@ -0,0 +1,291 @@
+package database
+
+import (
+	"expenses-api/internal/infrastructure/database/migrations"
+	"fmt"
+	"io/fs"
+	"log"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// SchemaMigration records an applied migration in the schema_migrations table
+type SchemaMigration struct {
+	Version   uint      `gorm:"primaryKey;autoIncrement:false"`
+	Name      string    `gorm:"size:255;not null"`
+	AppliedAt time.Time `gorm:"not null"`
+}
+
+// TableName specifies the table name for GORM
+func (SchemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// Migration is a versioned schema change with the SQL to apply and revert it
+type Migration struct {
+	Version uint
+	Name    string
+	Up      string
+	Down    string
+}
+
+// MigrationStatus reports whether a migration has been applied
+type MigrationStatus struct {
+	Migration
+	AppliedAt *time.Time // nil when pending
+}
+
+// Migrator applies and reverts the embedded schema migrations
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+}
+
+// dataMigrations fill data the SQL of a migration cannot build, by version
+// They run after the statements of the migration, in its transaction
+var dataMigrations = map[uint]func(tx *gorm.DB) error{
+	9: seedLedgerEvents,
+}
+
+// migrationFilePattern matches {version}_{name}.up.sql and {version}_{name}.down.sql
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// NewMigrator creates a migrator for the embedded migrations of the database dialect
+func NewMigrator(db *gorm.DB) (*Migrator, error) {
+	dialect := db.Dialector.Name()
+	if _, err := fs.Stat(migrations.FS, dialect); err != nil {
+		return nil, fmt.Errorf("no migrations for database driver %s", dialect)
+	}
+
+	dialectFS, err := fs.Sub(migrations.FS, dialect)
+	if err != nil {
+		return nil, err
+	}
+
+	loaded, err := loadMigrations(dialectFS)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: loaded}, nil
+}
+
+// Up applies every pending migration in version order and returns the applied ones
+func (m *Migrator) Up() ([]Migration, error) {
+	pending, err := m.Pending()
+	if err != nil {
+		return nil, err
+	}
+
+	applied := make([]Migration, 0, len(pending))
+	for _, migration := range pending {
+		err := m.run(migration.Up, func(tx *gorm.DB) error {
+			if fill, ok := dataMigrations[migration.Version]; ok {
+				if err := fill(tx); err != nil {
+					return err
+				}
+			}
+			return tx.Create(&SchemaMigration{
+				Version:   migration.Version,
+				Name:      migration.Name,
+				AppliedAt: time.Now(),
+			}).Error
+		})
+		if err != nil {
+			return applied, fmt.Errorf("migration %s failed: %w", migration.ID(), err)
+		}
+
+		log.Printf("Applied migration %s", migration.ID())
+		applied = append(applied, migration)
+	}
+
+	return applied, nil
+}
+
+// Down reverts the last steps applied migrations, newest first
+func (m *Migrator) Down(steps int) ([]Migration, error) {
+	if steps < 1 {
+		return nil, fmt.Errorf("steps must be at least 1")
+	}
+
+	statuses, err := m.Status()
+	if err != nil {
+		return nil, err
+	}
+
+	reverted := make([]Migration, 0, steps)
+	for i := len(statuses) - 1; i >= 0 && len(reverted) < steps; i-- {
+		migration := statuses[i].Migration
+		if statuses[i].AppliedAt == nil {
+			continue
+		}
+
+		err := m.run(migration.Down, func(tx *gorm.DB) error {
+			return tx.Delete(&SchemaMigration{}, migration.Version).Error
+		})
+		if err != nil {
+			return reverted, fmt.Errorf("reverting migration %s failed: %w", migration.ID(), err)
+		}
+
+		log.Printf("Reverted migration %s", migration.ID())
+		reverted = append(reverted, migration)
+	}
+
+	return reverted, nil
+}
+
+// Status lists every known migration with the time it was applied
+func (m *Migrator) Status() ([]MigrationStatus, error) {
+	if err := m.ensureTable(); err != nil {
+		return nil, err
+	}
+
+	var records []SchemaMigration
+	if err := m.db.Find(&records).Error; err != nil {
+		return nil, err
+	}
+
+	appliedAt := make(map[uint]time.Time, len(records))
+	for _, record := range records {
+		appliedAt[record.Version] = record.AppliedAt
+	}
+
+	statuses := make([]MigrationStatus, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := MigrationStatus{Migration: migration}
+		if at, ok := appliedAt[migration.Version]; ok {
+			status.AppliedAt = &at
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+// Pending returns the migrations not applied yet, in version order
+func (m *Migrator) Pending() ([]Migration, error) {
+	statuses, err := m.Status()
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []Migration
+	for _, status := range statuses {
+		if status.AppliedAt == nil {
+			pending = append(pending, status.Migration)
+		}
+	}
+	return pending, nil
+}
+
+// ID returns the migration identifier, e.g. "0002_unique_salary_month"
+func (m Migration) ID() string {
+	return fmt.Sprintf("%04d_%s", m.Version, m.Name)
+}
+
+// ensureTable creates the schema_migrations table when missing
+func (m *Migrator) ensureTable() error {
+	if m.db.Migrator().HasTable(&SchemaMigration{}) {
+		return nil
+	}
+	return m.db.Migrator().CreateTable(&SchemaMigration{})
+}
+
+// run executes the statements of a migration and records it in one transaction
+// MySQL commits DDL statements implicitly, so a failing migration may leave the
+// statements before the failure applied; SQLite rolls them back
+func (m *Migrator) run(script string, record func(tx *gorm.DB) error) error {
+	return m.db.Transaction(func(tx *gorm.DB) error {
+		for _, statement := range splitStatements(script) {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+		return record(tx)
+	})
+}
+
+// loadMigrations reads and pairs the up and down files of every migration
+func loadMigrations(fsys fs.FS) ([]Migration, error) {
+	files, err := fs.Glob(fsys, "*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[uint]*Migration)
+	for _, file := range files {
+		match := migrationFilePattern.FindStringSubmatch(file)
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name: %s", file)
+		}
+
+		version, err := strconv.ParseUint(match[1], 10, 32)
+		if err != nil || version == 0 {
+			return nil, fmt.Errorf("invalid migration version: %s", file)
+		}
+
+		content, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[uint(version)]
+		if !ok {
+			migration = &Migration{Version: uint(version), Name: match[2]}
+			byVersion[uint(version)] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration version %d is used by %s and %s", version, migration.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	loaded := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if strings.TrimSpace(migration.Up) == "" || strings.TrimSpace(migration.Down) == "" {
+			return nil, fmt.Errorf("migration %s needs both up and down files", migration.ID())
+		}
+		loaded = append(loaded, *migration)
+	}
+
+	sort.Slice(loaded, func(i, j int) bool {
+		return loaded[i].Version < loaded[j].Version
+	})
+	return loaded, nil
+}
+
+// splitStatements splits a script into statements ending with ";" at the end of a line
+// Full-line "--" comments are dropped
+func splitStatements(script string) []string {
+	var statements []string
+	var current strings.Builder
+
+	for _, line := range strings.Split(script, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "--") {
+			continue
+		}
+
+		current.WriteString(line)
+		current.WriteString("\n")
+
+		if strings.HasSuffix(trimmed, ";") {
+			statements = append(statements, strings.TrimSpace(current.String()))
+			current.Reset()
+		}
+	}
+
+	if rest := strings.TrimSpace(current.String()); rest != "" {
+		statements = append(statements, rest)
+	}
+	return statements
+}
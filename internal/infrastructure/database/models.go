@@ -0,0 +1,54 @@
+package database
+
+import (
+	"expenses-api/internal/domain/alert"
+	"expenses-api/internal/domain/attachment"
+	"expenses-api/internal/domain/audit"
+	"expenses-api/internal/domain/categorization"
+	"expenses-api/internal/domain/daily_expense"
+	"expenses-api/internal/domain/daily_expense_config"
+	"expenses-api/internal/domain/fixed_expense"
+	"expenses-api/internal/domain/household"
+	"expenses-api/internal/domain/idempotency"
+	"expenses-api/internal/domain/ledger"
+	"expenses-api/internal/domain/pocket"
+	"expenses-api/internal/domain/pocket_allocation"
+	"expenses-api/internal/domain/receivable"
+	"expenses-api/internal/domain/reminder"
+	"expenses-api/internal/domain/salary"
+	"expenses-api/internal/domain/tag"
+	"expenses-api/internal/domain/transfer"
+	"expenses-api/internal/domain/webhook"
+)
+
+// Models returns the domain models persisted by the repositories
+// The schema check compares them against the database; add new models here
+func Models() []interface{} {
+	return []interface{}{
+		&salary.Salary{},
+		&pocket.Pocket{},
+		&fixed_expense.FixedExpense{},
+		&fixed_expense.Payment{},
+		&daily_expense.DailyExpense{},
+		&daily_expense.Split{},
+		&daily_expense_config.DailyExpenseConfig{},
+		&transfer.Transfer{},
+		&pocket_allocation.PocketAllocation{},
+		&reminder.ReminderLog{},
+		&webhook.Subscription{},
+		&webhook.Delivery{},
+		&alert.Rule{},
+		&alert.Alert{},
+		&attachment.Attachment{},
+		&tag.Tag{},
+		&receivable.Receivable{},
+		&receivable.Reimbursement{},
+		&household.Member{},
+		&household.Share{},
+		&household.SharePart{},
+		&categorization.Rule{},
+		&idempotency.Record{},
+		&audit.Entry{},
+		&ledger.Event{},
+	}
+}
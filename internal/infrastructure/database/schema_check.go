@@ -0,0 +1,223 @@
+package database
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// PrepareSchema brings the database schema up to date before the API starts
+// With autoMigrate pending migrations are applied; otherwise they are reported
+// as an error. Either way the schema must then match the domain models
+func PrepareSchema(db *gorm.DB, autoMigrate bool) error {
+	migrator, err := NewMigrator(db)
+	if err != nil {
+		return err
+	}
+
+	if autoMigrate {
+		if _, err := migrator.Up(); err != nil {
+			return err
+		}
+	} else {
+		pending, err := migrator.Pending()
+		if err != nil {
+			return err
+		}
+		if len(pending) > 0 {
+			return fmt.Errorf("%d pending migrations starting at %s; run \"migrate up\" or set DB_AUTO_MIGRATE=true",
+				len(pending), pending[0].ID())
+		}
+	}
+
+	return CheckSchema(db)
+}
+
+// CheckSchema verifies that every table, column and unique index declared by the
+// domain models exists in the database, including many-to-many join tables.
+// Extra tables, columns and indexes in the database are allowed
+func CheckSchema(db *gorm.DB) error {
+	var problems []string
+
+	for _, model := range Models() {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(model); err != nil {
+			return err
+		}
+
+		modelProblems, err := checkTable(db, stmt.Schema.Table, modelColumns(stmt.Schema), modelUniqueIndexes(stmt.Schema))
+		if err != nil {
+			return err
+		}
+		problems = append(problems, modelProblems...)
+
+		for _, relationship := range stmt.Schema.Relationships.Many2Many {
+			joinTable := relationship.JoinTable
+			joinProblems, err := checkTable(db, joinTable.Table, modelColumns(joinTable), nil)
+			if err != nil {
+				return err
+			}
+			problems = append(problems, joinProblems...)
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("database schema does not match the domain models: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// checkTable compares one table against the columns and unique indexes a model expects
+func checkTable(db *gorm.DB, table string, columns []string, uniqueIndexes [][]string) ([]string, error) {
+	if !db.Migrator().HasTable(table) {
+		return []string{fmt.Sprintf("table %s is missing", table)}, nil
+	}
+
+	columnTypes, err := db.Migrator().ColumnTypes(table)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make(map[string]bool, len(columnTypes))
+	for _, columnType := range columnTypes {
+		existing[strings.ToLower(columnType.Name())] = true
+	}
+
+	var problems []string
+	for _, column := range columns {
+		if !existing[strings.ToLower(column)] {
+			problems = append(problems, fmt.Sprintf("column %s.%s is missing", table, column))
+		}
+	}
+
+	if len(uniqueIndexes) == 0 {
+		return problems, nil
+	}
+
+	indexes, err := tableUniqueIndexes(db, table)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, expected := range uniqueIndexes {
+		if !hasUniqueIndex(indexes, expected) {
+			problems = append(problems, fmt.Sprintf("unique index on %s(%s) is missing", table, strings.Join(expected, ", ")))
+		}
+	}
+
+	return problems, nil
+}
+
+// modelColumns returns the database columns of a parsed model
+func modelColumns(s *schema.Schema) []string {
+	columns := make([]string, 0, len(s.Fields))
+	for _, field := range s.Fields {
+		if field.DBName != "" {
+			columns = append(columns, field.DBName)
+		}
+	}
+	return columns
+}
+
+// modelUniqueIndexes returns the column sets the model declares unique,
+// from both uniqueIndex and unique tags
+func modelUniqueIndexes(s *schema.Schema) [][]string {
+	var uniqueIndexes [][]string
+	for _, index := range s.ParseIndexes() {
+		if index.Class != "UNIQUE" {
+			continue
+		}
+
+		columns := make([]string, 0, len(index.Fields))
+		for _, option := range index.Fields {
+			columns = append(columns, option.DBName)
+		}
+		uniqueIndexes = append(uniqueIndexes, columns)
+	}
+
+	for _, field := range s.Fields {
+		if field.Unique && field.DBName != "" {
+			uniqueIndexes = append(uniqueIndexes, []string{field.DBName})
+		}
+	}
+
+	return uniqueIndexes
+}
+
+// tableUniqueIndexes returns the columns of every unique index of a table,
+// including those created by UNIQUE constraints and the primary key
+func tableUniqueIndexes(db *gorm.DB, table string) ([][]string, error) {
+	type indexColumn struct {
+		IndexName  string
+		ColumnName string
+	}
+
+	var rows []indexColumn
+	switch db.Dialector.Name() {
+	case "sqlite":
+		var names []string
+		err := db.Raw(`SELECT name FROM pragma_index_list(?) WHERE "unique" = 1`, table).Scan(&names).Error
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range names {
+			var columns []string
+			if err := db.Raw("SELECT name FROM pragma_index_info(?) ORDER BY seqno", name).Scan(&columns).Error; err != nil {
+				return nil, err
+			}
+			for _, column := range columns {
+				rows = append(rows, indexColumn{IndexName: name, ColumnName: column})
+			}
+		}
+	default:
+		err := db.Raw(`
+			SELECT INDEX_NAME AS index_name, COLUMN_NAME AS column_name
+			FROM information_schema.STATISTICS
+			WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND NON_UNIQUE = 0
+			ORDER BY INDEX_NAME, SEQ_IN_INDEX
+		`, table).Scan(&rows).Error
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	byName := make(map[string][]string)
+	var names []string
+	for _, row := range rows {
+		if _, ok := byName[row.IndexName]; !ok {
+			names = append(names, row.IndexName)
+		}
+		byName[row.IndexName] = append(byName[row.IndexName], row.ColumnName)
+	}
+
+	indexes := make([][]string, 0, len(names))
+	for _, name := range names {
+		indexes = append(indexes, byName[name])
+	}
+	return indexes, nil
+}
+
+// hasUniqueIndex reports whether a unique index covers exactly the given columns
+// Index names are ignored since the SQL scripts and GORM name them differently
+func hasUniqueIndex(indexes [][]string, columns []string) bool {
+	expected := strings.Join(sortedLower(columns), ",")
+	for _, index := range indexes {
+		if strings.Join(sortedLower(index), ",") == expected {
+			return true
+		}
+	}
+	return false
+}
+
+// sortedLower returns a lowercased, sorted copy of the column names
+func sortedLower(columns []string) []string {
+	sorted := make([]string, 0, len(columns))
+	for _, column := range columns {
+		sorted = append(sorted, strings.ToLower(column))
+	}
+	sort.Strings(sorted)
+	return sorted
+}
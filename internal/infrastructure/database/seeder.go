@@ -1,6 +1,7 @@
 package database
 
 import (
+	"expenses-api/internal/domain/alert"
 	"expenses-api/internal/domain/daily_expense_config"
 	"expenses-api/internal/domain/pocket"
 	"expenses-api/internal/domain/salary"
@@ -48,6 +49,11 @@ func (s *Seeder) SeedInitialData() error {
 		return err
 	}
 
+	// Seed default budget alert rules
+	if err := s.seedAlertRules(); err != nil {
+		return err
+	}
+
 	log.Println("Initial data seeding completed successfully!")
 	return nil
 }
@@ -88,8 +94,9 @@ func (s *Seeder) seedPockets() error {
 }
 
 // seedCurrentMonthConfigs creates default configurations for current month
+// The month comes from GORM's clock, which runs in the business timezone
 func (s *Seeder) seedCurrentMonthConfigs() error {
-	currentMonth := salary.GetCurrentMonth()
+	currentMonth := salary.GetCurrentMonth(s.db.NowFunc())
 
 	// Seed salary config
 	var existingSalary salary.Salary
@@ -132,5 +139,34 @@ func (s *Seeder) seedCurrentMonthConfigs() error {
 	return nil
 }
 
-// Note: Database schema management is handled manually via SQL scripts
-// See sql/database/ directory for schema creation and migration scripts
+// seedAlertRules creates the default 50/80/100% daily budget alert rules
+func (s *Seeder) seedAlertRules() error {
+	var count int64
+	if err := s.db.Model(&alert.Rule{}).Count(&count).Error; err != nil {
+		log.Printf("Error checking alert rules: %v", err)
+		return err
+	}
+
+	// Respect rules configured by the user, including deleted defaults
+	if count > 0 {
+		return nil
+	}
+
+	for _, threshold := range []float64{50, 80, 100} {
+		rule := alert.Rule{
+			Kind:             alert.KindDailyBudget,
+			ThresholdPercent: threshold,
+			Active:           true,
+		}
+		if err := s.db.Omit("Pocket").Create(&rule).Error; err != nil {
+			log.Printf("Failed to create alert rule %.0f%%: %v", threshold, err)
+			return err
+		}
+		log.Printf("Created daily budget alert rule: %.0f%%", threshold)
+	}
+
+	return nil
+}
+
+// Note: The database schema is managed by the versioned migrations in
+// database/migrations; the seeder only inserts data
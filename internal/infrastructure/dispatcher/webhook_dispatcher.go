@@ -0,0 +1,182 @@
+package dispatcher
+
+import (
+	"bytes"
+	"encoding/json"
+	"expenses-api/internal/application/port"
+	"expenses-api/internal/domain/event"
+	"expenses-api/internal/domain/webhook"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Headers sent with every webhook delivery
+const (
+	HeaderSignature = "X-Expenses-Signature"
+	HeaderEvent     = "X-Expenses-Event"
+	HeaderDelivery  = "X-Expenses-Delivery"
+	HeaderTimestamp = "X-Expenses-Timestamp"
+)
+
+// WebhookDispatcher publishes ledger events to the registered webhook subscriptions
+// Deliveries run in the background, are signed with the subscription secret and
+// retried with exponential backoff; every attempt is recorded in the delivery log
+type WebhookDispatcher struct {
+	subscriptionRepo port.WebhookSubscriptionRepository
+	deliveryRepo     port.WebhookDeliveryRepository
+	client           *http.Client
+	maxAttempts      int
+	backoff          time.Duration
+	clock            port.Clock
+
+	wg sync.WaitGroup
+}
+
+// NewWebhookDispatcher creates a new webhook dispatcher instance
+// A nil client defaults to an HTTP client with a 10 second timeout
+func NewWebhookDispatcher(
+	subscriptionRepo port.WebhookSubscriptionRepository,
+	deliveryRepo port.WebhookDeliveryRepository,
+	client *http.Client,
+	maxAttempts int,
+	backoff time.Duration,
+	clock port.Clock,
+) *WebhookDispatcher {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	if maxAttempts <= 0 {
+		maxAttempts = 1
+	}
+
+	return &WebhookDispatcher{
+		subscriptionRepo: subscriptionRepo,
+		deliveryRepo:     deliveryRepo,
+		client:           client,
+		maxAttempts:      maxAttempts,
+		backoff:          backoff,
+		clock:            clock,
+	}
+}
+
+// Publish sends the event to every matching subscription without blocking the caller
+func (d *WebhookDispatcher) Publish(name string, data interface{}) {
+	ev := event.New(name, data, d.clock.Now())
+
+	// Encode now so later changes to data don't leak into the payload
+	body, err := json.Marshal(ev)
+	if err != nil {
+		log.Printf("Webhook event %s could not be encoded: %v", name, err)
+		return
+	}
+
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		d.dispatch(ev, body)
+	}()
+}
+
+// Wait blocks until every pending delivery has finished, including retries
+func (d *WebhookDispatcher) Wait() {
+	d.wg.Wait()
+}
+
+// dispatch fans the event out to the active subscriptions that want it
+func (d *WebhookDispatcher) dispatch(ev event.Event, body []byte) {
+	subscriptions, err := d.subscriptionRepo.GetActive()
+	if err != nil {
+		log.Printf("Webhook subscriptions could not be loaded for event %s: %v", ev.Name, err)
+		return
+	}
+
+	for _, subscription := range subscriptions {
+		if !subscription.Matches(ev.Name) {
+			continue
+		}
+
+		d.wg.Add(1)
+		go func(subscription webhook.Subscription) {
+			defer d.wg.Done()
+			d.deliver(subscription, ev, body)
+		}(subscription)
+	}
+}
+
+// deliver posts the event to a subscription, retrying failed attempts
+func (d *WebhookDispatcher) deliver(subscription webhook.Subscription, ev event.Event, body []byte) {
+	delay := d.backoff
+	for attempt := 1; attempt <= d.maxAttempts; attempt++ {
+		delivery := d.send(subscription, ev, body)
+		delivery.Attempt = attempt
+		if runes := []rune(delivery.Error); len(runes) > webhook.MaxErrorLength {
+			delivery.Error = string(runes[:webhook.MaxErrorLength])
+		}
+
+		if err := d.deliveryRepo.Create(delivery); err != nil {
+			log.Printf("Webhook delivery %s to subscription %d could not be logged: %v", ev.ID, subscription.ID, err)
+		}
+
+		if delivery.Success {
+			return
+		}
+
+		if attempt < d.maxAttempts {
+			time.Sleep(delay)
+			delay *= 2
+		}
+	}
+
+	log.Printf("Webhook delivery %s to subscription %d failed after %d attempts", ev.ID, subscription.ID, d.maxAttempts)
+}
+
+// send performs a single signed delivery attempt
+func (d *WebhookDispatcher) send(subscription webhook.Subscription, ev event.Event, body []byte) *webhook.Delivery {
+	delivery := &webhook.Delivery{
+		SubscriptionID: subscription.ID,
+		EventID:        ev.ID,
+		Event:          ev.Name,
+		Payload:        string(body),
+	}
+
+	start := time.Now()
+	defer func() {
+		delivery.DurationMs = time.Since(start).Milliseconds()
+	}()
+
+	req, err := http.NewRequest(http.MethodPost, subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		delivery.Error = err.Error()
+		return delivery
+	}
+
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "expenses-api-webhooks")
+	req.Header.Set(HeaderEvent, ev.Name)
+	req.Header.Set(HeaderDelivery, ev.ID)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, webhook.Sign(subscription.Secret, timestamp, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		delivery.Error = err.Error()
+		return delivery
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	delivery.StatusCode = resp.StatusCode
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		delivery.Error = fmt.Sprintf("webhook responded with status %d", resp.StatusCode)
+		return delivery
+	}
+
+	delivery.Success = true
+	return delivery
+}
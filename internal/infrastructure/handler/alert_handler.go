@@ -0,0 +1,200 @@
+package handler
+
+import (
+	"expenses-api/internal/api/dto"
+	"expenses-api/internal/application/usecase"
+	"expenses-api/internal/domain/alert"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AlertHandler handles budget alert-related HTTP requests
+type AlertHandler struct {
+	alertUseCase *usecase.AlertUseCase
+}
+
+// NewAlertHandler creates a new alert handler instance
+func NewAlertHandler(alertUseCase *usecase.AlertUseCase) *AlertHandler {
+	return &AlertHandler{
+		alertUseCase: alertUseCase,
+	}
+}
+
+// GetAlerts obtiene las alertas de un mes, o todas las pendientes si no se envía el mes
+// GET /api/alerts?month=2024-01&pending=true
+func (h *AlertHandler) GetAlerts(c *gin.Context) {
+	pendingOnly, _ := strconv.ParseBool(c.Query("pending"))
+
+	alerts, err := h.alertUseCase.GetAlerts(c.Query("month"), pendingOnly)
+	if err != nil {
+		respondError(c, "Error getting alerts", err)
+		return
+	}
+
+	// Convert to DTOs
+	alertDTOs := make([]dto.AlertDTO, 0, len(alerts))
+	for i := range alerts {
+		alertDTOs = append(alertDTOs, toAlertDTO(&alerts[i]))
+	}
+
+	c.JSON(http.StatusOK, alertDTOs)
+}
+
+// Acknowledge marca una alerta como vista
+// PUT /api/alerts/{id}/acknowledge
+func (h *AlertHandler) Acknowledge(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		respondInvalid(c, "id", "Invalid alert ID")
+		return
+	}
+
+	acknowledged, err := h.alertUseCase.Acknowledge(c.Request.Context(), uint(id))
+	if err != nil {
+		respondError(c, "Error acknowledging alert", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toAlertDTO(acknowledged))
+}
+
+// GetRules obtiene todas las reglas de alerta
+// GET /api/alert-rules
+func (h *AlertHandler) GetRules(c *gin.Context) {
+	rules, err := h.alertUseCase.GetRules()
+	if err != nil {
+		respondError(c, "Error getting alert rules", err)
+		return
+	}
+
+	// Convert to DTOs
+	ruleDTOs := make([]dto.AlertRuleDTO, 0, len(rules))
+	for i := range rules {
+		ruleDTOs = append(ruleDTOs, toAlertRuleDTO(&rules[i]))
+	}
+
+	c.JSON(http.StatusOK, ruleDTOs)
+}
+
+// CreateRule crea una nueva regla de alerta
+// POST /api/alert-rules
+func (h *AlertHandler) CreateRule(c *gin.Context) {
+	var ruleDTO dto.AlertRuleDTO
+	if err := c.ShouldBindJSON(&ruleDTO); err != nil {
+		respondError(c, "Invalid request body", invalidBody(err))
+		return
+	}
+
+	created, err := h.alertUseCase.CreateRule(
+		c.Request.Context(),
+		ruleDTO.Kind,
+		pocketIDFromDTO(ruleDTO.PocketID),
+		ruleDTO.ThresholdPercent,
+		ruleDTO.Active == nil || *ruleDTO.Active,
+	)
+	if err != nil {
+		respondError(c, "Error creating alert rule", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toAlertRuleDTO(created))
+}
+
+// UpdateRule actualiza una regla de alerta existente
+// PUT /api/alert-rules/{id}
+func (h *AlertHandler) UpdateRule(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		respondInvalid(c, "id", "Invalid rule ID")
+		return
+	}
+
+	var ruleDTO dto.AlertRuleDTO
+	if err := c.ShouldBindJSON(&ruleDTO); err != nil {
+		respondError(c, "Invalid request body", invalidBody(err))
+		return
+	}
+
+	updated, err := h.alertUseCase.UpdateRule(
+		c.Request.Context(),
+		uint(id),
+		ruleDTO.Kind,
+		pocketIDFromDTO(ruleDTO.PocketID),
+		ruleDTO.ThresholdPercent,
+		ruleDTO.Active == nil || *ruleDTO.Active,
+	)
+	if err != nil {
+		respondError(c, "Error updating alert rule", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toAlertRuleDTO(updated))
+}
+
+// DeleteRule elimina una regla de alerta y las alertas que disparó
+// DELETE /api/alert-rules/{id}
+func (h *AlertHandler) DeleteRule(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		respondInvalid(c, "id", "Invalid rule ID")
+		return
+	}
+
+	err = h.alertUseCase.DeleteRule(c.Request.Context(), uint(id))
+	if err != nil {
+		respondError(c, "Error deleting alert rule", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Alert rule deleted successfully",
+		"id":      id,
+	})
+}
+
+// toAlertDTO convierte el modelo de dominio en el DTO de respuesta
+func toAlertDTO(a *alert.Alert) dto.AlertDTO {
+	alertDTO := dto.AlertDTO{
+		ID:               int(a.ID),
+		RuleID:           int(a.RuleID),
+		Month:            a.Month.String(),
+		Kind:             a.Kind,
+		ThresholdPercent: a.ThresholdPercent,
+		Budget:           a.Budget,
+		Spent:            a.Spent,
+		PercentUsed:      a.GetPercentUsed(),
+		Message:          a.Message,
+		Acknowledged:     a.Acknowledged,
+		AcknowledgedAt:   a.AcknowledgedAt,
+		CreatedAt:        a.CreatedAt,
+	}
+
+	if a.PocketID != nil {
+		alertDTO.PocketID = int(*a.PocketID)
+	}
+
+	return alertDTO
+}
+
+// toAlertRuleDTO convierte el modelo de dominio en el DTO de respuesta
+func toAlertRuleDTO(r *alert.Rule) dto.AlertRuleDTO {
+	active := r.Active
+	ruleDTO := dto.AlertRuleDTO{
+		ID:               int(r.ID),
+		Kind:             r.Kind,
+		PocketName:       r.GetPocketName(),
+		ThresholdPercent: r.ThresholdPercent,
+		Active:           &active,
+	}
+
+	if r.PocketID != nil {
+		ruleDTO.PocketID = int(*r.PocketID)
+	}
+
+	return ruleDTO
+}
@@ -0,0 +1,186 @@
+package handler
+
+import (
+	"expenses-api/internal/api/dto"
+	"expenses-api/internal/application/usecase"
+	"expenses-api/internal/domain/apperror"
+	"expenses-api/internal/domain/attachment"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AttachmentHandler handles expense attachment-related HTTP requests
+type AttachmentHandler struct {
+	attachmentUseCase *usecase.AttachmentUseCase
+}
+
+// NewAttachmentHandler creates a new attachment handler instance
+func NewAttachmentHandler(attachmentUseCase *usecase.AttachmentUseCase) *AttachmentHandler {
+	return &AttachmentHandler{
+		attachmentUseCase: attachmentUseCase,
+	}
+}
+
+// UploadDaily adjunta un comprobante a un gasto diario (multipart, campo "file")
+// POST /api/daily-expenses/{id}/attachments
+func (h *AttachmentHandler) UploadDaily(c *gin.Context) {
+	h.upload(c, attachment.ExpenseTypeDaily)
+}
+
+// UploadFixed adjunta un comprobante a un gasto fijo (multipart, campo "file")
+// POST /api/fixed-expenses/{id}/attachments
+func (h *AttachmentHandler) UploadFixed(c *gin.Context) {
+	h.upload(c, attachment.ExpenseTypeFixed)
+}
+
+// GetByExpense lista los comprobantes de un gasto
+// GET /api/attachments?expense_type=daily|fixed&expense_id={id}
+func (h *AttachmentHandler) GetByExpense(c *gin.Context) {
+	expenseID, err := strconv.ParseUint(c.Query("expense_id"), 10, 32)
+	if err != nil {
+		respondInvalid(c, "expense_id", "Invalid expense_id")
+		return
+	}
+
+	attachments, err := h.attachmentUseCase.GetByExpense(c.Query("expense_type"), uint(expenseID))
+	if err != nil {
+		respondError(c, "Error getting attachments", err)
+		return
+	}
+
+	// Convert to DTOs
+	attachmentDTOs := make([]dto.AttachmentDTO, 0, len(attachments))
+	for i := range attachments {
+		attachmentDTOs = append(attachmentDTOs, toAttachmentDTO(&attachments[i]))
+	}
+
+	c.JSON(http.StatusOK, attachmentDTOs)
+}
+
+// Download descarga el archivo original de un comprobante
+// GET /api/attachments/{id}
+func (h *AttachmentHandler) Download(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondInvalid(c, "id", "Invalid attachment ID")
+		return
+	}
+
+	a, reader, err := h.attachmentUseCase.Open(uint(id))
+	if err != nil {
+		h.respondOpenError(c, err)
+		return
+	}
+	defer reader.Close()
+
+	c.DataFromReader(http.StatusOK, a.Size, a.ContentType, reader, map[string]string{
+		"Content-Disposition": fmt.Sprintf("inline; filename=%q", a.FileName),
+		"ETag":                fmt.Sprintf("%q", a.SHA256),
+	})
+}
+
+// Thumbnail devuelve la miniatura JPEG de un comprobante de imagen
+// GET /api/attachments/{id}/thumbnail
+func (h *AttachmentHandler) Thumbnail(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondInvalid(c, "id", "Invalid attachment ID")
+		return
+	}
+
+	reader, err := h.attachmentUseCase.OpenThumbnail(uint(id))
+	if err != nil {
+		h.respondOpenError(c, err)
+		return
+	}
+	defer reader.Close()
+
+	c.DataFromReader(http.StatusOK, -1, "image/jpeg", reader, nil)
+}
+
+// Delete elimina un comprobante; el archivo se borra si ningún otro gasto lo usa
+// DELETE /api/attachments/{id}
+func (h *AttachmentHandler) Delete(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondInvalid(c, "id", "Invalid attachment ID")
+		return
+	}
+
+	if err := h.attachmentUseCase.Delete(c.Request.Context(), uint(id)); err != nil {
+		respondError(c, "Error deleting attachment", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Attachment deleted successfully",
+	})
+}
+
+// upload procesa la subida multipart de un comprobante para el tipo de gasto indicado
+func (h *AttachmentHandler) upload(c *gin.Context, expenseType string) {
+	expenseID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondInvalid(c, "id", "Invalid expense ID")
+		return
+	}
+
+	// Limit the whole request body; the multipart overhead gets 1 MB of headroom
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.attachmentUseCase.MaxSize()+(1<<20))
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		respondError(c, "A file is required in the \"file\" form field", apperror.Invalid("file", err.Error()))
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		respondError(c, "Error reading uploaded file", apperror.Invalid("file", err.Error()))
+		return
+	}
+	defer file.Close()
+
+	created, isNew, err := h.attachmentUseCase.Upload(c.Request.Context(), expenseType, uint(expenseID), fileHeader.Filename, file)
+	if err != nil {
+		respondError(c, "Error uploading attachment", err)
+		return
+	}
+
+	// The same file was already attached to this expense
+	statusCode := http.StatusCreated
+	if !isNew {
+		statusCode = http.StatusOK
+	}
+
+	c.JSON(statusCode, toAttachmentDTO(created))
+}
+
+// respondOpenError responde al fallo de apertura de un comprobante o su miniatura
+func (h *AttachmentHandler) respondOpenError(c *gin.Context, err error) {
+	respondError(c, "Error reading attachment", err)
+}
+
+// toAttachmentDTO convierte el modelo de dominio en el DTO de respuesta
+func toAttachmentDTO(a *attachment.Attachment) dto.AttachmentDTO {
+	attachmentDTO := dto.AttachmentDTO{
+		ID:          int(a.ID),
+		ExpenseType: a.ExpenseType,
+		ExpenseID:   int(a.ExpenseID),
+		FileName:    a.FileName,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		SHA256:      a.SHA256,
+		URL:         fmt.Sprintf("/api/attachments/%d", a.ID),
+		CreatedAt:   a.CreatedAt,
+	}
+
+	if a.HasThumbnail() {
+		attachmentDTO.ThumbnailURL = fmt.Sprintf("/api/attachments/%d/thumbnail", a.ID)
+	}
+
+	return attachmentDTO
+}
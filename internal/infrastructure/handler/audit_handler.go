@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"encoding/json"
+	"expenses-api/internal/api/dto"
+	"expenses-api/internal/application/usecase"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AuditHandler handles audit log HTTP requests
+type AuditHandler struct {
+	auditUseCase *usecase.AuditUseCase
+}
+
+// NewAuditHandler creates a new audit handler instance
+func NewAuditHandler(auditUseCase *usecase.AuditUseCase) *AuditHandler {
+	return &AuditHandler{
+		auditUseCase: auditUseCase,
+	}
+}
+
+// GetEntries obtiene el historial de cambios, del más reciente al más antiguo
+// GET /api/audit?entity={tabla}&entity_id={id}&start_date=YYYY-MM-DD&end_date=YYYY-MM-DD&limit={n}
+func (h *AuditHandler) GetEntries(c *gin.Context) {
+	var entityID uint64
+	if param := c.Query("entity_id"); param != "" {
+		parsed, err := strconv.ParseUint(param, 10, 32)
+		if err != nil {
+			respondInvalid(c, "entity_id", "Invalid entity ID")
+			return
+		}
+		entityID = parsed
+	}
+
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	entries, err := h.auditUseCase.GetEntries(c.Query("entity"), uint(entityID), c.Query("start_date"), c.Query("end_date"), limit)
+	if err != nil {
+		respondError(c, "Error getting audit log", err)
+		return
+	}
+
+	// Convert to DTOs
+	entryDTOs := make([]dto.AuditEntryDTO, 0, len(entries))
+	for _, entry := range entries {
+		entryDTOs = append(entryDTOs, dto.AuditEntryDTO{
+			ID:        int(entry.ID),
+			Entity:    entry.Entity,
+			EntityID:  int(entry.EntityID),
+			Action:    entry.Action,
+			Before:    rawJSON(entry.Before),
+			After:     rawJSON(entry.After),
+			Actor:     entry.Actor,
+			CreatedAt: entry.CreatedAt,
+		})
+	}
+
+	c.JSON(http.StatusOK, entryDTOs)
+}
+
+// rawJSON devuelve un estado guardado como JSON sin volver a codificarlo; nil se envía como null
+func rawJSON(state *string) json.RawMessage {
+	if state == nil {
+		return nil
+	}
+	return json.RawMessage(*state)
+}
@@ -0,0 +1,209 @@
+package handler
+
+import (
+	"expenses-api/internal/api/dto"
+	"expenses-api/internal/application/usecase"
+	"expenses-api/internal/domain/categorization"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CategorizationHandler handles daily expense categorization rule HTTP requests
+type CategorizationHandler struct {
+	categorizationUseCase *usecase.CategorizationUseCase
+}
+
+// NewCategorizationHandler creates a new categorization handler instance
+func NewCategorizationHandler(categorizationUseCase *usecase.CategorizationUseCase) *CategorizationHandler {
+	return &CategorizationHandler{
+		categorizationUseCase: categorizationUseCase,
+	}
+}
+
+// GetRules obtiene las reglas de categorización en orden de evaluación
+// GET /api/categorization-rules
+func (h *CategorizationHandler) GetRules(c *gin.Context) {
+	rules, err := h.categorizationUseCase.GetRules()
+	if err != nil {
+		respondError(c, "Error getting categorization rules", err)
+		return
+	}
+
+	// Convert to DTOs
+	ruleDTOs := make([]dto.CategorizationRuleDTO, 0, len(rules))
+	for i := range rules {
+		ruleDTOs = append(ruleDTOs, toCategorizationRuleDTO(&rules[i]))
+	}
+
+	c.JSON(http.StatusOK, ruleDTOs)
+}
+
+// CreateRule crea una nueva regla de categorización
+// POST /api/categorization-rules
+func (h *CategorizationHandler) CreateRule(c *gin.Context) {
+	var ruleDTO dto.CategorizationRuleDTO
+	if err := c.ShouldBindJSON(&ruleDTO); err != nil {
+		respondError(c, "Invalid request body", invalidBody(err))
+		return
+	}
+
+	created, err := h.categorizationUseCase.CreateRule(c.Request.Context(), ruleFromDTO(&ruleDTO), ruleDTO.Tags)
+	if err != nil {
+		respondError(c, "Error creating categorization rule", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toCategorizationRuleDTO(created))
+}
+
+// UpdateRule actualiza una regla de categorización existente
+// PUT /api/categorization-rules/{id}
+func (h *CategorizationHandler) UpdateRule(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondInvalid(c, "id", "Invalid rule ID")
+		return
+	}
+
+	var ruleDTO dto.CategorizationRuleDTO
+	if err := c.ShouldBindJSON(&ruleDTO); err != nil {
+		respondError(c, "Invalid request body", invalidBody(err))
+		return
+	}
+
+	updated, err := h.categorizationUseCase.UpdateRule(c.Request.Context(), uint(id), ruleFromDTO(&ruleDTO), ruleDTO.Tags)
+	if err != nil {
+		respondError(c, "Error updating categorization rule", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toCategorizationRuleDTO(updated))
+}
+
+// DeleteRule elimina una regla de categorización
+// DELETE /api/categorization-rules/{id}
+func (h *CategorizationHandler) DeleteRule(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondInvalid(c, "id", "Invalid rule ID")
+		return
+	}
+
+	if err := h.categorizationUseCase.DeleteRule(c.Request.Context(), uint(id)); err != nil {
+		respondError(c, "Error deleting categorization rule", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Categorization rule deleted successfully",
+		"id":      id,
+	})
+}
+
+// Apply aplica las reglas a los gastos diarios de un mes
+// POST /api/categorization-rules/apply/{month}?dry_run=true|false&overwrite=true|false
+// Por defecto es una vista previa (dry_run=true) y no reemplaza bolsillos ya asignados
+func (h *CategorizationHandler) Apply(c *gin.Context) {
+	dryRun, err := strconv.ParseBool(c.DefaultQuery("dry_run", "true"))
+	if err != nil {
+		respondInvalid(c, "dry_run", "Invalid dry_run value")
+		return
+	}
+
+	overwrite, err := strconv.ParseBool(c.DefaultQuery("overwrite", "false"))
+	if err != nil {
+		respondInvalid(c, "overwrite", "Invalid overwrite value")
+		return
+	}
+
+	month := c.Param("month")
+	changes, err := h.categorizationUseCase.Apply(c.Request.Context(), month, dryRun, overwrite)
+	if err != nil {
+		respondError(c, "Error applying categorization rules", err)
+		return
+	}
+
+	result := dto.ApplyRulesResultDTO{
+		Month:     month,
+		DryRun:    dryRun,
+		Overwrite: overwrite,
+		Changed:   len(changes),
+		Changes:   make([]dto.CategorizationChangeDTO, 0, len(changes)),
+	}
+
+	for i := range changes {
+		result.Changes = append(result.Changes, toCategorizationChangeDTO(&changes[i]))
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// ruleFromDTO convierte el request en el modelo de dominio
+func ruleFromDTO(ruleDTO *dto.CategorizationRuleDTO) *categorization.Rule {
+	return &categorization.Rule{
+		Name:      ruleDTO.Name,
+		MatchType: ruleDTO.MatchType,
+		Pattern:   ruleDTO.Pattern,
+		MinAmount: ruleDTO.MinAmount,
+		MaxAmount: ruleDTO.MaxAmount,
+		PocketID:  pocketIDFromDTO(ruleDTO.PocketID),
+		Priority:  ruleDTO.Priority,
+		Active:    ruleDTO.Active == nil || *ruleDTO.Active,
+	}
+}
+
+// toCategorizationRuleDTO convierte el modelo de dominio en el DTO de respuesta
+func toCategorizationRuleDTO(rule *categorization.Rule) dto.CategorizationRuleDTO {
+	active := rule.Active
+	ruleDTO := dto.CategorizationRuleDTO{
+		ID:         int(rule.ID),
+		Name:       rule.Name,
+		MatchType:  rule.MatchType,
+		Pattern:    rule.Pattern,
+		MinAmount:  rule.MinAmount,
+		MaxAmount:  rule.MaxAmount,
+		PocketName: rule.GetPocketName(),
+		Tags:       rule.GetTagNames(),
+		Priority:   rule.Priority,
+		Active:     &active,
+	}
+
+	if rule.PocketID != nil {
+		ruleDTO.PocketID = int(*rule.PocketID)
+	}
+
+	return ruleDTO
+}
+
+// toCategorizationChangeDTO convierte el cambio planeado en el DTO de respuesta
+func toCategorizationChangeDTO(change *categorization.Change) dto.CategorizationChangeDTO {
+	changeDTO := dto.CategorizationChangeDTO{
+		ExpenseID:      int(change.ExpenseID),
+		Description:    change.Description,
+		Amount:         change.Amount,
+		Date:           change.Date.String(),
+		RuleID:         int(change.RuleID),
+		RuleName:       change.RuleName,
+		FromPocketName: change.FromPocketName,
+		ToPocketName:   change.ToPocketName,
+		AddedTags:      change.AddedTags,
+	}
+
+	if change.FromPocketID != nil {
+		id := int(*change.FromPocketID)
+		changeDTO.FromPocketID = &id
+	}
+
+	if change.ToPocketID != nil {
+		id := int(*change.ToPocketID)
+		changeDTO.ToPocketID = &id
+	}
+
+	if changeDTO.AddedTags == nil {
+		changeDTO.AddedTags = []string{}
+	}
+
+	return changeDTO
+}
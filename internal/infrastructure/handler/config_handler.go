@@ -3,9 +3,9 @@ package handler
 import (
 	"expenses-api/internal/api/dto"
 	"expenses-api/internal/application/usecase"
+	"expenses-api/internal/domain/civil"
 	"net/http"
 	"strconv"
-	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -37,11 +37,9 @@ func (h *ConfigHandler) GetIncome(c *gin.Context) {
 	monthParam := c.Param("month")
 
 	// Validate month format
-	_, err := time.Parse("2006-01", monthParam)
+	_, err := civil.ParseMonth(monthParam)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid month format. Use YYYY-MM",
-		})
+		respondInvalid(c, "month", "Invalid month format. Use YYYY-MM")
 		return
 	}
 
@@ -58,44 +56,47 @@ func (h *ConfigHandler) GetIncome(c *gin.Context) {
 
 	response := dto.SalaryDTO{
 		MonthlyAmount: salary.MonthlyAmount,
+		Version:       salary.Version,
 	}
 
+	setETag(c, salary.Version)
 	c.JSON(http.StatusOK, response)
 }
 
 // UpdateIncome actualiza la configuración de ingresos para un mes específico
 // PUT /api/config/income/{month}
+// Con If-Match solo actualiza si la configuración guardada sigue en esa versión
 func (h *ConfigHandler) UpdateIncome(c *gin.Context) {
 	monthParam := c.Param("month")
 
 	// Validate month format
-	_, err := time.Parse("2006-01", monthParam)
+	_, err := civil.ParseMonth(monthParam)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid month format. Use YYYY-MM",
-		})
+		respondInvalid(c, "month", "Invalid month format. Use YYYY-MM")
+		return
+	}
+
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		respondError(c, "Invalid If-Match header", err)
 		return
 	}
 
 	var salaryDTO dto.SalaryDTO
 	if err := c.ShouldBindJSON(&salaryDTO); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request body",
-			"details": err.Error(),
-		})
+		respondError(c, "Invalid request body", invalidBody(err))
 		return
 	}
 
 	// Update salary using use case for specified month
-	err = h.salaryUseCase.UpdateSalary(salaryDTO.MonthlyAmount, monthParam)
+	salary, err := h.salaryUseCase.UpdateSalary(c.Request.Context(), salaryDTO.MonthlyAmount, monthParam, version)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Error updating income configuration",
-			"details": err.Error(),
-		})
+		respondError(c, "Error updating income configuration", withCurrentState(c, err, h.currentIncome(monthParam)))
 		return
 	}
 
+	salaryDTO.Version = salary.Version
+	setETag(c, salary.Version)
 	c.JSON(http.StatusOK, salaryDTO)
 }
 
@@ -104,10 +105,7 @@ func (h *ConfigHandler) UpdateIncome(c *gin.Context) {
 func (h *ConfigHandler) GetPockets(c *gin.Context) {
 	pockets, err := h.pocketUseCase.GetAll()
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Error getting pockets",
-			"details": err.Error(),
-		})
+		respondError(c, "Error getting pockets", err)
 		return
 	}
 
@@ -115,9 +113,11 @@ func (h *ConfigHandler) GetPockets(c *gin.Context) {
 	var pocketDTOs []dto.PocketDTO
 	for _, pocket := range pockets {
 		pocketDTOs = append(pocketDTOs, dto.PocketDTO{
-			ID:          int(pocket.ID),
-			Name:        pocket.Name,
-			Description: pocket.Description,
+			ID:             int(pocket.ID),
+			Name:           pocket.Name,
+			Description:    pocket.Description,
+			RolloverPolicy: pocket.RolloverPolicy,
+			Version:        pocket.Version,
 		})
 	}
 
@@ -129,93 +129,94 @@ func (h *ConfigHandler) GetPockets(c *gin.Context) {
 func (h *ConfigHandler) CreatePocket(c *gin.Context) {
 	var pocketDTO dto.PocketDTO
 	if err := c.ShouldBindJSON(&pocketDTO); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request body",
-			"details": err.Error(),
-		})
+		respondError(c, "Invalid request body", invalidBody(err))
 		return
 	}
 
 	// Create pocket using use case
-	pocket, err := h.pocketUseCase.Create(pocketDTO.Name, pocketDTO.Description)
+	pocket, err := h.pocketUseCase.Create(c.Request.Context(), pocketDTO.Name, pocketDTO.Description, pocketDTO.RolloverPolicy)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Error creating pocket",
-			"details": err.Error(),
-		})
+		respondError(c, "Error creating pocket", err)
 		return
 	}
 
 	// Return created pocket as DTO
 	responseDTO := dto.PocketDTO{
-		ID:          int(pocket.ID),
-		Name:        pocket.Name,
-		Description: pocket.Description,
+		ID:             int(pocket.ID),
+		Name:           pocket.Name,
+		Description:    pocket.Description,
+		RolloverPolicy: pocket.RolloverPolicy,
+		Version:        pocket.Version,
 	}
 
+	setETag(c, pocket.Version)
 	c.JSON(http.StatusCreated, responseDTO)
 }
 
 // UpdatePocket actualiza un bolsillo existente
 // PUT /api/config/pockets/{id}
+// Con If-Match solo actualiza si el bolsillo sigue en esa versión
 func (h *ConfigHandler) UpdatePocket(c *gin.Context) {
 	idParam := c.Param("id")
 	id, err := strconv.ParseUint(idParam, 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid pocket ID",
-		})
+		respondInvalid(c, "id", "Invalid pocket ID")
+		return
+	}
+
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		respondError(c, "Invalid If-Match header", err)
 		return
 	}
 
 	var pocketDTO dto.PocketDTO
 	if err := c.ShouldBindJSON(&pocketDTO); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request body",
-			"details": err.Error(),
-		})
+		respondError(c, "Invalid request body", invalidBody(err))
 		return
 	}
 
 	// Update pocket using use case
-	pocket, err := h.pocketUseCase.Update(uint(id), pocketDTO.Name, pocketDTO.Description)
+	pocket, err := h.pocketUseCase.Update(c.Request.Context(), uint(id), pocketDTO.Name, pocketDTO.Description, pocketDTO.RolloverPolicy, version)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Error updating pocket",
-			"details": err.Error(),
-		})
+		respondError(c, "Error updating pocket", withCurrentState(c, err, h.currentPocket(uint(id))))
 		return
 	}
 
 	// Return updated pocket as DTO
 	responseDTO := dto.PocketDTO{
-		ID:          int(pocket.ID),
-		Name:        pocket.Name,
-		Description: pocket.Description,
+		ID:             int(pocket.ID),
+		Name:           pocket.Name,
+		Description:    pocket.Description,
+		RolloverPolicy: pocket.RolloverPolicy,
+		Version:        pocket.Version,
 	}
 
+	setETag(c, pocket.Version)
 	c.JSON(http.StatusOK, responseDTO)
 }
 
 // DeletePocket elimina un bolsillo
 // DELETE /api/config/pockets/{id}
+// Con If-Match solo elimina si el bolsillo sigue en esa versión
 func (h *ConfigHandler) DeletePocket(c *gin.Context) {
 	idParam := c.Param("id")
 	id, err := strconv.ParseUint(idParam, 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid pocket ID",
-		})
+		respondInvalid(c, "id", "Invalid pocket ID")
+		return
+	}
+
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		respondError(c, "Invalid If-Match header", err)
 		return
 	}
 
 	// Delete pocket using use case
-	err = h.pocketUseCase.Delete(uint(id))
+	err = h.pocketUseCase.Delete(c.Request.Context(), uint(id), version)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Error deleting pocket",
-			"details": err.Error(),
-		})
+		respondError(c, "Error deleting pocket", withCurrentState(c, err, h.currentPocket(uint(id))))
 		return
 	}
 
@@ -232,11 +233,9 @@ func (h *ConfigHandler) GetDailyBudget(c *gin.Context) {
 	monthParam := c.Param("month")
 
 	// Validate month format
-	_, err := time.Parse("2006-01", monthParam)
+	_, err := civil.ParseMonth(monthParam)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid month format. Use YYYY-MM",
-		})
+		respondInvalid(c, "month", "Invalid month format. Use YYYY-MM")
 		return
 	}
 
@@ -253,43 +252,85 @@ func (h *ConfigHandler) GetDailyBudget(c *gin.Context) {
 
 	response := dto.DailyExpensesConfigDTO{
 		MonthlyBudget: config.MonthlyBudget,
+		Version:       config.Version,
 	}
 
+	setETag(c, config.Version)
 	c.JSON(http.StatusOK, response)
 }
 
 // UpdateDailyBudget actualiza la configuración de presupuesto diario para un mes específico
 // PUT /api/config/daily-budget/{month}
+// Con If-Match solo actualiza si la configuración guardada sigue en esa versión
 func (h *ConfigHandler) UpdateDailyBudget(c *gin.Context) {
 	monthParam := c.Param("month")
 
 	// Validate month format
-	_, err := time.Parse("2006-01", monthParam)
+	_, err := civil.ParseMonth(monthParam)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid month format. Use YYYY-MM",
-		})
+		respondInvalid(c, "month", "Invalid month format. Use YYYY-MM")
+		return
+	}
+
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		respondError(c, "Invalid If-Match header", err)
 		return
 	}
 
 	var configDTO dto.DailyExpensesConfigDTO
 	if err := c.ShouldBindJSON(&configDTO); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request body",
-			"details": err.Error(),
-		})
+		respondError(c, "Invalid request body", invalidBody(err))
 		return
 	}
 
 	// Update daily budget using use case for specified month
-	err = h.dailyExpenseConfigUseCase.UpdateBudget(configDTO.MonthlyBudget, monthParam)
+	config, err := h.dailyExpenseConfigUseCase.UpdateBudget(c.Request.Context(), configDTO.MonthlyBudget, monthParam, version)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Error updating daily budget configuration",
-			"details": err.Error(),
-		})
+		respondError(c, "Error updating daily budget configuration", withCurrentState(c, err, h.currentDailyBudget(monthParam)))
 		return
 	}
 
+	configDTO.Version = config.Version
+	setETag(c, config.Version)
 	c.JSON(http.StatusOK, configDTO)
 }
+
+// currentIncome carga la configuración de ingresos guardada de un mes para un conflicto de versión
+func (h *ConfigHandler) currentIncome(month string) func() (interface{}, uint, error) {
+	return func() (interface{}, uint, error) {
+		salary, err := h.salaryUseCase.GetByMonth(month)
+		if err != nil {
+			return nil, 0, err
+		}
+		return dto.SalaryDTO{MonthlyAmount: salary.MonthlyAmount, Version: salary.Version}, salary.Version, nil
+	}
+}
+
+// currentPocket carga el estado actual de un bolsillo para un conflicto de versión
+func (h *ConfigHandler) currentPocket(id uint) func() (interface{}, uint, error) {
+	return func() (interface{}, uint, error) {
+		pocket, err := h.pocketUseCase.GetByID(id)
+		if err != nil {
+			return nil, 0, err
+		}
+		return dto.PocketDTO{
+			ID:             int(pocket.ID),
+			Name:           pocket.Name,
+			Description:    pocket.Description,
+			RolloverPolicy: pocket.RolloverPolicy,
+			Version:        pocket.Version,
+		}, pocket.Version, nil
+	}
+}
+
+// currentDailyBudget carga la configuración de presupuesto diario guardada de un mes para un conflicto de versión
+func (h *ConfigHandler) currentDailyBudget(month string) func() (interface{}, uint, error) {
+	return func() (interface{}, uint, error) {
+		config, err := h.dailyExpenseConfigUseCase.GetByMonth(month)
+		if err != nil {
+			return nil, 0, err
+		}
+		return dto.DailyExpensesConfigDTO{MonthlyBudget: config.MonthlyBudget, Version: config.Version}, config.Version, nil
+	}
+}
@@ -2,11 +2,12 @@ package handler
 
 import (
 	"expenses-api/internal/api/dto"
+	"expenses-api/internal/application/port"
 	"expenses-api/internal/application/usecase"
+	"expenses-api/internal/domain/civil"
 	"expenses-api/internal/domain/daily_expense"
 	"net/http"
 	"strconv"
-	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -14,49 +15,41 @@ import (
 // DailyExpenseHandler handles daily expense-related HTTP requests
 type DailyExpenseHandler struct {
 	dailyExpenseUseCase *usecase.DailyExpenseUseCase
+	clock               port.Clock
 }
 
 // NewDailyExpenseHandler creates a new daily expense handler instance
-func NewDailyExpenseHandler(dailyExpenseUseCase *usecase.DailyExpenseUseCase) *DailyExpenseHandler {
+func NewDailyExpenseHandler(dailyExpenseUseCase *usecase.DailyExpenseUseCase, clock port.Clock) *DailyExpenseHandler {
 	return &DailyExpenseHandler{
 		dailyExpenseUseCase: dailyExpenseUseCase,
+		clock:               clock,
 	}
 }
 
 // GetByMonth obtiene los gastos diarios de un mes específico
-// GET /api/daily-expenses/{month}
+// GET /api/daily-expenses/{month}?tag={nombre}
+// El parámetro opcional tag filtra los gastos que tienen esa etiqueta
 func (h *DailyExpenseHandler) GetByMonth(c *gin.Context) {
 	monthParam := c.Param("month")
 
 	// Validate month format
-	_, err := time.Parse("2006-01", monthParam)
+	_, err := civil.ParseMonth(monthParam)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid month format. Use YYYY-MM",
-		})
+		respondInvalid(c, "month", "Invalid month format. Use YYYY-MM")
 		return
 	}
 
 	// Get daily expenses using use case
-	expenses, err := h.dailyExpenseUseCase.GetByMonth(monthParam)
+	expenses, err := h.dailyExpenseUseCase.GetByMonthAndTag(monthParam, c.Query("tag"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Error getting daily expenses",
-			"details": err.Error(),
-		})
+		respondError(c, "Error getting daily expenses", err)
 		return
 	}
 
 	// Convert to DTOs
 	var expenseDTOs []dto.DailyExpenseDTO
 	for _, expense := range expenses {
-		expenseDTOs = append(expenseDTOs, dto.DailyExpenseDTO{
-			ID:          int(expense.ID),
-			Amount:      expense.Amount,
-			Description: expense.Description,
-			Date:        expense.Date,
-			CreatedAt:   expense.CreatedAt,
-		})
+		expenseDTOs = append(expenseDTOs, toDailyExpenseDTO(&expense))
 	}
 
 	c.JSON(http.StatusOK, expenseDTOs)
@@ -67,106 +60,130 @@ func (h *DailyExpenseHandler) GetByMonth(c *gin.Context) {
 func (h *DailyExpenseHandler) Create(c *gin.Context) {
 	var expenseDTO dto.DailyExpenseDTO
 	if err := c.ShouldBindJSON(&expenseDTO); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request body",
-			"details": err.Error(),
-		})
+		respondError(c, "Invalid request body", invalidBody(err))
 		return
 	}
 
 	// Create daily expense using use case with current date
 	expense, err := h.dailyExpenseUseCase.Create(
+		c.Request.Context(),
 		expenseDTO.Description,
 		expenseDTO.Amount,
-		daily_expense.GetCurrentDate(), // Usar fecha actual automáticamente
+		daily_expense.GetCurrentDate(h.clock.Now()).String(), // Usar fecha actual automáticamente
+		pocketIDFromDTO(expenseDTO.PocketID),
+		expenseDTO.Tags,
+		splitsFromDTO(expenseDTO.Splits),
 	)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Error creating daily expense",
-			"details": err.Error(),
-		})
+		respondError(c, "Error creating daily expense", err)
 		return
 	}
 
 	// Return created expense as DTO
-	responseDTO := dto.DailyExpenseDTO{
-		ID:          int(expense.ID),
-		Amount:      expense.Amount,
-		Description: expense.Description,
-		Date:        expense.Date,
-		CreatedAt:   expense.CreatedAt,
-	}
+	responseDTO := toDailyExpenseDTO(expense)
 
+	setETag(c, expense.Version)
 	c.JSON(http.StatusCreated, responseDTO)
 }
 
+// QuickEntry interpreta un gasto escrito como texto libre
+// POST /api/daily-expenses/parse
+// Devuelve la vista previa del gasto, o lo crea si create es true
+func (h *DailyExpenseHandler) QuickEntry(c *gin.Context) {
+	var request dto.QuickEntryRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		respondError(c, "Invalid request body", invalidBody(err))
+		return
+	}
+
+	if !request.Create {
+		expense, err := h.dailyExpenseUseCase.PreviewQuickEntry(request.Text)
+		if err != nil {
+			respondError(c, "Error parsing daily expense", err)
+			return
+		}
+
+		c.JSON(http.StatusOK, toDailyExpenseDTO(expense))
+		return
+	}
+
+	expense, err := h.dailyExpenseUseCase.CreateQuickEntry(c.Request.Context(), request.Text)
+	if err != nil {
+		respondError(c, "Error creating daily expense", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toDailyExpenseDTO(expense))
+}
+
 // Update actualiza un gasto diario existente
 // PUT /api/daily-expenses/{id}
+// Con If-Match solo actualiza si el gasto sigue en esa versión
 func (h *DailyExpenseHandler) Update(c *gin.Context) {
 	idParam := c.Param("id")
 	id, err := strconv.ParseUint(idParam, 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid expense ID",
-		})
+		respondInvalid(c, "id", "Invalid expense ID")
+		return
+	}
+
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		respondError(c, "Invalid If-Match header", err)
 		return
 	}
 
 	var expenseDTO dto.DailyExpenseDTO
 	if err := c.ShouldBindJSON(&expenseDTO); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request body",
-			"details": err.Error(),
-		})
+		respondError(c, "Invalid request body", invalidBody(err))
 		return
 	}
 
 	// Update daily expense using use case (keep original date)
 	expense, err := h.dailyExpenseUseCase.Update(
+		c.Request.Context(),
 		uint(id),
 		expenseDTO.Description,
 		expenseDTO.Amount,
 		"", // Empty date means keep original date
+		pocketIDFromDTO(expenseDTO.PocketID),
+		expenseDTO.Tags,
+		splitsFromDTO(expenseDTO.Splits),
+		version,
 	)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Error updating daily expense",
-			"details": err.Error(),
-		})
+		respondError(c, "Error updating daily expense", withCurrentState(c, err, h.currentExpense(uint(id))))
 		return
 	}
 
 	// Return updated expense as DTO
-	responseDTO := dto.DailyExpenseDTO{
-		ID:          int(expense.ID),
-		Amount:      expense.Amount,
-		Description: expense.Description,
-		Date:        expense.Date,
-		CreatedAt:   expense.CreatedAt,
-	}
+	responseDTO := toDailyExpenseDTO(expense)
 
+	setETag(c, expense.Version)
 	c.JSON(http.StatusOK, responseDTO)
 }
 
 // Delete elimina un gasto diario
 // DELETE /api/daily-expenses/{id}
+// Con If-Match solo elimina si el gasto sigue en esa versión
 func (h *DailyExpenseHandler) Delete(c *gin.Context) {
 	idParam := c.Param("id")
 	id, err := strconv.ParseUint(idParam, 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid expense ID",
-		})
+		respondInvalid(c, "id", "Invalid expense ID")
+		return
+	}
+
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		respondError(c, "Invalid If-Match header", err)
 		return
 	}
 
 	// Delete daily expense using use case
-	err = h.dailyExpenseUseCase.Delete(uint(id))
+	err = h.dailyExpenseUseCase.Delete(c.Request.Context(), uint(id), version)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Error deleting daily expense",
-			"details": err.Error(),
-		})
+		respondError(c, "Error deleting daily expense", withCurrentState(c, err, h.currentExpense(uint(id))))
 		return
 	}
 
@@ -175,3 +192,63 @@ func (h *DailyExpenseHandler) Delete(c *gin.Context) {
 		"id":      id,
 	})
 }
+
+// currentExpense carga el estado actual de un gasto diario para un conflicto de versión
+func (h *DailyExpenseHandler) currentExpense(id uint) func() (interface{}, uint, error) {
+	return func() (interface{}, uint, error) {
+		expense, err := h.dailyExpenseUseCase.GetByID(id)
+		if err != nil {
+			return nil, 0, err
+		}
+		return toDailyExpenseDTO(expense), expense.Version, nil
+	}
+}
+
+// toDailyExpenseDTO convierte el modelo de dominio en el DTO de respuesta
+func toDailyExpenseDTO(expense *daily_expense.DailyExpense) dto.DailyExpenseDTO {
+	expenseDTO := dto.DailyExpenseDTO{
+		ID:          int(expense.ID),
+		Amount:      expense.Amount,
+		Description: expense.Description,
+		Date:        expense.Date.String(),
+		PocketName:  expense.GetPocketName(),
+		Tags:        expense.GetTagNames(),
+		CreatedAt:   expense.CreatedAt,
+		Version:     expense.Version,
+	}
+
+	if expense.PocketID != nil {
+		expenseDTO.PocketID = int(*expense.PocketID)
+	}
+
+	for i := range expense.Splits {
+		split := &expense.Splits[i]
+		expenseDTO.Splits = append(expenseDTO.Splits, dto.DailyExpenseSplitDTO{
+			ID:         int(split.ID),
+			PocketID:   int(split.PocketID),
+			PocketName: split.GetPocketName(),
+			Amount:     split.Amount,
+			Note:       split.Note,
+		})
+	}
+
+	return expenseDTO
+}
+
+// splitsFromDTO convierte las líneas de división del request al modelo de dominio
+// Devuelve nil si no se enviaron, para conservar las existentes
+func splitsFromDTO(splitDTOs []dto.DailyExpenseSplitDTO) []daily_expense.Split {
+	if splitDTOs == nil {
+		return nil
+	}
+
+	splits := make([]daily_expense.Split, 0, len(splitDTOs))
+	for _, splitDTO := range splitDTOs {
+		splits = append(splits, daily_expense.Split{
+			PocketID: uint(splitDTO.PocketID),
+			Amount:   splitDTO.Amount,
+			Note:     splitDTO.Note,
+		})
+	}
+	return splits
+}
@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"expenses-api/internal/api/dto"
+	"expenses-api/internal/application/usecase"
+	"expenses-api/internal/domain/civil"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// EnvelopeHandler handles envelope-related HTTP requests
+type EnvelopeHandler struct {
+	envelopeUseCase *usecase.EnvelopeUseCase
+}
+
+// NewEnvelopeHandler creates a new envelope handler instance
+func NewEnvelopeHandler(envelopeUseCase *usecase.EnvelopeUseCase) *EnvelopeHandler {
+	return &EnvelopeHandler{
+		envelopeUseCase: envelopeUseCase,
+	}
+}
+
+// GetByMonth obtiene el saldo de cada bolsillo (sobre) en un mes específico
+// GET /api/envelopes/{month}
+func (h *EnvelopeHandler) GetByMonth(c *gin.Context) {
+	monthParam := c.Param("month")
+
+	// Validate month format
+	_, err := civil.ParseMonth(monthParam)
+	if err != nil {
+		respondInvalid(c, "month", "Invalid month format. Use YYYY-MM")
+		return
+	}
+
+	envelopes, err := h.envelopeUseCase.GetBalances(monthParam)
+	if err != nil {
+		respondError(c, "Error calculating envelope balances", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, envelopes)
+}
+
+// UpdateAllocation actualiza la asignación mensual de un bolsillo
+// PUT /api/envelopes/{month}/{pocket_id}
+func (h *EnvelopeHandler) UpdateAllocation(c *gin.Context) {
+	monthParam := c.Param("month")
+
+	// Validate month format
+	_, err := civil.ParseMonth(monthParam)
+	if err != nil {
+		respondInvalid(c, "month", "Invalid month format. Use YYYY-MM")
+		return
+	}
+
+	pocketID, err := strconv.ParseUint(c.Param("pocket_id"), 10, 32)
+	if err != nil {
+		respondInvalid(c, "pocket_id", "Invalid pocket ID")
+		return
+	}
+
+	var allocationDTO dto.PocketAllocationDTO
+	if err := c.ShouldBindJSON(&allocationDTO); err != nil {
+		respondError(c, "Invalid request body", invalidBody(err))
+		return
+	}
+
+	_, err = h.envelopeUseCase.SetAllocation(c.Request.Context(), uint(pocketID), monthParam, allocationDTO.Amount)
+	if err != nil {
+		respondError(c, "Error updating pocket allocation", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, allocationDTO)
+}
@@ -2,11 +2,12 @@ package handler
 
 import (
 	"expenses-api/internal/api/dto"
+	"expenses-api/internal/application/port"
 	"expenses-api/internal/application/usecase"
+	"expenses-api/internal/domain/civil"
 	"expenses-api/internal/domain/fixed_expense"
 	"net/http"
 	"strconv"
-	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -14,59 +15,43 @@ import (
 // FixedExpenseHandler handles fixed expense-related HTTP requests
 type FixedExpenseHandler struct {
 	fixedExpenseUseCase *usecase.FixedExpenseUseCase
+	clock               port.Clock
 }
 
 // NewFixedExpenseHandler creates a new fixed expense handler instance
-func NewFixedExpenseHandler(fixedExpenseUseCase *usecase.FixedExpenseUseCase) *FixedExpenseHandler {
+func NewFixedExpenseHandler(fixedExpenseUseCase *usecase.FixedExpenseUseCase, clock port.Clock) *FixedExpenseHandler {
 	return &FixedExpenseHandler{
 		fixedExpenseUseCase: fixedExpenseUseCase,
+		clock:               clock,
 	}
 }
 
 // GetByMonth obtiene los gastos fijos de un mes específico
-// GET /api/fixed-expenses/{month}
+// GET /api/fixed-expenses/{month}?tag={nombre}
 // Implementa herencia automática del mes anterior si no existen gastos
+// El parámetro opcional tag filtra los gastos que tienen esa etiqueta
 func (h *FixedExpenseHandler) GetByMonth(c *gin.Context) {
 	monthParam := c.Param("month")
 
 	// Validate month format
-	_, err := time.Parse("2006-01", monthParam)
+	_, err := civil.ParseMonth(monthParam)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid month format. Use YYYY-MM",
-		})
+		respondInvalid(c, "month", "Invalid month format. Use YYYY-MM")
 		return
 	}
 
 	// Get fixed expenses with inheritance
-	expenses, err := h.fixedExpenseUseCase.GetByMonthWithInheritance(monthParam)
+	expenses, err := h.fixedExpenseUseCase.GetByMonthAndTag(monthParam, c.Query("tag"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Error getting fixed expenses",
-			"details": err.Error(),
-		})
+		respondError(c, "Error getting fixed expenses", err)
 		return
 	}
 
 	// Convert to DTOs
 	var expenseDTOs []dto.FixedExpenseDTO
-	for _, expense := range expenses {
-		// Get pocket name from the preloaded relationship
-		pocketName := ""
-		if expense.Pocket != nil {
-			pocketName = expense.Pocket.Name
-		}
-
-		expenseDTOs = append(expenseDTOs, dto.FixedExpenseDTO{
-			ID:          int(expense.ID), // Será 0 si es heredado
-			PocketName:  pocketName,
-			ConceptName: expense.ConceptName,
-			Amount:      expense.Amount,
-			PaymentDay:  expense.PaymentDay,
-			Month:       expense.Month,    // Siempre el mes solicitado
-			IsPaid:      expense.IsPaid,   // Será false si es heredado
-			PaidDate:    expense.PaidDate, // Será nil si es heredado
-		})
+	// Los heredados tienen ID 0, no están pagados y no tienen pagos
+	for i := range expenses {
+		expenseDTOs = append(expenseDTOs, toFixedExpenseDTO(&expenses[i]))
 	}
 
 	c.JSON(http.StatusOK, expenseDTOs)
@@ -74,57 +59,70 @@ func (h *FixedExpenseHandler) GetByMonth(c *gin.Context) {
 
 // UpdateStatus actualiza el estado de pago de un gasto fijo
 // PUT /api/fixed-expenses/{id}/status
+// Con If-Match solo actualiza si el gasto sigue en esa versión
 func (h *FixedExpenseHandler) UpdateStatus(c *gin.Context) {
 	idParam := c.Param("id")
 	id, err := strconv.ParseUint(idParam, 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid expense ID",
-		})
+		respondInvalid(c, "id", "Invalid expense ID")
+		return
+	}
+
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		respondError(c, "Invalid If-Match header", err)
 		return
 	}
 
 	var statusUpdate struct {
-		IsPaid *bool `json:"is_paid" binding:"required"`
+		IsPaid       *bool    `json:"is_paid" binding:"required"`
+		ActualAmount *float64 `json:"actual_amount" binding:"omitempty,min=0"` // Opcional: monto real si difiere del planeado
+		PaidDate     string   `json:"paid_date"`                               // Opcional: YYYY-MM-DD, por defecto hoy
 	}
 
 	if err := c.ShouldBindJSON(&statusUpdate); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request body",
-			"details": err.Error(),
-		})
+		respondError(c, "Invalid request body", invalidBody(err))
 		return
 	}
 
 	// Validate that is_paid was provided
 	if statusUpdate.IsPaid == nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "is_paid field is required",
-		})
+		respondInvalid(c, "is_paid", "is_paid field is required")
 		return
 	}
 
 	// Update payment status using use case
-	err = h.fixedExpenseUseCase.UpdatePaymentStatus(uint(id), *statusUpdate.IsPaid)
+	err = h.fixedExpenseUseCase.UpdatePaymentStatus(
+		c.Request.Context(),
+		uint(id),
+		*statusUpdate.IsPaid,
+		statusUpdate.ActualAmount,
+		statusUpdate.PaidDate,
+		version,
+	)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Error updating payment status",
-			"details": err.Error(),
-		})
+		respondError(c, "Error updating payment status", withCurrentState(c, err, h.currentExpense(uint(id))))
 		return
 	}
 
-	response := gin.H{
-		"message": "Status updated successfully",
-		"id":      id,
-		"is_paid": *statusUpdate.IsPaid,
+	expense, err := h.fixedExpenseUseCase.GetByID(uint(id))
+	if err != nil {
+		respondError(c, "Error retrieving updated expense", err)
+		return
 	}
 
-	if *statusUpdate.IsPaid {
-		now := time.Now()
-		response["paid_date"] = now
+	response := gin.H{
+		"message":        "Status updated successfully",
+		"id":             id,
+		"is_paid":        expense.IsPaid,
+		"paid_date":      expense.PaidDate,
+		"actual_amount":  expense.ActualAmount,
+		"paid_amount":    expense.GetPaidAmount(),
+		"payment_status": expense.GetPaymentStatus(),
+		"version":        expense.Version,
 	}
 
+	setETag(c, expense.Version)
 	c.JSON(http.StatusOK, response)
 }
 
@@ -134,10 +132,7 @@ func (h *FixedExpenseHandler) Create(c *gin.Context) {
 	var expenseDTO dto.FixedExpenseDTO
 
 	if err := c.ShouldBindJSON(&expenseDTO); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request body",
-			"details": err.Error(),
-		})
+		respondError(c, "Invalid request body", invalidBody(err))
 		return
 	}
 
@@ -147,69 +142,48 @@ func (h *FixedExpenseHandler) Create(c *gin.Context) {
 		Amount:      expenseDTO.Amount,
 		PaymentDay:  expenseDTO.PaymentDay,
 		PocketID:    uint(expenseDTO.PocketID),
-		Month:       time.Now().Format("2006-01"), // Siempre usar mes actual
-		IsPaid:      false,                        // Siempre false por defecto
+		Month:       fixed_expense.GetCurrentMonth(h.clock.Now()), // Siempre usar mes actual
+		IsPaid:      false,                                        // Siempre false por defecto
 	}
 
 	// Create expense using use case
-	err := h.fixedExpenseUseCase.Create(expense)
+	err := h.fixedExpenseUseCase.Create(c.Request.Context(), expense, expenseDTO.Tags)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Error creating fixed expense",
-			"details": err.Error(),
-		})
+		respondError(c, "Error creating fixed expense", err)
 		return
 	}
 
 	// Get created expense with pocket information
 	createdExpense, err := h.fixedExpenseUseCase.GetByID(expense.ID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Error retrieving created expense",
-			"details": err.Error(),
-		})
+		respondError(c, "Error retrieving created expense", err)
 		return
 	}
 
-	// Get pocket name from the preloaded relationship
-	pocketName := ""
-	if createdExpense.Pocket != nil {
-		pocketName = createdExpense.Pocket.Name
-	}
-
-	// Convert back to DTO for response
-	responseDTO := dto.FixedExpenseDTO{
-		ID:          int(createdExpense.ID),
-		PocketName:  pocketName,
-		ConceptName: createdExpense.ConceptName,
-		Amount:      createdExpense.Amount,
-		PaymentDay:  createdExpense.PaymentDay,
-		Month:       createdExpense.Month,
-		IsPaid:      createdExpense.IsPaid,
-		PaidDate:    createdExpense.PaidDate,
-	}
-
-	c.JSON(http.StatusCreated, responseDTO)
+	setETag(c, createdExpense.Version)
+	c.JSON(http.StatusCreated, toFixedExpenseDTO(createdExpense))
 }
 
 // Update actualiza un gasto fijo existente
 // PUT /api/fixed-expenses/{id}
+// Con If-Match solo actualiza si el gasto sigue en esa versión
 func (h *FixedExpenseHandler) Update(c *gin.Context) {
 	idParam := c.Param("id")
 	id, err := strconv.ParseUint(idParam, 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid expense ID",
-		})
+		respondInvalid(c, "id", "Invalid expense ID")
+		return
+	}
+
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		respondError(c, "Invalid If-Match header", err)
 		return
 	}
 
 	var expenseDTO dto.FixedExpenseDTO
 	if err := c.ShouldBindJSON(&expenseDTO); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request body",
-			"details": err.Error(),
-		})
+		respondError(c, "Invalid request body", invalidBody(err))
 		return
 	}
 
@@ -219,60 +193,142 @@ func (h *FixedExpenseHandler) Update(c *gin.Context) {
 		Amount:      expenseDTO.Amount,
 		PaymentDay:  expenseDTO.PaymentDay,
 		PocketID:    uint(expenseDTO.PocketID),
-		Month:       time.Now().Format("2006-01"), // Siempre usar mes actual
+		Month:       fixed_expense.GetCurrentMonth(h.clock.Now()), // Siempre usar mes actual
+		Version:     version,
 	}
 
 	// Update expense using use case
-	err = h.fixedExpenseUseCase.Update(uint(id), updatedExpense)
+	err = h.fixedExpenseUseCase.Update(c.Request.Context(), uint(id), updatedExpense, expenseDTO.Tags)
 	if err != nil {
-		statusCode := http.StatusInternalServerError
-		if err.Error() == "expense not found" {
-			statusCode = http.StatusNotFound
-		} else if err.Error() == "expense ID is required" ||
-			err.Error() == "expense data is required" ||
-			err.Error() == "concept name is required" ||
-			err.Error() == "amount must be greater than 0" ||
-			err.Error() == "payment day must be between 1 and 31" ||
-			err.Error() == "month is required" ||
-			err.Error() == "pocket ID is required" ||
-			err.Error() == "invalid month format, must be YYYY-MM" {
-			statusCode = http.StatusBadRequest
-		}
-
-		c.JSON(statusCode, gin.H{
-			"error":   "Error updating fixed expense",
-			"details": err.Error(),
-		})
+		respondError(c, "Error updating fixed expense", withCurrentState(c, err, h.currentExpense(uint(id))))
 		return
 	}
 
 	// Get updated expense to return in response
 	updatedExpenseFromDB, err := h.fixedExpenseUseCase.GetByID(uint(id))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Error retrieving updated expense",
-			"details": err.Error(),
-		})
+		respondError(c, "Error retrieving updated expense", err)
+		return
+	}
+
+	setETag(c, updatedExpenseFromDB.Version)
+	c.JSON(http.StatusOK, toFixedExpenseDTO(updatedExpenseFromDB))
+}
+
+// AddPayment registra un pago (total o parcial) de un gasto fijo
+// POST /api/fixed-expenses/{id}/payments
+func (h *FixedExpenseHandler) AddPayment(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		respondInvalid(c, "id", "Invalid expense ID")
+		return
+	}
+
+	var paymentDTO dto.FixedExpensePaymentDTO
+	if err := c.ShouldBindJSON(&paymentDTO); err != nil {
+		respondError(c, "Invalid request body", invalidBody(err))
 		return
 	}
 
+	expense, err := h.fixedExpenseUseCase.AddPayment(
+		c.Request.Context(),
+		uint(id),
+		paymentDTO.Amount,
+		paymentDTO.PaidDate,
+		paymentDTO.Method,
+		paymentDTO.Note,
+	)
+	if err != nil {
+		respondError(c, "Error recording payment", err)
+		return
+	}
+
+	setETag(c, expense.Version)
+	c.JSON(http.StatusCreated, toFixedExpenseDTO(expense))
+}
+
+// DeletePayment elimina un pago de un gasto fijo y recalcula su estado
+// DELETE /api/fixed-expenses/{id}/payments/{payment_id}
+func (h *FixedExpenseHandler) DeletePayment(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		respondInvalid(c, "id", "Invalid expense ID")
+		return
+	}
+
+	paymentID, err := strconv.ParseUint(c.Param("payment_id"), 10, 32)
+	if err != nil {
+		respondInvalid(c, "payment_id", "Invalid payment ID")
+		return
+	}
+
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		respondError(c, "Invalid If-Match header", err)
+		return
+	}
+
+	expense, err := h.fixedExpenseUseCase.DeletePayment(c.Request.Context(), uint(id), uint(paymentID), version)
+	if err != nil {
+		respondError(c, "Error deleting payment", withCurrentState(c, err, h.currentExpense(uint(id))))
+		return
+	}
+
+	setETag(c, expense.Version)
+	c.JSON(http.StatusOK, toFixedExpenseDTO(expense))
+}
+
+// currentExpense carga el estado actual de un gasto fijo para un conflicto de versión
+func (h *FixedExpenseHandler) currentExpense(id uint) func() (interface{}, uint, error) {
+	return func() (interface{}, uint, error) {
+		expense, err := h.fixedExpenseUseCase.GetByID(id)
+		if err != nil {
+			return nil, 0, err
+		}
+		return toFixedExpenseDTO(expense), expense.Version, nil
+	}
+}
+
+// toFixedExpenseDTO convierte el modelo de dominio en el DTO de respuesta
+func toFixedExpenseDTO(expense *fixed_expense.FixedExpense) dto.FixedExpenseDTO {
 	// Get pocket name from the preloaded relationship
 	pocketName := ""
-	if updatedExpenseFromDB.Pocket != nil {
-		pocketName = updatedExpenseFromDB.Pocket.Name
+	if expense.Pocket != nil {
+		pocketName = expense.Pocket.Name
 	}
 
-	// Convert to DTO for response
-	responseDTO := dto.FixedExpenseDTO{
-		ID:          int(updatedExpenseFromDB.ID),
-		PocketName:  pocketName,
-		ConceptName: updatedExpenseFromDB.ConceptName,
-		Amount:      updatedExpenseFromDB.Amount,
-		PaymentDay:  updatedExpenseFromDB.PaymentDay,
-		Month:       updatedExpenseFromDB.Month,
-		IsPaid:      updatedExpenseFromDB.IsPaid,
-		PaidDate:    updatedExpenseFromDB.PaidDate,
+	expenseDTO := dto.FixedExpenseDTO{
+		ID:            int(expense.ID),
+		PocketName:    pocketName,
+		ConceptName:   expense.ConceptName,
+		Amount:        expense.Amount,
+		PaymentDay:    expense.PaymentDay,
+		Month:         expense.Month.String(),
+		IsPaid:        expense.IsPaid,
+		ActualAmount:  expense.ActualAmount,
+		PaidAmount:    expense.GetPaidAmount(),
+		PaymentStatus: expense.GetPaymentStatus(),
+		Tags:          expense.GetTagNames(),
+		Version:       expense.Version,
+	}
+
+	if expense.PaidDate != nil {
+		paidDate := expense.PaidDate.String()
+		expenseDTO.PaidDate = &paidDate
+	}
+
+	for _, payment := range expense.Payments {
+		expenseDTO.Payments = append(expenseDTO.Payments, dto.FixedExpensePaymentDTO{
+			ID:        int(payment.ID),
+			Amount:    payment.Amount,
+			PaidDate:  payment.PaidDate.String(),
+			Method:    payment.Method,
+			Note:      payment.Note,
+			CreatedAt: payment.CreatedAt,
+		})
 	}
 
-	c.JSON(http.StatusOK, responseDTO)
+	return expenseDTO
 }
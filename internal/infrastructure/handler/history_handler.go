@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"expenses-api/internal/api/dto"
+	"expenses-api/internal/application/usecase"
+	"expenses-api/internal/domain/civil"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HistoryHandler handles ledger event log HTTP requests
+type HistoryHandler struct {
+	historyUseCase *usecase.HistoryUseCase
+}
+
+// NewHistoryHandler creates a new history handler instance
+func NewHistoryHandler(historyUseCase *usecase.HistoryUseCase) *HistoryHandler {
+	return &HistoryHandler{
+		historyUseCase: historyUseCase,
+	}
+}
+
+// GetEvents obtiene los eventos del libro en el orden en que se reproducen
+// GET /api/history/events?entity={tabla}&after={id}&limit={n}
+func (h *HistoryHandler) GetEvents(c *gin.Context) {
+	var afterID uint64
+	if param := c.Query("after"); param != "" {
+		parsed, err := strconv.ParseUint(param, 10, 32)
+		if err != nil {
+			respondInvalid(c, "after", "Invalid event ID")
+			return
+		}
+		afterID = parsed
+	}
+
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	events, err := h.historyUseCase.GetEvents(c.Query("entity"), uint(afterID), limit)
+	if err != nil {
+		respondError(c, "Error getting ledger events", err)
+		return
+	}
+
+	// Convert to DTOs
+	eventDTOs := make([]dto.LedgerEventDTO, 0, len(events))
+	for _, event := range events {
+		eventDTOs = append(eventDTOs, dto.LedgerEventDTO{
+			ID:         int(event.ID),
+			Name:       event.Name,
+			Entity:     event.Entity,
+			EntityID:   int(event.EntityID),
+			Data:       rawJSON(event.Data),
+			OccurredAt: event.OccurredAt,
+		})
+	}
+
+	c.JSON(http.StatusOK, eventDTOs)
+}
+
+// GetMonthlySummary reconstruye el resumen mensual tal como estaba en un momento dado
+// GET /api/history/summary/{month}?as_of=YYYY-MM-DD
+// as_of también acepta un instante RFC 3339; una fecha equivale al final de ese día
+func (h *HistoryHandler) GetMonthlySummary(c *gin.Context) {
+	monthParam := c.Param("month")
+
+	// Validate month format
+	_, err := civil.ParseMonth(monthParam)
+	if err != nil {
+		respondInvalid(c, "month", "Invalid month format. Use YYYY-MM")
+		return
+	}
+
+	summary, err := h.historyUseCase.GetMonthlySummaryAt(monthParam, c.Query("as_of"))
+	if err != nil {
+		respondError(c, "Error rebuilding monthly summary", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, summary)
+}
+
+// CheckConsistency compara el libro reconstruido desde los eventos con las tablas actuales
+// GET /api/history/consistency
+func (h *HistoryHandler) CheckConsistency(c *gin.Context) {
+	report, err := h.historyUseCase.CheckConsistency()
+	if err != nil {
+		respondError(c, "Error checking ledger consistency", err)
+		return
+	}
+
+	response := dto.ConsistencyReportDTO{
+		CheckedAt:       report.CheckedAt,
+		Consistent:      report.IsConsistent(),
+		Events:          report.Events,
+		LastEventID:     int(report.LastEventID),
+		EntitiesChecked: report.Entities,
+		Discrepancies:   make([]dto.DiscrepancyDTO, 0, len(report.Discrepancies)),
+	}
+	for _, discrepancy := range report.Discrepancies {
+		response.Discrepancies = append(response.Discrepancies, dto.DiscrepancyDTO{
+			Entity:    discrepancy.Entity,
+			EntityID:  int(discrepancy.EntityID),
+			Kind:      discrepancy.Kind,
+			Projected: rawJSON(discrepancy.Projected),
+			Current:   rawJSON(discrepancy.Current),
+		})
+	}
+
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,300 @@
+package handler
+
+import (
+	"expenses-api/internal/api/dto"
+	"expenses-api/internal/application/usecase"
+	"expenses-api/internal/domain/civil"
+	"expenses-api/internal/domain/household"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HouseholdHandler handles household member and shared expense HTTP requests
+type HouseholdHandler struct {
+	householdUseCase *usecase.HouseholdUseCase
+}
+
+// NewHouseholdHandler creates a new household handler instance
+func NewHouseholdHandler(householdUseCase *usecase.HouseholdUseCase) *HouseholdHandler {
+	return &HouseholdHandler{
+		householdUseCase: householdUseCase,
+	}
+}
+
+// GetMembers obtiene los miembros del hogar
+// GET /api/household/members
+func (h *HouseholdHandler) GetMembers(c *gin.Context) {
+	members, err := h.householdUseCase.GetMembers()
+	if err != nil {
+		respondError(c, "Error getting household members", err)
+		return
+	}
+
+	// Convert to DTOs
+	memberDTOs := make([]dto.HouseholdMemberDTO, 0, len(members))
+	for i := range members {
+		memberDTOs = append(memberDTOs, toHouseholdMemberDTO(&members[i]))
+	}
+
+	c.JSON(http.StatusOK, memberDTOs)
+}
+
+// CreateMember agrega un miembro al hogar
+// POST /api/household/members
+func (h *HouseholdHandler) CreateMember(c *gin.Context) {
+	var memberDTO dto.HouseholdMemberDTO
+	if err := c.ShouldBindJSON(&memberDTO); err != nil {
+		respondError(c, "Invalid request body", invalidBody(err))
+		return
+	}
+
+	created, err := h.householdUseCase.CreateMember(c.Request.Context(), memberDTO.Name, memberDTO.Email)
+	if err != nil {
+		respondError(c, "Error creating household member", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toHouseholdMemberDTO(created))
+}
+
+// UpdateMember renombra o activa/desactiva un miembro del hogar
+// PUT /api/household/members/{id}
+func (h *HouseholdHandler) UpdateMember(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondInvalid(c, "id", "Invalid member ID")
+		return
+	}
+
+	var memberDTO dto.HouseholdMemberDTO
+	if err := c.ShouldBindJSON(&memberDTO); err != nil {
+		respondError(c, "Invalid request body", invalidBody(err))
+		return
+	}
+
+	updated, err := h.householdUseCase.UpdateMember(c.Request.Context(), uint(id), memberDTO.Name, memberDTO.Email, memberDTO.IsActive)
+	if err != nil {
+		respondError(c, "Error updating household member", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toHouseholdMemberDTO(updated))
+}
+
+// DeleteMember elimina un miembro sin gastos compartidos
+// DELETE /api/household/members/{id}
+func (h *HouseholdHandler) DeleteMember(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondInvalid(c, "id", "Invalid member ID")
+		return
+	}
+
+	if err := h.householdUseCase.DeleteMember(c.Request.Context(), uint(id)); err != nil {
+		respondError(c, "Error deleting household member", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Household member deleted successfully",
+		"id":      id,
+	})
+}
+
+// GetSharesByMonth obtiene los gastos compartidos de un mes
+// GET /api/expense-shares/{month}
+func (h *HouseholdHandler) GetSharesByMonth(c *gin.Context) {
+	shares, err := h.householdUseCase.GetSharesByMonth(c.Param("month"))
+	if err != nil {
+		respondError(c, "Error getting shared expenses", err)
+		return
+	}
+
+	// Convert to DTOs
+	shareDTOs := make([]dto.ExpenseShareDTO, 0, len(shares))
+	for i := range shares {
+		shareDTOs = append(shareDTOs, toExpenseShareDTO(&shares[i]))
+	}
+
+	c.JSON(http.StatusOK, shareDTOs)
+}
+
+// CreateShare registra quién pagó un gasto y cómo se reparte
+// POST /api/expense-shares
+func (h *HouseholdHandler) CreateShare(c *gin.Context) {
+	var shareDTO dto.ExpenseShareDTO
+	if err := c.ShouldBindJSON(&shareDTO); err != nil {
+		respondError(c, "Invalid request body", invalidBody(err))
+		return
+	}
+
+	created, err := h.householdUseCase.CreateShare(
+		c.Request.Context(),
+		shareDTO.ExpenseType,
+		uint(shareDTO.ExpenseID),
+		uint(shareDTO.PayerID),
+		shareDTO.Method,
+		sharePartsFromDTO(shareDTO.Parts),
+	)
+	if err != nil {
+		respondError(c, "Error creating shared expense", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toExpenseShareDTO(created))
+}
+
+// UpdateShare cambia el pagador, el método o los participantes de un gasto compartido
+// PUT /api/expense-shares/{id}
+func (h *HouseholdHandler) UpdateShare(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondInvalid(c, "id", "Invalid share ID")
+		return
+	}
+
+	var shareDTO dto.ExpenseShareDTO
+	if err := c.ShouldBindJSON(&shareDTO); err != nil {
+		respondError(c, "Invalid request body", invalidBody(err))
+		return
+	}
+
+	updated, err := h.householdUseCase.UpdateShare(
+		c.Request.Context(),
+		uint(id),
+		uint(shareDTO.PayerID),
+		shareDTO.Method,
+		sharePartsFromDTO(shareDTO.Parts),
+	)
+	if err != nil {
+		respondError(c, "Error updating shared expense", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toExpenseShareDTO(updated))
+}
+
+// DeleteShare deja de compartir un gasto
+// DELETE /api/expense-shares/{id}
+func (h *HouseholdHandler) DeleteShare(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondInvalid(c, "id", "Invalid share ID")
+		return
+	}
+
+	if err := h.householdUseCase.DeleteShare(c.Request.Context(), uint(id)); err != nil {
+		respondError(c, "Error deleting shared expense", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Shared expense deleted successfully",
+		"id":      id,
+	})
+}
+
+// SettleUp calcula los saldos del mes y las transferencias mínimas para saldar cuentas
+// GET /api/household/settle-up/{month}
+func (h *HouseholdHandler) SettleUp(c *gin.Context) {
+	monthParam := c.Param("month")
+
+	// Validate month format
+	if _, err := civil.ParseMonth(monthParam); err != nil {
+		respondInvalid(c, "month", "Invalid month format. Use YYYY-MM")
+		return
+	}
+
+	balances, settlements, err := h.householdUseCase.SettleUp(monthParam)
+	if err != nil {
+		respondError(c, "Error settling up household expenses", err)
+		return
+	}
+
+	settleUp := dto.SettleUpDTO{
+		Month:       monthParam,
+		Balances:    make([]dto.MemberBalanceDTO, 0, len(balances)),
+		Settlements: make([]dto.SettlementDTO, 0, len(settlements)),
+	}
+
+	for i := range balances {
+		balance := &balances[i]
+		settleUp.Balances = append(settleUp.Balances, dto.MemberBalanceDTO{
+			MemberID:   int(balance.MemberID),
+			MemberName: balance.MemberName,
+			Paid:       balance.Paid,
+			Owed:       balance.Owed,
+			Net:        balance.GetNet(),
+		})
+	}
+
+	for _, settlement := range settlements {
+		settleUp.Settlements = append(settleUp.Settlements, dto.SettlementDTO{
+			FromMemberID: int(settlement.FromMemberID),
+			FromName:     settlement.FromName,
+			ToMemberID:   int(settlement.ToMemberID),
+			ToName:       settlement.ToName,
+			Amount:       settlement.Amount,
+		})
+	}
+
+	c.JSON(http.StatusOK, settleUp)
+}
+
+// sharePartsFromDTO convierte los participantes del request al modelo de dominio
+func sharePartsFromDTO(partDTOs []dto.ExpenseSharePartDTO) []household.SharePart {
+	parts := make([]household.SharePart, 0, len(partDTOs))
+	for _, partDTO := range partDTOs {
+		parts = append(parts, household.SharePart{
+			MemberID:   uint(partDTO.MemberID),
+			Percentage: partDTO.Percentage,
+			Amount:     partDTO.Amount,
+		})
+	}
+	return parts
+}
+
+// toHouseholdMemberDTO convierte el modelo de dominio en el DTO de respuesta
+func toHouseholdMemberDTO(member *household.Member) dto.HouseholdMemberDTO {
+	isActive := member.IsActive
+	return dto.HouseholdMemberDTO{
+		ID:        int(member.ID),
+		Name:      member.Name,
+		Email:     member.Email,
+		IsActive:  &isActive,
+		CreatedAt: member.CreatedAt,
+	}
+}
+
+// toExpenseShareDTO convierte el modelo de dominio en el DTO de respuesta
+func toExpenseShareDTO(share *household.Share) dto.ExpenseShareDTO {
+	shareDTO := dto.ExpenseShareDTO{
+		ID:                 int(share.ID),
+		ExpenseType:        share.ExpenseType,
+		ExpenseID:          int(share.ExpenseID),
+		PayerID:            int(share.PayerID),
+		Method:             share.Method,
+		Parts:              make([]dto.ExpenseSharePartDTO, 0, len(share.Parts)),
+		PayerName:          share.GetPayerName(),
+		ExpenseDescription: share.ExpenseDescription,
+		ExpenseAmount:      share.ExpenseAmount,
+		ExpenseMonth:       share.ExpenseMonth.String(),
+		CreatedAt:          share.CreatedAt,
+	}
+
+	owed := share.GetOwedAmounts(share.ExpenseAmount)
+	for i := range share.Parts {
+		part := &share.Parts[i]
+		shareDTO.Parts = append(shareDTO.Parts, dto.ExpenseSharePartDTO{
+			MemberID:   int(part.MemberID),
+			Percentage: part.Percentage,
+			Amount:     part.Amount,
+			MemberName: part.GetMemberName(),
+			OwedAmount: owed[part.MemberID],
+		})
+	}
+
+	return shareDTO
+}
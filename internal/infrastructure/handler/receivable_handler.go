@@ -0,0 +1,228 @@
+package handler
+
+import (
+	"expenses-api/internal/api/dto"
+	"expenses-api/internal/application/usecase"
+	"expenses-api/internal/domain/receivable"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReceivableHandler handles reimbursable expense-related HTTP requests
+type ReceivableHandler struct {
+	receivableUseCase *usecase.ReceivableUseCase
+}
+
+// NewReceivableHandler creates a new receivable handler instance
+func NewReceivableHandler(receivableUseCase *usecase.ReceivableUseCase) *ReceivableHandler {
+	return &ReceivableHandler{
+		receivableUseCase: receivableUseCase,
+	}
+}
+
+// GetAll obtiene los gastos reembolsables
+// GET /api/receivables?status=outstanding|partial|settled&counterparty={nombre}
+func (h *ReceivableHandler) GetAll(c *gin.Context) {
+	receivables, err := h.receivableUseCase.GetAll(c.Query("status"), c.Query("counterparty"))
+	if err != nil {
+		respondError(c, "Error getting receivables", err)
+		return
+	}
+
+	// Convert to DTOs
+	receivableDTOs := make([]dto.ReceivableDTO, 0, len(receivables))
+	for i := range receivables {
+		receivableDTOs = append(receivableDTOs, toReceivableDTO(&receivables[i]))
+	}
+
+	c.JSON(http.StatusOK, receivableDTOs)
+}
+
+// GetOutstanding obtiene el reporte de reembolsos pendientes agrupado por tercero
+// GET /api/receivables/outstanding
+func (h *ReceivableHandler) GetOutstanding(c *gin.Context) {
+	receivables, balances, err := h.receivableUseCase.GetOutstanding()
+	if err != nil {
+		respondError(c, "Error getting outstanding receivables", err)
+		return
+	}
+
+	report := dto.OutstandingReceivablesDTO{
+		Counterparties: make([]dto.CounterpartyBalanceDTO, 0, len(balances)),
+		Receivables:    make([]dto.ReceivableDTO, 0, len(receivables)),
+	}
+
+	for _, balance := range balances {
+		report.TotalOutstanding += balance.Outstanding
+		report.Counterparties = append(report.Counterparties, dto.CounterpartyBalanceDTO{
+			Counterparty: balance.Counterparty,
+			Amount:       balance.Amount,
+			Reimbursed:   balance.Reimbursed,
+			Outstanding:  balance.Outstanding,
+			Count:        balance.Count,
+		})
+	}
+
+	for i := range receivables {
+		report.Receivables = append(report.Receivables, toReceivableDTO(&receivables[i]))
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// Create marca un gasto como reembolsable por un tercero
+// POST /api/receivables
+func (h *ReceivableHandler) Create(c *gin.Context) {
+	var receivableDTO dto.ReceivableDTO
+	if err := c.ShouldBindJSON(&receivableDTO); err != nil {
+		respondError(c, "Invalid request body", invalidBody(err))
+		return
+	}
+
+	created, err := h.receivableUseCase.Create(
+		c.Request.Context(),
+		receivableDTO.ExpenseType,
+		uint(receivableDTO.ExpenseID),
+		receivableDTO.Counterparty,
+		receivableDTO.Amount,
+		receivableDTO.Note,
+	)
+	if err != nil {
+		respondError(c, "Error creating receivable", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toReceivableDTO(created))
+}
+
+// Update cambia el tercero, el monto esperado o la nota de un gasto reembolsable
+// PUT /api/receivables/{id}
+func (h *ReceivableHandler) Update(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondInvalid(c, "id", "Invalid receivable ID")
+		return
+	}
+
+	var request dto.UpdateReceivableRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		respondError(c, "Invalid request body", invalidBody(err))
+		return
+	}
+
+	updated, err := h.receivableUseCase.Update(c.Request.Context(), uint(id), request.Counterparty, request.Amount, request.Note)
+	if err != nil {
+		respondError(c, "Error updating receivable", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toReceivableDTO(updated))
+}
+
+// Delete quita la marca de reembolsable de un gasto junto con sus reembolsos
+// DELETE /api/receivables/{id}
+func (h *ReceivableHandler) Delete(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondInvalid(c, "id", "Invalid receivable ID")
+		return
+	}
+
+	if err := h.receivableUseCase.Delete(c.Request.Context(), uint(id)); err != nil {
+		respondError(c, "Error deleting receivable", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Receivable deleted successfully",
+		"id":      id,
+	})
+}
+
+// AddReimbursement registra un reembolso recibido (total o parcial)
+// POST /api/receivables/{id}/reimbursements
+func (h *ReceivableHandler) AddReimbursement(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondInvalid(c, "id", "Invalid receivable ID")
+		return
+	}
+
+	var reimbursementDTO dto.ReimbursementDTO
+	if err := c.ShouldBindJSON(&reimbursementDTO); err != nil {
+		respondError(c, "Invalid request body", invalidBody(err))
+		return
+	}
+
+	updated, err := h.receivableUseCase.AddReimbursement(
+		c.Request.Context(),
+		uint(id),
+		reimbursementDTO.Amount,
+		reimbursementDTO.ReceivedDate,
+		reimbursementDTO.Note,
+	)
+	if err != nil {
+		respondError(c, "Error recording reimbursement", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toReceivableDTO(updated))
+}
+
+// DeleteReimbursement elimina un reembolso y recalcula el estado
+// DELETE /api/receivables/{id}/reimbursements/{reimbursement_id}
+func (h *ReceivableHandler) DeleteReimbursement(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondInvalid(c, "id", "Invalid receivable ID")
+		return
+	}
+
+	reimbursementID, err := strconv.ParseUint(c.Param("reimbursement_id"), 10, 32)
+	if err != nil {
+		respondInvalid(c, "reimbursement_id", "Invalid reimbursement ID")
+		return
+	}
+
+	updated, err := h.receivableUseCase.DeleteReimbursement(c.Request.Context(), uint(id), uint(reimbursementID))
+	if err != nil {
+		respondError(c, "Error deleting reimbursement", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toReceivableDTO(updated))
+}
+
+// toReceivableDTO convierte el modelo de dominio en el DTO de respuesta
+func toReceivableDTO(r *receivable.Receivable) dto.ReceivableDTO {
+	amount := r.Amount
+	receivableDTO := dto.ReceivableDTO{
+		ID:                 int(r.ID),
+		ExpenseType:        r.ExpenseType,
+		ExpenseID:          int(r.ExpenseID),
+		Counterparty:       r.Counterparty,
+		Amount:             &amount,
+		Note:               r.Note,
+		ExpenseDescription: r.ExpenseDescription,
+		ExpenseAmount:      r.ExpenseAmount,
+		ExpenseMonth:       r.ExpenseMonth.String(),
+		ReimbursedAmount:   r.GetReimbursedAmount(),
+		OutstandingAmount:  r.GetOutstandingAmount(),
+		Status:             r.GetStatus(),
+		CreatedAt:          r.CreatedAt,
+	}
+
+	for _, reimbursement := range r.Reimbursements {
+		receivableDTO.Reimbursements = append(receivableDTO.Reimbursements, dto.ReimbursementDTO{
+			ID:           int(reimbursement.ID),
+			Amount:       reimbursement.Amount,
+			ReceivedDate: reimbursement.ReceivedDate.String(),
+			Note:         reimbursement.Note,
+			CreatedAt:    reimbursement.CreatedAt,
+		})
+	}
+
+	return receivableDTO
+}
@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"expenses-api/internal/api/dto"
+	"expenses-api/internal/application/usecase"
+	"expenses-api/internal/domain/suggestion"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SuggestionHandler handles pocket suggestion HTTP requests
+type SuggestionHandler struct {
+	suggestionUseCase *usecase.SuggestionUseCase
+}
+
+// NewSuggestionHandler creates a new suggestion handler instance
+func NewSuggestionHandler(suggestionUseCase *usecase.SuggestionUseCase) *SuggestionHandler {
+	return &SuggestionHandler{
+		suggestionUseCase: suggestionUseCase,
+	}
+}
+
+// SuggestPocket sugiere bolsillos para la descripción de un gasto diario nuevo
+// GET /api/suggestions/pocket?description={texto}&limit={n}
+func (h *SuggestionHandler) SuggestPocket(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "3"))
+	if err != nil {
+		respondInvalid(c, "limit", "Invalid limit")
+		return
+	}
+
+	description := c.Query("description")
+	suggestions, model, err := h.suggestionUseCase.SuggestPocket(description, limit)
+	if err != nil {
+		respondError(c, "Error suggesting pocket", err)
+		return
+	}
+
+	response := dto.PocketSuggestionsDTO{
+		Description: description,
+		Suggestions: make([]dto.PocketSuggestionDTO, 0, len(suggestions)),
+		Model:       toSuggestionModelDTO(model),
+	}
+
+	for _, s := range suggestions {
+		response.Suggestions = append(response.Suggestions, dto.PocketSuggestionDTO{
+			PocketID:   int(s.PocketID),
+			PocketName: s.PocketName,
+			Confidence: s.Confidence,
+		})
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// Retrain vuelve a entrenar el modelo con el historial de gastos diarios
+// POST /api/suggestions/retrain
+func (h *SuggestionHandler) Retrain(c *gin.Context) {
+	model, err := h.suggestionUseCase.Retrain()
+	if err != nil {
+		respondError(c, "Error training suggestion model", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toSuggestionModelDTO(model))
+}
+
+// toSuggestionModelDTO convierte el estado del modelo en el DTO de respuesta
+func toSuggestionModelDTO(model *suggestion.Model) dto.SuggestionModelDTO {
+	return dto.SuggestionModelDTO{
+		TrainedAt: model.TrainedAt,
+		Examples:  model.Examples,
+		Pockets:   model.Pockets(),
+	}
+}
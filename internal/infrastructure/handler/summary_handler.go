@@ -2,8 +2,8 @@ package handler
 
 import (
 	"expenses-api/internal/application/usecase"
+	"expenses-api/internal/domain/civil"
 	"net/http"
-	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -26,21 +26,16 @@ func (h *SummaryHandler) GetMonthlySummary(c *gin.Context) {
 	monthParam := c.Param("month")
 
 	// Validate month format
-	_, err := time.Parse("2006-01", monthParam)
+	_, err := civil.ParseMonth(monthParam)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid month format. Use YYYY-MM",
-		})
+		respondInvalid(c, "month", "Invalid month format. Use YYYY-MM")
 		return
 	}
 
 	// Get monthly summary using use case
 	summary, err := h.summaryUseCase.GetMonthlySummary(monthParam)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Error calculating monthly summary",
-			"details": err.Error(),
-		})
+		respondError(c, "Error calculating monthly summary", err)
 		return
 	}
 
@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"expenses-api/internal/api/dto"
+	"expenses-api/internal/application/usecase"
+	"expenses-api/internal/domain/tag"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TagHandler handles expense tag-related HTTP requests
+type TagHandler struct {
+	tagUseCase *usecase.TagUseCase
+}
+
+// NewTagHandler creates a new tag handler instance
+func NewTagHandler(tagUseCase *usecase.TagUseCase) *TagHandler {
+	return &TagHandler{
+		tagUseCase: tagUseCase,
+	}
+}
+
+// GetAll obtiene todas las etiquetas
+// GET /api/tags
+func (h *TagHandler) GetAll(c *gin.Context) {
+	tags, err := h.tagUseCase.GetAll()
+	if err != nil {
+		respondError(c, "Error getting tags", err)
+		return
+	}
+
+	// Convert to DTOs
+	tagDTOs := make([]dto.TagDTO, 0, len(tags))
+	for i := range tags {
+		tagDTOs = append(tagDTOs, toTagDTO(&tags[i]))
+	}
+
+	c.JSON(http.StatusOK, tagDTOs)
+}
+
+// Create crea una nueva etiqueta
+// POST /api/tags
+func (h *TagHandler) Create(c *gin.Context) {
+	var tagDTO dto.TagDTO
+	if err := c.ShouldBindJSON(&tagDTO); err != nil {
+		respondError(c, "Invalid request body", invalidBody(err))
+		return
+	}
+
+	created, err := h.tagUseCase.Create(c.Request.Context(), tagDTO.Name, tagDTO.Color)
+	if err != nil {
+		respondError(c, "Error creating tag", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toTagDTO(created))
+}
+
+// Update renombra o cambia el color de una etiqueta
+// PUT /api/tags/{id}
+func (h *TagHandler) Update(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		respondInvalid(c, "id", "Invalid tag ID")
+		return
+	}
+
+	var tagDTO dto.TagDTO
+	if err := c.ShouldBindJSON(&tagDTO); err != nil {
+		respondError(c, "Invalid request body", invalidBody(err))
+		return
+	}
+
+	updated, err := h.tagUseCase.Update(c.Request.Context(), uint(id), tagDTO.Name, tagDTO.Color)
+	if err != nil {
+		respondError(c, "Error updating tag", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toTagDTO(updated))
+}
+
+// Delete elimina una etiqueta y la quita de todos los gastos
+// DELETE /api/tags/{id}
+func (h *TagHandler) Delete(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		respondInvalid(c, "id", "Invalid tag ID")
+		return
+	}
+
+	if err := h.tagUseCase.Delete(c.Request.Context(), uint(id)); err != nil {
+		respondError(c, "Error deleting tag", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Tag deleted successfully",
+		"id":      id,
+	})
+}
+
+// GetTotals obtiene el gasto por etiqueta en un rango de fechas (inclusive)
+// GET /api/tags/totals?start_date=2026-01-01&end_date=2026-03-31
+func (h *TagHandler) GetTotals(c *gin.Context) {
+	startDate := c.Query("start_date")
+	endDate := c.Query("end_date")
+
+	totals, err := h.tagUseCase.GetTotals(startDate, endDate)
+	if err != nil {
+		respondError(c, "Error getting tag totals", err)
+		return
+	}
+
+	response := dto.TagTotalsDTO{
+		StartDate: startDate,
+		EndDate:   endDate,
+		Tags:      make([]dto.TagTotalDTO, 0, len(totals)),
+	}
+
+	for _, total := range totals {
+		response.Tags = append(response.Tags, dto.TagTotalDTO{
+			TagID:       int(total.TagID),
+			Name:        total.Name,
+			DailyAmount: total.DailyAmount,
+			DailyCount:  total.DailyCount,
+			FixedAmount: total.FixedAmount,
+			FixedCount:  total.FixedCount,
+			TotalAmount: total.GetTotalAmount(),
+			TotalCount:  total.GetTotalCount(),
+		})
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// toTagDTO convierte el modelo de dominio en el DTO de respuesta
+func toTagDTO(t *tag.Tag) dto.TagDTO {
+	return dto.TagDTO{
+		ID:        int(t.ID),
+		Name:      t.Name,
+		Color:     t.Color,
+		CreatedAt: t.CreatedAt,
+	}
+}
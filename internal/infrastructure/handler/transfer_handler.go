@@ -0,0 +1,230 @@
+package handler
+
+import (
+	"errors"
+	"expenses-api/internal/api/dto"
+	"expenses-api/internal/application/port"
+	"expenses-api/internal/application/usecase"
+	"expenses-api/internal/domain/civil"
+	"expenses-api/internal/domain/daily_expense"
+	"expenses-api/internal/domain/transfer"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TransferHandler handles transfer-related HTTP requests
+type TransferHandler struct {
+	transferUseCase *usecase.TransferUseCase
+	clock           port.Clock
+}
+
+// NewTransferHandler creates a new transfer handler instance
+func NewTransferHandler(transferUseCase *usecase.TransferUseCase, clock port.Clock) *TransferHandler {
+	return &TransferHandler{
+		transferUseCase: transferUseCase,
+		clock:           clock,
+	}
+}
+
+// GetByMonth obtiene las transferencias de un mes específico
+// GET /api/transfers/{month}
+func (h *TransferHandler) GetByMonth(c *gin.Context) {
+	monthParam := c.Param("month")
+
+	// Validate month format
+	_, err := civil.ParseMonth(monthParam)
+	if err != nil {
+		respondInvalid(c, "month", "Invalid month format. Use YYYY-MM")
+		return
+	}
+
+	transfers, err := h.transferUseCase.GetByMonth(monthParam)
+	if err != nil {
+		respondError(c, "Error getting transfers", err)
+		return
+	}
+
+	// Convert to DTOs
+	transferDTOs := make([]dto.TransferDTO, 0, len(transfers))
+	for i := range transfers {
+		transferDTOs = append(transferDTOs, toTransferDTO(&transfers[i]))
+	}
+
+	c.JSON(http.StatusOK, transferDTOs)
+}
+
+// GetBalances obtiene el saldo neto movido por transferencias en cada cuenta y bolsillo
+// GET /api/transfers/balances
+func (h *TransferHandler) GetBalances(c *gin.Context) {
+	balances, err := h.transferUseCase.GetBalances()
+	if err != nil {
+		respondError(c, "Error calculating transfer balances", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, balances)
+}
+
+// Create registra una nueva transferencia
+// POST /api/transfers
+func (h *TransferHandler) Create(c *gin.Context) {
+	var transferDTO dto.TransferDTO
+	if err := c.ShouldBindJSON(&transferDTO); err != nil {
+		respondError(c, "Invalid request body", invalidBody(err))
+		return
+	}
+
+	// Usar fecha actual si no se envía
+	if transferDTO.Date == "" {
+		transferDTO.Date = daily_expense.GetCurrentDate(h.clock.Now()).String()
+	}
+
+	t, err := fromTransferDTO(&transferDTO)
+	if err != nil {
+		respondError(c, "Invalid request body", invalidBody(err))
+		return
+	}
+
+	created, err := h.transferUseCase.Create(c.Request.Context(), t)
+	if err != nil {
+		respondError(c, "Error creating transfer", err)
+		return
+	}
+
+	setETag(c, created.Version)
+	c.JSON(http.StatusCreated, toTransferDTO(created))
+}
+
+// Update actualiza una transferencia existente
+// PUT /api/transfers/{id}
+// Con If-Match solo actualiza si la transferencia sigue en esa versión
+func (h *TransferHandler) Update(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		respondInvalid(c, "id", "Invalid transfer ID")
+		return
+	}
+
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		respondError(c, "Invalid If-Match header", err)
+		return
+	}
+
+	var transferDTO dto.TransferDTO
+	if err := c.ShouldBindJSON(&transferDTO); err != nil {
+		respondError(c, "Invalid request body", invalidBody(err))
+		return
+	}
+
+	t, err := fromTransferDTO(&transferDTO)
+	if err != nil {
+		respondError(c, "Invalid request body", invalidBody(err))
+		return
+	}
+
+	t.Version = version
+	updated, err := h.transferUseCase.Update(c.Request.Context(), uint(id), t)
+	if err != nil {
+		respondError(c, "Error updating transfer", withCurrentState(c, err, h.currentTransfer(uint(id))))
+		return
+	}
+
+	setETag(c, updated.Version)
+	c.JSON(http.StatusOK, toTransferDTO(updated))
+}
+
+// Delete elimina una transferencia
+// DELETE /api/transfers/{id}
+// Con If-Match solo elimina si la transferencia sigue en esa versión
+func (h *TransferHandler) Delete(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		respondInvalid(c, "id", "Invalid transfer ID")
+		return
+	}
+
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		respondError(c, "Invalid If-Match header", err)
+		return
+	}
+
+	err = h.transferUseCase.Delete(c.Request.Context(), uint(id), version)
+	if err != nil {
+		respondError(c, "Error deleting transfer", withCurrentState(c, err, h.currentTransfer(uint(id))))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Transfer deleted successfully",
+		"id":      id,
+	})
+}
+
+// currentTransfer carga el estado actual de una transferencia para un conflicto de versión
+func (h *TransferHandler) currentTransfer(id uint) func() (interface{}, uint, error) {
+	return func() (interface{}, uint, error) {
+		t, err := h.transferUseCase.GetByID(id)
+		if err != nil {
+			return nil, 0, err
+		}
+		return toTransferDTO(t), t.Version, nil
+	}
+}
+
+// fromTransferDTO convierte el DTO recibido en el modelo de dominio
+// Una fecha vacía queda sin asignar para que la actualización conserve la original
+func fromTransferDTO(transferDTO *dto.TransferDTO) (*transfer.Transfer, error) {
+	var date civil.Date
+	if transferDTO.Date != "" {
+		parsed, err := civil.ParseDate(transferDTO.Date)
+		if err != nil {
+			return nil, errors.New("invalid date format, must be YYYY-MM-DD")
+		}
+		date = parsed
+	}
+
+	return &transfer.Transfer{
+		SourceType:          transferDTO.SourceType,
+		SourcePocketID:      pocketIDFromDTO(transferDTO.SourcePocketID),
+		SourceAccount:       transferDTO.SourceAccount,
+		DestinationType:     transferDTO.DestinationType,
+		DestinationPocketID: pocketIDFromDTO(transferDTO.DestinationPocketID),
+		DestinationAccount:  transferDTO.DestinationAccount,
+		Amount:              transferDTO.Amount,
+		Date:                date,
+		Description:         transferDTO.Description,
+	}, nil
+}
+
+// toTransferDTO convierte el modelo de dominio en el DTO de respuesta
+func toTransferDTO(t *transfer.Transfer) dto.TransferDTO {
+	transferDTO := dto.TransferDTO{
+		ID:                 int(t.ID),
+		SourceType:         t.SourceType,
+		SourceAccount:      t.SourceAccount,
+		SourceName:         t.SourceName(),
+		DestinationType:    t.DestinationType,
+		DestinationAccount: t.DestinationAccount,
+		DestinationName:    t.DestinationName(),
+		Amount:             t.Amount,
+		Date:               t.Date.String(),
+		Description:        t.Description,
+		CreatedAt:          t.CreatedAt,
+		Version:            t.Version,
+	}
+
+	if t.SourcePocketID != nil {
+		transferDTO.SourcePocketID = int(*t.SourcePocketID)
+	}
+	if t.DestinationPocketID != nil {
+		transferDTO.DestinationPocketID = int(*t.DestinationPocketID)
+	}
+
+	return transferDTO
+}
@@ -2,21 +2,76 @@ package handler
 
 import (
 	"errors"
-	"time"
+	"expenses-api/internal/domain/apperror"
+	"expenses-api/internal/domain/civil"
+	"strings"
+	"unicode"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
 )
 
+// respondError adjunta el error al contexto para que el middleware de errores
+// responda con el status y el código correspondientes; message resume la operación fallida
+func respondError(c *gin.Context, message string, err error) {
+	_ = c.Error(err).SetMeta(message)
+}
+
+// respondInvalid responde un parámetro de la URL o del query inválido
+func respondInvalid(c *gin.Context, field, message string) {
+	respondError(c, message, apperror.Invalid(field, message))
+}
+
+// invalidBody convierte el error de binding del cuerpo en un error de validación
+// con el detalle de cada campo que no cumple sus reglas
+func invalidBody(err error) error {
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return apperror.Validation(err.Error())
+	}
+
+	fields := make([]apperror.FieldError, 0, len(validationErrors))
+	for _, fieldErr := range validationErrors {
+		fields = append(fields, apperror.FieldError{
+			Field:   toSnakeCase(fieldErr.Field()),
+			Message: "failed on the '" + fieldErr.Tag() + "' rule",
+		})
+	}
+	return apperror.Validation(err.Error(), fields...)
+}
+
+// toSnakeCase convierte el nombre del campo del DTO al de su JSON (ej: PocketID → pocket_id)
+func toSnakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 &&
+			(unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+			b.WriteByte('_')
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
+
 // getPreviousMonth calcula el mes anterior en formato YYYY-MM
 // Maneja correctamente el cambio de año (ej: 2024-01 → 2023-12)
 func getPreviousMonth(month string) (string, error) {
 	// Validar y parsear el mes
-	date, err := time.Parse("2006-01", month)
+	date, err := civil.ParseMonth(month)
 	if err != nil {
 		return "", errors.New("invalid month format, must be YYYY-MM")
 	}
 
-	// Restar un mes
-	previousMonth := date.AddDate(0, -1, 0)
+	// Restar un mes y retornar en formato YYYY-MM
+	return date.AddMonths(-1).String(), nil
+}
 
-	// Retornar en formato YYYY-MM
-	return previousMonth.Format("2006-01"), nil
+// pocketIDFromDTO convierte el pocket_id opcional del DTO (0 = sin bolsillo) en un puntero
+func pocketIDFromDTO(pocketID int) *uint {
+	if pocketID <= 0 {
+		return nil
+	}
+	id := uint(pocketID)
+	return &id
 }
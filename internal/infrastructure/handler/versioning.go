@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"errors"
+	"expenses-api/internal/application/port"
+	"expenses-api/internal/domain/apperror"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ifMatchVersion lee la versión esperada del header If-Match (el ETag del recurso, ej: "3")
+// Devuelve 0 si el header falta o incluye "*", en cuyo caso la versión no se verifica
+// Acepta ETags débiles (W/"3"), que algunos proxies generan al comprimir, y listas
+// separadas por comas siempre que todas nombren la misma versión: el cambio se
+// verifica contra una sola versión, así que una lista con varias responde 400
+func ifMatchVersion(c *gin.Context) (uint, error) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" {
+		return 0, nil
+	}
+
+	var version uint64
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" {
+			return 0, nil
+		}
+
+		tag = strings.TrimPrefix(tag, "W/")
+		if unquoted, err := strconv.Unquote(tag); err == nil {
+			tag = unquoted
+		}
+		parsed, err := strconv.ParseUint(tag, 10, 32)
+		if err != nil || parsed == 0 {
+			return 0, apperror.Invalid("If-Match", `If-Match must be the ETag of the resource, e.g. "3"`)
+		}
+		if version != 0 && parsed != version {
+			return 0, apperror.Invalid("If-Match", "If-Match must name a single version of the resource")
+		}
+		version = parsed
+	}
+	return uint(version), nil
+}
+
+// setETag publica la versión del recurso como ETag fuerte; las versiones 0
+// (configuración heredada o por defecto, aún no guardada) no tienen ETag
+func setETag(c *gin.Context, version uint) {
+	if version == 0 {
+		return
+	}
+	c.Header("ETag", `"`+strconv.FormatUint(uint64(version), 10)+`"`)
+}
+
+// withCurrentState agrega a un conflicto de versión el estado actual del recurso
+// y su ETag, para que el cliente pueda mostrar los cambios ajenos y reintentar
+// Cualquier otro error, o un fallo al recargar el recurso, se devuelve sin cambios
+func withCurrentState(c *gin.Context, err error, load func() (interface{}, uint, error)) error {
+	var appErr *apperror.Error
+	if !errors.Is(err, port.ErrVersionConflict) || !errors.As(err, &appErr) {
+		return err
+	}
+
+	current, version, loadErr := load()
+	if loadErr != nil {
+		return err
+	}
+	setETag(c, version)
+	return appErr.WithCurrent(current)
+}
@@ -0,0 +1,177 @@
+package handler
+
+import (
+	"expenses-api/internal/api/dto"
+	"expenses-api/internal/application/usecase"
+	"expenses-api/internal/domain/webhook"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WebhookHandler handles webhook subscription-related HTTP requests
+type WebhookHandler struct {
+	webhookUseCase *usecase.WebhookUseCase
+}
+
+// NewWebhookHandler creates a new webhook handler instance
+func NewWebhookHandler(webhookUseCase *usecase.WebhookUseCase) *WebhookHandler {
+	return &WebhookHandler{
+		webhookUseCase: webhookUseCase,
+	}
+}
+
+// GetAll obtiene todas las suscripciones de webhooks
+// GET /api/webhooks
+func (h *WebhookHandler) GetAll(c *gin.Context) {
+	subscriptions, err := h.webhookUseCase.GetAll()
+	if err != nil {
+		respondError(c, "Error getting webhook subscriptions", err)
+		return
+	}
+
+	// Convert to DTOs
+	subscriptionDTOs := make([]dto.WebhookSubscriptionDTO, 0, len(subscriptions))
+	for i := range subscriptions {
+		subscriptionDTOs = append(subscriptionDTOs, toWebhookSubscriptionDTO(&subscriptions[i]))
+	}
+
+	c.JSON(http.StatusOK, subscriptionDTOs)
+}
+
+// Create registra una nueva suscripción; el secreto de firma solo se devuelve en esta respuesta
+// POST /api/webhooks
+func (h *WebhookHandler) Create(c *gin.Context) {
+	var subscriptionDTO dto.WebhookSubscriptionDTO
+	if err := c.ShouldBindJSON(&subscriptionDTO); err != nil {
+		respondError(c, "Invalid request body", invalidBody(err))
+		return
+	}
+
+	created, err := h.webhookUseCase.Create(
+		c.Request.Context(),
+		subscriptionDTO.URL,
+		subscriptionDTO.Events,
+		subscriptionDTO.Description,
+		subscriptionDTO.Secret,
+	)
+	if err != nil {
+		respondError(c, "Error creating webhook subscription", err)
+		return
+	}
+
+	response := toWebhookSubscriptionDTO(created)
+	response.Secret = created.Secret
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// Update actualiza una suscripción existente (el secreto no cambia)
+// PUT /api/webhooks/{id}
+func (h *WebhookHandler) Update(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		respondInvalid(c, "id", "Invalid subscription ID")
+		return
+	}
+
+	var subscriptionDTO dto.WebhookSubscriptionDTO
+	if err := c.ShouldBindJSON(&subscriptionDTO); err != nil {
+		respondError(c, "Invalid request body", invalidBody(err))
+		return
+	}
+
+	active := true
+	if subscriptionDTO.Active != nil {
+		active = *subscriptionDTO.Active
+	}
+
+	updated, err := h.webhookUseCase.Update(
+		c.Request.Context(),
+		uint(id),
+		subscriptionDTO.URL,
+		subscriptionDTO.Events,
+		active,
+		subscriptionDTO.Description,
+	)
+	if err != nil {
+		respondError(c, "Error updating webhook subscription", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toWebhookSubscriptionDTO(updated))
+}
+
+// Delete elimina una suscripción y su historial de entregas
+// DELETE /api/webhooks/{id}
+func (h *WebhookHandler) Delete(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		respondInvalid(c, "id", "Invalid subscription ID")
+		return
+	}
+
+	err = h.webhookUseCase.Delete(c.Request.Context(), uint(id))
+	if err != nil {
+		respondError(c, "Error deleting webhook subscription", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Webhook subscription deleted successfully",
+		"id":      id,
+	})
+}
+
+// GetDeliveries obtiene los últimos intentos de entrega de una suscripción
+// GET /api/webhooks/{id}/deliveries?limit=50
+func (h *WebhookHandler) GetDeliveries(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		respondInvalid(c, "id", "Invalid subscription ID")
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	deliveries, err := h.webhookUseCase.GetDeliveries(uint(id), limit)
+	if err != nil {
+		respondError(c, "Error getting webhook deliveries", err)
+		return
+	}
+
+	// Convert to DTOs
+	deliveryDTOs := make([]dto.WebhookDeliveryDTO, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		deliveryDTOs = append(deliveryDTOs, dto.WebhookDeliveryDTO{
+			ID:         int(delivery.ID),
+			EventID:    delivery.EventID,
+			Event:      delivery.Event,
+			Attempt:    delivery.Attempt,
+			StatusCode: delivery.StatusCode,
+			Success:    delivery.Success,
+			Error:      delivery.Error,
+			DurationMs: delivery.DurationMs,
+			CreatedAt:  delivery.CreatedAt,
+		})
+	}
+
+	c.JSON(http.StatusOK, deliveryDTOs)
+}
+
+// toWebhookSubscriptionDTO convierte el modelo de dominio en el DTO de respuesta (sin secreto)
+func toWebhookSubscriptionDTO(s *webhook.Subscription) dto.WebhookSubscriptionDTO {
+	active := s.Active
+	return dto.WebhookSubscriptionDTO{
+		ID:          int(s.ID),
+		URL:         s.URL,
+		Events:      s.EventList(),
+		Active:      &active,
+		Description: s.Description,
+		CreatedAt:   s.CreatedAt,
+	}
+}
@@ -0,0 +1,29 @@
+package audit
+
+import (
+	domain "expenses-api/internal/domain/audit"
+	"expenses-api/internal/infrastructure/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// actorHeader names who makes the request, e.g. the household member using the app
+const actorHeader = "X-Actor"
+
+// actorMiddleware puts the actor of the request in its context, from where the
+// audit log records it for every change the request makes. Requests without
+// the header are recorded as the system actor
+type actorMiddleware struct{}
+
+func (t actorMiddleware) Execute() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if actor := c.GetHeader(actorHeader); actor != "" {
+			c.Request = c.Request.WithContext(domain.WithActor(c.Request.Context(), actor))
+		}
+		c.Next()
+	}
+}
+
+func NewActorMiddleware() middleware.Middleware {
+	return actorMiddleware{}
+}
@@ -0,0 +1,100 @@
+package errorhandler
+
+import (
+	"errors"
+	"expenses-api/internal/api/dto"
+	"expenses-api/internal/application/port"
+	"expenses-api/internal/domain/apperror"
+	"expenses-api/internal/infrastructure/middleware"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// statusByCode maps every error code to its HTTP status
+var statusByCode = map[apperror.Code]int{
+	apperror.CodeNotFound:   http.StatusNotFound,
+	apperror.CodeValidation: http.StatusBadRequest,
+	apperror.CodeConflict:   http.StatusConflict,
+	apperror.CodeForbidden:  http.StatusForbidden,
+	apperror.CodeInternal:   http.StatusInternalServerError,
+}
+
+// defaultMessages summarize the error when the handler gives no message
+var defaultMessages = map[apperror.Code]string{
+	apperror.CodeNotFound:   "Resource not found",
+	apperror.CodeValidation: "Invalid request",
+	apperror.CodeConflict:   "Request conflicts with the current state",
+	apperror.CodeForbidden:  "Operation not allowed",
+	apperror.CodeInternal:   "Internal server error",
+}
+
+// errorMiddleware renders the error a handler attached with c.Error as the
+// common JSON envelope, with the status code taken from the error code.
+// The handler may set the error meta to a summary of the failed operation
+type errorMiddleware struct{}
+
+func (t errorMiddleware) Execute() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+
+		last := c.Errors.Last()
+		message, _ := last.Meta.(string)
+		code := codeOf(last.Err)
+		if code == apperror.CodeInternal {
+			log.Printf("%s %s failed: %v", c.Request.Method, c.Request.URL.Path, last.Err)
+		}
+
+		c.JSON(statusByCode[code], envelope(last.Err, code, message))
+	}
+}
+
+func NewErrorMiddleware() middleware.Middleware {
+	return errorMiddleware{}
+}
+
+// codeOf returns the code of a typed error, recognizing the untyped errors
+// the repositories and the blob store return for missing or duplicate records
+func codeOf(err error) apperror.Code {
+	if code := apperror.CodeOf(err); code != apperror.CodeInternal {
+		return code
+	}
+
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound), errors.Is(err, port.ErrBlobNotFound):
+		return apperror.CodeNotFound
+	case errors.Is(err, gorm.ErrDuplicatedKey), errors.Is(err, gorm.ErrForeignKeyViolated):
+		return apperror.CodeConflict
+	}
+	return apperror.CodeInternal
+}
+
+// envelope builds the JSON error response
+func envelope(err error, code apperror.Code, message string) dto.ErrorResponseDTO {
+	if message == "" {
+		message = defaultMessages[code]
+	}
+
+	response := dto.ErrorResponseDTO{
+		Error:   message,
+		Code:    string(code),
+		Current: apperror.CurrentOf(err),
+	}
+	// Internal errors may carry SQL or file paths; they are only logged
+	if code != apperror.CodeInternal {
+		response.Details = err.Error()
+	}
+	for _, field := range apperror.FieldsOf(err) {
+		response.Fields = append(response.Fields, dto.FieldErrorDTO{
+			Field:   field.Field,
+			Message: field.Message,
+		})
+	}
+	return response
+}
@@ -0,0 +1,108 @@
+package idempotency
+
+import (
+	"bytes"
+	"expenses-api/internal/application/usecase"
+	"expenses-api/internal/domain/apperror"
+	domain "expenses-api/internal/domain/idempotency"
+	"expenses-api/internal/infrastructure/middleware"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	keyHeader      = "Idempotency-Key"
+	replayedHeader = "Idempotent-Replayed"
+)
+
+// idempotencyMiddleware makes POST requests sent with an Idempotency-Key header
+// safe to retry: the first successful response is stored and replayed for every
+// repeat of the same request until the key expires. A key reused for a
+// different request is rejected. Failed requests release the key so the
+// client can retry them with the same key
+type idempotencyMiddleware struct {
+	useCase *usecase.IdempotencyUseCase
+}
+
+func (t idempotencyMiddleware) Execute() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(keyHeader)
+		if c.Request.Method != http.MethodPost || key == "" {
+			c.Next()
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			abort(c, apperror.Validation("could not read the request body").WithCause(err))
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		stored, err := t.useCase.Begin(key, domain.Fingerprint(c.Request.Method, c.Request.URL.RequestURI(), body))
+		if err != nil {
+			abort(c, err)
+			return
+		}
+		if stored != nil {
+			c.Header(replayedHeader, "true")
+			c.Data(stored.StatusCode, stored.ContentType, []byte(stored.ResponseBody))
+			c.Abort()
+			return
+		}
+
+		// The key is released unless the response is stored, also when the handler panics
+		completed := false
+		defer func() {
+			if completed {
+				return
+			}
+			if err := t.useCase.Release(key); err != nil {
+				log.Printf("Releasing idempotency key failed: %v", err)
+			}
+		}()
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+
+		status := recorder.Status()
+		if !recorder.Written() || status < http.StatusOK || status >= http.StatusMultipleChoices {
+			return
+		}
+		if err := t.useCase.Complete(key, status, recorder.Header().Get("Content-Type"), recorder.body.String()); err != nil {
+			log.Printf("Storing idempotent response failed: %v", err)
+			return
+		}
+		completed = true
+	}
+}
+
+func NewIdempotencyMiddleware(useCase *usecase.IdempotencyUseCase) middleware.Middleware {
+	return idempotencyMiddleware{useCase: useCase}
+}
+
+// abort stops the request with an error for the error middleware to render
+func abort(c *gin.Context, err error) {
+	_ = c.Error(err).SetMeta("Invalid Idempotency-Key request")
+	c.Abort()
+}
+
+// responseRecorder copies the response body while it is written to the client
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *responseRecorder) Write(data []byte) (int, error) {
+	r.body.Write(data)
+	return r.ResponseWriter.Write(data)
+}
+
+func (r *responseRecorder) WriteString(s string) (int, error) {
+	r.body.WriteString(s)
+	return r.ResponseWriter.WriteString(s)
+}
@@ -0,0 +1,76 @@
+package notifier
+
+import (
+	"errors"
+	"expenses-api/internal/application/port"
+	"expenses-api/internal/domain/reminder"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// SMTPNotifier delivers notifications by email through an SMTP server
+type SMTPNotifier struct {
+	host     string
+	port     string
+	username string
+	password string
+	from     string
+	to       []string
+	clock    port.Clock
+}
+
+// NewSMTPNotifier creates a new SMTP notifier instance
+// Authentication is skipped when username is empty, which allows using local stand-in servers
+// clock dates the messages
+func NewSMTPNotifier(host, port, username, password, from string, to []string, clock port.Clock) *SMTPNotifier {
+	return &SMTPNotifier{
+		host:     host,
+		port:     port,
+		username: username,
+		password: password,
+		from:     from,
+		to:       to,
+		clock:    clock,
+	}
+}
+
+// Name returns the notifier name used in logs
+func (n *SMTPNotifier) Name() string {
+	return "smtp"
+}
+
+// Notify sends the notification as a plain text email
+func (n *SMTPNotifier) Notify(notification reminder.Notification) error {
+	if n.host == "" || n.from == "" || len(n.to) == 0 {
+		return errors.New("smtp notifier is not configured")
+	}
+
+	var auth smtp.Auth
+	if n.username != "" {
+		auth = smtp.PlainAuth("", n.username, n.password, n.host)
+	}
+
+	addr := net.JoinHostPort(n.host, n.port)
+	return smtp.SendMail(addr, auth, n.from, n.to, n.buildMessage(notification))
+}
+
+// buildMessage builds an RFC 5322 message for the notification
+func (n *SMTPNotifier) buildMessage(notification reminder.Notification) []byte {
+	var b strings.Builder
+	b.WriteString("From: " + n.from + "\r\n")
+	b.WriteString("To: " + strings.Join(n.to, ", ") + "\r\n")
+	b.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", notification.Subject) + "\r\n")
+	b.WriteString("Date: " + n.clock.Now().Format(time.RFC1123Z) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(notification.Message + "\r\n")
+	if notification.PocketName != "" {
+		b.WriteString(fmt.Sprintf("Bolsillo: %s\r\n", notification.PocketName))
+	}
+	return []byte(b.String())
+}
@@ -0,0 +1,167 @@
+package notifier_test
+
+import (
+	"bufio"
+	"expenses-api/internal/domain/reminder"
+	"expenses-api/internal/infrastructure/clock"
+	"expenses-api/internal/infrastructure/notifier"
+	"mime"
+	"net"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+)
+
+// smtpMessage is a message accepted by the stand-in SMTP server
+type smtpMessage struct {
+	from string
+	to   []string
+	data string
+}
+
+// startSMTPServer runs a minimal SMTP server on a local port that accepts one
+// message per connection, without authentication or TLS
+func startSMTPServer(t *testing.T) (host, port string, messages <-chan smtpMessage) {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listening: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	received := make(chan smtpMessage, 1)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveSMTP(conn, received)
+		}
+	}()
+
+	host, port, _ = net.SplitHostPort(listener.Addr().String())
+	return host, port, received
+}
+
+// serveSMTP speaks just enough SMTP for net/smtp.SendMail
+func serveSMTP(conn net.Conn, received chan<- smtpMessage) {
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	reply := func(line string) {
+		conn.Write([]byte(line + "\r\n"))
+	}
+
+	var msg smtpMessage
+	reply("220 localhost ESMTP")
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		command := strings.ToUpper(line)
+
+		switch {
+		case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+			reply("250 localhost")
+		case strings.HasPrefix(command, "MAIL FROM:"):
+			msg.from = strings.Trim(line[len("MAIL FROM:"):], "<>")
+			reply("250 OK")
+		case strings.HasPrefix(command, "RCPT TO:"):
+			msg.to = append(msg.to, strings.Trim(line[len("RCPT TO:"):], "<>"))
+			reply("250 OK")
+		case command == "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			var data strings.Builder
+			for {
+				dataLine, err := reader.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if dataLine == ".\r\n" {
+					break
+				}
+				data.WriteString(dataLine)
+			}
+			msg.data = data.String()
+			received <- msg
+			reply("250 OK")
+		case command == "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("502 Command not implemented")
+		}
+	}
+}
+
+func TestSMTPNotifierSendsMessage(t *testing.T) {
+	host, port, messages := startSMTPServer(t)
+
+	bogota := time.FixedZone("America/Bogota", -5*60*60)
+	now := time.Date(2024, 3, 3, 8, 30, 0, 0, bogota)
+	n := notifier.NewSMTPNotifier(host, port, "", "", "gastos@example.com",
+		[]string{"ana@example.com", "luis@example.com"}, clock.NewFixedClock(now))
+
+	notification := reminder.NewDueSoonNotification(7, "Arriendo", "Casa", 1500000, "2024-03-05", 2)
+	if err := n.Notify(notification); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+
+	var msg smtpMessage
+	select {
+	case msg = <-messages:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the SMTP server received no message")
+	}
+
+	if msg.from != "gastos@example.com" {
+		t.Errorf("MAIL FROM = %q, want gastos@example.com", msg.from)
+	}
+	if strings.Join(msg.to, ",") != "ana@example.com,luis@example.com" {
+		t.Errorf("RCPT TO = %v, want both recipients", msg.to)
+	}
+
+	parsed, err := mail.ReadMessage(strings.NewReader(msg.data))
+	if err != nil {
+		t.Fatalf("parsing message: %v", err)
+	}
+
+	date, err := parsed.Header.Date()
+	if err != nil {
+		t.Fatalf("parsing Date header: %v", err)
+	}
+	if !date.Equal(now) {
+		t.Errorf("Date = %v, want %v", date, now)
+	}
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+	if err != nil {
+		t.Fatalf("decoding Subject header: %v", err)
+	}
+	if subject != notification.Subject {
+		t.Errorf("Subject = %q, want %q", subject, notification.Subject)
+	}
+
+	body := new(strings.Builder)
+	if _, err := bufio.NewReader(parsed.Body).WriteTo(body); err != nil {
+		t.Fatalf("reading body: %v", err)
+	}
+	if !strings.Contains(body.String(), notification.Message) {
+		t.Errorf("body %q does not contain the message %q", body.String(), notification.Message)
+	}
+	if !strings.Contains(body.String(), "Bolsillo: Casa") {
+		t.Errorf("body %q does not name the pocket", body.String())
+	}
+}
+
+func TestSMTPNotifierRequiresConfiguration(t *testing.T) {
+	n := notifier.NewSMTPNotifier("", "25", "", "", "", nil, clock.NewFixedClock(time.Now()))
+	if err := n.Notify(reminder.Notification{}); err == nil {
+		t.Fatal("Notify() error = nil, want a configuration error")
+	}
+}
@@ -0,0 +1,66 @@
+package notifier
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"expenses-api/internal/domain/reminder"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// WebhookNotifier delivers notifications as JSON POST requests to a generic webhook URL
+type WebhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookNotifier creates a new webhook notifier instance
+// A nil client defaults to an HTTP client with a 10 second timeout
+func NewWebhookNotifier(url string, client *http.Client) *WebhookNotifier {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	return &WebhookNotifier{
+		url:    url,
+		client: client,
+	}
+}
+
+// Name returns the notifier name used in logs
+func (n *WebhookNotifier) Name() string {
+	return "webhook"
+}
+
+// Notify posts the notification as JSON and expects a 2xx response
+func (n *WebhookNotifier) Notify(notification reminder.Notification) error {
+	if n.url == "" {
+		return errors.New("webhook notifier is not configured")
+	}
+
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "expenses-api-reminders")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
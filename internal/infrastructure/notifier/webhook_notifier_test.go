@@ -0,0 +1,53 @@
+package notifier_test
+
+import (
+	"encoding/json"
+	"expenses-api/internal/domain/reminder"
+	"expenses-api/internal/infrastructure/notifier"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWebhookNotifierPostsNotification(t *testing.T) {
+	var received reminder.Notification
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("method = %s, want POST", r.Method)
+		}
+		if got := r.Header.Get("Content-Type"); got != "application/json" {
+			t.Errorf("Content-Type = %q, want application/json", got)
+		}
+		if got := r.Header.Get("User-Agent"); got != "expenses-api-reminders" {
+			t.Errorf("User-Agent = %q, want expenses-api-reminders", got)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("decoding body: %v", err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	notification := reminder.NewDueSoonNotification(7, "Arriendo", "Casa", 1500000, "2024-03-05", 2)
+	if err := notifier.NewWebhookNotifier(server.URL, server.Client()).Notify(notification); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+
+	if received != notification {
+		t.Errorf("received %+v, want %+v", received, notification)
+	}
+}
+
+func TestWebhookNotifierFailsOnErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	notification := reminder.NewOverdueNotification(7, "Arriendo", "", 1500000, "2024-03-05", -1)
+	err := notifier.NewWebhookNotifier(server.URL, server.Client()).Notify(notification)
+	if err == nil || !strings.Contains(err.Error(), "502") {
+		t.Fatalf("Notify() error = %v, want status 502", err)
+	}
+}
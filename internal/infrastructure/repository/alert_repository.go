@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"expenses-api/internal/domain/alert"
+	"expenses-api/internal/domain/civil"
+
+	"gorm.io/gorm"
+)
+
+// AlertRuleRepository handles budget alert rule database operations
+type AlertRuleRepository struct {
+	*BaseRepository
+}
+
+// NewAlertRuleRepository creates a new alert rule repository instance
+func NewAlertRuleRepository(db *gorm.DB) *AlertRuleRepository {
+	return &AlertRuleRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// GetAll retrieves all alert rules with pocket information
+func (r *AlertRuleRepository) GetAll() ([]alert.Rule, error) {
+	var rules []alert.Rule
+	err := r.db.Preload("Pocket").
+		Order("kind ASC, pocket_id ASC, threshold_percent ASC").
+		Find(&rules).Error
+	return rules, err
+}
+
+// GetActive retrieves the active alert rules with pocket information
+func (r *AlertRuleRepository) GetActive() ([]alert.Rule, error) {
+	var rules []alert.Rule
+	err := r.db.Preload("Pocket").
+		Where("active = ?", true).
+		Order("kind ASC, pocket_id ASC, threshold_percent ASC").
+		Find(&rules).Error
+	return rules, err
+}
+
+// GetByID retrieves an alert rule by ID with pocket information
+func (r *AlertRuleRepository) GetByID(id uint) (*alert.Rule, error) {
+	var rule alert.Rule
+	err := r.db.Preload("Pocket").First(&rule, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+// Create creates a new alert rule
+func (r *AlertRuleRepository) Create(ctx context.Context, rule *alert.Rule) error {
+	return r.db.WithContext(ctx).Omit("Pocket").Create(rule).Error
+}
+
+// Update updates an existing alert rule
+func (r *AlertRuleRepository) Update(ctx context.Context, rule *alert.Rule) error {
+	return r.db.WithContext(ctx).Omit("Pocket").Save(rule).Error
+}
+
+// Delete deletes an alert rule and the alerts it fired
+func (r *AlertRuleRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("rule_id = ?", id).Delete(&alert.Alert{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&alert.Rule{}, id).Error
+	})
+}
+
+// AlertRepository handles fired alert database operations
+type AlertRepository struct {
+	*BaseRepository
+}
+
+// NewAlertRepository creates a new alert repository instance
+func NewAlertRepository(db *gorm.DB) *AlertRepository {
+	return &AlertRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// GetByMonth retrieves all alerts fired for a specific month
+func (r *AlertRepository) GetByMonth(month civil.Month) ([]alert.Alert, error) {
+	var alerts []alert.Alert
+	err := r.db.Where("month = ?", month).
+		Order("created_at DESC, id DESC").
+		Find(&alerts).Error
+	return alerts, err
+}
+
+// GetPending retrieves all alerts that have not been acknowledged yet
+func (r *AlertRepository) GetPending() ([]alert.Alert, error) {
+	var alerts []alert.Alert
+	err := r.db.Where("acknowledged = ?", false).
+		Order("created_at DESC, id DESC").
+		Find(&alerts).Error
+	return alerts, err
+}
+
+// GetByID retrieves an alert by ID
+func (r *AlertRepository) GetByID(id uint) (*alert.Alert, error) {
+	var a alert.Alert
+	err := r.db.First(&a, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+// Exists checks if a rule already fired for a month
+func (r *AlertRepository) Exists(ruleID uint, month civil.Month) (bool, error) {
+	var count int64
+	err := r.db.Model(&alert.Alert{}).
+		Where("rule_id = ? AND month = ?", ruleID, month).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// Create records a fired alert
+func (r *AlertRepository) Create(ctx context.Context, a *alert.Alert) error {
+	return r.db.WithContext(ctx).Create(a).Error
+}
+
+// Update updates an existing alert
+func (r *AlertRepository) Update(ctx context.Context, a *alert.Alert) error {
+	return r.db.WithContext(ctx).Save(a).Error
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"expenses-api/internal/domain/attachment"
+
+	"gorm.io/gorm"
+)
+
+// AttachmentRepository handles expense attachment database operations
+type AttachmentRepository struct {
+	*BaseRepository
+}
+
+// NewAttachmentRepository creates a new attachment repository instance
+func NewAttachmentRepository(db *gorm.DB) *AttachmentRepository {
+	return &AttachmentRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// GetByExpense retrieves all attachments of an expense, oldest first
+func (r *AttachmentRepository) GetByExpense(expenseType string, expenseID uint) ([]attachment.Attachment, error) {
+	var attachments []attachment.Attachment
+	err := r.db.Where("expense_type = ? AND expense_id = ?", expenseType, expenseID).
+		Order("created_at ASC, id ASC").
+		Find(&attachments).Error
+	return attachments, err
+}
+
+// GetByID retrieves an attachment by ID
+func (r *AttachmentRepository) GetByID(id uint) (*attachment.Attachment, error) {
+	var a attachment.Attachment
+	err := r.db.First(&a, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+// GetByExpenseAndHash retrieves the attachment of an expense with the given content hash
+// Returns nil without error when the expense has no such file
+func (r *AttachmentRepository) GetByExpenseAndHash(expenseType string, expenseID uint, sha256 string) (*attachment.Attachment, error) {
+	var a attachment.Attachment
+	err := r.db.Where("expense_type = ? AND expense_id = ? AND sha256 = ?", expenseType, expenseID, sha256).
+		First(&a).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+// CountBySHA256 counts the attachments that share the given content hash
+func (r *AttachmentRepository) CountBySHA256(sha256 string) (int64, error) {
+	var count int64
+	err := r.db.Model(&attachment.Attachment{}).
+		Where("sha256 = ?", sha256).
+		Count(&count).Error
+	return count, err
+}
+
+// Create creates a new attachment
+func (r *AttachmentRepository) Create(ctx context.Context, a *attachment.Attachment) error {
+	return r.db.WithContext(ctx).Create(a).Error
+}
+
+// Delete deletes an attachment by ID
+func (r *AttachmentRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&attachment.Attachment{}, id).Error
+}
+
+// deleteExpenseAttachments removes the attachments of an expense
+// It runs inside the transaction that deletes the expense; the files stay in
+// the blob store until the caller releases them
+func deleteExpenseAttachments(tx *gorm.DB, expenseType string, expenseID uint) error {
+	return tx.Where("expense_type = ? AND expense_id = ?", expenseType, expenseID).
+		Delete(&attachment.Attachment{}).Error
+}
@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"expenses-api/internal/domain/audit"
+
+	"gorm.io/gorm"
+)
+
+// AuditRepository handles audit log database operations
+// Entries are written by the audit callbacks of the database layer, never here
+type AuditRepository struct {
+	*BaseRepository
+}
+
+// NewAuditRepository creates a new audit repository instance
+func NewAuditRepository(db *gorm.DB) *AuditRepository {
+	return &AuditRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// Find retrieves the most recent audit entries matching the filter
+func (r *AuditRepository) Find(filter audit.Filter) ([]audit.Entry, error) {
+	query := r.db.Model(&audit.Entry{})
+	if filter.Entity != "" {
+		query = query.Where("entity = ?", filter.Entity)
+	}
+	if filter.EntityID != 0 {
+		query = query.Where("entity_id = ?", filter.EntityID)
+	}
+	if !filter.From.IsZero() {
+		query = query.Where("created_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("created_at < ?", filter.To)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	var entries []audit.Entry
+	err := query.Order("created_at DESC, id DESC").Find(&entries).Error
+	return entries, err
+}
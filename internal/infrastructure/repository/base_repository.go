@@ -1,7 +1,11 @@
 package repository
 
 import (
+	"expenses-api/internal/application/port"
+	"expenses-api/internal/domain/civil"
+
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // BaseRepository provides common database operations
@@ -29,7 +33,8 @@ func (r *BaseRepository) Create(entity interface{}) error {
 	return r.db.Create(entity).Error
 }
 
-// Update updates an existing record
+// Update updates an existing record unconditionally
+// Versioned entities are saved with updateVersioned instead
 func (r *BaseRepository) Update(entity interface{}) error {
 	return r.db.Save(entity).Error
 }
@@ -80,3 +85,61 @@ func (r *BaseRepository) Paginate(page, pageSize int) func(db *gorm.DB) *gorm.DB
 		return db.Offset(offset).Limit(pageSize)
 	}
 }
+
+// updateVersioned saves every column of entity, but not its relationships, only
+// while the stored row still has the version it was read with, and increments
+// *version. It returns port.ErrVersionConflict when the row was changed or
+// removed since it was read
+func updateVersioned(db *gorm.DB, entity interface{}, version *uint) error {
+	read := *version
+	*version = read + 1
+
+	result := db.Model(entity).
+		Where("version = ?", read).
+		Select("*").
+		Omit(clause.Associations).
+		Updates(entity)
+	if result.Error == nil && result.RowsAffected == 0 {
+		result.Error = port.ErrVersionConflict
+	}
+	if result.Error != nil {
+		*version = read
+	}
+	return result.Error
+}
+
+// deleteVersioned deletes the entity with the given ID, but only at the given
+// version when it is non-zero; ErrVersionConflict reports a stale version
+// Inside a transaction the conflict rolls back the deletes made before it
+func deleteVersioned(db *gorm.DB, entity interface{}, id uint, version uint) error {
+	if version != 0 {
+		db = db.Where("version = ?", version)
+	}
+
+	result := db.Delete(entity, id)
+	if result.Error == nil && version != 0 && result.RowsAffected == 0 {
+		result.Error = port.ErrVersionConflict
+	}
+	return result.Error
+}
+
+// nextVersion is the update expression that increments the version column
+// for changes made with UpdateColumns, which skip updateVersioned
+var nextVersion = gorm.Expr("version + 1")
+
+// dateInMonth filters a DATE column to the days of a month
+// A range on the column itself lets the database use its index
+func dateInMonth(column string, month civil.Month) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where(column+" >= ? AND "+column+" < ?", month.FirstDay(), month.AddMonths(1).FirstDay())
+	}
+}
+
+// monthOfDate returns the SQL expression for the first day of the month of a DATE column
+// Month columns store that same day, so both can be compared and grouped together
+func monthOfDate(db *gorm.DB, column string) string {
+	if db.Dialector.Name() == "sqlite" {
+		return "date(" + column + ", 'start of month')"
+	}
+	return "DATE_SUB(" + column + ", INTERVAL DAY(" + column + ") - 1 DAY)"
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"expenses-api/internal/domain/categorization"
+
+	"gorm.io/gorm"
+)
+
+// CategorizationRuleRepository handles categorization rule database operations
+type CategorizationRuleRepository struct {
+	*BaseRepository
+}
+
+// NewCategorizationRuleRepository creates a new categorization rule repository instance
+func NewCategorizationRuleRepository(db *gorm.DB) *CategorizationRuleRepository {
+	return &CategorizationRuleRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// GetAll retrieves all rules in evaluation order
+func (r *CategorizationRuleRepository) GetAll() ([]categorization.Rule, error) {
+	var rules []categorization.Rule
+	err := r.db.Preload("Pocket").Preload("Tags").
+		Order("priority ASC, id ASC").
+		Find(&rules).Error
+	return rules, err
+}
+
+// GetActive retrieves the active rules in evaluation order
+func (r *CategorizationRuleRepository) GetActive() ([]categorization.Rule, error) {
+	var rules []categorization.Rule
+	err := r.db.Preload("Pocket").Preload("Tags").
+		Where("active = ?", true).
+		Order("priority ASC, id ASC").
+		Find(&rules).Error
+	return rules, err
+}
+
+// GetByID retrieves a rule by ID with its pocket and tags
+func (r *CategorizationRuleRepository) GetByID(id uint) (*categorization.Rule, error) {
+	var rule categorization.Rule
+	err := r.db.Preload("Pocket").Preload("Tags").First(&rule, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+// Create creates a new rule
+// Tags are linked by TagRepository
+func (r *CategorizationRuleRepository) Create(ctx context.Context, rule *categorization.Rule) error {
+	return r.db.WithContext(ctx).Omit("Pocket", "Tags").Create(rule).Error
+}
+
+// Update updates an existing rule
+// Relationships are not saved; tags are managed by TagRepository
+func (r *CategorizationRuleRepository) Update(ctx context.Context, rule *categorization.Rule) error {
+	return r.db.WithContext(ctx).Omit("Pocket", "Tags").Save(rule).Error
+}
+
+// Delete deletes a rule and its tag links by ID
+func (r *CategorizationRuleRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := deleteTagLinks(tx, ruleTagLinks, id); err != nil {
+			return err
+		}
+		return tx.Delete(&categorization.Rule{}, id).Error
+	})
+}
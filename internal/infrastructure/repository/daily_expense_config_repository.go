@@ -1,7 +1,11 @@
 package repository
 
 import (
+	"context"
+	"expenses-api/internal/application/port"
+	"expenses-api/internal/domain/civil"
 	"expenses-api/internal/domain/daily_expense_config"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -19,7 +23,7 @@ func NewDailyExpenseConfigRepository(db *gorm.DB) *DailyExpenseConfigRepository
 }
 
 // GetByMonth retrieves daily expense configuration for a specific month
-func (r *DailyExpenseConfigRepository) GetByMonth(month string) (*daily_expense_config.DailyExpenseConfig, error) {
+func (r *DailyExpenseConfigRepository) GetByMonth(month civil.Month) (*daily_expense_config.DailyExpenseConfig, error) {
 	var config daily_expense_config.DailyExpenseConfig
 	err := r.db.Where("month = ?", month).First(&config).Error
 	if err != nil {
@@ -29,22 +33,35 @@ func (r *DailyExpenseConfigRepository) GetByMonth(month string) (*daily_expense_
 }
 
 // CreateOrUpdate creates a new config record or updates existing one
-func (r *DailyExpenseConfigRepository) CreateOrUpdate(config *daily_expense_config.DailyExpenseConfig) error {
+func (r *DailyExpenseConfigRepository) CreateOrUpdate(ctx context.Context, config *daily_expense_config.DailyExpenseConfig) error {
 	// Try to find existing record
 	var existing daily_expense_config.DailyExpenseConfig
-	err := r.db.Where("month = ?", config.Month).First(&existing).Error
+	err := r.db.WithContext(ctx).Where("month = ?", config.Month).First(&existing).Error
 
 	if err == gorm.ErrRecordNotFound {
+		// A version refers to a record that no longer exists
+		if config.Version != 0 {
+			return port.ErrVersionConflict
+		}
 		// Create new record
-		return r.db.Create(config).Error
+		return r.db.WithContext(ctx).Create(config).Error
 	} else if err != nil {
 		// Other error
 		return err
 	}
 
+	// The caller expects to replace a version that is no longer the stored one
+	if config.Version != 0 && config.Version != existing.Version {
+		return port.ErrVersionConflict
+	}
+
 	// Update existing record
 	existing.MonthlyBudget = config.MonthlyBudget
-	return r.db.Save(&existing).Error
+	if err := updateVersioned(r.db.WithContext(ctx), &existing, &existing.Version); err != nil {
+		return err
+	}
+	*config = existing
+	return nil
 }
 
 // GetAll retrieves all daily expense configurations ordered by month descending
@@ -62,19 +79,19 @@ func (r *DailyExpenseConfigRepository) GetRecent(limit int) ([]daily_expense_con
 }
 
 // DeleteByMonth deletes config record for a specific month
-func (r *DailyExpenseConfigRepository) DeleteByMonth(month string) error {
+func (r *DailyExpenseConfigRepository) DeleteByMonth(month civil.Month) error {
 	return r.db.Where("month = ?", month).Delete(&daily_expense_config.DailyExpenseConfig{}).Error
 }
 
-// GetCurrentMonth retrieves config for the current month
-func (r *DailyExpenseConfigRepository) GetCurrentMonth() (*daily_expense_config.DailyExpenseConfig, error) {
-	currentMonth := daily_expense_config.GetCurrentMonth()
+// GetCurrentMonth retrieves config for the month of now
+func (r *DailyExpenseConfigRepository) GetCurrentMonth(now time.Time) (*daily_expense_config.DailyExpenseConfig, error) {
+	currentMonth := daily_expense_config.GetCurrentMonth(now)
 	return r.GetByMonth(currentMonth)
 }
 
 // GetMonthsWithConfig retrieves all months that have budget configured
-func (r *DailyExpenseConfigRepository) GetMonthsWithConfig() ([]string, error) {
-	var months []string
+func (r *DailyExpenseConfigRepository) GetMonthsWithConfig() ([]civil.Month, error) {
+	var months []civil.Month
 	err := r.db.Model(&daily_expense_config.DailyExpenseConfig{}).
 		Select("month").
 		Order("month DESC").
@@ -83,7 +100,7 @@ func (r *DailyExpenseConfigRepository) GetMonthsWithConfig() ([]string, error) {
 }
 
 // GetTotalBudgetByMonths calculates total budget for multiple months
-func (r *DailyExpenseConfigRepository) GetTotalBudgetByMonths(months []string) (float64, error) {
+func (r *DailyExpenseConfigRepository) GetTotalBudgetByMonths(months []civil.Month) (float64, error) {
 	var total float64
 	err := r.db.Model(&daily_expense_config.DailyExpenseConfig{}).
 		Select("COALESCE(SUM(monthly_budget), 0)").
@@ -113,11 +130,11 @@ func (r *DailyExpenseConfigRepository) GetConfigsWithUsage() ([]ConfigWithUsage,
 		Joins(`
 			LEFT JOIN (
 				SELECT 
-					LEFT(date, 7) as month,
+					` + monthOfDate(r.db, "date") + ` as month,
 					SUM(amount) as total_spent,
 					COUNT(*) as expense_count
 				FROM daily_expenses
-				GROUP BY LEFT(date, 7)
+				GROUP BY ` + monthOfDate(r.db, "date") + `
 			) de_stats ON dec.month = de_stats.month
 		`).
 		Order("dec.month DESC").
@@ -127,7 +144,7 @@ func (r *DailyExpenseConfigRepository) GetConfigsWithUsage() ([]ConfigWithUsage,
 }
 
 // GetBudgetUtilization calculates budget utilization for a specific month
-func (r *DailyExpenseConfigRepository) GetBudgetUtilization(month string) (*BudgetUtilization, error) {
+func (r *DailyExpenseConfigRepository) GetBudgetUtilization(month civil.Month) (*BudgetUtilization, error) {
 	var result BudgetUtilization
 
 	err := r.db.Table("daily_expenses_configs dec").
@@ -145,14 +162,13 @@ func (r *DailyExpenseConfigRepository) GetBudgetUtilization(month string) (*Budg
 		Joins(`
 			LEFT JOIN (
 				SELECT 
-					LEFT(date, 7) as month,
+					? as month,
 					SUM(amount) as total_spent,
 					COUNT(*) as expense_count
 				FROM daily_expenses
-				WHERE LEFT(date, 7) = ?
-				GROUP BY LEFT(date, 7)
+				WHERE date >= ? AND date < ?
 			) de_stats ON dec.month = de_stats.month
-		`, month).
+		`, month, month.FirstDay(), month.AddMonths(1).FirstDay()).
 		Where("dec.month = ?", month).
 		Scan(&result).Error
 
@@ -166,21 +182,21 @@ func (r *DailyExpenseConfigRepository) GetBudgetUtilization(month string) (*Budg
 
 // ConfigWithUsage represents a config with usage statistics
 type ConfigWithUsage struct {
-	ID              uint    `json:"id"`
-	MonthlyBudget   float64 `json:"monthly_budget"`
-	Month           string  `json:"month"`
-	CreatedAt       string  `json:"created_at"`
-	TotalSpent      float64 `json:"total_spent"`
-	ExpenseCount    int     `json:"expense_count"`
-	UsagePercentage float64 `json:"usage_percentage"`
+	ID              uint        `json:"id"`
+	MonthlyBudget   float64     `json:"monthly_budget"`
+	Month           civil.Month `json:"month"`
+	CreatedAt       string      `json:"created_at"`
+	TotalSpent      float64     `json:"total_spent"`
+	ExpenseCount    int         `json:"expense_count"`
+	UsagePercentage float64     `json:"usage_percentage"`
 }
 
 // BudgetUtilization represents budget utilization statistics
 type BudgetUtilization struct {
-	Month           string  `json:"month"`
-	MonthlyBudget   float64 `json:"monthly_budget"`
-	TotalSpent      float64 `json:"total_spent"`
-	ExpenseCount    int     `json:"expense_count"`
-	RemainingBudget float64 `json:"remaining_budget"`
-	UsagePercentage float64 `json:"usage_percentage"`
+	Month           civil.Month `json:"month"`
+	MonthlyBudget   float64     `json:"monthly_budget"`
+	TotalSpent      float64     `json:"total_spent"`
+	ExpenseCount    int         `json:"expense_count"`
+	RemainingBudget float64     `json:"remaining_budget"`
+	UsagePercentage float64     `json:"usage_percentage"`
 }
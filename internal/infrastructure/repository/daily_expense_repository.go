@@ -1,9 +1,17 @@
 package repository
 
 import (
+	"context"
+	"expenses-api/internal/domain/attachment"
+	"expenses-api/internal/domain/civil"
 	"expenses-api/internal/domain/daily_expense"
+	"expenses-api/internal/domain/household"
+	"expenses-api/internal/domain/receivable"
+	"fmt"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // DailyExpenseRepository handles daily expense-related database operations
@@ -19,25 +27,27 @@ func NewDailyExpenseRepository(db *gorm.DB) *DailyExpenseRepository {
 }
 
 // GetByMonth retrieves all daily expenses for a specific month
-func (r *DailyExpenseRepository) GetByMonth(month string) ([]daily_expense.DailyExpense, error) {
+func (r *DailyExpenseRepository) GetByMonth(month civil.Month) ([]daily_expense.DailyExpense, error) {
 	var expenses []daily_expense.DailyExpense
-	err := r.db.Where("date LIKE ?", month+"%").
+	err := r.db.Preload("Pocket").Preload("Tags").Preload("Splits", orderSplits).Preload("Splits.Pocket").
+		Scopes(dateInMonth("date", month)).
 		Order("date DESC, created_at DESC").
 		Find(&expenses).Error
 	return expenses, err
 }
 
 // GetByDateRange retrieves daily expenses within a date range
-func (r *DailyExpenseRepository) GetByDateRange(startDate, endDate string) ([]daily_expense.DailyExpense, error) {
+func (r *DailyExpenseRepository) GetByDateRange(startDate, endDate civil.Date) ([]daily_expense.DailyExpense, error) {
 	var expenses []daily_expense.DailyExpense
-	err := r.db.Where("date >= ? AND date <= ?", startDate, endDate).
+	err := r.db.Preload("Tags").Preload("Splits", orderSplits).
+		Where("date >= ? AND date <= ?", startDate, endDate).
 		Order("date DESC, created_at DESC").
 		Find(&expenses).Error
 	return expenses, err
 }
 
 // GetByDate retrieves all daily expenses for a specific date
-func (r *DailyExpenseRepository) GetByDate(date string) ([]daily_expense.DailyExpense, error) {
+func (r *DailyExpenseRepository) GetByDate(date civil.Date) ([]daily_expense.DailyExpense, error) {
 	var expenses []daily_expense.DailyExpense
 	err := r.db.Where("date = ?", date).
 		Order("created_at DESC").
@@ -48,26 +58,48 @@ func (r *DailyExpenseRepository) GetByDate(date string) ([]daily_expense.DailyEx
 // GetByID retrieves a daily expense by ID
 func (r *DailyExpenseRepository) GetByID(id uint) (*daily_expense.DailyExpense, error) {
 	var expense daily_expense.DailyExpense
-	err := r.db.First(&expense, id).Error
+	err := r.db.Preload("Pocket").Preload("Tags").Preload("Splits", orderSplits).Preload("Splits.Pocket").
+		First(&expense, id).Error
 	if err != nil {
 		return nil, err
 	}
 	return &expense, nil
 }
 
-// Create creates a new daily expense
-func (r *DailyExpenseRepository) Create(expense *daily_expense.DailyExpense) error {
-	return r.db.Create(expense).Error
+// Create creates a new daily expense with its split lines and tag links in one transaction
+// Tags are linked by ID and must already exist
+func (r *DailyExpenseRepository) Create(ctx context.Context, expense *daily_expense.DailyExpense) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(expense).Error; err != nil {
+			return err
+		}
+		return saveExpenseDetails(tx, expense)
+	})
 }
 
-// Update updates an existing daily expense
-func (r *DailyExpenseRepository) Update(expense *daily_expense.DailyExpense) error {
-	return r.db.Save(expense).Error
+// Update updates an existing daily expense with its split lines and tag links
+// in one transaction, unless it changed since it was read
+// Nil Splits or Tags leave the stored ones as they are; split lines without an
+// ID are added, the listed ones are updated and the stored ones no longer listed are deleted
+func (r *DailyExpenseRepository) Update(ctx context.Context, expense *daily_expense.DailyExpense) error {
+	read := expense.Version
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := updateVersioned(tx, expense, &expense.Version); err != nil {
+			return err
+		}
+		return saveExpenseDetails(tx, expense)
+	})
+	if err != nil {
+		expense.Version = read
+	}
+	return err
 }
 
-// Delete deletes a daily expense by ID
-func (r *DailyExpenseRepository) Delete(id uint) error {
-	return r.db.Delete(&daily_expense.DailyExpense{}, id).Error
+// Delete deletes a daily expense, its split lines, its tag links, its receivable, its household share and its attachments by ID
+func (r *DailyExpenseRepository) Delete(ctx context.Context, id uint, version uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return deleteDailyExpense(tx, id, version)
+	})
 }
 
 // GetRecent retrieves the most recent daily expenses (limit specified)
@@ -80,7 +112,7 @@ func (r *DailyExpenseRepository) GetRecent(limit int) ([]daily_expense.DailyExpe
 }
 
 // GetSummaryByMonth calculates summary statistics for daily expenses in a month
-func (r *DailyExpenseRepository) GetSummaryByMonth(month string) (*DailyExpenseSummary, error) {
+func (r *DailyExpenseRepository) GetSummaryByMonth(month civil.Month) (*DailyExpenseSummary, error) {
 	var summary DailyExpenseSummary
 
 	err := r.db.Model(&daily_expense.DailyExpense{}).
@@ -91,7 +123,7 @@ func (r *DailyExpenseRepository) GetSummaryByMonth(month string) (*DailyExpenseS
 			MIN(amount) as min_amount,
 			MAX(amount) as max_amount
 		`).
-		Where("date LIKE ?", month+"%").
+		Scopes(dateInMonth("date", month)).
 		Scan(&summary).Error
 
 	if err != nil {
@@ -103,12 +135,12 @@ func (r *DailyExpenseRepository) GetSummaryByMonth(month string) (*DailyExpenseS
 }
 
 // GetDailyTotals retrieves daily totals for a specific month
-func (r *DailyExpenseRepository) GetDailyTotals(month string) ([]DailyTotal, error) {
+func (r *DailyExpenseRepository) GetDailyTotals(month civil.Month) ([]DailyTotal, error) {
 	var totals []DailyTotal
 
 	err := r.db.Model(&daily_expense.DailyExpense{}).
 		Select("date, SUM(amount) as total_amount, COUNT(*) as expense_count").
-		Where("date LIKE ?", month+"%").
+		Scopes(dateInMonth("date", month)).
 		Group("date").
 		Order("date ASC").
 		Scan(&totals).Error
@@ -117,10 +149,10 @@ func (r *DailyExpenseRepository) GetDailyTotals(month string) ([]DailyTotal, err
 }
 
 // GetMonthsWithExpenses retrieves all months that have daily expenses
-func (r *DailyExpenseRepository) GetMonthsWithExpenses() ([]string, error) {
-	var months []string
+func (r *DailyExpenseRepository) GetMonthsWithExpenses() ([]civil.Month, error) {
+	var months []civil.Month
 	err := r.db.Model(&daily_expense.DailyExpense{}).
-		Select("DISTINCT LEFT(date, 7) as month").
+		Select("DISTINCT "+monthOfDate(r.db, "date")+" as month").
 		Order("month DESC").
 		Pluck("month", &months).Error
 	return months, err
@@ -137,12 +169,12 @@ func (r *DailyExpenseRepository) SearchByDescription(query string, limit int) ([
 }
 
 // GetByAmountRange retrieves daily expenses within an amount range
-func (r *DailyExpenseRepository) GetByAmountRange(minAmount, maxAmount float64, month string) ([]daily_expense.DailyExpense, error) {
+func (r *DailyExpenseRepository) GetByAmountRange(minAmount, maxAmount float64, month civil.Month) ([]daily_expense.DailyExpense, error) {
 	var expenses []daily_expense.DailyExpense
 	query := r.db.Where("amount >= ? AND amount <= ?", minAmount, maxAmount)
 
-	if month != "" {
-		query = query.Where("date LIKE ?", month+"%")
+	if !month.IsZero() {
+		query = query.Scopes(dateInMonth("date", month))
 	}
 
 	err := query.Order("date DESC, amount DESC").Find(&expenses).Error
@@ -150,54 +182,173 @@ func (r *DailyExpenseRepository) GetByAmountRange(minAmount, maxAmount float64,
 }
 
 // GetTopExpensesByMonth retrieves the highest expenses for a month
-func (r *DailyExpenseRepository) GetTopExpensesByMonth(month string, limit int) ([]daily_expense.DailyExpense, error) {
+func (r *DailyExpenseRepository) GetTopExpensesByMonth(month civil.Month, limit int) ([]daily_expense.DailyExpense, error) {
 	var expenses []daily_expense.DailyExpense
-	err := r.db.Where("date LIKE ?", month+"%").
+	err := r.db.Scopes(dateInMonth("date", month)).
 		Order("amount DESC, date DESC").
 		Limit(limit).
 		Find(&expenses).Error
 	return expenses, err
 }
 
-// BulkDelete deletes multiple daily expenses by IDs
-func (r *DailyExpenseRepository) BulkDelete(ids []uint) error {
-	return r.db.Delete(&daily_expense.DailyExpense{}, ids).Error
+// BulkDelete deletes multiple daily expenses by IDs, each with what Delete
+// removes along with it, in one transaction
+func (r *DailyExpenseRepository) BulkDelete(ctx context.Context, ids []uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, id := range ids {
+			if err := deleteDailyExpense(tx, id, 0); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 }
 
-// GetExpensesByWeekday retrieves expenses grouped by weekday for a month
-func (r *DailyExpenseRepository) GetExpensesByWeekday(month string) ([]WeekdayExpense, error) {
+// GetExpensesByWeekday retrieves expenses grouped by weekday for a month, Monday first
+// Weekdays are computed in Go since date functions differ between MySQL and SQLite
+func (r *DailyExpenseRepository) GetExpensesByWeekday(month civil.Month) ([]WeekdayExpense, error) {
+	var expenses []daily_expense.DailyExpense
+	err := r.db.Select("date, amount").
+		Scopes(dateInMonth("date", month)).
+		Find(&expenses).Error
+	if err != nil {
+		return nil, err
+	}
+
+	byWeekday := make(map[time.Weekday]*WeekdayExpense)
+	for _, expense := range expenses {
+		weekday := expense.Date.Weekday()
+		result, ok := byWeekday[weekday]
+		if !ok {
+			result = &WeekdayExpense{Weekday: weekday.String()}
+			byWeekday[weekday] = result
+		}
+		result.ExpenseCount++
+		result.TotalAmount += expense.Amount
+	}
+
+	weekdays := []time.Weekday{
+		time.Monday, time.Tuesday, time.Wednesday, time.Thursday,
+		time.Friday, time.Saturday, time.Sunday,
+	}
+
 	var results []WeekdayExpense
+	for _, weekday := range weekdays {
+		if result, ok := byWeekday[weekday]; ok {
+			result.AverageAmount = result.TotalAmount / float64(result.ExpenseCount)
+			results = append(results, *result)
+		}
+	}
 
-	err := r.db.Model(&daily_expense.DailyExpense{}).
-		Select(`
-			DAYNAME(STR_TO_DATE(date, '%Y-%m-%d')) as weekday,
-			COUNT(*) as expense_count,
-			SUM(amount) as total_amount,
-			AVG(amount) as average_amount
-		`).
-		Where("date LIKE ?", month+"%").
-		Group("DAYNAME(STR_TO_DATE(date, '%Y-%m-%d'))").
-		Order("FIELD(DAYNAME(STR_TO_DATE(date, '%Y-%m-%d')), 'Monday', 'Tuesday', 'Wednesday', 'Thursday', 'Friday', 'Saturday', 'Sunday')").
-		Scan(&results).Error
+	return results, nil
+}
 
-	return results, err
+// saveExpenseDetails stores the split lines and tag links of a saved expense
+// Nil lists are left as stored
+func saveExpenseDetails(tx *gorm.DB, expense *daily_expense.DailyExpense) error {
+	if expense.Splits != nil {
+		if err := saveSplits(tx, expense.ID, expense.Splits); err != nil {
+			return err
+		}
+	}
+
+	if expense.Tags == nil {
+		return nil
+	}
+	tagIDs := make([]uint, 0, len(expense.Tags))
+	for _, t := range expense.Tags {
+		tagIDs = append(tagIDs, t.ID)
+	}
+	return replaceTagLinks(tx, dailyExpenseTagLinks, expense.ID, tagIDs)
+}
+
+// deleteDailyExpense deletes a daily expense with its split lines, tag links,
+// receivable, household share and attachments inside the caller's transaction
+// A non-zero version must match the stored one
+func deleteDailyExpense(tx *gorm.DB, id uint, version uint) error {
+	if err := tx.Where("daily_expense_id = ?", id).Delete(&daily_expense.Split{}).Error; err != nil {
+		return err
+	}
+	if err := deleteTagLinks(tx, dailyExpenseTagLinks, id); err != nil {
+		return err
+	}
+	if err := deleteExpenseReceivable(tx, receivable.ExpenseTypeDaily, id); err != nil {
+		return err
+	}
+	if err := deleteExpenseShare(tx, household.ExpenseTypeDaily, id); err != nil {
+		return err
+	}
+	if err := deleteExpenseAttachments(tx, attachment.ExpenseTypeDaily, id); err != nil {
+		return err
+	}
+	return deleteVersioned(tx, &daily_expense.DailyExpense{}, id, version)
+}
+
+// saveSplits stores splits as the split lines of an expense, adding the ones
+// without an ID, updating the listed ones and deleting the stored ones no longer listed
+func saveSplits(tx *gorm.DB, expenseID uint, splits []daily_expense.Split) error {
+	kept := make([]uint, 0, len(splits))
+	for _, split := range splits {
+		if split.ID == 0 {
+			continue
+		}
+		if split.DailyExpenseID != expenseID {
+			return fmt.Errorf("split line %d belongs to daily expense %d", split.ID, split.DailyExpenseID)
+		}
+		kept = append(kept, split.ID)
+	}
+
+	removed := tx.Where("daily_expense_id = ?", expenseID)
+	if len(kept) > 0 {
+		var owned int64
+		if err := tx.Model(&daily_expense.Split{}).Where("daily_expense_id = ? AND id IN ?", expenseID, kept).Count(&owned).Error; err != nil {
+			return err
+		}
+		if owned != int64(len(kept)) {
+			return fmt.Errorf("split lines %v are not all stored lines of daily expense %d", kept, expenseID)
+		}
+		removed = removed.Where("id NOT IN ?", kept)
+	}
+	if err := removed.Delete(&daily_expense.Split{}).Error; err != nil {
+		return err
+	}
+
+	for i := range splits {
+		split := &splits[i]
+		if split.ID != 0 {
+			if err := tx.Model(split).Select("PocketID", "Amount", "Note").Updates(split).Error; err != nil {
+				return err
+			}
+			continue
+		}
+		split.DailyExpenseID = expenseID
+		if err := tx.Omit("Pocket").Create(split).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// orderSplits preloads split lines in the order they were entered
+func orderSplits(db *gorm.DB) *gorm.DB {
+	return db.Order("id ASC")
 }
 
 // DailyExpenseSummary represents summary statistics for daily expenses
 type DailyExpenseSummary struct {
-	Month         string  `json:"month"`
-	TotalCount    int     `json:"total_count"`
-	TotalAmount   float64 `json:"total_amount"`
-	AverageAmount float64 `json:"average_amount"`
-	MinAmount     float64 `json:"min_amount"`
-	MaxAmount     float64 `json:"max_amount"`
+	Month         civil.Month `json:"month"`
+	TotalCount    int         `json:"total_count"`
+	TotalAmount   float64     `json:"total_amount"`
+	AverageAmount float64     `json:"average_amount"`
+	MinAmount     float64     `json:"min_amount"`
+	MaxAmount     float64     `json:"max_amount"`
 }
 
 // DailyTotal represents daily expense totals
 type DailyTotal struct {
-	Date         string  `json:"date"`
-	TotalAmount  float64 `json:"total_amount"`
-	ExpenseCount int     `json:"expense_count"`
+	Date         civil.Date `json:"date"`
+	TotalAmount  float64    `json:"total_amount"`
+	ExpenseCount int        `json:"expense_count"`
 }
 
 // WeekdayExpense represents expenses grouped by weekday
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"expenses-api/internal/domain/fixed_expense"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// FixedExpensePaymentRepository handles fixed expense payment database operations
+type FixedExpensePaymentRepository struct {
+	*BaseRepository
+}
+
+// NewFixedExpensePaymentRepository creates a new fixed expense payment repository instance
+func NewFixedExpensePaymentRepository(db *gorm.DB) *FixedExpensePaymentRepository {
+	return &FixedExpensePaymentRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// GetByFixedExpense retrieves all payments of a fixed expense in chronological order
+func (r *FixedExpensePaymentRepository) GetByFixedExpense(fixedExpenseID uint) ([]fixed_expense.Payment, error) {
+	var payments []fixed_expense.Payment
+	err := r.db.Where("fixed_expense_id = ?", fixedExpenseID).
+		Order("paid_date ASC, id ASC").
+		Find(&payments).Error
+	return payments, err
+}
+
+// GetByID retrieves a payment by ID
+func (r *FixedExpensePaymentRepository) GetByID(id uint) (*fixed_expense.Payment, error) {
+	var payment fixed_expense.Payment
+	err := r.db.First(&payment, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &payment, nil
+}
+
+// Create records a new payment
+func (r *FixedExpensePaymentRepository) Create(ctx context.Context, payment *fixed_expense.Payment) error {
+	return r.db.WithContext(ctx).Create(payment).Error
+}
+
+// Delete deletes a payment by ID
+func (r *FixedExpensePaymentRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&fixed_expense.Payment{}, id).Error
+}
+
+// DeleteByFixedExpense deletes every payment of a fixed expense
+func (r *FixedExpensePaymentRepository) DeleteByFixedExpense(ctx context.Context, fixedExpenseID uint) error {
+	return r.db.WithContext(ctx).Where("fixed_expense_id = ?", fixedExpenseID).Delete(&fixed_expense.Payment{}).Error
+}
+
+// Save stores expense.Payments as the payments of the expense, recording the
+// ones without an ID and deleting the stored ones no longer listed, and saves
+// the expense with its payment status, all in one transaction
+// The expense is only saved while it still has the version it was read with
+func (r *FixedExpensePaymentRepository) Save(ctx context.Context, expense *fixed_expense.FixedExpense) error {
+	read := expense.Version
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := updateVersioned(tx, expense, &expense.Version); err != nil {
+			return err
+		}
+
+		kept := make([]uint, 0, len(expense.Payments))
+		for _, payment := range expense.Payments {
+			if payment.ID != 0 {
+				kept = append(kept, payment.ID)
+			}
+		}
+		removed := tx.Where("fixed_expense_id = ?", expense.ID)
+		if len(kept) > 0 {
+			removed = removed.Where("id NOT IN ?", kept)
+		}
+		if err := removed.Delete(&fixed_expense.Payment{}).Error; err != nil {
+			return err
+		}
+
+		for i := range expense.Payments {
+			payment := &expense.Payments[i]
+			if payment.ID != 0 {
+				if payment.FixedExpenseID != expense.ID {
+					return fmt.Errorf("payment %d belongs to fixed expense %d", payment.ID, payment.FixedExpenseID)
+				}
+				continue
+			}
+			payment.FixedExpenseID = expense.ID
+			if err := tx.Create(payment).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		expense.Version = read
+	}
+	return err
+}
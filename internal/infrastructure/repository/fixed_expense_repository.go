@@ -1,7 +1,12 @@
 package repository
 
 import (
+	"context"
+	"expenses-api/internal/domain/attachment"
+	"expenses-api/internal/domain/civil"
 	"expenses-api/internal/domain/fixed_expense"
+	"expenses-api/internal/domain/household"
+	"expenses-api/internal/domain/receivable"
 
 	"gorm.io/gorm"
 )
@@ -19,19 +24,29 @@ func NewFixedExpenseRepository(db *gorm.DB) *FixedExpenseRepository {
 }
 
 // GetByMonth retrieves all fixed expenses for a specific month with pocket information
-func (r *FixedExpenseRepository) GetByMonth(month string) ([]fixed_expense.FixedExpense, error) {
+func (r *FixedExpenseRepository) GetByMonth(month civil.Month) ([]fixed_expense.FixedExpense, error) {
 	var expenses []fixed_expense.FixedExpense
-	err := r.db.Preload("Pocket").
+	err := r.db.Preload("Pocket").Preload("Payments", orderPayments).Preload("Tags").
 		Where("month = ?", month).
 		Order("payment_day ASC, concept_name ASC").
 		Find(&expenses).Error
 	return expenses, err
 }
 
+// GetByMonthRange retrieves the fixed expenses of every month from start to end, both included
+func (r *FixedExpenseRepository) GetByMonthRange(start, end civil.Month) ([]fixed_expense.FixedExpense, error) {
+	var expenses []fixed_expense.FixedExpense
+	err := r.db.Preload("Pocket").Preload("Payments", orderPayments).Preload("Tags").
+		Where("month >= ? AND month <= ?", start, end).
+		Order("month ASC, payment_day ASC, concept_name ASC").
+		Find(&expenses).Error
+	return expenses, err
+}
+
 // GetByMonthAndPocket retrieves fixed expenses for a specific month and pocket
-func (r *FixedExpenseRepository) GetByMonthAndPocket(month string, pocketID uint) ([]fixed_expense.FixedExpense, error) {
+func (r *FixedExpenseRepository) GetByMonthAndPocket(month civil.Month, pocketID uint) ([]fixed_expense.FixedExpense, error) {
 	var expenses []fixed_expense.FixedExpense
-	err := r.db.Preload("Pocket").
+	err := r.db.Preload("Pocket").Preload("Payments", orderPayments).Preload("Tags").
 		Where("month = ? AND pocket_id = ?", month, pocketID).
 		Order("payment_day ASC, concept_name ASC").
 		Find(&expenses).Error
@@ -41,7 +56,7 @@ func (r *FixedExpenseRepository) GetByMonthAndPocket(month string, pocketID uint
 // GetByID retrieves a fixed expense by ID with pocket information
 func (r *FixedExpenseRepository) GetByID(id uint) (*fixed_expense.FixedExpense, error) {
 	var expense fixed_expense.FixedExpense
-	err := r.db.Preload("Pocket").First(&expense, id).Error
+	err := r.db.Preload("Pocket").Preload("Payments", orderPayments).Preload("Tags").First(&expense, id).Error
 	if err != nil {
 		return nil, err
 	}
@@ -49,42 +64,43 @@ func (r *FixedExpenseRepository) GetByID(id uint) (*fixed_expense.FixedExpense,
 }
 
 // Create creates a new fixed expense
-func (r *FixedExpenseRepository) Create(expense *fixed_expense.FixedExpense) error {
-	return r.db.Create(expense).Error
-}
-
-// Update updates an existing fixed expense
-func (r *FixedExpenseRepository) Update(expense *fixed_expense.FixedExpense) error {
-	return r.db.Save(expense).Error
-}
-
-// Delete deletes a fixed expense by ID
-func (r *FixedExpenseRepository) Delete(id uint) error {
-	return r.db.Delete(&fixed_expense.FixedExpense{}, id).Error
-}
-
-// UpdatePaymentStatus updates the payment status of a fixed expense
-func (r *FixedExpenseRepository) UpdatePaymentStatus(id uint, isPaid bool, paidDate *string) error {
-	updates := map[string]interface{}{
-		"is_paid": isPaid,
-	}
-
-	if isPaid && paidDate != nil {
-		updates["paid_date"] = *paidDate
-	} else if !isPaid {
-		updates["paid_date"] = nil
-	}
-
-	// Use UpdateColumns to skip hooks and avoid validation errors
-	return r.db.Model(&fixed_expense.FixedExpense{}).
-		Where("id = ?", id).
-		UpdateColumns(updates).Error
+func (r *FixedExpenseRepository) Create(ctx context.Context, expense *fixed_expense.FixedExpense) error {
+	return r.db.WithContext(ctx).Create(expense).Error
+}
+
+// Update updates an existing fixed expense unless it changed since it was read
+// Relationships are not saved; payments are managed by FixedExpensePaymentRepository
+// and tags by TagRepository
+func (r *FixedExpenseRepository) Update(ctx context.Context, expense *fixed_expense.FixedExpense) error {
+	return updateVersioned(r.db.WithContext(ctx), expense, &expense.Version)
+}
+
+// Delete deletes a fixed expense, its payments, its tag links, its receivable, its household share and its attachments by ID
+func (r *FixedExpenseRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("fixed_expense_id = ?", id).Delete(&fixed_expense.Payment{}).Error; err != nil {
+			return err
+		}
+		if err := deleteTagLinks(tx, fixedExpenseTagLinks, id); err != nil {
+			return err
+		}
+		if err := deleteExpenseReceivable(tx, receivable.ExpenseTypeFixed, id); err != nil {
+			return err
+		}
+		if err := deleteExpenseShare(tx, household.ExpenseTypeFixed, id); err != nil {
+			return err
+		}
+		if err := deleteExpenseAttachments(tx, attachment.ExpenseTypeFixed, id); err != nil {
+			return err
+		}
+		return tx.Delete(&fixed_expense.FixedExpense{}, id).Error
+	})
 }
 
 // GetPaidByMonth retrieves all paid fixed expenses for a specific month
-func (r *FixedExpenseRepository) GetPaidByMonth(month string) ([]fixed_expense.FixedExpense, error) {
+func (r *FixedExpenseRepository) GetPaidByMonth(month civil.Month) ([]fixed_expense.FixedExpense, error) {
 	var expenses []fixed_expense.FixedExpense
-	err := r.db.Preload("Pocket").
+	err := r.db.Preload("Pocket").Preload("Payments", orderPayments).Preload("Tags").
 		Where("month = ? AND is_paid = ?", month, true).
 		Order("paid_date DESC, concept_name ASC").
 		Find(&expenses).Error
@@ -92,9 +108,9 @@ func (r *FixedExpenseRepository) GetPaidByMonth(month string) ([]fixed_expense.F
 }
 
 // GetUnpaidByMonth retrieves all unpaid fixed expenses for a specific month
-func (r *FixedExpenseRepository) GetUnpaidByMonth(month string) ([]fixed_expense.FixedExpense, error) {
+func (r *FixedExpenseRepository) GetUnpaidByMonth(month civil.Month) ([]fixed_expense.FixedExpense, error) {
 	var expenses []fixed_expense.FixedExpense
-	err := r.db.Preload("Pocket").
+	err := r.db.Preload("Pocket").Preload("Payments", orderPayments).Preload("Tags").
 		Where("month = ? AND is_paid = ?", month, false).
 		Order("payment_day ASC, concept_name ASC").
 		Find(&expenses).Error
@@ -102,17 +118,27 @@ func (r *FixedExpenseRepository) GetUnpaidByMonth(month string) ([]fixed_expense
 }
 
 // GetOverdueByMonth retrieves overdue fixed expenses for a specific month
-func (r *FixedExpenseRepository) GetOverdueByMonth(month string, currentDay int) ([]fixed_expense.FixedExpense, error) {
+func (r *FixedExpenseRepository) GetOverdueByMonth(month civil.Month, currentDay int) ([]fixed_expense.FixedExpense, error) {
 	var expenses []fixed_expense.FixedExpense
-	err := r.db.Preload("Pocket").
+	err := r.db.Preload("Pocket").Preload("Payments", orderPayments).Preload("Tags").
 		Where("month = ? AND is_paid = ? AND payment_day < ?", month, false, currentDay).
 		Order("payment_day ASC, concept_name ASC").
 		Find(&expenses).Error
 	return expenses, err
 }
 
+// GetUnpaidInMonthRange retrieves the unpaid fixed expenses from start to end, both included
+func (r *FixedExpenseRepository) GetUnpaidInMonthRange(start, end civil.Month) ([]fixed_expense.FixedExpense, error) {
+	var expenses []fixed_expense.FixedExpense
+	err := r.db.Preload("Pocket").Preload("Payments", orderPayments).Preload("Tags").
+		Where("month >= ? AND month <= ? AND is_paid = ?", start, end, false).
+		Order("month ASC, payment_day ASC, concept_name ASC").
+		Find(&expenses).Error
+	return expenses, err
+}
+
 // GetSummaryByMonth calculates summary statistics for fixed expenses in a month
-func (r *FixedExpenseRepository) GetSummaryByMonth(month string) (*FixedExpenseSummary, error) {
+func (r *FixedExpenseRepository) GetSummaryByMonth(month civil.Month) (*FixedExpenseSummary, error) {
 	var summary FixedExpenseSummary
 
 	err := r.db.Model(&fixed_expense.FixedExpense{}).
@@ -136,8 +162,8 @@ func (r *FixedExpenseRepository) GetSummaryByMonth(month string) (*FixedExpenseS
 }
 
 // GetMonthsWithExpenses retrieves all months that have fixed expenses
-func (r *FixedExpenseRepository) GetMonthsWithExpenses() ([]string, error) {
-	var months []string
+func (r *FixedExpenseRepository) GetMonthsWithExpenses() ([]civil.Month, error) {
+	var months []civil.Month
 	err := r.db.Model(&fixed_expense.FixedExpense{}).
 		Select("DISTINCT month").
 		Order("month DESC").
@@ -146,9 +172,10 @@ func (r *FixedExpenseRepository) GetMonthsWithExpenses() ([]string, error) {
 }
 
 // BulkUpdatePaymentStatus updates payment status for multiple expenses
-func (r *FixedExpenseRepository) BulkUpdatePaymentStatus(ids []uint, isPaid bool, paidDate *string) error {
+func (r *FixedExpenseRepository) BulkUpdatePaymentStatus(ctx context.Context, ids []uint, isPaid bool, paidDate *civil.Date) error {
 	updates := map[string]interface{}{
 		"is_paid": isPaid,
+		"version": nextVersion,
 	}
 
 	if isPaid && paidDate != nil {
@@ -157,28 +184,33 @@ func (r *FixedExpenseRepository) BulkUpdatePaymentStatus(ids []uint, isPaid bool
 		updates["paid_date"] = nil
 	}
 
-	return r.db.Model(&fixed_expense.FixedExpense{}).
+	return r.db.WithContext(ctx).Model(&fixed_expense.FixedExpense{}).
 		Where("id IN ?", ids).
 		Updates(updates).Error
 }
 
 // GetByPocketAndMonths retrieves fixed expenses for a pocket across multiple months
-func (r *FixedExpenseRepository) GetByPocketAndMonths(pocketID uint, months []string) ([]fixed_expense.FixedExpense, error) {
+func (r *FixedExpenseRepository) GetByPocketAndMonths(pocketID uint, months []civil.Month) ([]fixed_expense.FixedExpense, error) {
 	var expenses []fixed_expense.FixedExpense
-	err := r.db.Preload("Pocket").
+	err := r.db.Preload("Pocket").Preload("Payments", orderPayments).Preload("Tags").
 		Where("pocket_id = ? AND month IN ?", pocketID, months).
 		Order("month DESC, payment_day ASC").
 		Find(&expenses).Error
 	return expenses, err
 }
 
+// orderPayments preloads payments in chronological order
+func orderPayments(db *gorm.DB) *gorm.DB {
+	return db.Order("paid_date ASC, id ASC")
+}
+
 // FixedExpenseSummary represents summary statistics for fixed expenses
 type FixedExpenseSummary struct {
-	Month        string  `json:"month"`
-	TotalCount   int     `json:"total_count"`
-	TotalAmount  float64 `json:"total_amount"`
-	PaidCount    int     `json:"paid_count"`
-	PaidAmount   float64 `json:"paid_amount"`
-	UnpaidCount  int     `json:"unpaid_count"`
-	UnpaidAmount float64 `json:"unpaid_amount"`
+	Month        civil.Month `json:"month"`
+	TotalCount   int         `json:"total_count"`
+	TotalAmount  float64     `json:"total_amount"`
+	PaidCount    int         `json:"paid_count"`
+	PaidAmount   float64     `json:"paid_amount"`
+	UnpaidCount  int         `json:"unpaid_count"`
+	UnpaidAmount float64     `json:"unpaid_amount"`
 }
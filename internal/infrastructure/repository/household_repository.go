@@ -0,0 +1,202 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"expenses-api/internal/domain/household"
+
+	"gorm.io/gorm"
+)
+
+// HouseholdMemberRepository handles household member database operations
+type HouseholdMemberRepository struct {
+	*BaseRepository
+}
+
+// NewHouseholdMemberRepository creates a new household member repository instance
+func NewHouseholdMemberRepository(db *gorm.DB) *HouseholdMemberRepository {
+	return &HouseholdMemberRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// GetAll retrieves all household members ordered by name
+func (r *HouseholdMemberRepository) GetAll() ([]household.Member, error) {
+	var members []household.Member
+	err := r.db.Order("name ASC").Find(&members).Error
+	return members, err
+}
+
+// GetByID retrieves a household member by ID
+func (r *HouseholdMemberRepository) GetByID(id uint) (*household.Member, error) {
+	var member household.Member
+	err := r.db.First(&member, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+// GetByName retrieves a household member by name
+// Returns nil without error when no member has that name
+func (r *HouseholdMemberRepository) GetByName(name string) (*household.Member, error) {
+	var member household.Member
+	err := r.db.Where("name = ?", name).First(&member).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+// Create creates a new household member
+func (r *HouseholdMemberRepository) Create(ctx context.Context, member *household.Member) error {
+	return r.db.WithContext(ctx).Create(member).Error
+}
+
+// Update updates an existing household member
+func (r *HouseholdMemberRepository) Update(ctx context.Context, member *household.Member) error {
+	return r.db.WithContext(ctx).Save(member).Error
+}
+
+// Delete deletes a household member by ID
+func (r *HouseholdMemberRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&household.Member{}, id).Error
+}
+
+// HasShares checks if the member paid for or participates in any shared expense
+func (r *HouseholdMemberRepository) HasShares(id uint) (bool, error) {
+	var count int64
+	if err := r.db.Model(&household.Share{}).Where("payer_id = ?", id).Count(&count).Error; err != nil {
+		return false, err
+	}
+	if count > 0 {
+		return true, nil
+	}
+
+	err := r.db.Model(&household.SharePart{}).Where("member_id = ?", id).Count(&count).Error
+	return count > 0, err
+}
+
+// ExpenseShareRepository handles shared expense database operations
+type ExpenseShareRepository struct {
+	*BaseRepository
+}
+
+// NewExpenseShareRepository creates a new expense share repository instance
+func NewExpenseShareRepository(db *gorm.DB) *ExpenseShareRepository {
+	return &ExpenseShareRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// GetByID retrieves a shared expense by ID with its payer and participants
+func (r *ExpenseShareRepository) GetByID(id uint) (*household.Share, error) {
+	var share household.Share
+	err := r.preload().First(&share, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &share, nil
+}
+
+// GetByExpense retrieves the share of an expense
+// Returns nil without error when the expense is not shared
+func (r *ExpenseShareRepository) GetByExpense(expenseType string, expenseID uint) (*household.Share, error) {
+	var share household.Share
+	err := r.preload().
+		Where("expense_type = ? AND expense_id = ?", expenseType, expenseID).
+		First(&share).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &share, nil
+}
+
+// GetByExpenses retrieves the shares of several expenses of the same type
+func (r *ExpenseShareRepository) GetByExpenses(expenseType string, expenseIDs []uint) ([]household.Share, error) {
+	var shares []household.Share
+	if len(expenseIDs) == 0 {
+		return shares, nil
+	}
+
+	err := r.preload().
+		Where("expense_type = ? AND expense_id IN ?", expenseType, expenseIDs).
+		Order("id ASC").
+		Find(&shares).Error
+	return shares, err
+}
+
+// Create creates a shared expense together with its participants
+func (r *ExpenseShareRepository) Create(ctx context.Context, share *household.Share) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Payer", "Parts").Create(share).Error; err != nil {
+			return err
+		}
+		return createShareParts(tx, share)
+	})
+}
+
+// Update updates a shared expense and replaces its participants
+func (r *ExpenseShareRepository) Update(ctx context.Context, share *household.Share) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Payer", "Parts").Save(share).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("share_id = ?", share.ID).Delete(&household.SharePart{}).Error; err != nil {
+			return err
+		}
+		return createShareParts(tx, share)
+	})
+}
+
+// Delete deletes a shared expense and its participants by ID
+func (r *ExpenseShareRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("share_id = ?", id).Delete(&household.SharePart{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&household.Share{}, id).Error
+	})
+}
+
+// preload loads the payer and the participants with their members
+func (r *ExpenseShareRepository) preload() *gorm.DB {
+	return r.db.Preload("Payer").Preload("Parts", orderShareParts).Preload("Parts.Member")
+}
+
+// createShareParts inserts the participants of a share
+func createShareParts(tx *gorm.DB, share *household.Share) error {
+	for i := range share.Parts {
+		part := &share.Parts[i]
+		part.ID = 0
+		part.ShareID = share.ID
+		if err := tx.Omit("Member").Create(part).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteExpenseShare removes the share of an expense and its participants
+// It runs inside the transaction that deletes the expense
+func deleteExpenseShare(tx *gorm.DB, expenseType string, expenseID uint) error {
+	shares := tx.Model(&household.Share{}).
+		Select("id").
+		Where("expense_type = ? AND expense_id = ?", expenseType, expenseID)
+	if err := tx.Where("share_id IN (?)", shares).Delete(&household.SharePart{}).Error; err != nil {
+		return err
+	}
+	return tx.Where("expense_type = ? AND expense_id = ?", expenseType, expenseID).
+		Delete(&household.Share{}).Error
+}
+
+// orderShareParts keeps participants in the order they were entered
+func orderShareParts(db *gorm.DB) *gorm.DB {
+	return db.Order("id ASC")
+}
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"expenses-api/internal/domain/idempotency"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IdempotencyRepository handles stored idempotency key database operations
+type IdempotencyRepository struct {
+	*BaseRepository
+}
+
+// NewIdempotencyRepository creates a new idempotency key repository instance
+func NewIdempotencyRepository(db *gorm.DB) *IdempotencyRepository {
+	return &IdempotencyRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// GetByKey retrieves the stored record of an idempotency key
+func (r *IdempotencyRepository) GetByKey(key string) (*idempotency.Record, error) {
+	var record idempotency.Record
+	err := r.db.Where("idempotency_key = ?", key).First(&record).Error
+	if err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// Reserve stores a new record, relying on the unique key so that only one of
+// several concurrent requests with the same key wins; false when the key is taken
+func (r *IdempotencyRepository) Reserve(record *idempotency.Record) (bool, error) {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(record)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// Complete stores the response of the request that reserved the key
+func (r *IdempotencyRepository) Complete(key string, statusCode int, contentType, body string) error {
+	return r.db.Model(&idempotency.Record{}).
+		Where("idempotency_key = ?", key).
+		Updates(map[string]interface{}{
+			"status_code":   statusCode,
+			"content_type":  contentType,
+			"response_body": body,
+		}).Error
+}
+
+// Delete removes an idempotency key so it can be used again
+func (r *IdempotencyRepository) Delete(key string) error {
+	return r.db.Where("idempotency_key = ?", key).Delete(&idempotency.Record{}).Error
+}
+
+// DeleteExpired removes the keys that expired at now and returns how many were removed
+func (r *IdempotencyRepository) DeleteExpired(now time.Time) (int64, error) {
+	result := r.db.Where("expires_at <= ?", now).Delete(&idempotency.Record{})
+	return result.RowsAffected, result.Error
+}
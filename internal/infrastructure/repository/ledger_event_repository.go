@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"encoding/json"
+	"expenses-api/internal/domain/ledger"
+	"fmt"
+	"reflect"
+
+	"gorm.io/gorm"
+)
+
+// LedgerEventRepository handles ledger event log database operations
+// Events are written by the database layer with every change, never here
+type LedgerEventRepository struct {
+	*BaseRepository
+}
+
+// NewLedgerEventRepository creates a new ledger event repository instance
+func NewLedgerEventRepository(db *gorm.DB) *LedgerEventRepository {
+	return &LedgerEventRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// Find retrieves the events matching the filter in replay order
+func (r *LedgerEventRepository) Find(filter ledger.Filter) ([]ledger.Event, error) {
+	query := r.db.Model(&ledger.Event{})
+	if filter.Entity != "" {
+		query = query.Where("entity = ?", filter.Entity)
+	}
+	if len(filter.Entities) > 0 {
+		query = query.Where("entity IN ?", filter.Entities)
+	}
+	if !filter.Month.IsZero() {
+		// Every event of an entity that was in the month, so a later move or deletion replays too
+		query = query.Where(
+			"(entity NOT IN ? OR EXISTS (SELECT 1 FROM ledger_events m WHERE m.entity = ledger_events.entity AND m.entity_id = ledger_events.entity_id AND m.month = ?))",
+			ledger.MonthKeyed(), filter.Month,
+		)
+	}
+	if filter.AfterID != 0 {
+		query = query.Where("id > ?", filter.AfterID)
+	}
+	if !filter.Until.IsZero() {
+		query = query.Where("occurred_at <= ?", filter.Until)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	var events []ledger.Event
+	err := query.Order("id ASC").Find(&events).Error
+	return events, err
+}
+
+// CurrentStates returns the current rows of a ledger entity's table as JSON by ID
+func (r *LedgerEventRepository) CurrentStates(entity string) (map[uint]string, error) {
+	model := ledger.NewModel(entity)
+	if model == nil {
+		return nil, fmt.Errorf("%s is not a ledger entity", entity)
+	}
+
+	rows := reflect.New(reflect.SliceOf(reflect.TypeOf(model).Elem()))
+	if err := r.db.Find(rows.Interface()).Error; err != nil {
+		return nil, err
+	}
+
+	states := make(map[uint]string, rows.Elem().Len())
+	for i := 0; i < rows.Elem().Len(); i++ {
+		data, err := json.Marshal(rows.Elem().Index(i).Interface())
+		if err != nil {
+			return nil, err
+		}
+
+		var row struct {
+			ID uint `json:"id"`
+		}
+		if err := json.Unmarshal(data, &row); err != nil {
+			return nil, err
+		}
+		states[row.ID] = string(data)
+	}
+	return states, nil
+}
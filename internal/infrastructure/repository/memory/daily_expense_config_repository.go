@@ -0,0 +1,65 @@
+package memory
+
+import (
+	"context"
+	"expenses-api/internal/application/port"
+	"expenses-api/internal/domain/civil"
+	"expenses-api/internal/domain/daily_expense_config"
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+// DailyExpenseConfigRepository keeps daily expense budgets in memory, one per month
+type DailyExpenseConfigRepository struct {
+	mu      sync.RWMutex
+	configs map[civil.Month]daily_expense_config.DailyExpenseConfig
+	nextID  uint
+}
+
+// NewDailyExpenseConfigRepository creates a new in-memory daily expense config repository
+func NewDailyExpenseConfigRepository() *DailyExpenseConfigRepository {
+	return &DailyExpenseConfigRepository{configs: make(map[civil.Month]daily_expense_config.DailyExpenseConfig)}
+}
+
+// GetByMonth retrieves daily expense configuration for a specific month
+func (r *DailyExpenseConfigRepository) GetByMonth(month civil.Month) (*daily_expense_config.DailyExpenseConfig, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	config, ok := r.configs[month]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &config, nil
+}
+
+// CreateOrUpdate creates a new config record or updates the budget of the existing one
+func (r *DailyExpenseConfigRepository) CreateOrUpdate(ctx context.Context, config *daily_expense_config.DailyExpenseConfig) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.configs[config.Month]
+	if config.Version != 0 && (!ok || existing.Version != config.Version) {
+		return port.ErrVersionConflict
+	}
+	if ok {
+		existing.MonthlyBudget = config.MonthlyBudget
+		if err := existing.BeforeUpdate(nil); err != nil {
+			return err
+		}
+		existing.Version++
+		r.configs[config.Month] = existing
+		*config = existing
+		return nil
+	}
+
+	if err := config.BeforeCreate(nil); err != nil {
+		return err
+	}
+
+	r.nextID++
+	config.ID = r.nextID
+	r.configs[config.Month] = *config
+	return nil
+}
@@ -0,0 +1,216 @@
+package memory
+
+import (
+	"context"
+	"expenses-api/internal/application/port"
+	"expenses-api/internal/domain/civil"
+	"expenses-api/internal/domain/daily_expense"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// DailyExpenseRepository keeps daily expenses in memory
+// Pockets are resolved from the pocket repository when reading, like GORM's Preload;
+// tags are kept as given since the tag repository is not available here
+type DailyExpenseRepository struct {
+	mu          sync.RWMutex
+	expenses    map[uint]daily_expense.DailyExpense
+	nextID      uint
+	nextSplitID uint
+	pockets     *PocketRepository
+}
+
+// NewDailyExpenseRepository creates a new in-memory daily expense repository
+// pockets may be nil, in which case expenses are returned without their pocket
+func NewDailyExpenseRepository(pockets *PocketRepository) *DailyExpenseRepository {
+	return &DailyExpenseRepository{
+		expenses: make(map[uint]daily_expense.DailyExpense),
+		pockets:  pockets,
+	}
+}
+
+// GetByMonth retrieves all daily expenses for a specific month
+func (r *DailyExpenseRepository) GetByMonth(month civil.Month) ([]daily_expense.DailyExpense, error) {
+	return r.find(func(expense *daily_expense.DailyExpense) bool {
+		return month.Contains(expense.Date)
+	}, true), nil
+}
+
+// GetByDateRange retrieves daily expenses within a date range, both ends included
+// Pockets are not resolved, matching the GORM repository
+func (r *DailyExpenseRepository) GetByDateRange(startDate, endDate civil.Date) ([]daily_expense.DailyExpense, error) {
+	return r.find(func(expense *daily_expense.DailyExpense) bool {
+		return !expense.Date.Before(startDate) && !expense.Date.After(endDate)
+	}, false), nil
+}
+
+// GetByID retrieves a daily expense by ID
+func (r *DailyExpenseRepository) GetByID(id uint) (*daily_expense.DailyExpense, error) {
+	r.mu.RLock()
+	expense, ok := r.expenses[id]
+	r.mu.RUnlock()
+
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	expense = r.load(expense, true)
+	return &expense, nil
+}
+
+// Create creates a new daily expense with its split lines and tags
+func (r *DailyExpenseRepository) Create(ctx context.Context, expense *daily_expense.DailyExpense) error {
+	if err := expense.BeforeCreate(nil); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.nextID++
+	expense.ID = r.nextID
+	if expense.CreatedAt.IsZero() {
+		expense.CreatedAt = time.Now()
+	}
+	r.addSplits(expense.ID, expense.Splits)
+	r.expenses[expense.ID] = copyDailyExpense(*expense)
+	return nil
+}
+
+// Update updates an existing daily expense with its split lines and tags unless
+// it changed since it was read
+// Nil Splits or Tags keep the stored ones; split lines without an ID are added,
+// the listed ones are updated and the stored ones no longer listed are dropped
+func (r *DailyExpenseRepository) Update(ctx context.Context, expense *daily_expense.DailyExpense) error {
+	if err := expense.BeforeUpdate(nil); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.expenses[expense.ID]
+	if !ok || existing.Version != expense.Version {
+		return port.ErrVersionConflict
+	}
+
+	if expense.Splits != nil {
+		stored := make(map[uint]bool, len(existing.Splits))
+		for _, split := range existing.Splits {
+			stored[split.ID] = true
+		}
+		for _, split := range expense.Splits {
+			if split.ID != 0 && (!stored[split.ID] || split.DailyExpenseID != expense.ID) {
+				return fmt.Errorf("split line %d belongs to daily expense %d", split.ID, split.DailyExpenseID)
+			}
+		}
+		r.addSplits(expense.ID, expense.Splits)
+	}
+
+	expense.Version++
+	updated := copyDailyExpense(*expense)
+	if expense.Tags == nil {
+		updated.Tags = existing.Tags
+	}
+	if expense.Splits == nil {
+		updated.Splits = existing.Splits
+	}
+	r.expenses[expense.ID] = updated
+	return nil
+}
+
+// addSplits assigns an ID to the split lines that have none and links every
+// line to the expense; callers hold the lock
+func (r *DailyExpenseRepository) addSplits(expenseID uint, splits []daily_expense.Split) {
+	for i := range splits {
+		if splits[i].ID == 0 {
+			r.nextSplitID++
+			splits[i].ID = r.nextSplitID
+			splits[i].DailyExpenseID = expenseID
+		}
+	}
+}
+
+// Delete deletes a daily expense with its tags and split lines by ID
+func (r *DailyExpenseRepository) Delete(ctx context.Context, id uint, version uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if existing, ok := r.expenses[id]; version != 0 && (!ok || existing.Version != version) {
+		return port.ErrVersionConflict
+	}
+	delete(r.expenses, id)
+	return nil
+}
+
+// find returns the matching expenses, newest first
+func (r *DailyExpenseRepository) find(match func(expense *daily_expense.DailyExpense) bool, withPockets bool) []daily_expense.DailyExpense {
+	r.mu.RLock()
+	var expenses []daily_expense.DailyExpense
+	for _, expense := range r.expenses {
+		if match(&expense) {
+			expenses = append(expenses, expense)
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(expenses, func(i, j int) bool {
+		if expenses[i].Date != expenses[j].Date {
+			return expenses[i].Date.After(expenses[j].Date)
+		}
+		if !expenses[i].CreatedAt.Equal(expenses[j].CreatedAt) {
+			return expenses[i].CreatedAt.After(expenses[j].CreatedAt)
+		}
+		return expenses[i].ID > expenses[j].ID
+	})
+
+	for i := range expenses {
+		expenses[i] = r.load(expenses[i], withPockets)
+	}
+	return expenses
+}
+
+// load returns a copy of a stored expense with split lines in the order they were entered
+// and, when withPockets is set, the pockets of the expense and its split lines resolved
+func (r *DailyExpenseRepository) load(expense daily_expense.DailyExpense, withPockets bool) daily_expense.DailyExpense {
+	expense = copyDailyExpense(expense)
+
+	sort.SliceStable(expense.Splits, func(i, j int) bool {
+		return expense.Splits[i].ID < expense.Splits[j].ID
+	})
+
+	if !withPockets {
+		return expense
+	}
+
+	if expense.PocketID != nil {
+		if id, name, ok := r.pockets.lookup(*expense.PocketID); ok {
+			expense.Pocket = &daily_expense.Pocket{ID: id, Name: name}
+		}
+	}
+	for i := range expense.Splits {
+		if id, name, ok := r.pockets.lookup(expense.Splits[i].PocketID); ok {
+			expense.Splits[i].Pocket = &daily_expense.Pocket{ID: id, Name: name}
+		}
+	}
+	return expense
+}
+
+// copyDailyExpense returns a copy of an expense that shares no memory with the caller
+func copyDailyExpense(expense daily_expense.DailyExpense) daily_expense.DailyExpense {
+	expense.Pocket = nil
+	if expense.PocketID != nil {
+		pocketID := *expense.PocketID
+		expense.PocketID = &pocketID
+	}
+	expense.Tags = append([]daily_expense.Tag(nil), expense.Tags...)
+	expense.Splits = append([]daily_expense.Split(nil), expense.Splits...)
+	for i := range expense.Splits {
+		expense.Splits[i].Pocket = nil
+	}
+	return expense
+}
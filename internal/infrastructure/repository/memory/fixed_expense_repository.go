@@ -0,0 +1,181 @@
+package memory
+
+import (
+	"context"
+	"expenses-api/internal/application/port"
+	"expenses-api/internal/domain/civil"
+	"expenses-api/internal/domain/fixed_expense"
+	"sort"
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+// FixedExpenseRepository keeps fixed expenses in memory
+// Pockets are resolved from the pocket repository when reading, like GORM's Preload;
+// payments and tags are kept as given on Create since other repositories manage them
+type FixedExpenseRepository struct {
+	mu       sync.RWMutex
+	expenses map[uint]fixed_expense.FixedExpense
+	nextID   uint
+	pockets  *PocketRepository
+}
+
+// NewFixedExpenseRepository creates a new in-memory fixed expense repository
+// pockets may be nil, in which case expenses are returned without their pocket
+func NewFixedExpenseRepository(pockets *PocketRepository) *FixedExpenseRepository {
+	return &FixedExpenseRepository{
+		expenses: make(map[uint]fixed_expense.FixedExpense),
+		pockets:  pockets,
+	}
+}
+
+// GetByMonth retrieves all fixed expenses for a specific month with pocket information
+func (r *FixedExpenseRepository) GetByMonth(month civil.Month) ([]fixed_expense.FixedExpense, error) {
+	return r.find(func(expense *fixed_expense.FixedExpense) bool {
+		return expense.Month == month
+	}), nil
+}
+
+// GetByMonthRange retrieves the fixed expenses of every month from start to end, both included
+func (r *FixedExpenseRepository) GetByMonthRange(start, end civil.Month) ([]fixed_expense.FixedExpense, error) {
+	return r.find(func(expense *fixed_expense.FixedExpense) bool {
+		return !expense.Month.Before(start) && !expense.Month.After(end)
+	}), nil
+}
+
+// GetByID retrieves a fixed expense by ID with pocket information
+func (r *FixedExpenseRepository) GetByID(id uint) (*fixed_expense.FixedExpense, error) {
+	r.mu.RLock()
+	expense, ok := r.expenses[id]
+	r.mu.RUnlock()
+
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	expense = r.load(expense)
+	return &expense, nil
+}
+
+// Create creates a new fixed expense
+func (r *FixedExpenseRepository) Create(ctx context.Context, expense *fixed_expense.FixedExpense) error {
+	if err := expense.BeforeCreate(nil); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.nextID++
+	expense.ID = r.nextID
+	for i := range expense.Payments {
+		expense.Payments[i].FixedExpenseID = expense.ID
+	}
+	r.expenses[expense.ID] = copyFixedExpense(*expense)
+	return nil
+}
+
+// Update updates an existing fixed expense unless it changed since it was read
+// Relationships are not saved; the stored payments and tags are kept
+func (r *FixedExpenseRepository) Update(ctx context.Context, expense *fixed_expense.FixedExpense) error {
+	if err := expense.BeforeUpdate(nil); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.expenses[expense.ID]
+	if !ok || existing.Version != expense.Version {
+		return port.ErrVersionConflict
+	}
+
+	expense.Version++
+	updated := copyFixedExpense(*expense)
+	updated.Payments = existing.Payments
+	updated.Tags = existing.Tags
+	r.expenses[expense.ID] = updated
+	return nil
+}
+
+// GetUnpaidByMonth retrieves all unpaid fixed expenses for a specific month
+func (r *FixedExpenseRepository) GetUnpaidByMonth(month civil.Month) ([]fixed_expense.FixedExpense, error) {
+	return r.find(func(expense *fixed_expense.FixedExpense) bool {
+		return expense.Month == month && !expense.IsPaid
+	}), nil
+}
+
+// GetOverdueByMonth retrieves unpaid fixed expenses of a month whose payment day is before currentDay
+func (r *FixedExpenseRepository) GetOverdueByMonth(month civil.Month, currentDay int) ([]fixed_expense.FixedExpense, error) {
+	return r.find(func(expense *fixed_expense.FixedExpense) bool {
+		return expense.Month == month && !expense.IsPaid && expense.PaymentDay < currentDay
+	}), nil
+}
+
+// GetUnpaidInMonthRange retrieves the unpaid fixed expenses from start to end, both included
+func (r *FixedExpenseRepository) GetUnpaidInMonthRange(start, end civil.Month) ([]fixed_expense.FixedExpense, error) {
+	return r.find(func(expense *fixed_expense.FixedExpense) bool {
+		return !expense.Month.Before(start) && !expense.Month.After(end) && !expense.IsPaid
+	}), nil
+}
+
+// find returns the matching expenses ordered by month, payment day and concept name
+func (r *FixedExpenseRepository) find(match func(expense *fixed_expense.FixedExpense) bool) []fixed_expense.FixedExpense {
+	r.mu.RLock()
+	var expenses []fixed_expense.FixedExpense
+	for _, expense := range r.expenses {
+		if match(&expense) {
+			expenses = append(expenses, expense)
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(expenses, func(i, j int) bool {
+		if expenses[i].Month != expenses[j].Month {
+			return expenses[i].Month.Before(expenses[j].Month)
+		}
+		if expenses[i].PaymentDay != expenses[j].PaymentDay {
+			return expenses[i].PaymentDay < expenses[j].PaymentDay
+		}
+		return expenses[i].ConceptName < expenses[j].ConceptName
+	})
+
+	for i := range expenses {
+		expenses[i] = r.load(expenses[i])
+	}
+	return expenses
+}
+
+// load returns a copy of a stored expense with its pocket resolved and payments in chronological order
+func (r *FixedExpenseRepository) load(expense fixed_expense.FixedExpense) fixed_expense.FixedExpense {
+	expense = copyFixedExpense(expense)
+
+	if id, name, ok := r.pockets.lookup(expense.PocketID); ok {
+		expense.Pocket = &fixed_expense.Pocket{ID: id, Name: name}
+	}
+
+	sort.SliceStable(expense.Payments, func(i, j int) bool {
+		if expense.Payments[i].PaidDate != expense.Payments[j].PaidDate {
+			return expense.Payments[i].PaidDate.Before(expense.Payments[j].PaidDate)
+		}
+		return expense.Payments[i].ID < expense.Payments[j].ID
+	})
+	return expense
+}
+
+// copyFixedExpense returns a copy of an expense that shares no memory with the caller
+func copyFixedExpense(expense fixed_expense.FixedExpense) fixed_expense.FixedExpense {
+	expense.Pocket = nil
+	if expense.PaidDate != nil {
+		date := *expense.PaidDate
+		expense.PaidDate = &date
+	}
+	if expense.ActualAmount != nil {
+		amount := *expense.ActualAmount
+		expense.ActualAmount = &amount
+	}
+	expense.Payments = append([]fixed_expense.Payment(nil), expense.Payments...)
+	expense.Tags = append([]fixed_expense.Tag(nil), expense.Tags...)
+	return expense
+}
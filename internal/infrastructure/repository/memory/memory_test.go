@@ -0,0 +1,20 @@
+package memory_test
+
+import (
+	"expenses-api/internal/application/port/porttest"
+	"expenses-api/internal/infrastructure/repository/memory"
+	"testing"
+)
+
+func TestMemoryRepositories(t *testing.T) {
+	porttest.RunRepositories(t, func(t *testing.T) porttest.Repositories {
+		pockets := memory.NewPocketRepository()
+		return porttest.Repositories{
+			Salaries:            memory.NewSalaryRepository(),
+			Pockets:             pockets,
+			FixedExpenses:       memory.NewFixedExpenseRepository(pockets),
+			DailyExpenses:       memory.NewDailyExpenseRepository(pockets),
+			DailyExpenseConfigs: memory.NewDailyExpenseConfigRepository(),
+		}
+	})
+}
@@ -0,0 +1,138 @@
+package memory
+
+import (
+	"context"
+	"expenses-api/internal/application/port"
+	"expenses-api/internal/domain/pocket"
+	"sort"
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+// PocketRepository keeps pockets in memory with unique names
+type PocketRepository struct {
+	mu      sync.RWMutex
+	pockets map[uint]pocket.Pocket
+	nextID  uint
+}
+
+// NewPocketRepository creates a new in-memory pocket repository
+func NewPocketRepository() *PocketRepository {
+	return &PocketRepository{pockets: make(map[uint]pocket.Pocket)}
+}
+
+// GetAll retrieves all pockets ordered by name
+func (r *PocketRepository) GetAll() ([]pocket.Pocket, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	pockets := make([]pocket.Pocket, 0, len(r.pockets))
+	for _, p := range r.pockets {
+		pockets = append(pockets, p)
+	}
+	sort.Slice(pockets, func(i, j int) bool {
+		return pockets[i].Name < pockets[j].Name
+	})
+	return pockets, nil
+}
+
+// GetByID retrieves a pocket by ID
+func (r *PocketRepository) GetByID(id uint) (*pocket.Pocket, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	p, ok := r.pockets[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &p, nil
+}
+
+// GetByName retrieves a pocket by name
+func (r *PocketRepository) GetByName(name string) (*pocket.Pocket, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, p := range r.pockets {
+		if p.Name == name {
+			return &p, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+// Create creates a new pocket
+func (r *PocketRepository) Create(ctx context.Context, p *pocket.Pocket) error {
+	if err := p.BeforeCreate(nil); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.nameTaken(p.Name, 0) {
+		return gorm.ErrDuplicatedKey
+	}
+
+	r.nextID++
+	p.ID = r.nextID
+	r.pockets[p.ID] = *p
+	return nil
+}
+
+// Update updates an existing pocket unless it changed since it was read
+func (r *PocketRepository) Update(ctx context.Context, p *pocket.Pocket) error {
+	if err := p.BeforeUpdate(nil); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if existing, ok := r.pockets[p.ID]; !ok || existing.Version != p.Version {
+		return port.ErrVersionConflict
+	}
+	if r.nameTaken(p.Name, p.ID) {
+		return gorm.ErrDuplicatedKey
+	}
+
+	p.Version++
+	r.pockets[p.ID] = *p
+	return nil
+}
+
+// Delete deletes a pocket by ID
+func (r *PocketRepository) Delete(ctx context.Context, id uint, version uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if existing, ok := r.pockets[id]; version != 0 && (!ok || existing.Version != version) {
+		return port.ErrVersionConflict
+	}
+	delete(r.pockets, id)
+	return nil
+}
+
+// nameTaken reports whether another pocket already uses the name; callers hold the lock
+func (r *PocketRepository) nameTaken(name string, excludeID uint) bool {
+	for id, p := range r.pockets {
+		if id != excludeID && p.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// lookup returns the ID and name of a pocket for the expense repositories' preloads
+func (r *PocketRepository) lookup(id uint) (uint, string, bool) {
+	if r == nil {
+		return 0, "", false
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	p, ok := r.pockets[id]
+	return p.ID, p.Name, ok
+}
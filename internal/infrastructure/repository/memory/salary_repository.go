@@ -0,0 +1,64 @@
+// Package memory provides thread-safe in-memory implementations of the
+// repository ports, mirroring the behavior of the GORM repositories
+package memory
+
+import (
+	"context"
+	"expenses-api/internal/application/port"
+	"expenses-api/internal/domain/civil"
+	"expenses-api/internal/domain/salary"
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+// SalaryRepository keeps salaries in memory, one per month
+type SalaryRepository struct {
+	mu       sync.RWMutex
+	salaries map[civil.Month]salary.Salary
+	nextID   uint
+}
+
+// NewSalaryRepository creates a new in-memory salary repository
+func NewSalaryRepository() *SalaryRepository {
+	return &SalaryRepository{salaries: make(map[civil.Month]salary.Salary)}
+}
+
+// GetByMonth retrieves salary configuration for a specific month
+func (r *SalaryRepository) GetByMonth(month civil.Month) (*salary.Salary, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	s, ok := r.salaries[month]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &s, nil
+}
+
+// CreateOrUpdate creates a new salary record or updates the amount of the existing one
+func (r *SalaryRepository) CreateOrUpdate(ctx context.Context, s *salary.Salary) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.salaries[s.Month]
+	if s.Version != 0 && (!ok || existing.Version != s.Version) {
+		return port.ErrVersionConflict
+	}
+	if ok {
+		existing.MonthlyAmount = s.MonthlyAmount
+		existing.Version++
+		r.salaries[s.Month] = existing
+		*s = existing
+		return nil
+	}
+
+	if err := s.BeforeCreate(nil); err != nil {
+		return err
+	}
+
+	r.nextID++
+	s.ID = r.nextID
+	r.salaries[s.Month] = *s
+	return nil
+}
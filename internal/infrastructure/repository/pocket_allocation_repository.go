@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"expenses-api/internal/domain/civil"
+	"expenses-api/internal/domain/pocket_allocation"
+
+	"gorm.io/gorm"
+)
+
+// PocketAllocationRepository handles pocket allocation-related database operations
+type PocketAllocationRepository struct {
+	*BaseRepository
+}
+
+// NewPocketAllocationRepository creates a new pocket allocation repository instance
+func NewPocketAllocationRepository(db *gorm.DB) *PocketAllocationRepository {
+	return &PocketAllocationRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// GetByMonth retrieves all pocket allocations for a specific month
+func (r *PocketAllocationRepository) GetByMonth(month civil.Month) ([]pocket_allocation.PocketAllocation, error) {
+	var allocations []pocket_allocation.PocketAllocation
+	err := r.db.Where("month = ?", month).
+		Order("pocket_id ASC").
+		Find(&allocations).Error
+	return allocations, err
+}
+
+// GetByMonthRange retrieves the allocations of every month from start to end, both included
+func (r *PocketAllocationRepository) GetByMonthRange(start, end civil.Month) ([]pocket_allocation.PocketAllocation, error) {
+	var allocations []pocket_allocation.PocketAllocation
+	err := r.db.Where("month >= ? AND month <= ?", start, end).
+		Order("month ASC, pocket_id ASC").
+		Find(&allocations).Error
+	return allocations, err
+}
+
+// GetByPocket retrieves all allocations of a pocket ordered by month
+func (r *PocketAllocationRepository) GetByPocket(pocketID uint) ([]pocket_allocation.PocketAllocation, error) {
+	var allocations []pocket_allocation.PocketAllocation
+	err := r.db.Where("pocket_id = ?", pocketID).
+		Order("month ASC").
+		Find(&allocations).Error
+	return allocations, err
+}
+
+// GetEarliestMonth retrieves the first month with any allocation, or the zero month if none
+func (r *PocketAllocationRepository) GetEarliestMonth() (civil.Month, error) {
+	var months []civil.Month
+	err := r.db.Model(&pocket_allocation.PocketAllocation{}).
+		Order("month ASC").
+		Limit(1).
+		Pluck("month", &months).Error
+	if err != nil || len(months) == 0 {
+		return civil.Month{}, err
+	}
+	return months[0], nil
+}
+
+// CreateOrUpdate creates a new allocation or updates the existing one for the pocket and month
+func (r *PocketAllocationRepository) CreateOrUpdate(ctx context.Context, allocation *pocket_allocation.PocketAllocation) error {
+	// Try to find existing record
+	var existing pocket_allocation.PocketAllocation
+	err := r.db.WithContext(ctx).Where("pocket_id = ? AND month = ?", allocation.PocketID, allocation.Month).
+		First(&existing).Error
+
+	if err == gorm.ErrRecordNotFound {
+		// Create new record
+		return r.db.WithContext(ctx).Create(allocation).Error
+	} else if err != nil {
+		// Other error
+		return err
+	}
+
+	// Update existing record
+	existing.Amount = allocation.Amount
+	if err := r.db.WithContext(ctx).Save(&existing).Error; err != nil {
+		return err
+	}
+
+	allocation.ID = existing.ID
+	return nil
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"expenses-api/internal/domain/pocket"
 
 	"gorm.io/gorm"
@@ -36,18 +37,18 @@ func (r *PocketRepository) GetByID(id uint) (*pocket.Pocket, error) {
 }
 
 // Create creates a new pocket
-func (r *PocketRepository) Create(p *pocket.Pocket) error {
-	return r.db.Create(p).Error
+func (r *PocketRepository) Create(ctx context.Context, p *pocket.Pocket) error {
+	return r.db.WithContext(ctx).Create(p).Error
 }
 
-// Update updates an existing pocket
-func (r *PocketRepository) Update(p *pocket.Pocket) error {
-	return r.db.Save(p).Error
+// Update updates an existing pocket unless it changed since it was read
+func (r *PocketRepository) Update(ctx context.Context, p *pocket.Pocket) error {
+	return updateVersioned(r.db.WithContext(ctx), p, &p.Version)
 }
 
 // Delete deletes a pocket by ID
-func (r *PocketRepository) Delete(id uint) error {
-	return r.db.Delete(&pocket.Pocket{}, id).Error
+func (r *PocketRepository) Delete(ctx context.Context, id uint, version uint) error {
+	return deleteVersioned(r.db.WithContext(ctx), &pocket.Pocket{}, id, version)
 }
 
 // GetByName retrieves a pocket by name
@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"expenses-api/internal/domain/receivable"
+
+	"gorm.io/gorm"
+)
+
+// ReceivableRepository handles reimbursable expense database operations
+type ReceivableRepository struct {
+	*BaseRepository
+}
+
+// NewReceivableRepository creates a new receivable repository instance
+func NewReceivableRepository(db *gorm.DB) *ReceivableRepository {
+	return &ReceivableRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// GetAll retrieves all receivables with their reimbursements, newest first
+func (r *ReceivableRepository) GetAll() ([]receivable.Receivable, error) {
+	var receivables []receivable.Receivable
+	err := r.db.Preload("Reimbursements", orderReimbursements).
+		Order("created_at DESC, id DESC").
+		Find(&receivables).Error
+	return receivables, err
+}
+
+// GetByID retrieves a receivable by ID with its reimbursements
+func (r *ReceivableRepository) GetByID(id uint) (*receivable.Receivable, error) {
+	var rec receivable.Receivable
+	err := r.db.Preload("Reimbursements", orderReimbursements).First(&rec, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &rec, nil
+}
+
+// GetByExpense retrieves the receivable of an expense
+// Returns nil without error when the expense is not reimbursable
+func (r *ReceivableRepository) GetByExpense(expenseType string, expenseID uint) (*receivable.Receivable, error) {
+	var rec receivable.Receivable
+	err := r.db.Preload("Reimbursements", orderReimbursements).
+		Where("expense_type = ? AND expense_id = ?", expenseType, expenseID).
+		First(&rec).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &rec, nil
+}
+
+// GetByExpenses retrieves the receivables of several expenses of the same type
+func (r *ReceivableRepository) GetByExpenses(expenseType string, expenseIDs []uint) ([]receivable.Receivable, error) {
+	var receivables []receivable.Receivable
+	if len(expenseIDs) == 0 {
+		return receivables, nil
+	}
+
+	err := r.db.Preload("Reimbursements", orderReimbursements).
+		Where("expense_type = ? AND expense_id IN ?", expenseType, expenseIDs).
+		Find(&receivables).Error
+	return receivables, err
+}
+
+// Create creates a new receivable
+func (r *ReceivableRepository) Create(ctx context.Context, rec *receivable.Receivable) error {
+	return r.db.WithContext(ctx).Omit("Reimbursements").Create(rec).Error
+}
+
+// Update updates an existing receivable
+// Reimbursements are not saved; they are managed by ReimbursementRepository
+func (r *ReceivableRepository) Update(ctx context.Context, rec *receivable.Receivable) error {
+	return r.db.WithContext(ctx).Omit("Reimbursements").Save(rec).Error
+}
+
+// Delete deletes a receivable and its reimbursements by ID
+func (r *ReceivableRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("receivable_id = ?", id).Delete(&receivable.Reimbursement{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&receivable.Receivable{}, id).Error
+	})
+}
+
+// ReimbursementRepository handles reimbursement database operations
+type ReimbursementRepository struct {
+	*BaseRepository
+}
+
+// NewReimbursementRepository creates a new reimbursement repository instance
+func NewReimbursementRepository(db *gorm.DB) *ReimbursementRepository {
+	return &ReimbursementRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// GetByID retrieves a reimbursement by ID
+func (r *ReimbursementRepository) GetByID(id uint) (*receivable.Reimbursement, error) {
+	var reimbursement receivable.Reimbursement
+	err := r.db.First(&reimbursement, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &reimbursement, nil
+}
+
+// Create records a new reimbursement
+func (r *ReimbursementRepository) Create(ctx context.Context, reimbursement *receivable.Reimbursement) error {
+	return r.db.WithContext(ctx).Create(reimbursement).Error
+}
+
+// Delete deletes a reimbursement by ID
+func (r *ReimbursementRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&receivable.Reimbursement{}, id).Error
+}
+
+// deleteExpenseReceivable removes the receivable of an expense and its reimbursements
+// It runs inside the transaction that deletes the expense
+func deleteExpenseReceivable(tx *gorm.DB, expenseType string, expenseID uint) error {
+	receivables := tx.Model(&receivable.Receivable{}).
+		Select("id").
+		Where("expense_type = ? AND expense_id = ?", expenseType, expenseID)
+	if err := tx.Where("receivable_id IN (?)", receivables).Delete(&receivable.Reimbursement{}).Error; err != nil {
+		return err
+	}
+	return tx.Where("expense_type = ? AND expense_id = ?", expenseType, expenseID).
+		Delete(&receivable.Receivable{}).Error
+}
+
+// orderReimbursements sorts preloaded reimbursements chronologically
+func orderReimbursements(db *gorm.DB) *gorm.DB {
+	return db.Order("received_date ASC, id ASC")
+}
@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"expenses-api/internal/domain/reminder"
+
+	"gorm.io/gorm"
+)
+
+// ReminderLogRepository handles sent reminder bookkeeping database operations
+type ReminderLogRepository struct {
+	*BaseRepository
+}
+
+// NewReminderLogRepository creates a new reminder log repository instance
+func NewReminderLogRepository(db *gorm.DB) *ReminderLogRepository {
+	return &ReminderLogRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// Exists checks if a reminder of the given kind was already sent for a fixed expense through a channel
+func (r *ReminderLogRepository) Exists(fixedExpenseID uint, kind, channel string) (bool, error) {
+	var count int64
+	err := r.db.Model(&reminder.ReminderLog{}).
+		Where("fixed_expense_id = ? AND kind = ? AND channel = ?", fixedExpenseID, kind, channel).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// Create records a sent reminder
+func (r *ReminderLogRepository) Create(log *reminder.ReminderLog) error {
+	return r.db.Create(log).Error
+}
+
+// GetByFixedExpense retrieves all reminders sent for a fixed expense
+func (r *ReminderLogRepository) GetByFixedExpense(fixedExpenseID uint) ([]reminder.ReminderLog, error) {
+	var logs []reminder.ReminderLog
+	err := r.db.Where("fixed_expense_id = ?", fixedExpenseID).
+		Order("sent_at ASC").
+		Find(&logs).Error
+	return logs, err
+}
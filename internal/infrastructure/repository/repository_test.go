@@ -0,0 +1,55 @@
+package repository_test
+
+import (
+	"expenses-api/internal/application/port/porttest"
+	"expenses-api/internal/infrastructure/database"
+	"expenses-api/internal/infrastructure/repository"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestGormRepositories(t *testing.T) {
+	porttest.RunRepositories(t, func(t *testing.T) porttest.Repositories {
+		db := newTestDB(t)
+		return porttest.Repositories{
+			Salaries:            repository.NewSalaryRepository(db),
+			Pockets:             repository.NewPocketRepository(db),
+			FixedExpenses:       repository.NewFixedExpenseRepository(db),
+			DailyExpenses:       repository.NewDailyExpenseRepository(db),
+			DailyExpenseConfigs: repository.NewDailyExpenseConfigRepository(db),
+		}
+	})
+}
+
+// newTestDB opens an empty in-memory SQLite database with every migration applied
+func newTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open("file::memory:?_pragma=foreign_keys(1)"), &gorm.Config{
+		Logger:         logger.Default.LogMode(logger.Silent),
+		TranslateError: true,
+	})
+	if err != nil {
+		t.Fatalf("opening database: %v", err)
+	}
+
+	// Every connection to ":memory:" is a different database, so keep a single one open
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("getting connection pool: %v", err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	migrator, err := database.NewMigrator(db)
+	if err != nil {
+		t.Fatalf("loading migrations: %v", err)
+	}
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("applying migrations: %v", err)
+	}
+	return db
+}
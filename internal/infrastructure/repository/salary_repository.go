@@ -1,7 +1,11 @@
 package repository
 
 import (
+	"context"
+	"expenses-api/internal/application/port"
+	"expenses-api/internal/domain/civil"
 	"expenses-api/internal/domain/salary"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -19,7 +23,7 @@ func NewSalaryRepository(db *gorm.DB) *SalaryRepository {
 }
 
 // GetByMonth retrieves salary configuration for a specific month
-func (r *SalaryRepository) GetByMonth(month string) (*salary.Salary, error) {
+func (r *SalaryRepository) GetByMonth(month civil.Month) (*salary.Salary, error) {
 	var s salary.Salary
 	err := r.db.Where("month = ?", month).First(&s).Error
 	if err != nil {
@@ -29,22 +33,35 @@ func (r *SalaryRepository) GetByMonth(month string) (*salary.Salary, error) {
 }
 
 // CreateOrUpdate creates a new salary record or updates existing one
-func (r *SalaryRepository) CreateOrUpdate(s *salary.Salary) error {
+func (r *SalaryRepository) CreateOrUpdate(ctx context.Context, s *salary.Salary) error {
 	// Try to find existing record
 	var existing salary.Salary
-	err := r.db.Where("month = ?", s.Month).First(&existing).Error
+	err := r.db.WithContext(ctx).Where("month = ?", s.Month).First(&existing).Error
 
 	if err == gorm.ErrRecordNotFound {
+		// A version refers to a record that no longer exists
+		if s.Version != 0 {
+			return port.ErrVersionConflict
+		}
 		// Create new record
-		return r.db.Create(s).Error
+		return r.db.WithContext(ctx).Create(s).Error
 	} else if err != nil {
 		// Other error
 		return err
 	}
 
+	// The caller expects to replace a version that is no longer the stored one
+	if s.Version != 0 && s.Version != existing.Version {
+		return port.ErrVersionConflict
+	}
+
 	// Update existing record
 	existing.MonthlyAmount = s.MonthlyAmount
-	return r.db.Save(&existing).Error
+	if err := updateVersioned(r.db.WithContext(ctx), &existing, &existing.Version); err != nil {
+		return err
+	}
+	*s = existing
+	return nil
 }
 
 // GetAll retrieves all salary records ordered by month descending
@@ -62,19 +79,19 @@ func (r *SalaryRepository) GetRecent(limit int) ([]salary.Salary, error) {
 }
 
 // DeleteByMonth deletes salary record for a specific month
-func (r *SalaryRepository) DeleteByMonth(month string) error {
+func (r *SalaryRepository) DeleteByMonth(month civil.Month) error {
 	return r.db.Where("month = ?", month).Delete(&salary.Salary{}).Error
 }
 
-// GetCurrentMonth retrieves salary for the current month
-func (r *SalaryRepository) GetCurrentMonth() (*salary.Salary, error) {
-	currentMonth := salary.GetCurrentMonth()
+// GetCurrentMonth retrieves salary for the month of now
+func (r *SalaryRepository) GetCurrentMonth(now time.Time) (*salary.Salary, error) {
+	currentMonth := salary.GetCurrentMonth(now)
 	return r.GetByMonth(currentMonth)
 }
 
 // GetMonthsWithSalary retrieves all months that have salary configured
-func (r *SalaryRepository) GetMonthsWithSalary() ([]string, error) {
-	var months []string
+func (r *SalaryRepository) GetMonthsWithSalary() ([]civil.Month, error) {
+	var months []civil.Month
 	err := r.db.Model(&salary.Salary{}).
 		Select("month").
 		Order("month DESC").
@@ -83,7 +100,7 @@ func (r *SalaryRepository) GetMonthsWithSalary() ([]string, error) {
 }
 
 // GetTotalByMonths calculates total salary for multiple months
-func (r *SalaryRepository) GetTotalByMonths(months []string) (float64, error) {
+func (r *SalaryRepository) GetTotalByMonths(months []civil.Month) (float64, error) {
 	var total float64
 	err := r.db.Model(&salary.Salary{}).
 		Select("COALESCE(SUM(monthly_amount), 0)").
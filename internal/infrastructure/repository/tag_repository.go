@@ -0,0 +1,240 @@
+package repository
+
+import (
+	"context"
+	"expenses-api/internal/domain/civil"
+	"expenses-api/internal/domain/tag"
+
+	"gorm.io/gorm"
+)
+
+// TagRepository handles expense tag database operations
+type TagRepository struct {
+	*BaseRepository
+}
+
+// NewTagRepository creates a new tag repository instance
+func NewTagRepository(db *gorm.DB) *TagRepository {
+	return &TagRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// GetAll retrieves all tags ordered by name
+func (r *TagRepository) GetAll() ([]tag.Tag, error) {
+	var tags []tag.Tag
+	err := r.db.Order("name ASC").Find(&tags).Error
+	return tags, err
+}
+
+// GetByID retrieves a tag by ID
+func (r *TagRepository) GetByID(id uint) (*tag.Tag, error) {
+	var t tag.Tag
+	err := r.db.First(&t, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// GetByNames retrieves the tags with the given names; unknown names are skipped
+func (r *TagRepository) GetByNames(names []string) ([]tag.Tag, error) {
+	var tags []tag.Tag
+	if len(names) == 0 {
+		return tags, nil
+	}
+	err := r.db.Where("name IN ?", names).Find(&tags).Error
+	return tags, err
+}
+
+// Create creates a new tag
+func (r *TagRepository) Create(ctx context.Context, t *tag.Tag) error {
+	return r.db.WithContext(ctx).Create(t).Error
+}
+
+// Update updates an existing tag
+func (r *TagRepository) Update(ctx context.Context, t *tag.Tag) error {
+	return r.db.WithContext(ctx).Save(t).Error
+}
+
+// Delete deletes a tag and unlinks it from every expense and categorization rule
+func (r *TagRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, links := range []tagLinks{dailyExpenseTagLinks, fixedExpenseTagLinks, ruleTagLinks} {
+			if err := tx.Where("tag_id = ?", id).Delete(links.row(0, 0)).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Delete(&tag.Tag{}, id).Error
+	})
+}
+
+// SetFixedExpenseTags replaces the tags of a fixed expense
+func (r *TagRepository) SetFixedExpenseTags(ctx context.Context, fixedExpenseID uint, tagIDs []uint) error {
+	return r.replaceLinks(ctx, fixedExpenseTagLinks, fixedExpenseID, tagIDs)
+}
+
+// SetCategorizationRuleTags replaces the tags a categorization rule assigns
+func (r *TagRepository) SetCategorizationRuleTags(ctx context.Context, ruleID uint, tagIDs []uint) error {
+	return r.replaceLinks(ctx, ruleTagLinks, ruleID, tagIDs)
+}
+
+// GetTotals aggregates tagged spending between two dates (inclusive)
+// Daily expenses count on their date; fixed expenses count their due amount
+// (actual amount when recorded, otherwise planned) on their payment day
+func (r *TagRepository) GetTotals(startDate, endDate civil.Date) ([]tag.Total, error) {
+	var daily []tagAmount
+	err := r.db.Table("tags").
+		Select("tags.id AS tag_id, tags.name AS name, SUM(daily_expenses.amount) AS amount, COUNT(*) AS count").
+		Joins("JOIN daily_expense_tags ON daily_expense_tags.tag_id = tags.id").
+		Joins("JOIN daily_expenses ON daily_expenses.id = daily_expense_tags.daily_expense_id").
+		Where("daily_expenses.date >= ? AND daily_expenses.date <= ?", startDate, endDate).
+		Group("tags.id, tags.name").
+		Scan(&daily).Error
+	if err != nil {
+		return nil, err
+	}
+
+	// Payment days in the boundary months are compared against the range days
+	startMonth, startDay := startDate.Month(), startDate.Day()
+	endMonth, endDay := endDate.Month(), endDate.Day()
+
+	var fixed []tagAmount
+	err = r.db.Table("tags").
+		Select("tags.id AS tag_id, tags.name AS name, SUM(COALESCE(fixed_expenses.actual_amount, fixed_expenses.amount)) AS amount, COUNT(*) AS count").
+		Joins("JOIN fixed_expense_tags ON fixed_expense_tags.tag_id = tags.id").
+		Joins("JOIN fixed_expenses ON fixed_expenses.id = fixed_expense_tags.fixed_expense_id").
+		Where("fixed_expenses.month >= ? AND fixed_expenses.month <= ?", startMonth, endMonth).
+		Where("fixed_expenses.month > ? OR fixed_expenses.payment_day >= ?", startMonth, startDay).
+		Where("fixed_expenses.month < ? OR fixed_expenses.payment_day <= ?", endMonth, endDay).
+		Group("tags.id, tags.name").
+		Scan(&fixed).Error
+	if err != nil {
+		return nil, err
+	}
+
+	// Merge both aggregations keeping tags ordered by name
+	tags, err := r.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	totalsByTag := make(map[uint]*tag.Total)
+	for _, row := range daily {
+		total := totalFor(totalsByTag, row)
+		total.DailyAmount = row.Amount
+		total.DailyCount = row.Count
+	}
+	for _, row := range fixed {
+		total := totalFor(totalsByTag, row)
+		total.FixedAmount = row.Amount
+		total.FixedCount = row.Count
+	}
+
+	totals := make([]tag.Total, 0, len(totalsByTag))
+	for _, t := range tags {
+		if total, ok := totalsByTag[t.ID]; ok {
+			totals = append(totals, *total)
+		}
+	}
+	return totals, nil
+}
+
+// tagLinks describes a join table between tags and the expenses or rules they label
+// Links are written through their models so that the audit log records them
+type tagLinks struct {
+	column string                                // Column holding the expense or rule ID
+	row    func(ownerID, tagID uint) interface{} // Builds one link; zero IDs give an empty row
+}
+
+var (
+	dailyExpenseTagLinks = tagLinks{
+		column: "daily_expense_id",
+		row: func(ownerID, tagID uint) interface{} {
+			return &tag.DailyExpenseTag{DailyExpenseID: ownerID, TagID: tagID}
+		},
+	}
+	fixedExpenseTagLinks = tagLinks{
+		column: "fixed_expense_id",
+		row: func(ownerID, tagID uint) interface{} {
+			return &tag.FixedExpenseTag{FixedExpenseID: ownerID, TagID: tagID}
+		},
+	}
+	ruleTagLinks = tagLinks{
+		column: "rule_id",
+		row: func(ownerID, tagID uint) interface{} {
+			return &tag.CategorizationRuleTag{RuleID: ownerID, TagID: tagID}
+		},
+	}
+)
+
+// replaceLinks replaces the tag links of one expense or rule in a transaction
+func (r *TagRepository) replaceLinks(ctx context.Context, links tagLinks, ownerID uint, tagIDs []uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return replaceTagLinks(tx, links, ownerID, tagIDs)
+	})
+}
+
+// replaceTagLinks makes tagIDs the tags of one expense or rule inside the
+// caller's transaction, deleting the links no longer listed and adding the
+// missing ones; links that stay are not rewritten
+func replaceTagLinks(tx *gorm.DB, links tagLinks, ownerID uint, tagIDs []uint) error {
+	var linked []uint
+	if err := tx.Model(links.row(0, 0)).Where(links.column+" = ?", ownerID).Pluck("tag_id", &linked).Error; err != nil {
+		return err
+	}
+
+	wanted := make(map[uint]bool, len(tagIDs))
+	for _, tagID := range tagIDs {
+		wanted[tagID] = true
+	}
+
+	isLinked := make(map[uint]bool, len(linked))
+	var removed []uint
+	for _, tagID := range linked {
+		isLinked[tagID] = true
+		if !wanted[tagID] {
+			removed = append(removed, tagID)
+		}
+	}
+	if len(removed) > 0 {
+		err := tx.Where(links.column+" = ? AND tag_id IN ?", ownerID, removed).Delete(links.row(0, 0)).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, tagID := range tagIDs {
+		if isLinked[tagID] {
+			continue
+		}
+		isLinked[tagID] = true
+		if err := tx.Create(links.row(ownerID, tagID)).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteTagLinks deletes every tag link of one expense or rule
+func deleteTagLinks(tx *gorm.DB, links tagLinks, ownerID uint) error {
+	return tx.Where(links.column+" = ?", ownerID).Delete(links.row(0, 0)).Error
+}
+
+// totalFor returns the total of a tag, creating it on first use
+func totalFor(totalsByTag map[uint]*tag.Total, row tagAmount) *tag.Total {
+	total, ok := totalsByTag[row.TagID]
+	if !ok {
+		total = &tag.Total{TagID: row.TagID, Name: row.Name}
+		totalsByTag[row.TagID] = total
+	}
+	return total
+}
+
+// tagAmount represents the spending of one tag in one kind of expense
+type tagAmount struct {
+	TagID  uint
+	Name   string
+	Amount float64
+	Count  int
+}
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"expenses-api/internal/domain/civil"
+	"expenses-api/internal/domain/transfer"
+
+	"gorm.io/gorm"
+)
+
+// TransferRepository handles transfer-related database operations
+type TransferRepository struct {
+	*BaseRepository
+}
+
+// NewTransferRepository creates a new transfer repository instance
+func NewTransferRepository(db *gorm.DB) *TransferRepository {
+	return &TransferRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// GetAll retrieves all transfers with pocket information ordered by date
+func (r *TransferRepository) GetAll() ([]transfer.Transfer, error) {
+	var transfers []transfer.Transfer
+	err := r.db.Preload("SourcePocket").
+		Preload("DestinationPocket").
+		Order("date ASC, id ASC").
+		Find(&transfers).Error
+	return transfers, err
+}
+
+// GetByMonth retrieves all transfers for a specific month with pocket information
+func (r *TransferRepository) GetByMonth(month civil.Month) ([]transfer.Transfer, error) {
+	var transfers []transfer.Transfer
+	err := r.db.Preload("SourcePocket").
+		Preload("DestinationPocket").
+		Scopes(dateInMonth("date", month)).
+		Order("date DESC, created_at DESC").
+		Find(&transfers).Error
+	return transfers, err
+}
+
+// GetByDateRange retrieves the transfers between two dates, both included
+func (r *TransferRepository) GetByDateRange(startDate, endDate civil.Date) ([]transfer.Transfer, error) {
+	var transfers []transfer.Transfer
+	err := r.db.Preload("SourcePocket").
+		Preload("DestinationPocket").
+		Where("date >= ? AND date <= ?", startDate, endDate).
+		Order("date DESC, created_at DESC").
+		Find(&transfers).Error
+	return transfers, err
+}
+
+// GetByID retrieves a transfer by ID with pocket information
+func (r *TransferRepository) GetByID(id uint) (*transfer.Transfer, error) {
+	var t transfer.Transfer
+	err := r.db.Preload("SourcePocket").
+		Preload("DestinationPocket").
+		First(&t, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// Create creates a new transfer
+func (r *TransferRepository) Create(ctx context.Context, t *transfer.Transfer) error {
+	return r.db.WithContext(ctx).Create(t).Error
+}
+
+// Update updates an existing transfer unless it changed since it was read
+func (r *TransferRepository) Update(ctx context.Context, t *transfer.Transfer) error {
+	return updateVersioned(r.db.WithContext(ctx), t, &t.Version)
+}
+
+// Delete deletes a transfer by ID
+func (r *TransferRepository) Delete(ctx context.Context, id uint, version uint) error {
+	return deleteVersioned(r.db.WithContext(ctx), &transfer.Transfer{}, id, version)
+}
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"expenses-api/internal/domain/webhook"
+
+	"gorm.io/gorm"
+)
+
+// WebhookSubscriptionRepository handles webhook subscription-related database operations
+type WebhookSubscriptionRepository struct {
+	*BaseRepository
+}
+
+// NewWebhookSubscriptionRepository creates a new webhook subscription repository instance
+func NewWebhookSubscriptionRepository(db *gorm.DB) *WebhookSubscriptionRepository {
+	return &WebhookSubscriptionRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// GetAll retrieves all webhook subscriptions
+func (r *WebhookSubscriptionRepository) GetAll() ([]webhook.Subscription, error) {
+	var subscriptions []webhook.Subscription
+	err := r.db.Order("id ASC").Find(&subscriptions).Error
+	return subscriptions, err
+}
+
+// GetActive retrieves all active webhook subscriptions
+func (r *WebhookSubscriptionRepository) GetActive() ([]webhook.Subscription, error) {
+	var subscriptions []webhook.Subscription
+	err := r.db.Where("active = ?", true).Order("id ASC").Find(&subscriptions).Error
+	return subscriptions, err
+}
+
+// GetByID retrieves a webhook subscription by ID
+func (r *WebhookSubscriptionRepository) GetByID(id uint) (*webhook.Subscription, error) {
+	var s webhook.Subscription
+	err := r.db.First(&s, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// Create creates a new webhook subscription
+func (r *WebhookSubscriptionRepository) Create(ctx context.Context, s *webhook.Subscription) error {
+	return r.db.WithContext(ctx).Create(s).Error
+}
+
+// Update updates an existing webhook subscription
+func (r *WebhookSubscriptionRepository) Update(ctx context.Context, s *webhook.Subscription) error {
+	return r.db.WithContext(ctx).Save(s).Error
+}
+
+// Delete deletes a webhook subscription and its delivery log
+func (r *WebhookSubscriptionRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("subscription_id = ?", id).Delete(&webhook.Delivery{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&webhook.Subscription{}, id).Error
+	})
+}
+
+// WebhookDeliveryRepository handles webhook delivery log database operations
+type WebhookDeliveryRepository struct {
+	*BaseRepository
+}
+
+// NewWebhookDeliveryRepository creates a new webhook delivery repository instance
+func NewWebhookDeliveryRepository(db *gorm.DB) *WebhookDeliveryRepository {
+	return &WebhookDeliveryRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// GetBySubscription retrieves the most recent delivery attempts of a subscription
+func (r *WebhookDeliveryRepository) GetBySubscription(subscriptionID uint, limit int) ([]webhook.Delivery, error) {
+	var deliveries []webhook.Delivery
+	err := r.db.Where("subscription_id = ?", subscriptionID).
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Find(&deliveries).Error
+	return deliveries, err
+}
+
+// Create records a delivery attempt
+func (r *WebhookDeliveryRepository) Create(d *webhook.Delivery) error {
+	return r.db.Create(d).Error
+}
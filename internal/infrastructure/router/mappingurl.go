@@ -2,25 +2,39 @@ package router
 
 import (
 	"expenses-api/internal/infrastructure/container"
+	"expenses-api/internal/infrastructure/middleware/audit"
+	"expenses-api/internal/infrastructure/middleware/idempotency"
 	"log"
 
 	"github.com/gin-gonic/gin"
 )
 
 func mapURLs(router *gin.Engine) {
-	// Initialize dependency injection container
-	c, err := container.NewContainer()
+	// Initialize dependency injection container with the system clock
+	c, err := container.NewContainer(nil)
 	if err != nil {
 		log.Fatalf("Failed to initialize container: %v", err)
 	}
 
 	frontendUrls(router, c)
+	startBackgroundJobs(c)
+}
+
+// startBackgroundJobs starts the schedulers enabled in the container
+func startBackgroundJobs(c *container.Container) {
+	if c.ReminderScheduler != nil {
+		c.ReminderScheduler.Start()
+	}
 }
 
 // frontendUrls define las rutas específicas para el frontend Angular usando handlers
 func frontendUrls(router *gin.Engine, c *container.Container) {
 	// Grupo de rutas API
 	api := router.Group("/api")
+	// El header X-Actor indica quién hace el cambio en el historial de auditoría
+	api.Use(audit.NewActorMiddleware().Execute())
+	// Los POST con header Idempotency-Key se pueden reintentar sin duplicar cambios
+	api.Use(idempotency.NewIdempotencyMiddleware(c.IdempotencyUseCase).Execute())
 	{
 		// Resumen mensual
 		api.GET("/summary/:month", c.SummaryHandler.GetMonthlySummary)
@@ -40,11 +54,94 @@ func frontendUrls(router *gin.Engine, c *container.Container) {
 		api.POST("/fixed-expenses", c.FixedExpenseHandler.Create)
 		api.PUT("/fixed-expenses/:id", c.FixedExpenseHandler.Update)
 		api.PUT("/fixed-expenses/:id/status", c.FixedExpenseHandler.UpdateStatus)
+		api.POST("/fixed-expenses/:id/payments", c.FixedExpenseHandler.AddPayment)
+		api.DELETE("/fixed-expenses/:id/payments/:payment_id", c.FixedExpenseHandler.DeletePayment)
+		api.POST("/fixed-expenses/:id/attachments", c.AttachmentHandler.UploadFixed)
 
 		// Gastos diarios
 		api.GET("/daily-expenses/:month", c.DailyExpenseHandler.GetByMonth)
 		api.POST("/daily-expenses", c.DailyExpenseHandler.Create)
+		api.POST("/daily-expenses/parse", c.DailyExpenseHandler.QuickEntry)
 		api.PUT("/daily-expenses/:id", c.DailyExpenseHandler.Update)
 		api.DELETE("/daily-expenses/:id", c.DailyExpenseHandler.Delete)
+		api.POST("/daily-expenses/:id/attachments", c.AttachmentHandler.UploadDaily)
+
+		// Reglas de categorización automática de gastos diarios
+		api.GET("/categorization-rules", c.CategorizationHandler.GetRules)
+		api.POST("/categorization-rules", c.CategorizationHandler.CreateRule)
+		api.PUT("/categorization-rules/:id", c.CategorizationHandler.UpdateRule)
+		api.DELETE("/categorization-rules/:id", c.CategorizationHandler.DeleteRule)
+		api.POST("/categorization-rules/apply/:month", c.CategorizationHandler.Apply)
+
+		// Sugerencias de bolsillo aprendidas del historial
+		api.GET("/suggestions/pocket", c.SuggestionHandler.SuggestPocket)
+		api.POST("/suggestions/retrain", c.SuggestionHandler.Retrain)
+
+		// Etiquetas de gastos
+		api.GET("/tags", c.TagHandler.GetAll)
+		api.GET("/tags/totals", c.TagHandler.GetTotals)
+		api.POST("/tags", c.TagHandler.Create)
+		api.PUT("/tags/:id", c.TagHandler.Update)
+		api.DELETE("/tags/:id", c.TagHandler.Delete)
+
+		// Gastos reembolsables por terceros
+		api.GET("/receivables", c.ReceivableHandler.GetAll)
+		api.GET("/receivables/outstanding", c.ReceivableHandler.GetOutstanding)
+		api.POST("/receivables", c.ReceivableHandler.Create)
+		api.PUT("/receivables/:id", c.ReceivableHandler.Update)
+		api.DELETE("/receivables/:id", c.ReceivableHandler.Delete)
+		api.POST("/receivables/:id/reimbursements", c.ReceivableHandler.AddReimbursement)
+		api.DELETE("/receivables/:id/reimbursements/:reimbursement_id", c.ReceivableHandler.DeleteReimbursement)
+
+		// Gastos compartidos entre miembros del hogar
+		api.GET("/household/members", c.HouseholdHandler.GetMembers)
+		api.POST("/household/members", c.HouseholdHandler.CreateMember)
+		api.PUT("/household/members/:id", c.HouseholdHandler.UpdateMember)
+		api.DELETE("/household/members/:id", c.HouseholdHandler.DeleteMember)
+		api.GET("/household/settle-up/:month", c.HouseholdHandler.SettleUp)
+		api.GET("/expense-shares/:month", c.HouseholdHandler.GetSharesByMonth)
+		api.POST("/expense-shares", c.HouseholdHandler.CreateShare)
+		api.PUT("/expense-shares/:id", c.HouseholdHandler.UpdateShare)
+		api.DELETE("/expense-shares/:id", c.HouseholdHandler.DeleteShare)
+
+		// Comprobantes adjuntos a gastos
+		api.GET("/attachments", c.AttachmentHandler.GetByExpense)
+		api.GET("/attachments/:id", c.AttachmentHandler.Download)
+		api.GET("/attachments/:id/thumbnail", c.AttachmentHandler.Thumbnail)
+		api.DELETE("/attachments/:id", c.AttachmentHandler.Delete)
+
+		// Transferencias entre cuentas y bolsillos
+		api.GET("/transfers/balances", c.TransferHandler.GetBalances)
+		api.GET("/transfers/:month", c.TransferHandler.GetByMonth)
+		api.POST("/transfers", c.TransferHandler.Create)
+		api.PUT("/transfers/:id", c.TransferHandler.Update)
+		api.DELETE("/transfers/:id", c.TransferHandler.Delete)
+
+		// Bolsillos tipo sobre
+		api.GET("/envelopes/:month", c.EnvelopeHandler.GetByMonth)
+		api.PUT("/envelopes/:month/:pocket_id", c.EnvelopeHandler.UpdateAllocation)
+
+		// Alertas de presupuesto
+		api.GET("/alerts", c.AlertHandler.GetAlerts)
+		api.PUT("/alerts/:id/acknowledge", c.AlertHandler.Acknowledge)
+		api.GET("/alert-rules", c.AlertHandler.GetRules)
+		api.POST("/alert-rules", c.AlertHandler.CreateRule)
+		api.PUT("/alert-rules/:id", c.AlertHandler.UpdateRule)
+		api.DELETE("/alert-rules/:id", c.AlertHandler.DeleteRule)
+
+		// Webhooks salientes
+		api.GET("/webhooks", c.WebhookHandler.GetAll)
+		api.POST("/webhooks", c.WebhookHandler.Create)
+		api.PUT("/webhooks/:id", c.WebhookHandler.Update)
+		api.DELETE("/webhooks/:id", c.WebhookHandler.Delete)
+		api.GET("/webhooks/:id/deliveries", c.WebhookHandler.GetDeliveries)
+
+		// Historial de auditoría
+		api.GET("/audit", c.AuditHandler.GetEntries)
+
+		// Historial de eventos del libro y reconstrucción en el tiempo
+		api.GET("/history/events", c.HistoryHandler.GetEvents)
+		api.GET("/history/summary/:month", c.HistoryHandler.GetMonthlySummary)
+		api.GET("/history/consistency", c.HistoryHandler.CheckConsistency)
 	}
 }
@@ -2,6 +2,7 @@ package router
 
 import (
 	"expenses-api/internal/infrastructure/middleware/cors"
+	"expenses-api/internal/infrastructure/middleware/errorhandler"
 	"github.com/gin-gonic/gin"
 	"log"
 	"os"
@@ -16,6 +17,7 @@ func StartApp() {
 func run() error {
 	router := gin.Default()
 	router.Use(cors.NewCorsMiddleware().Execute())
+	router.Use(errorhandler.NewErrorMiddleware().Execute())
 	mapURLs(router)
 
 	port := os.Getenv("PORT")
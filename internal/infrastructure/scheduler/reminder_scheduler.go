@@ -0,0 +1,67 @@
+package scheduler
+
+import (
+	"expenses-api/internal/application/port"
+	"expenses-api/internal/application/usecase"
+	"log"
+	"sync"
+	"time"
+)
+
+// ReminderScheduler periodically checks fixed expense due dates inside the API process
+type ReminderScheduler struct {
+	reminderUseCase *usecase.ReminderUseCase
+	interval        time.Duration
+	clock           port.Clock
+
+	stop chan struct{}
+	once sync.Once
+}
+
+// NewReminderScheduler creates a new reminder scheduler instance
+func NewReminderScheduler(reminderUseCase *usecase.ReminderUseCase, interval time.Duration, clock port.Clock) *ReminderScheduler {
+	if interval <= 0 {
+		interval = time.Hour
+	}
+
+	return &ReminderScheduler{
+		reminderUseCase: reminderUseCase,
+		interval:        interval,
+		clock:           clock,
+		stop:            make(chan struct{}),
+	}
+}
+
+// Start runs a first check immediately and then one check per interval in the background
+func (s *ReminderScheduler) Start() {
+	log.Printf("Starting reminder scheduler (interval: %s)", s.interval)
+
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		s.run()
+		for {
+			select {
+			case <-ticker.C:
+				s.run()
+			case <-s.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops the scheduler; it is safe to call more than once
+func (s *ReminderScheduler) Stop() {
+	s.once.Do(func() {
+		close(s.stop)
+	})
+}
+
+// run performs a single due date check, logging failures
+func (s *ReminderScheduler) run() {
+	if err := s.reminderUseCase.CheckDueDates(s.clock.Now()); err != nil {
+		log.Printf("Reminder check failed: %v", err)
+	}
+}
@@ -2,13 +2,24 @@ package main
 
 import (
 	"expenses-api/internal/infrastructure/config"
+	"expenses-api/internal/infrastructure/database"
 	"expenses-api/internal/infrastructure/router"
 	"log"
+	"os"
 )
 
 func main() {
 	// Load configuration
 	cfg := config.LoadConfig()
+
+	// "migrate" manages the database schema without starting the server
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := database.RunMigrateCommand(os.Args[2:]); err != nil {
+			log.Fatalf("Migration failed: %v", err)
+		}
+		return
+	}
+
 	log.Printf("Starting Expenses API in %s mode on port %s", cfg.Env, cfg.Port)
 
 	// Start the application